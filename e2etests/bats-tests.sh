@@ -61,6 +61,57 @@ get_value_from() {
   [[ "${count}" == "1" ]]
 }
 
+@test "dangerous-capabilities" {
+  tmp="tests/checks/dangerous-capabilities.yml"
+  cmd="${KUBE_LINTER_BIN} lint --include dangerous-capabilities --do-not-auto-add-defaults --format json ${tmp}"
+  run ${cmd}
+
+  print_info "${status}" "${output}" "${cmd}" "${tmp}"
+  [ "$status" -eq 1 ]
+
+  message1=$(get_value_from "${lines[0]}" '.Reports[0].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[0].Diagnostic.Message')
+  message2=$(get_value_from "${lines[0]}" '.Reports[1].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[1].Diagnostic.Message')
+  count=$(get_value_from "${lines[0]}" '.Reports | length')
+
+  [[ "${message1}" == "Deployment: container \"app\" adds denied capability \"SYS_ADMIN\"" ]]
+  [[ "${message2}" == "DeploymentConfig: container \"app\" adds denied capability \"SYS_ADMIN\"" ]]
+  [[ "${count}" == "2" ]]
+}
+
+@test "dangling-horizontalpodautoscaler" {
+  tmp="tests/checks/dangling-horizontalpodautoscaler.yml"
+  cmd="${KUBE_LINTER_BIN} lint --include dangling-horizontalpodautoscaler --do-not-auto-add-defaults --format json ${tmp}"
+  run ${cmd}
+
+  print_info "${status}" "${output}" "${cmd}" "${tmp}"
+  [ "$status" -eq 1 ]
+
+  message1=$(get_value_from "${lines[0]}" '.Reports[0].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[0].Diagnostic.Message')
+  message2=$(get_value_from "${lines[0]}" '.Reports[1].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[1].Diagnostic.Message')
+  count=$(get_value_from "${lines[0]}" '.Reports | length')
+
+  [[ "${message1}" == "HorizontalPodAutoscaler: scale target apps/v1 Deployment \"dont-fir\" does not exist" ]]
+  [[ "${message2}" == "HorizontalPodAutoscaler: scale target apps/v1 Deployment \"static\" sets replicas statically to 3, which conflicts with the horizontal pod autoscaler managing them" ]]
+  [[ "${count}" == "2" ]]
+}
+
+@test "dangling-ingress" {
+  tmp="tests/checks/dangling-ingress.yml"
+  cmd="${KUBE_LINTER_BIN} lint --include dangling-ingress --do-not-auto-add-defaults --format json ${tmp}"
+  run ${cmd}
+
+  print_info "${status}" "${output}" "${cmd}" "${tmp}"
+  [ "$status" -eq 1 ]
+
+  message1=$(get_value_from "${lines[0]}" '.Reports[0].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[0].Diagnostic.Message')
+  message2=$(get_value_from "${lines[0]}" '.Reports[1].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[1].Diagnostic.Message')
+  count=$(get_value_from "${lines[0]}" '.Reports | length')
+
+  [[ "${message1}" == "Ingress: backend of path \"/\" for all hosts references port 8080 of service \"dont-fire\", which does not expose it" ]]
+  [[ "${message2}" == "Ingress: backend of path \"/api\" for host \"example.com\" references service \"api\", which does not exist" ]]
+  [[ "${count}" == "2" ]]
+}
+
 @test "dangling-networkpolicy" {
   tmp="tests/checks/dangling-networkpolicy.yml"
   cmd="${KUBE_LINTER_BIN} lint --include dangling-networkpolicy --do-not-auto-add-defaults --format json ${tmp}"
@@ -103,8 +154,8 @@ get_value_from() {
   message2=$(get_value_from "${lines[0]}" '.Reports[1].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[1].Diagnostic.Message')
   count=$(get_value_from "${lines[0]}" '.Reports | length')
 
-  [[ "${message1}" == "Service: no pods found matching service labels (map[app.kubernetes.io/name:app])" ]]
-  [[ "${message2}" == "Service: no pods found matching service labels (map[app.kubernetes.io/name:app])" ]]
+  [[ "${message1}" == "Service: no pods found matching the labels of service \"app1\" (map[app.kubernetes.io/name:app])" ]]
+  [[ "${message2}" == "Service: no pods found matching the labels of service \"app2\" (map[app.kubernetes.io/name:app])" ]]
   [[ "${count}" == "2" ]]
 }
 
@@ -120,8 +171,8 @@ get_value_from() {
   message2=$(get_value_from "${lines[0]}" '.Reports[1].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[1].Diagnostic.Message')
   count=$(get_value_from "${lines[0]}" '.Reports | length')
 
-  [[ "${message1}" == "Deployment: found matching serviceAccount (\"default\")" ]]
-  [[ "${message2}" == "DeploymentConfig: found matching serviceAccount (\"default\")" ]]
+  [[ "${message1}" == "Deployment: object uses the default service account" ]]
+  [[ "${message2}" == "DeploymentConfig: object uses the default service account" ]]
   [[ "${count}" == "2" ]]
 }
 
@@ -176,6 +227,23 @@ get_value_from() {
   [[ "${count}" == "4" ]]
 }
 
+@test "duplicate-object" {
+  tmp="tests/checks/duplicate-object.yml"
+  cmd="${KUBE_LINTER_BIN} lint --include duplicate-object --do-not-auto-add-defaults --format json ${tmp}"
+  run ${cmd}
+
+  print_info "${status}" "${output}" "${cmd}" "${tmp}"
+  [ "$status" -eq 1 ]
+
+  message1=$(get_value_from "${lines[0]}" '.Reports[0].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[0].Diagnostic.Message')
+  message2=$(get_value_from "${lines[0]}" '.Reports[1].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[1].Diagnostic.Message')
+  count=$(get_value_from "${lines[0]}" '.Reports | length')
+
+  [[ "${message1}" == "ConfigMap: ConfigMap \"app/config\" is defined 2 times, at tests/checks/duplicate-object.yml:1, tests/checks/duplicate-object.yml:9, so only the last one applied takes effect" ]]
+  [[ "${message2}" == "ConfigMap: ConfigMap \"app/config\" is defined 2 times, at tests/checks/duplicate-object.yml:1, tests/checks/duplicate-object.yml:9, so only the last one applied takes effect" ]]
+  [[ "${count}" == "2" ]]
+}
+
 @test "env-var-secret" {
   tmp="tests/checks/env-var-secret.yml"
   cmd="${KUBE_LINTER_BIN} lint --include env-var-secret --do-not-auto-add-defaults --format json ${tmp}"
@@ -242,6 +310,23 @@ get_value_from() {
   [[ "${count}" == "2" ]]
 }
 
+@test "host-path-volume" {
+  tmp="tests/checks/host-path-volume.yml"
+  cmd="${KUBE_LINTER_BIN} lint --include host-path-volume --do-not-auto-add-defaults --format json ${tmp}"
+  run ${cmd}
+
+  print_info "${status}" "${output}" "${cmd}" "${tmp}"
+  [ "$status" -eq 1 ]
+
+  message1=$(get_value_from "${lines[0]}" '.Reports[0].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[0].Diagnostic.Message')
+  message2=$(get_value_from "${lines[0]}" '.Reports[1].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[1].Diagnostic.Message')
+  count=$(get_value_from "${lines[0]}" '.Reports | length')
+
+  [[ "${message1}" == "Deployment: volume \"logs\" mounts host path \"/var/log\"" ]]
+  [[ "${message2}" == "DeploymentConfig: volume \"logs\" mounts host path \"/var/log\"" ]]
+  [[ "${count}" == "2" ]]
+}
+
 @test "host-pid" {
   tmp="tests/checks/host-pid.yml"
   cmd="${KUBE_LINTER_BIN} lint --include host-pid --do-not-auto-add-defaults --format json ${tmp}"
@@ -293,6 +378,23 @@ get_value_from() {
   [[ "${count}" == "2" ]]
 }
 
+@test "mismatching-image-pull-policy" {
+  tmp="tests/checks/mismatching-image-pull-policy.yml"
+  cmd="${KUBE_LINTER_BIN} lint --include mismatching-image-pull-policy --do-not-auto-add-defaults --format json ${tmp}"
+  run ${cmd}
+
+  print_info "${status}" "${output}" "${cmd}" "${tmp}"
+  [ "$status" -eq 1 ]
+
+  message1=$(get_value_from "${lines[0]}" '.Reports[0].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[0].Diagnostic.Message')
+  message2=$(get_value_from "${lines[0]}" '.Reports[1].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[1].Diagnostic.Message')
+  count=$(get_value_from "${lines[0]}" '.Reports | length')
+
+  [[ "${message1}" == "Deployment: container \"app\" has image \"nginx:latest\", which has the mutable tag latest, but uses imagePullPolicy IfNotPresent (allowed: Always)" ]]
+  [[ "${message2}" == "DeploymentConfig: container \"app\" has image \"nginx:latest\", which has the mutable tag latest, but uses imagePullPolicy IfNotPresent (allowed: Always)" ]]
+  [[ "${count}" == "2" ]]
+}
+
 @test "mismatching-selector" {
   tmp="tests/checks/mismatching-selector.yml"
   cmd="${KUBE_LINTER_BIN} lint --include mismatching-selector --do-not-auto-add-defaults --format json ${tmp}"
@@ -322,8 +424,8 @@ get_value_from() {
   message2=$(get_value_from "${lines[0]}" '.Reports[1].Object.K8sObject.GroupVersionKind.Kind + ": " +.Reports[1].Diagnostic.Message')
   count=$(get_value_from "${lines[0]}" '.Reports | length')
 
-  [[ "${message1}" == "Deployment: object has 3 replicas but does not specify inter pod anti-affinity" ]]
-  [[ "${message2}" == "DeploymentConfig: object has 3 replicas but does not specify inter pod anti-affinity" ]]
+  [[ "${message1}" == "Deployment: object has 3 replicas but does not specify inter pod anti-affinity or topology spread constraints" ]]
+  [[ "${message2}" == "DeploymentConfig: object has 3 replicas but does not specify inter pod anti-affinity or topology spread constraints" ]]
   [[ "${count}" == "2" ]]
 }
 
@@ -359,6 +461,23 @@ get_value_from() {
   [[ "${count}" == "2" ]]
 }
 
+@test "no-pod-disruption-budget" {
+  tmp="tests/checks/no-pod-disruption-budget.yml"
+  cmd="${KUBE_LINTER_BIN} lint --include no-pod-disruption-budget --do-not-auto-add-defaults --format json ${tmp}"
+  run ${cmd}
+
+  print_info "${status}" "${output}" "${cmd}" "${tmp}"
+  [ "$status" -eq 1 ]
+
+  message1=$(get_value_from "${lines[0]}" '.Reports[0].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[0].Diagnostic.Message')
+  message2=$(get_value_from "${lines[0]}" '.Reports[1].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[1].Diagnostic.Message')
+  count=$(get_value_from "${lines[0]}" '.Reports | length')
+
+  [[ "${message1}" == "Deployment: no PodDisruptionBudget selects the pods of Deployment \"app\", add one to keep it available during voluntary disruptions like node drains" ]]
+  [[ "${message2}" == "StatefulSet: no PodDisruptionBudget selects the pods of StatefulSet \"app\", add one to keep it available during voluntary disruptions like node drains" ]]
+  [[ "${count}" == "2" ]]
+}
+
 @test "no-read-only-root-fs" {
   tmp="tests/checks/no-read-only-root-fs.yml"
   cmd="${KUBE_LINTER_BIN} lint --include no-read-only-root-fs --do-not-auto-add-defaults --format json ${tmp}"
@@ -393,6 +512,25 @@ get_value_from() {
   [[ "${count}" == "2" ]]
 }
 
+@test "no-removed-api-versions" {
+  tmp="tests/checks/no-removed-api-versions.yml"
+  cmd="${KUBE_LINTER_BIN} lint --include no-removed-api-versions --do-not-auto-add-defaults --format json ${tmp}"
+  run ${cmd}
+
+  print_info "${status}" "${output}" "${cmd}" "${tmp}"
+  [ "$status" -eq 1 ]
+
+  message1=$(get_value_from "${lines[0]}" '.Reports[0].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[0].Diagnostic.Message')
+  message2=$(get_value_from "${lines[0]}" '.Reports[1].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[1].Diagnostic.Message')
+  message3=$(get_value_from "${lines[0]}" '.Reports[2].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[2].Diagnostic.Message')
+  count=$(get_value_from "${lines[0]}" '.Reports | length')
+
+  [[ "${message1}" == "CronJob: batch/v1beta1 CronJob is removed in Kubernetes 1.25, use batch/v1 instead" ]]
+  [[ "${message2}" == "Ingress: extensions/v1beta1 Ingress is removed in Kubernetes 1.22, use networking.k8s.io/v1 instead" ]]
+  [[ "${message3}" == "PodSecurityPolicy: policy/v1beta1 PodSecurityPolicy is removed in Kubernetes 1.25" ]]
+  [[ "${count}" == "3" ]]
+}
+
 @test "no-rolling-update-strategy" {
   tmp="tests/checks/no-rolling-update-strategy.yml"
   cmd="${KUBE_LINTER_BIN} lint --include no-rolling-update-strategy --do-not-auto-add-defaults --format json ${tmp}"
@@ -410,6 +548,57 @@ get_value_from() {
   [[ "${count}" == "2" ]]
 }
 
+@test "no-seccomp-profile" {
+  tmp="tests/checks/no-seccomp-profile.yml"
+  cmd="${KUBE_LINTER_BIN} lint --include no-seccomp-profile --do-not-auto-add-defaults --format json ${tmp}"
+  run ${cmd}
+
+  print_info "${status}" "${output}" "${cmd}" "${tmp}"
+  [ "$status" -eq 1 ]
+
+  message1=$(get_value_from "${lines[0]}" '.Reports[0].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[0].Diagnostic.Message')
+  message2=$(get_value_from "${lines[0]}" '.Reports[1].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[1].Diagnostic.Message')
+  count=$(get_value_from "${lines[0]}" '.Reports | length')
+
+  [[ "${message1}" == "Deployment: container \"app\" does not have a seccomp profile" ]]
+  [[ "${message2}" == "Deployment: container \"app\" has seccomp profile type \"Unconfined\", which is not one of the allowed types [RuntimeDefault Localhost]" ]]
+  [[ "${count}" == "2" ]]
+}
+
+@test "no-topology-spread-constraints" {
+  tmp="tests/checks/no-topology-spread-constraints.yml"
+  cmd="${KUBE_LINTER_BIN} lint --include no-topology-spread-constraints --do-not-auto-add-defaults --format json ${tmp}"
+  run ${cmd}
+
+  print_info "${status}" "${output}" "${cmd}" "${tmp}"
+  [ "$status" -eq 1 ]
+
+  message1=$(get_value_from "${lines[0]}" '.Reports[0].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[0].Diagnostic.Message')
+  message2=$(get_value_from "${lines[0]}" '.Reports[1].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[1].Diagnostic.Message')
+  count=$(get_value_from "${lines[0]}" '.Reports | length')
+
+  [[ "${message1}" == "Deployment: object does not specify any topology spread constraints" ]]
+  [[ "${message2}" == "DeploymentConfig: object does not specify any topology spread constraints" ]]
+  [[ "${count}" == "2" ]]
+}
+
+@test "non-existent-config-reference" {
+  tmp="tests/checks/non-existent-config-reference.yml"
+  cmd="${KUBE_LINTER_BIN} lint --include non-existent-config-reference --do-not-auto-add-defaults --format json ${tmp}"
+  run ${cmd}
+
+  print_info "${status}" "${output}" "${cmd}" "${tmp}"
+  [ "$status" -eq 1 ]
+
+  message1=$(get_value_from "${lines[0]}" '.Reports[0].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[0].Diagnostic.Message')
+  message2=$(get_value_from "${lines[0]}" '.Reports[1].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[1].Diagnostic.Message')
+  count=$(get_value_from "${lines[0]}" '.Reports | length')
+
+  [[ "${message1}" == "CronJob: volume \"config\" references ConfigMap \"missing-config\", which does not exist in the namespace" ]]
+  [[ "${message2}" == "Deployment: container \"app\", in environment variable \"PASSWORD\", references Secret \"missing-credentials\", which does not exist in the namespace" ]]
+  [[ "${count}" == "2" ]]
+}
+
 @test "non-existent-service-account" {
   tmp="tests/checks/non-existent-service-account.yml"
   cmd="${KUBE_LINTER_BIN} lint --include non-existent-service-account --do-not-auto-add-defaults --format json ${tmp}"
@@ -563,6 +752,23 @@ get_value_from() {
   [[ "${count}" == "2" ]]
 }
 
+@test "secret-values" {
+  tmp="tests/checks/secret-values.yml"
+  cmd="${KUBE_LINTER_BIN} lint --include secret-values --do-not-auto-add-defaults --format json ${tmp}"
+  run ${cmd}
+
+  print_info "${status}" "${output}" "${cmd}" "${tmp}"
+  [ "$status" -eq 1 ]
+
+  message1=$(get_value_from "${lines[0]}" '.Reports[0].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[0].Diagnostic.Message')
+  message2=$(get_value_from "${lines[0]}" '.Reports[1].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[1].Diagnostic.Message')
+  count=$(get_value_from "${lines[0]}" '.Reports | length')
+
+  [[ "${message1}" == "Secret: secret has a value for key \"password\" in data" ]]
+  [[ "${message2}" == "Secret: secret has a value for key \"token\" in stringData, which looks base64-encoded, although stringData values are stored without being decoded" ]]
+  [[ "${count}" == "2" ]]
+}
+
 @test "sensitive-host-mounts" {
   tmp="tests/checks/sensitive-host-mounts.yml"
   cmd="${KUBE_LINTER_BIN} lint --include sensitive-host-mounts --do-not-auto-add-defaults --format json ${tmp}"
@@ -597,6 +803,23 @@ get_value_from() {
   [[ "${count}" == "2" ]]
 }
 
+@test "unpinned-image" {
+  tmp="tests/checks/unpinned-image.yml"
+  cmd="${KUBE_LINTER_BIN} lint --include unpinned-image --do-not-auto-add-defaults --format json ${tmp}"
+  run ${cmd}
+
+  print_info "${status}" "${output}" "${cmd}" "${tmp}"
+  [ "$status" -eq 1 ]
+
+  message1=$(get_value_from "${lines[0]}" '.Reports[0].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[0].Diagnostic.Message')
+  message2=$(get_value_from "${lines[0]}" '.Reports[1].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[1].Diagnostic.Message')
+  count=$(get_value_from "${lines[0]}" '.Reports | length')
+
+  [[ "${message1}" == "Deployment: container \"app\" has image \"nginx:1.21\" that is not pinned by digest" ]]
+  [[ "${message2}" == "DeploymentConfig: container \"app\" has image \"nginx:1.21\" that is not pinned by digest" ]]
+  [[ "${count}" == "2" ]]
+}
+
 @test "unsafe-proc-mount" {
   tmp="tests/checks/unsafe-proc-mount.yml"
   cmd="${KUBE_LINTER_BIN} lint --include unsafe-proc-mount --do-not-auto-add-defaults --format json ${tmp}"
@@ -645,10 +868,10 @@ get_value_from() {
   message4=$(get_value_from "${lines[0]}" '.Reports[3].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[3].Diagnostic.Message')
   count=$(get_value_from "${lines[0]}" '.Reports | length')
 
-  [[ "${message1}" == "Deployment: container \"app\" has cpu request 0" ]]
-  [[ "${message2}" == "Deployment: container \"app\" has cpu limit 0" ]]
-  [[ "${message3}" == "DeploymentConfig: container \"app\" has cpu request 0" ]]
-  [[ "${message4}" == "DeploymentConfig: container \"app\" has cpu limit 0" ]]
+  [[ "${message1}" == "Deployment: container \"app\" has cpu limit 0" ]]
+  [[ "${message2}" == "Deployment: container \"app\" has cpu request 0" ]]
+  [[ "${message3}" == "DeploymentConfig: container \"app\" has cpu limit 0" ]]
+  [[ "${message4}" == "DeploymentConfig: container \"app\" has cpu request 0" ]]
   [[ "${count}" == "4" ]]
 }
 
@@ -666,10 +889,10 @@ get_value_from() {
   message4=$(get_value_from "${lines[0]}" '.Reports[3].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[3].Diagnostic.Message')
   count=$(get_value_from "${lines[0]}" '.Reports | length')
 
-  [[ "${message1}" == "Deployment: container \"app\" has memory request 0" ]]
-  [[ "${message2}" == "Deployment: container \"app\" has memory limit 0" ]]
-  [[ "${message3}" == "DeploymentConfig: container \"app\" has memory request 0" ]]
-  [[ "${message4}" == "DeploymentConfig: container \"app\" has memory limit 0" ]]
+  [[ "${message1}" == "Deployment: container \"app\" has memory limit 0" ]]
+  [[ "${message2}" == "Deployment: container \"app\" has memory request 0" ]]
+  [[ "${message3}" == "DeploymentConfig: container \"app\" has memory limit 0" ]]
+  [[ "${message4}" == "DeploymentConfig: container \"app\" has memory request 0" ]]
   [[ "${count}" == "4" ]]
 }
 
@@ -682,10 +905,12 @@ get_value_from() {
   [ "$status" -eq 1 ]
 
   message1=$(get_value_from "${lines[0]}" '.Reports[0].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[0].Diagnostic.Message')
+  message2=$(get_value_from "${lines[0]}" '.Reports[1].Object.K8sObject.GroupVersionKind.Kind + ": " + .Reports[1].Diagnostic.Message')
   count=$(get_value_from "${lines[0]}" '.Reports | length')
 
-  [[ "${message1}" == "Deployment: object in default namespace" ]]
-  [[ "${count}" == "1" ]]
+  [[ "${message1}" == "ConfigMap: object in default namespace" ]]
+  [[ "${message2}" == "Deployment: object in default namespace" ]]
+  [[ "${count}" == "2" ]]
 }
 
 @test "wildcard-in-rules" {
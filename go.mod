@@ -4,9 +4,14 @@ go 1.16
 
 require (
 	github.com/Masterminds/sprig/v3 v3.2.2
+	github.com/docker/cli v20.10.7+incompatible
+	github.com/docker/distribution v2.7.1+incompatible
 	github.com/fatih/color v1.12.0
+	github.com/fsnotify/fsnotify v1.5.1
 	github.com/ghodss/yaml v1.0.0
 	github.com/golangci/golangci-lint v1.42.1
+	github.com/google/cel-go v0.10.4
+	github.com/mattn/go-isatty v0.0.12
 	github.com/mitchellh/mapstructure v1.4.2
 	github.com/openshift/api v3.9.0+incompatible
 	github.com/owenrumney/go-sarif v1.0.11
@@ -15,6 +20,8 @@ require (
 	github.com/spf13/pflag v1.0.5
 	github.com/spf13/viper v1.9.0
 	github.com/stretchr/testify v1.7.0
+	google.golang.org/protobuf v1.27.1
+	gopkg.in/yaml.v3 v3.0.0-20210107192922-496545a6307b
 	helm.sh/helm/v3 v3.7.0
 	honnef.co/go/tools v0.2.1
 	k8s.io/api v0.22.2
@@ -22,4 +29,7 @@ require (
 	k8s.io/cli-runtime v0.22.2
 	k8s.io/client-go v0.22.2
 	k8s.io/gengo v0.0.0-20210915205010-39e73c8a59cd
+	oras.land/oras-go v0.4.0
+	sigs.k8s.io/kustomize/api v0.8.11
+	sigs.k8s.io/kustomize/kyaml v0.11.0
 )
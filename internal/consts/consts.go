@@ -7,4 +7,6 @@ const (
 	MainURL = "https://github.com/stackrox/kube-linter"
 	// TemplateURLFormat when formatted with template id, provides help link for the given template.
 	TemplateURLFormat = "https://docs.kubelinter.io/#/generated/templates?id=%s"
+	// CheckURLFormat when formatted with the name of a built-in check, provides help link for the given check.
+	CheckURLFormat = "https://docs.kubelinter.io/#/generated/checks?id=%s"
 )
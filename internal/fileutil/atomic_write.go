@@ -0,0 +1,77 @@
+package fileutil
+
+import (
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// WriteAtomically calls write with a temporary file created next to path, and renames the temporary file
+// into place once write has succeeded. This guarantees that readers of path never observe a partially
+// written file, even if the process crashes midway.
+func WriteAtomically(path string, write func(out io.Writer) error) error {
+	dir := filepath.Dir(path)
+	info, err := os.Stat(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return errors.Errorf("directory %s does not exist", dir)
+		}
+		return errors.Wrapf(err, "checking directory %s", dir)
+	}
+	if !info.IsDir() {
+		return errors.Errorf("%s is not a directory", dir)
+	}
+
+	tmpFile, err := createTempFile(dir, "."+filepath.Base(path)+".tmp-")
+	if err != nil {
+		return errors.Wrap(err, "creating temporary file")
+	}
+	tmpPath := tmpFile.Name()
+	committed := false
+	defer func() {
+		if !committed {
+			_ = os.Remove(tmpPath)
+		}
+	}()
+
+	if err := write(tmpFile); err != nil {
+		_ = tmpFile.Close()
+		return err
+	}
+	// An existing file keeps its mode, which new files get from the umask like with os.Create.
+	if info, err := os.Stat(path); err == nil {
+		if err := tmpFile.Chmod(info.Mode().Perm()); err != nil {
+			_ = tmpFile.Close()
+			return errors.Wrapf(err, "setting the mode of temporary file %s", tmpPath)
+		}
+	}
+	// The contents must be on disk before the rename is, or a crash could leave an empty or partial file behind.
+	if err := tmpFile.Sync(); err != nil {
+		_ = tmpFile.Close()
+		return errors.Wrapf(err, "syncing temporary file %s", tmpPath)
+	}
+	if err := tmpFile.Close(); err != nil {
+		return errors.Wrapf(err, "closing temporary file %s", tmpPath)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return errors.Wrapf(err, "renaming %s to %s", tmpPath, path)
+	}
+	committed = true
+	return nil
+}
+
+// createTempFile creates a new file in dir, whose name starts with prefix. Unlike ioutil.TempFile, which creates files
+// that only their owner can read, the file gets the mode of os.Create, 0666 minus the umask.
+func createTempFile(dir, prefix string) (*os.File, error) {
+	for i := 0; ; i++ {
+		f, err := os.OpenFile(filepath.Join(dir, prefix+strconv.FormatUint(uint64(rand.Uint32()), 10)), os.O_RDWR|os.O_CREATE|os.O_EXCL, 0666)
+		if os.IsExist(err) && i < 10000 {
+			continue
+		}
+		return f, err
+	}
+}
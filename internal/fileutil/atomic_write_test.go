@@ -0,0 +1,81 @@
+package fileutil
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteAtomically(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, os.RemoveAll(dir))
+	}()
+	path := filepath.Join(dir, "out.txt")
+
+	require.NoError(t, WriteAtomically(path, func(out io.Writer) error {
+		_, err := io.WriteString(out, "first")
+		return err
+	}))
+	contents, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "first", string(contents))
+
+	// A failing write must leave the previous contents untouched, and must not leave temp files behind.
+	err = WriteAtomically(path, func(out io.Writer) error {
+		_, _ = io.WriteString(out, "partial")
+		return errors.New("boom")
+	})
+	assert.EqualError(t, err, "boom")
+	contents, err = ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "first", string(contents))
+	entries, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	err = WriteAtomically(filepath.Join(dir, "missing", "out.txt"), func(out io.Writer) error {
+		return nil
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "does not exist")
+}
+
+func TestWriteAtomicallyFileMode(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file modes are not supported on Windows")
+	}
+	dir := t.TempDir()
+	write := func(path string) {
+		require.NoError(t, WriteAtomically(path, func(out io.Writer) error {
+			_, err := io.WriteString(out, "contents")
+			return err
+		}))
+	}
+	mode := func(path string) os.FileMode {
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		return info.Mode().Perm()
+	}
+
+	// New files get the same mode as with os.Create, which depends on the umask.
+	created, err := os.Create(filepath.Join(dir, "created.txt"))
+	require.NoError(t, err)
+	require.NoError(t, created.Close())
+	path := filepath.Join(dir, "out.txt")
+	write(path)
+	assert.Equal(t, mode(created.Name()), mode(path))
+
+	// Existing files keep their mode.
+	require.NoError(t, os.Chmod(path, 0640))
+	write(path)
+	assert.Equal(t, os.FileMode(0640), mode(path))
+}
@@ -0,0 +1,83 @@
+package jsonpathutil
+
+import (
+	"reflect"
+	"sync"
+
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// Path is a parsed JSONPath expression, which can be evaluated concurrently without parsing it again every time.
+type Path struct {
+	name             string
+	expr             string
+	allowMissingKeys bool
+	// reusable is whether evaluating the expression leaves the parsed expression as it was, which is not the case
+	// for expressions with range blocks.
+	reusable bool
+	parsed   sync.Pool
+}
+
+// Parse parses the given JSONPath expression, which must be in braces, like {.spec.template}. The name is used in
+// errors.
+func Parse(name, expr string, allowMissingKeys bool) (*Path, error) {
+	p := &Path{name: name, expr: expr, allowMissingKeys: allowMissingKeys}
+	jp, err := p.parse()
+	if err != nil {
+		return nil, err
+	}
+	parser, err := jsonpath.Parse(name, expr)
+	if err != nil {
+		return nil, err
+	}
+	p.reusable = !hasRange(parser.Root)
+	if p.reusable {
+		p.parsed.Put(jp)
+	}
+	return p, nil
+}
+
+func (p *Path) parse() (*jsonpath.JSONPath, error) {
+	jp := jsonpath.New(p.name).AllowMissingKeys(p.allowMissingKeys)
+	if err := jp.Parse(p.expr); err != nil {
+		return nil, err
+	}
+	return jp, nil
+}
+
+// FindResults evaluates the expression on the given data, like jsonpath.JSONPath.FindResults.
+func (p *Path) FindResults(data interface{}) ([][]reflect.Value, error) {
+	// A JSONPath keeps state while it is evaluated, so concurrent evaluations each need their own.
+	var jp *jsonpath.JSONPath
+	if p.reusable {
+		jp, _ = p.parsed.Get().(*jsonpath.JSONPath)
+	}
+	if jp == nil {
+		var err error
+		jp, err = p.parse()
+		if err != nil {
+			return nil, err
+		}
+	}
+	results, err := jp.FindResults(data)
+	if p.reusable {
+		p.parsed.Put(jp)
+	}
+	return results, err
+}
+
+// hasRange returns whether the given parsed expression has a range block.
+func hasRange(root *jsonpath.ListNode) bool {
+	for _, node := range root.Nodes {
+		list, ok := node.(*jsonpath.ListNode)
+		if !ok {
+			continue
+		}
+		for _, child := range list.Nodes {
+			if identifier, ok := child.(*jsonpath.IdentifierNode); ok && identifier.Name == "range" {
+				return true
+			}
+		}
+	}
+	return false
+}
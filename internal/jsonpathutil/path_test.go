@@ -0,0 +1,106 @@
+package jsonpathutil
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+var (
+	testData = map[string]interface{}{
+		"spec": map[string]interface{}{
+			"containers": []interface{}{
+				map[string]interface{}{"name": "app", "image": "app:1.0"},
+				map[string]interface{}{"name": "sidecar", "image": "sidecar:2.0"},
+			},
+		},
+	}
+)
+
+func values(t testing.TB, p *Path, data interface{}) []interface{} {
+	results, err := p.FindResults(data)
+	require.NoError(t, err)
+	var out []interface{}
+	for _, result := range results {
+		for _, value := range result {
+			out = append(out, value.Interface())
+		}
+	}
+	return out
+}
+
+func TestPath(t *testing.T) {
+	for _, testCase := range []struct {
+		expr     string
+		expected []interface{}
+	}{
+		{expr: "{.spec.containers[*].name}", expected: []interface{}{"app", "sidecar"}},
+		{expr: "{.spec.containers[1].image}", expected: []interface{}{"sidecar:2.0"}},
+		{expr: "{.spec.volumes[*].name}"},
+		{expr: "{range .spec.containers[*]}{.image}{end}", expected: []interface{}{"app:1.0", "sidecar:2.0"}},
+	} {
+		t.Run(testCase.expr, func(t *testing.T) {
+			p, err := Parse("test", testCase.expr, true)
+			require.NoError(t, err)
+			// Evaluating the expression again must give the same values.
+			for i := 0; i < 3; i++ {
+				assert.Equal(t, testCase.expected, values(t, p, testData))
+			}
+		})
+	}
+}
+
+func TestPathInvalid(t *testing.T) {
+	_, err := Parse("test", "{.spec.containers[}", true)
+	assert.Error(t, err)
+}
+
+func TestPathMissingKeys(t *testing.T) {
+	p, err := Parse("test", "{.spec.volumes}", false)
+	require.NoError(t, err)
+	_, err = p.FindResults(testData)
+	assert.Error(t, err)
+}
+
+func TestPathConcurrent(t *testing.T) {
+	p, err := Parse("test", "{.spec.containers[*].name}", true)
+	require.NoError(t, err)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				assert.Equal(t, []interface{}{"app", "sidecar"}, values(t, p, testData))
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// BenchmarkFindResults compares evaluating a parsed Path with parsing the expression for every evaluation.
+func BenchmarkFindResults(b *testing.B) {
+	const expr = "{.spec.containers[*].image}"
+	b.Run("parsed", func(b *testing.B) {
+		p, err := Parse("test", expr, true)
+		require.NoError(b, err)
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_, err := p.FindResults(testData)
+			require.NoError(b, err)
+		}
+	})
+	b.Run("parse-every-time", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			jp := jsonpath.New("test").AllowMissingKeys(true)
+			require.NoError(b, jp.Parse(expr))
+			_, err := jp.FindResults(testData)
+			require.NoError(b, err)
+		}
+	})
+}
@@ -0,0 +1,210 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Level is the severity of a log message.
+type Level int
+
+// The levels, from the least to the most severe.
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+var (
+	levelNames = map[Level]string{
+		LevelDebug: "debug",
+		LevelInfo:  "info",
+		LevelWarn:  "warn",
+		LevelError: "error",
+	}
+	// levelPrefixes are the prefixes of messages in the text format.
+	levelPrefixes = map[Level]string{
+		LevelDebug: "Debug",
+		LevelInfo:  "Info",
+		LevelWarn:  "Warning",
+		LevelError: "Error",
+	}
+)
+
+func (l Level) String() string {
+	if name, ok := levelNames[l]; ok {
+		return name
+	}
+	return fmt.Sprintf("Level(%d)", int(l))
+}
+
+// AllLevels returns the names of all levels, from the least to the most severe.
+func AllLevels() []string {
+	return []string{LevelDebug.String(), LevelInfo.String(), LevelWarn.String(), LevelError.String()}
+}
+
+// ParseLevel returns the level with the given name.
+func ParseLevel(name string) (Level, error) {
+	for level, levelName := range levelNames {
+		if levelName == name {
+			return level, nil
+		}
+	}
+	return 0, errors.Errorf("invalid log level %q, must be one of %v", name, AllLevels())
+}
+
+// Format is the format that messages are written in.
+type Format string
+
+const (
+	// FormatText writes a line per message meant for humans, like "Warning: message key=value".
+	FormatText Format = "text"
+	// FormatJSON writes a JSON object per line, with the keys time, level and msg, and the key-value pairs of the
+	// message.
+	FormatJSON Format = "json"
+)
+
+// AllFormats returns the names of all formats.
+func AllFormats() []string {
+	return []string{string(FormatText), string(FormatJSON)}
+}
+
+// A Logger writes messages of at least its level to its writer. It is safe for concurrent use.
+type Logger struct {
+	out    io.Writer
+	level  Level
+	format Format
+	now    func() time.Time
+
+	lock sync.Mutex
+}
+
+// New returns a logger that writes the messages of at least the given level to out, in the given format.
+func New(out io.Writer, level Level, format Format) *Logger {
+	return &Logger{out: out, level: level, format: format, now: time.Now}
+}
+
+// Enabled returns whether messages of the given level are written.
+func (l *Logger) Enabled(level Level) bool {
+	return level >= l.level
+}
+
+// Debug writes a message with the given key-value pairs at the debug level.
+func (l *Logger) Debug(msg string, keysAndValues ...interface{}) {
+	l.log(LevelDebug, msg, keysAndValues)
+}
+
+// Info writes a message with the given key-value pairs at the info level.
+func (l *Logger) Info(msg string, keysAndValues ...interface{}) {
+	l.log(LevelInfo, msg, keysAndValues)
+}
+
+// Warn writes a message with the given key-value pairs at the warn level.
+func (l *Logger) Warn(msg string, keysAndValues ...interface{}) {
+	l.log(LevelWarn, msg, keysAndValues)
+}
+
+// Error writes a message with the given key-value pairs at the error level.
+func (l *Logger) Error(msg string, keysAndValues ...interface{}) {
+	l.log(LevelError, msg, keysAndValues)
+}
+
+func (l *Logger) log(level Level, msg string, keysAndValues []interface{}) {
+	if !l.Enabled(level) {
+		return
+	}
+	var buf bytes.Buffer
+	if l.format == FormatJSON {
+		writeJSON(&buf, l.now(), level, msg, keysAndValues)
+	} else {
+		writeText(&buf, level, msg, keysAndValues)
+	}
+	buf.WriteByte('\n')
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	// Failing to log is not worth failing for.
+	_, _ = l.out.Write(buf.Bytes())
+}
+
+// pairs calls f with every key-value pair. A key without a value is paired with nil, and keys that are not strings
+// are formatted like values.
+func pairs(keysAndValues []interface{}, f func(key string, value interface{})) {
+	for i := 0; i < len(keysAndValues); i += 2 {
+		key, ok := keysAndValues[i].(string)
+		if !ok {
+			key = fmt.Sprint(keysAndValues[i])
+		}
+		var value interface{}
+		if i+1 < len(keysAndValues) {
+			value = keysAndValues[i+1]
+		}
+		f(key, value)
+	}
+}
+
+func writeText(buf *bytes.Buffer, level Level, msg string, keysAndValues []interface{}) {
+	buf.WriteString(levelPrefixes[level])
+	buf.WriteString(": ")
+	buf.WriteString(msg)
+	pairs(keysAndValues, func(key string, value interface{}) {
+		s := textValue(value)
+		if s == "" || strings.ContainsAny(s, " \t\n\"=") {
+			s = fmt.Sprintf("%q", s)
+		}
+		fmt.Fprintf(buf, " %s=%s", key, s)
+	})
+}
+
+func textValue(value interface{}) string {
+	switch value := value.(type) {
+	case string:
+		return value
+	case error:
+		return value.Error()
+	case fmt.Stringer:
+		return value.String()
+	}
+	return fmt.Sprint(value)
+}
+
+func writeJSON(buf *bytes.Buffer, now time.Time, level Level, msg string, keysAndValues []interface{}) {
+	buf.WriteString(`{"time":`)
+	writeJSONValue(buf, now.UTC().Format(time.RFC3339Nano))
+	buf.WriteString(`,"level":`)
+	writeJSONValue(buf, level.String())
+	buf.WriteString(`,"msg":`)
+	writeJSONValue(buf, msg)
+	pairs(keysAndValues, func(key string, value interface{}) {
+		buf.WriteByte(',')
+		writeJSONValue(buf, key)
+		buf.WriteByte(':')
+		writeJSONValue(buf, value)
+	})
+	buf.WriteByte('}')
+}
+
+func writeJSONValue(buf *bytes.Buffer, value interface{}) {
+	switch v := value.(type) {
+	case error:
+		value = v.Error()
+	case time.Duration:
+		value = v.String()
+	case json.Marshaler:
+	case fmt.Stringer:
+		value = v.String()
+	}
+	out, err := json.Marshal(value)
+	if err != nil {
+		out, _ = json.Marshal(fmt.Sprint(value))
+	}
+	buf.Write(out)
+}
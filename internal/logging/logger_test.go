@@ -0,0 +1,53 @@
+package logging
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLogger(level Level, format Format) (*Logger, *bytes.Buffer) {
+	var buf bytes.Buffer
+	l := New(&buf, level, format)
+	l.now = func() time.Time {
+		return time.Date(2021, 10, 1, 12, 0, 0, 0, time.UTC)
+	}
+	return l, &buf
+}
+
+func TestLevels(t *testing.T) {
+	l, buf := newTestLogger(LevelWarn, FormatText)
+	l.Debug("debug")
+	l.Info("info")
+	l.Warn("warn")
+	l.Error("error")
+	assert.Equal(t, "Warning: warn\nError: error\n", buf.String())
+	assert.False(t, l.Enabled(LevelInfo))
+	assert.True(t, l.Enabled(LevelError))
+}
+
+func TestText(t *testing.T) {
+	l, buf := newTestLogger(LevelDebug, FormatText)
+	l.Info("linted objects", "objects", 3, "file", "some file.yaml", "error", errors.New("oops"), "empty", "", "dangling")
+	assert.Equal(t, "Info: linted objects objects=3 file=\"some file.yaml\" error=oops empty=\"\" dangling=<nil>\n", buf.String())
+}
+
+func TestJSON(t *testing.T) {
+	l, buf := newTestLogger(LevelDebug, FormatJSON)
+	l.Warn("failed to load object", "file", "a.yaml", "error", errors.New("oops"), "duration", 1500*time.Millisecond, "count", 2)
+	assert.Equal(t, `{"time":"2021-10-01T12:00:00Z","level":"warn","msg":"failed to load object","file":"a.yaml","error":"oops","duration":"1.5s","count":2}`+"\n", buf.String())
+}
+
+func TestParseLevel(t *testing.T) {
+	for _, name := range AllLevels() {
+		level, err := ParseLevel(name)
+		require.NoError(t, err)
+		assert.Equal(t, name, level.String())
+	}
+	_, err := ParseLevel("verbose")
+	assert.Error(t, err)
+}
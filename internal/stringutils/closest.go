@@ -0,0 +1,84 @@
+package stringutils
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	maxClosestMatches = 3
+)
+
+// ClosestMatches returns up to limit candidates that are close enough to s to be likely typos of it, that is for which
+// at most a third of s has to change to get to them, ordered from the closest to the farthest.
+func ClosestMatches(s string, candidates []string, limit int) []string {
+	type match struct {
+		candidate string
+		distance  int
+	}
+	maxDistance := len(s) / 3
+	var matches []match
+	for _, candidate := range candidates {
+		if distance := editDistance(s, candidate); distance <= maxDistance {
+			matches = append(matches, match{candidate: candidate, distance: distance})
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].distance != matches[j].distance {
+			return matches[i].distance < matches[j].distance
+		}
+		return matches[i].candidate < matches[j].candidate
+	})
+	closest := make([]string, 0, minInt(len(matches), limit))
+	for i := 0; i < len(matches) && i < limit; i++ {
+		closest = append(closest, matches[i].candidate)
+	}
+	return closest
+}
+
+// DidYouMean returns a suggestion of the candidates that s is most likely a typo of, like `, did you mean "a" or "b"?`,
+// to be appended to an error message. It returns an empty string if no candidate is close enough.
+func DidYouMean(s string, candidates []string) string {
+	matches := ClosestMatches(s, candidates, maxClosestMatches)
+	if len(matches) == 0 {
+		return ""
+	}
+	quoted := make([]string, 0, len(matches))
+	for _, match := range matches {
+		quoted = append(quoted, fmt.Sprintf("%q", match))
+	}
+	if len(quoted) == 1 {
+		return fmt.Sprintf(", did you mean %s?", quoted[0])
+	}
+	return fmt.Sprintf(", did you mean %s or %s?", strings.Join(quoted[:len(quoted)-1], ", "), quoted[len(quoted)-1])
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	ar, br := []rune(a), []rune(b)
+	prev := make([]int, len(br)+1)
+	curr := make([]int, len(br)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ar); i++ {
+		curr[0] = i
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			curr[j] = minInt(minInt(prev[j]+1, curr[j-1]+1), prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(br)]
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,33 @@
+package stringutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClosestMatches(t *testing.T) {
+	candidates := []string{"latest-tag", "no-liveness-probe", "no-readiness-probe", "no-liveness-probes"}
+	for _, testCase := range []struct {
+		s        string
+		limit    int
+		expected []string
+	}{
+		{s: "latest-tag", limit: 3, expected: []string{"latest-tag"}},
+		{s: "lates-tag", limit: 3, expected: []string{"latest-tag"}},
+		{s: "no-livenes-probe", limit: 3, expected: []string{"no-liveness-probe", "no-liveness-probes"}},
+		{s: "no-livenes-probe", limit: 1, expected: []string{"no-liveness-probe"}},
+		{s: "no-probe", limit: 3, expected: []string{}},
+		{s: "", limit: 3, expected: []string{}},
+	} {
+		assert.Equal(t, testCase.expected, ClosestMatches(testCase.s, candidates, testCase.limit), testCase.s)
+	}
+}
+
+func TestDidYouMean(t *testing.T) {
+	candidates := []string{"latest-tag", "no-liveness-probe", "no-readiness-probe", "no-liveness-probes"}
+	assert.Equal(t, `, did you mean "latest-tag"?`, DidYouMean("lates-tag", candidates))
+	assert.Equal(t, `, did you mean "no-liveness-probe" or "no-liveness-probes"?`, DidYouMean("no-livenes-probe", candidates))
+	assert.Equal(t, `, did you mean "check-a", "check-b" or "check-c"?`, DidYouMean("check-x", []string{"check-d", "check-c", "check-b", "check-a"}))
+	assert.Equal(t, "", DidYouMean("something-else", candidates))
+}
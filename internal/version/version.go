@@ -5,10 +5,23 @@ import (
 )
 
 var (
-	version string //XDef:VERSION
+	version   string //XDef:VERSION
+	gitCommit string //XDef:GIT_COMMIT
+	buildDate string //XDef:BUILD_DATE
 )
 
 // Get returns the version.
 func Get() string {
 	return stringutils.OrDefault(version, "development")
 }
+
+// GitCommit returns the git commit that the binary was built from, or "unknown" if it was not stamped at build time.
+func GitCommit() string {
+	return stringutils.OrDefault(gitCommit, "unknown")
+}
+
+// BuildDate returns the time, in RFC 3339 format, that the binary was built at, or "unknown" if it was not stamped at
+// build time.
+func BuildDate() string {
+	return stringutils.OrDefault(buildDate, "unknown")
+}
@@ -0,0 +1,136 @@
+package baseline
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/pkg/errors"
+	"golang.stackrox.io/kube-linter/internal/fileutil"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+)
+
+const (
+	// currentVersion is the version of the baseline file format written by this package.
+	currentVersion = 1
+)
+
+// An Entry represents a single accepted lint error.
+// Only the fingerprint is used for matching; the other fields are there to make the file reviewable.
+type Entry struct {
+	Fingerprint string `json:"fingerprint"`
+	Check       string `json:"check"`
+	Object      string `json:"object"`
+	Message     string `json:"message"`
+}
+
+// A Baseline is a set of accepted lint errors that should not be reported again.
+type Baseline struct {
+	Version int     `json:"version"`
+	Entries []Entry `json:"entries"`
+}
+
+// Fingerprint identifies a report independently of the order in which reports were found, and of the file it was
+// found in, so that moving objects around or editing unrelated parts of a file does not invalidate the baseline.
+func Fingerprint(report *diagnostic.WithContext) string {
+	objectName := report.Object.GetK8sObjectName()
+	h := sha256.New()
+	for _, part := range []string{
+		report.Check,
+		objectName.Namespace,
+		objectName.Name,
+		objectName.GroupVersionKind.GroupKind().String(),
+		report.Diagnostic.Message,
+	} {
+		_, _ = h.Write([]byte(part))
+		// Separate the parts so that different tuples can never produce the same input.
+		_, _ = h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// FromReports creates a baseline accepting all the given reports.
+func FromReports(reports []diagnostic.WithContext) Baseline {
+	b := Baseline{Version: currentVersion, Entries: make([]Entry, 0, len(reports))}
+	seen := make(map[string]struct{}, len(reports))
+	for i := range reports {
+		report := &reports[i]
+		fingerprint := Fingerprint(report)
+		if _, ok := seen[fingerprint]; ok {
+			continue
+		}
+		seen[fingerprint] = struct{}{}
+		b.Entries = append(b.Entries, Entry{
+			Fingerprint: fingerprint,
+			Check:       report.Check,
+			Object:      report.Object.GetK8sObjectName().String(),
+			Message:     report.Diagnostic.Message,
+		})
+	}
+	// Sort entries so that regenerating the baseline produces minimal diffs.
+	sort.Slice(b.Entries, func(i, j int) bool {
+		if b.Entries[i].Object != b.Entries[j].Object {
+			return b.Entries[i].Object < b.Entries[j].Object
+		}
+		if b.Entries[i].Check != b.Entries[j].Check {
+			return b.Entries[i].Check < b.Entries[j].Check
+		}
+		return b.Entries[i].Fingerprint < b.Entries[j].Fingerprint
+	})
+	return b
+}
+
+// Load loads a baseline from the given path.
+func Load(path string) (Baseline, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Baseline{}, errors.Wrapf(err, "opening baseline file %s", path)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	var b Baseline
+	if err := json.NewDecoder(f).Decode(&b); err != nil {
+		return Baseline{}, errors.Wrapf(err, "parsing baseline file %s", path)
+	}
+	if b.Version != currentVersion {
+		return Baseline{}, errors.Errorf("unsupported baseline file version %d in %s (expected %d)", b.Version, path, currentVersion)
+	}
+	return b, nil
+}
+
+// Write writes the baseline to the given path.
+func (b Baseline) Write(path string) error {
+	return fileutil.WriteAtomically(path, func(out io.Writer) error {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		enc.SetEscapeHTML(false)
+		return enc.Encode(b)
+	})
+}
+
+// Filter returns the reports that are not accepted by the baseline, as well as the baseline entries
+// that did not match any of the reports, and can therefore be pruned.
+func (b Baseline) Filter(reports []diagnostic.WithContext) (filtered []diagnostic.WithContext, unmatched []Entry) {
+	matched := make(map[string]bool, len(b.Entries))
+	for _, entry := range b.Entries {
+		matched[entry.Fingerprint] = false
+	}
+	for i := range reports {
+		fingerprint := Fingerprint(&reports[i])
+		if _, inBaseline := matched[fingerprint]; inBaseline {
+			matched[fingerprint] = true
+			continue
+		}
+		filtered = append(filtered, reports[i])
+	}
+	for _, entry := range b.Entries {
+		if !matched[entry.Fingerprint] {
+			unmatched = append(unmatched, entry)
+		}
+	}
+	return filtered, unmatched
+}
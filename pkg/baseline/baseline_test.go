@@ -0,0 +1,73 @@
+package baseline
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	appsV1 "k8s.io/api/apps/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func report(filePath, name, check, message string) diagnostic.WithContext {
+	return diagnostic.WithContext{
+		Diagnostic: diagnostic.Diagnostic{Message: message},
+		Check:      check,
+		Object: lintcontext.Object{
+			Metadata: lintcontext.ObjectMetadata{FilePath: filePath},
+			K8sObject: &appsV1.Deployment{
+				TypeMeta:   metaV1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+				ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: "default"},
+			},
+		},
+	}
+}
+
+func TestFingerprintIgnoresFilePath(t *testing.T) {
+	a := report("a.yaml", "app", "latest-tag", "msg")
+	b := report("dir/b.yaml", "app", "latest-tag", "msg")
+	assert.Equal(t, Fingerprint(&a), Fingerprint(&b))
+
+	other := report("a.yaml", "other-app", "latest-tag", "msg")
+	assert.NotEqual(t, Fingerprint(&a), Fingerprint(&other))
+}
+
+func TestFilter(t *testing.T) {
+	known := report("a.yaml", "app", "latest-tag", "msg")
+	stale := report("a.yaml", "removed-app", "latest-tag", "msg")
+	b := FromReports([]diagnostic.WithContext{known, stale})
+
+	fresh := report("a.yaml", "app", "run-as-non-root", "msg")
+	filtered, unmatched := b.Filter([]diagnostic.WithContext{fresh, known})
+
+	require.Len(t, filtered, 1)
+	assert.Equal(t, "run-as-non-root", filtered[0].Check)
+	require.Len(t, unmatched, 1)
+	assert.Equal(t, Fingerprint(&stale), unmatched[0].Fingerprint)
+}
+
+func TestWriteAndLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, os.RemoveAll(dir))
+	}()
+	path := filepath.Join(dir, "baseline.json")
+
+	// Duplicate reports must collapse to a single entry.
+	b := FromReports([]diagnostic.WithContext{
+		report("a.yaml", "app", "latest-tag", "msg"),
+		report("b.yaml", "app", "latest-tag", "msg"),
+	})
+	require.Len(t, b.Entries, 1)
+	require.NoError(t, b.Write(path))
+
+	loaded, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, b, loaded)
+}
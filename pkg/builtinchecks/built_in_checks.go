@@ -1,7 +1,9 @@
 package builtinchecks
 
 import (
+	"crypto/sha256"
 	"embed"
+	"encoding/hex"
 	"fmt"
 	"path/filepath"
 	"sync"
@@ -16,9 +18,15 @@ var (
 	//go:embed yamls
 	yamlFiles embed.FS
 
-	loadOnce sync.Once
-	list     []config.Check
-	loadErr  error
+	loadOnce      sync.Once
+	list          []config.Check
+	bundleVersion string
+	loadErr       error
+)
+
+const (
+	// bundleVersionLength is the number of hex digits of the digest that BundleVersion returns.
+	bundleVersionLength = 12
 )
 
 // LoadInto loads built-in checks into the registry.
@@ -43,6 +51,7 @@ func List() ([]config.Check, error) {
 			loadErr = errors.Wrap(err, "reading embedded yaml files")
 			return
 		}
+		digest := sha256.New()
 		for _, entry := range fileEntries {
 			if entry.IsDir() || filepath.Ext(entry.Name()) != ".yaml" {
 				loadErr = errors.Errorf("found unexpected entry %s in yamls directory", entry.Name())
@@ -55,6 +64,9 @@ func List() ([]config.Check, error) {
 				loadErr = errors.Wrapf(err, "loading file %s", entry.Name())
 				return
 			}
+			// The names are hashed too, so that renaming a check changes the version even if its definition does not.
+			_, _ = fmt.Fprintf(digest, "%s\x00%d\x00", entry.Name(), len(contents))
+			_, _ = digest.Write(contents)
 			var chk config.Check
 			if err := yaml.Unmarshal(contents, &chk); err != nil {
 				loadErr = errors.Wrapf(err, "unmarshalling default check from %s", entry.Name())
@@ -62,9 +74,19 @@ func List() ([]config.Check, error) {
 			}
 			list = append(list, chk)
 		}
+		bundleVersion = hex.EncodeToString(digest.Sum(nil))[:bundleVersionLength]
 	})
 	if loadErr != nil {
 		return nil, errors.Wrap(loadErr, "UNEXPECTED: failed to load built-in checks")
 	}
 	return list, nil
 }
+
+// BundleVersion returns the version of the built-in checks, which is a digest of their definitions. It changes
+// whenever a built-in check is added, removed or changed, so it identifies the policy that a binary enforces.
+func BundleVersion() (string, error) {
+	if _, err := List(); err != nil {
+		return "", err
+	}
+	return bundleVersion, nil
+}
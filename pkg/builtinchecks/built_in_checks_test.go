@@ -15,6 +15,17 @@ func TestBuiltInChecksWellFormed(t *testing.T) {
 		t.Run(check.Name, func(t *testing.T) {
 			assert.NotEmpty(t, check.Remediation, "Please add remediation")
 			assert.True(t, strings.HasSuffix(check.Remediation, "."), "Please end your remediation texts with a period (got %q)", check.Remediation)
+			assert.True(t, check.Severity.IsValid(), "Please set a valid severity (got %q)", check.Severity)
 		})
 	}
 }
+
+func TestBundleVersion(t *testing.T) {
+	bundleVersion, err := BundleVersion()
+	require.NoError(t, err)
+	assert.Regexp(t, "^[0-9a-f]{12}$", bundleVersion)
+
+	again, err := BundleVersion()
+	require.NoError(t, err)
+	assert.Equal(t, bundleVersion, again)
+}
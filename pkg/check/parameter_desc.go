@@ -31,13 +31,17 @@ type ParameterDesc struct {
 	Enum []string
 
 	// SubParameters are the child parameters of the given parameter.
-	// Only relevant if Type is "object".
+	// Only relevant if Type is "object", or if Type is "array" and ArrayElemType is "object".
 	SubParameters []ParameterDesc
 
 	// ArrayElemType is only set when the object is of type array, and it describes the type
 	// of the element of the array.
 	ArrayElemType ParameterType
 
+	// Default is the value that the template uses if the parameter is not set, as it would be written in a config
+	// file. It is only used for documentation.
+	Default string `json:",omitempty"`
+
 	// Required denotes whether the parameter is required.
 	Required bool
 
@@ -64,6 +68,8 @@ type HumanReadableParamDesc struct {
 	Description     string                   `json:"description"`
 	Required        bool                     `json:"required"`
 	Examples        []string                 `json:"examples,omitempty"`
+	Enum            []string                 `json:"enum,omitempty"`
+	Default         string                   `json:"default,omitempty"`
 	RegexAllowed    *bool                    `json:"regexAllowed,omitempty"`
 	NegationAllowed *bool                    `json:"negationAllowed,omitempty"`
 	SubParameters   []HumanReadableParamDesc `json:"subParameters,omitempty"`
@@ -83,6 +89,8 @@ func transform(p *ParameterDesc, nestingLevel int) HumanReadableParamDesc {
 		Description:  p.Description,
 		Required:     p.Required,
 		Examples:     p.Examples,
+		Enum:         p.Enum,
+		Default:      p.Default,
 		NestingLevel: nestingLevel,
 	}
 
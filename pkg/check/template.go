@@ -8,9 +8,17 @@ import (
 
 // A Func is a specific lint-check, which runs on a specific objects, and emits diagnostics if problems are found.
 // Checks have access to the entire LintContext, with all the objects in it, but must only report problems for the
-// object passed in the second argument.
+// object passed in the second argument. Each diagnostic is reported on its own, so checks of sub-elements of an
+// object, like its containers, should emit one diagnostic per offending sub-element, with the FieldPath of that
+// sub-element, instead of joining them into a single message.
 type Func func(lintCtx lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic
 
+// A ContextMatcher is a lint-check that needs to correlate the objects in a LintContext, for example to find the
+// objects that a selector matches. It is called once per LintContext, before any object in it is checked, and
+// returns the Func that checks the objects in that LintContext. This allows it to index the objects up front,
+// instead of going through all of them for every object it checks.
+type ContextMatcher func(lintCtx lintcontext.LintContext) Func
+
 // A Template is a template for a check.
 type Template struct {
 	// HumanName is a human-friendly name for the template.
@@ -23,7 +31,10 @@ type Template struct {
 
 	Parameters             []ParameterDesc                                          // TODO: use HumanReadableParamDesc for json output instead
 	ParseAndValidateParams func(params map[string]interface{}) (interface{}, error) `json:"-"`
-	Instantiate            func(parsedParams interface{}) (Func, error)             `json:"-"`
+	// Exactly one of Instantiate and InstantiateContextMatcher must be set. Templates that check every object on
+	// its own use Instantiate, templates that correlate the objects in a LintContext use InstantiateContextMatcher.
+	Instantiate               func(parsedParams interface{}) (Func, error)           `json:"-"`
+	InstantiateContextMatcher func(parsedParams interface{}) (ContextMatcher, error) `json:"-"`
 }
 
 // HumanReadableParameters helper transforms each of Template.Parameters to HumanReadableParamDesc.
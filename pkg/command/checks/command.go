@@ -25,6 +25,7 @@ const (
 Name: {{.Name}}
 Description: {{.Description}}
 Remediation: {{.Remediation}}
+Severity: {{.Severity}}
 Template: {{.Template}}
 Parameters: {{.Params}}
 Enabled by default: {{ isDefault . }}
@@ -44,6 +45,8 @@ KubeLinter includes the following built-in checks:
 
 **Remediation**: {{.Remediation}}
 
+**Severity**: {{.Severity}}
+
 **Template**: [{{.Template}}](generated/templates.md#{{ templateLink . }})
 
 **Parameters**:
@@ -66,24 +69,32 @@ var (
 
 	formatters = common.Formatters{
 		Formatters: map[common.FormatType]common.FormatFunc{
-			common.PlainFormat:    plainTemplate.Execute,
+			common.PlainFormat:    common.PlainTemplateFormatter(plainTemplate),
 			common.MarkdownFormat: markDownTemplate.Execute,
-			common.JSONFormat:     common.FormatJSON,
+			common.JSONFormat:     formatChecksJSON,
 		},
 	}
 )
 
 func listCommand() *cobra.Command {
 	format := flagutil.NewEnumFlag("Output format", formatters.GetEnabledFormatters(), common.PlainFormat)
+	var templateKey string
+	var enabledOnly bool
 	c := &cobra.Command{
 		Use:   "list",
 		Short: "List built-in checks",
 		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, _ []string) error {
+			if templateKey != "" {
+				if _, found := templates.Get(templateKey); !found {
+					return errors.Errorf("template %q not found%s", templateKey, templates.DidYouMean(templateKey))
+				}
+			}
 			checks, err := builtinchecks.List()
 			if err != nil {
 				return err
 			}
+			checks = filterChecks(checks, templateKey, enabledOnly)
 			sort.Slice(checks, func(i, j int) bool {
 				return checks[i].Name < checks[j].Name
 			})
@@ -95,9 +106,27 @@ func listCommand() *cobra.Command {
 		},
 	}
 	c.Flags().Var(format, "format", format.Usage())
+	c.Flags().StringVar(&templateKey, "template", "", "Only list checks built on the template with this key")
+	c.Flags().BoolVar(&enabledOnly, "enabled-only", false, "Only list checks that are enabled by default")
 	return c
 }
 
+// filterChecks returns the checks built on the given template, if it is not empty, and that are enabled by default,
+// if enabledOnly is set.
+func filterChecks(checks []config.Check, templateKey string, enabledOnly bool) []config.Check {
+	filtered := make([]config.Check, 0, len(checks))
+	for _, check := range checks {
+		if templateKey != "" && check.Template != templateKey {
+			continue
+		}
+		if enabledOnly && !defaultchecks.List.Contains(check.Name) {
+			continue
+		}
+		filtered = append(filtered, check)
+	}
+	return filtered
+}
+
 // Command defines the root of the checks command.
 func Command() *cobra.Command {
 	c := &cobra.Command{
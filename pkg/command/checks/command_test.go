@@ -0,0 +1,30 @@
+package checks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.stackrox.io/kube-linter/pkg/config"
+)
+
+func TestFilterChecks(t *testing.T) {
+	checks := []config.Check{
+		{Name: "latest-tag", Template: "latest-tag"},
+		{Name: "docker-sock", Template: "host-mounts"},
+		{Name: "sensitive-host-mounts", Template: "host-mounts"},
+		{Name: "required-label-owner", Template: "required-label"},
+	}
+	names := func(checks []config.Check) []string {
+		var result []string
+		for _, check := range checks {
+			result = append(result, check.Name)
+		}
+		return result
+	}
+
+	assert.Equal(t, names(checks), names(filterChecks(checks, "", false)))
+	assert.Equal(t, []string{"docker-sock", "sensitive-host-mounts"}, names(filterChecks(checks, "host-mounts", false)))
+	assert.Equal(t, []string{"latest-tag", "docker-sock", "sensitive-host-mounts"}, names(filterChecks(checks, "", true)))
+	assert.Empty(t, filterChecks(checks, "required-label", true))
+	assert.Empty(t, filterChecks(checks, "privileged", false))
+}
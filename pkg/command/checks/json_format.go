@@ -0,0 +1,70 @@
+package checks
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/pkg/errors"
+	"golang.stackrox.io/kube-linter/internal/defaultchecks"
+	"golang.stackrox.io/kube-linter/pkg/config"
+)
+
+const (
+	// checkJSONVersion is the version of the JSON object of a check. It must be incremented whenever a field is
+	// removed or changes meaning; adding fields is backwards compatible.
+	checkJSONVersion = 1
+)
+
+// checkJSON is the JSON object of a check. The list of checks is a JSON array of them, and its fields are a superset
+// of the ones of config.Check, which the list used to be made of.
+type checkJSON struct {
+	Version          int                     `json:"version"`
+	Name             string                  `json:"name"`
+	Description      string                  `json:"description"`
+	Remediation      string                  `json:"remediation"`
+	DocsURL          string                  `json:"docsURL,omitempty"`
+	Template         string                  `json:"template"`
+	Scope            *config.ObjectKindsDesc `json:"scope"`
+	Namespaces       *config.NamespaceScope  `json:"namespaces,omitempty"`
+	EnabledByDefault bool                    `json:"enabledByDefault"`
+	Severity         config.Severity         `json:"severity"`
+	Params           map[string]interface{}  `json:"params"`
+}
+
+// formatChecksJSON implements common.JSONFormat.
+// Must be used only with the list command because it only understands []config.Check as data parameter.
+func formatChecksJSON(out io.Writer, data interface{}) error {
+	if checks, ok := data.([]config.Check); ok {
+		return formatJSON(out, checks)
+	}
+	return errors.New("Provided data must be of []config.Check type")
+}
+
+func formatJSON(out io.Writer, checks []config.Check) error {
+	// Make sure we output an empty array rather than null if there are no checks.
+	doc := make([]checkJSON, 0, len(checks))
+	for _, check := range checks {
+		severity := check.Severity
+		if severity == "" {
+			severity = config.DefaultSeverity
+		}
+		params := check.Params
+		if params == nil {
+			params = make(map[string]interface{})
+		}
+		doc = append(doc, checkJSON{
+			Version:          checkJSONVersion,
+			Name:             check.Name,
+			Description:      check.Description,
+			Remediation:      check.Remediation,
+			DocsURL:          check.DocsURL,
+			Template:         check.Template,
+			Scope:            check.Scope,
+			Namespaces:       check.Namespaces,
+			EnabledByDefault: defaultchecks.List.Contains(check.Name),
+			Severity:         severity,
+			Params:           params,
+		})
+	}
+	return json.NewEncoder(out).Encode(doc)
+}
@@ -0,0 +1,48 @@
+package checks
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.stackrox.io/kube-linter/pkg/config"
+)
+
+func TestFormatChecksJSON(t *testing.T) {
+	checks := []config.Check{
+		{
+			Name:        "latest-tag",
+			Description: "description",
+			Remediation: "remediation.",
+			Template:    "latest-tag",
+			Severity:    config.SeverityWarning,
+			Params:      map[string]interface{}{"blockList": []string{".*:latest$"}},
+		},
+		{
+			Name:     "custom",
+			Template: "required-label",
+		},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, formatChecksJSON(&buf, checks))
+
+	expected := `[
+		{"version": 1, "name": "latest-tag", "description": "description", "remediation": "remediation.", "template": "latest-tag",
+		 "scope": null, "enabledByDefault": true, "severity": "warning", "params": {"blockList": [".*:latest$"]}},
+		{"version": 1, "name": "custom", "description": "", "remediation": "", "template": "required-label",
+		 "scope": null, "enabledByDefault": false, "severity": "error", "params": {}}
+	]`
+	assert.JSONEq(t, expected, buf.String())
+
+	assert.Error(t, formatChecksJSON(&buf, "not checks"))
+}
+
+func TestFormatChecksJSONIsValidJSON(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, formatChecksJSON(&buf, []config.Check{}))
+	var doc []interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+	assert.Equal(t, []interface{}{}, doc)
+}
@@ -20,6 +20,21 @@ const (
 	// SARIFFormat is JSON-based standard for reporting lint errors.
 	// See https://www.oasis-open.org/committees/tc_home.php?wg_abbrev=sarif
 	SARIFFormat = "sarif"
+	// JUnitFormat is the JUnit XML format understood by most CI systems for rendering test results.
+	JUnitFormat = "junit"
+	// CSVFormat is for comma-separated values, one row per lint error, suitable for spreadsheets.
+	CSVFormat = "csv"
+	// GitHubActionsFormat is for GitHub Actions workflow commands, which show up as annotations on the affected files.
+	// See https://docs.github.com/en/actions/reference/workflow-commands-for-github-actions
+	GitHubActionsFormat = "github-actions"
+	// CodeClimateFormat is the CodeClimate JSON issue format, as ingested by GitLab Code Quality.
+	// See https://docs.gitlab.com/ee/user/project/merge_requests/code_quality.html#implementing-a-custom-tool
+	CodeClimateFormat = "codeclimate"
+	// HTMLFormat is for a self-contained HTML page, suitable for publishing as a CI artifact.
+	HTMLFormat = "html"
+	// JSONLinesFormat is for JSON Lines, one compact JSON object per lint error on its own line, suitable for piping
+	// into log processors. See https://jsonlines.org
+	JSONLinesFormat = "jsonl"
 )
 
 // FormatFunc sets contract formatter of each FormatType should follow.
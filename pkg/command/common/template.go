@@ -1,17 +1,25 @@
 package common
 
 import (
+	"fmt"
+	htmlTemplate "html/template"
+	"io"
+	"os"
 	"strings"
 	"text/template"
 
 	"github.com/Masterminds/sprig/v3"
 	"github.com/fatih/color"
+	"github.com/mattn/go-isatty"
 	"golang.stackrox.io/kube-linter/internal/utils"
+	"golang.stackrox.io/kube-linter/pkg/config"
 )
 
 var (
 	colorRed    = color.New(color.FgRed)
 	colorYellow = color.New(color.FgYellow)
+	colorGreen  = color.New(color.FgGreen)
+	colorBlue   = color.New(color.FgBlue)
 	colorBold   = color.New(color.Bold)
 
 	markdownFuncs = template.FuncMap{
@@ -33,10 +41,65 @@ var (
 	plainFuncs = template.FuncMap{
 		"red":    colorRed.Sprint,
 		"yellow": colorYellow.Sprint,
+		"green":  colorGreen.Sprint,
 		"bold":   colorBold.Sprint,
+
+		"severityIcon": severityIcon,
+	}
+
+	// noColorPlainFuncs replace the functions of plainFuncs when the output is not colored.
+	noColorPlainFuncs = template.FuncMap{
+		"red":    fmt.Sprint,
+		"yellow": fmt.Sprint,
+		"green":  fmt.Sprint,
+		"bold":   fmt.Sprint,
+
+		// Icons only make sense alongside colors, and uncolored output is often read by scripts.
+		"severityIcon": func(config.Severity) string { return "" },
 	}
 )
 
+// severityIcon returns the colored icon that plain output prefixes findings of the given severity with, followed by a
+// space, or an empty string for unknown severities.
+func severityIcon(severity config.Severity) string {
+	switch severity {
+	case config.SeverityError:
+		return colorRed.Sprint("✖") + " "
+	case config.SeverityWarning:
+		return colorYellow.Sprint("⚠") + " "
+	case config.SeverityInfo:
+		return colorBlue.Sprint("ℹ") + " "
+	}
+	return ""
+}
+
+// DisableColor disables colored output, as if the NO_COLOR environment variable was set.
+func DisableColor() {
+	color.NoColor = true
+}
+
+// ColorEnabled returns whether plain output written to out is colored. It is not if the NO_COLOR environment variable
+// is set, TERM is dumb, DisableColor was called, or out is not a terminal, like when it is redirected to a file.
+func ColorEnabled(out io.Writer) bool {
+	if color.NoColor {
+		return false
+	}
+	f, ok := out.(*os.File)
+	return ok && (isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd()))
+}
+
+// PlainTemplateFormatter returns a FormatFunc that executes the given plain template, with color functions that do
+// not emit any ANSI escape codes if ColorEnabled is false for the writer.
+func PlainTemplateFormatter(tpl *template.Template) FormatFunc {
+	noColorTpl := template.Must(tpl.Clone()).Funcs(noColorPlainFuncs)
+	return func(out io.Writer, data interface{}) error {
+		if ColorEnabled(out) {
+			return tpl.Execute(out, data)
+		}
+		return noColorTpl.Execute(out, data)
+	}
+}
+
 // MustInstantiateMarkdownTemplate instantiates the given go template with a common list of markdown functions.
 // It panics if there is an error.
 func MustInstantiateMarkdownTemplate(templateStr string, customFuncMap template.FuncMap) *template.Template {
@@ -53,7 +116,21 @@ func MustInstantiatePlainTemplate(templateStr string, customFuncMap template.Fun
 	return tpl
 }
 
+// InstantiatePlainTemplate is like MustInstantiatePlainTemplate, but returns an error instead of panicking.
+// It is intended for templates supplied by users.
+func InstantiatePlainTemplate(templateStr string, customFuncMap template.FuncMap) (*template.Template, error) {
+	return instantiateTemplate(templateStr, plainFuncs, customFuncMap)
+}
+
 func instantiateTemplate(templateStr string, commonFuncMap, customFuncMap template.FuncMap) (*template.Template, error) {
 	tpl, err := template.New("").Funcs(sprig.TxtFuncMap()).Funcs(commonFuncMap).Funcs(customFuncMap).Parse(templateStr)
 	return tpl, err
 }
+
+// MustInstantiateHTMLTemplate instantiates the given html template, which escapes all interpolated values.
+// It panics if there is an error.
+func MustInstantiateHTMLTemplate(templateStr string, customFuncMap htmlTemplate.FuncMap) *htmlTemplate.Template {
+	tpl, err := htmlTemplate.New("").Funcs(sprig.HtmlFuncMap()).Funcs(customFuncMap).Parse(templateStr)
+	utils.Must(err)
+	return tpl
+}
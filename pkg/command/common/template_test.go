@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"testing"
 
+	"github.com/fatih/color"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"golang.stackrox.io/kube-linter/pkg/command/common"
+	"golang.stackrox.io/kube-linter/pkg/config"
 )
 
 func TestMarkdownFunctions(t *testing.T) {
@@ -35,3 +37,48 @@ func TestMarkdownFunctions(t *testing.T) {
 		assert.Equal(t, tt.out, b.String())
 	}
 }
+
+func TestHTMLTemplateEscapes(t *testing.T) {
+	tpl := common.MustInstantiateHTMLTemplate("<td>{{ . }}</td>", nil)
+
+	var b bytes.Buffer
+	require.NoError(t, tpl.Execute(&b, "<script>alert('x')</script>"))
+
+	assert.Equal(t, "<td>&lt;script&gt;alert(&#39;x&#39;)&lt;/script&gt;</td>", b.String())
+}
+
+func TestPlainTemplateFormatterWithoutTerminal(t *testing.T) {
+	tpl := common.MustInstantiatePlainTemplate(`{{ . | red }} {{ . | yellow }} {{ . | green }} {{ . | bold }}`, nil)
+
+	// Output that is not written to a terminal, like a file or a pipe, is never colored.
+	var b bytes.Buffer
+	require.NoError(t, common.PlainTemplateFormatter(tpl)(&b, "x"))
+
+	assert.Equal(t, "x x x x", b.String())
+	assert.False(t, common.ColorEnabled(&b))
+}
+
+func TestSeverityIcon(t *testing.T) {
+	tpl := common.MustInstantiatePlainTemplate(`{{ severityIcon . }}finding`, nil)
+
+	noColor := color.NoColor
+	color.NoColor = false
+	defer func() {
+		color.NoColor = noColor
+	}()
+	for severity, expected := range map[config.Severity]string{
+		config.SeverityError:   "\x1b[31m✖\x1b[0m finding",
+		config.SeverityWarning: "\x1b[33m⚠\x1b[0m finding",
+		config.SeverityInfo:    "\x1b[34mℹ\x1b[0m finding",
+		"unknown":              "finding",
+	} {
+		var b bytes.Buffer
+		require.NoError(t, tpl.Execute(&b, severity))
+		assert.Equal(t, expected, b.String(), severity)
+	}
+
+	// Uncolored output has no icons either.
+	var b bytes.Buffer
+	require.NoError(t, common.PlainTemplateFormatter(tpl)(&b, config.SeverityError))
+	assert.Equal(t, "finding", b.String())
+}
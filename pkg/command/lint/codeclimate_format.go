@@ -0,0 +1,105 @@
+package lint
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+
+	"github.com/pkg/errors"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/run"
+)
+
+type codeClimateIssue struct {
+	Type        string              `json:"type"`
+	Description string              `json:"description"`
+	CheckName   string              `json:"check_name"`
+	Fingerprint string              `json:"fingerprint"`
+	Severity    string              `json:"severity"`
+	Location    codeClimateLocation `json:"location"`
+}
+
+type codeClimateLocation struct {
+	Path  string           `json:"path"`
+	Lines codeClimateLines `json:"lines"`
+}
+
+type codeClimateLines struct {
+	Begin int `json:"begin"`
+}
+
+// formatLintCodeClimate implements common.CodeClimateFormat.
+// Must be used only with lint.Command because it only understands run.Result as data parameter.
+func formatLintCodeClimate(out io.Writer, data interface{}) error {
+	if res, ok := data.(run.Result); ok {
+		return formatCodeClimate(out, res)
+	}
+	return errors.New("Provided data must be of run.Result type")
+}
+
+func formatCodeClimate(out io.Writer, result run.Result) error {
+	// Make sure we output an empty array rather than null if there are no reports.
+	issues := make([]codeClimateIssue, 0, len(result.Reports)+len(result.LoadErrors))
+	for i := range result.Reports {
+		report := &result.Reports[i]
+		line, _ := reportPosition(report)
+		issues = append(issues, codeClimateIssue{
+			Type:        "issue",
+			Description: report.Diagnostic.Message,
+			CheckName:   report.Check,
+			Fingerprint: codeClimateFingerprint(report),
+			Severity:    codeClimateSeverity(report.Severity),
+			Location: codeClimateLocation{
+				Path:  report.Object.Metadata.FilePath,
+				Lines: codeClimateLines{Begin: line},
+			},
+		})
+	}
+	for i := range result.LoadErrors {
+		loadErr := &result.LoadErrors[i]
+		issues = append(issues, codeClimateIssue{
+			Type:        "issue",
+			Description: loadErr.Message,
+			CheckName:   loadErrorCheckName,
+			Fingerprint: fingerprint(loadErrorCheckName, loadErr.FilePath),
+			Severity:    codeClimateSeverity(config.SeverityError),
+			Location: codeClimateLocation{
+				Path:  loadErr.FilePath,
+				Lines: codeClimateLines{Begin: loadErrorLine(loadErr)},
+			},
+		})
+	}
+	return json.NewEncoder(out).Encode(issues)
+}
+
+// codeClimateFingerprint identifies an issue across runs, so that GitLab can tell introduced issues from fixed ones.
+// It must therefore only depend on the identity of the issue, and never on the order in which issues were found.
+// The message tells apart the issues of a check on the same object, like the ones about each of its containers, and
+// unlike the field path, it does not change when the containers are reordered.
+func codeClimateFingerprint(report *diagnostic.WithContext) string {
+	return fingerprint(report.Check, report.Object.GetK8sObjectName().String(), report.Object.Metadata.FilePath, report.Diagnostic.Message)
+}
+
+func fingerprint(parts ...string) string {
+	h := sha256.New()
+	for _, part := range parts {
+		_, _ = h.Write([]byte(part))
+		// Separate the parts so that different tuples can never produce the same input.
+		_, _ = h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// codeClimateSeverity maps a severity to one of the severities defined by the CodeClimate spec.
+func codeClimateSeverity(severity config.Severity) string {
+	switch severity {
+	case config.SeverityWarning:
+		return "minor"
+	case config.SeverityInfo:
+		return "info"
+	default:
+		return "major"
+	}
+}
@@ -0,0 +1,75 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.stackrox.io/kube-linter/pkg/builtinchecks"
+	"golang.stackrox.io/kube-linter/pkg/checkregistry"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/run"
+)
+
+const (
+	latestDeploymentHeader = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  template:
+    spec:
+      containers:
+`
+	latestAppContainer = `      - name: app
+        image: app:latest
+`
+	latestSidecarContainer = `      - name: sidecar
+        image: sidecar:latest
+`
+)
+
+// codeClimateFingerprints lints a deployment with the given containers, and returns the fingerprints of the issues of
+// the latest-tag check by their description.
+func codeClimateFingerprints(t *testing.T, containers ...string) map[string]string {
+	dir := t.TempDir()
+	manifest := []byte(latestDeploymentHeader + strings.Join(containers, ""))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "deployment.yaml"), manifest, 0644))
+	registry := checkregistry.New()
+	require.NoError(t, builtinchecks.LoadInto(registry))
+	lintCtxs, err := lintcontext.CreateContexts(filepath.Join(dir, "deployment.yaml"))
+	require.NoError(t, err)
+	result, err := run.Run(lintCtxs, registry, []string{"latest-tag"})
+	require.NoError(t, err)
+	// Paths are made relative like in CI, so that the fingerprints of both runs can be compared.
+	require.NoError(t, relativizeFilePaths(&result, dir, []string{dir}))
+
+	var out bytes.Buffer
+	require.NoError(t, formatCodeClimate(&out, result))
+	var issues []codeClimateIssue
+	require.NoError(t, json.Unmarshal(out.Bytes(), &issues))
+	fingerprints := make(map[string]string)
+	for _, issue := range issues {
+		fingerprints[issue.Description] = issue.Fingerprint
+	}
+	return fingerprints
+}
+
+func TestCodeClimateFingerprintsOfFindingsOnTheSameObject(t *testing.T) {
+	fingerprints := codeClimateFingerprints(t, latestAppContainer, latestSidecarContainer)
+	require.Len(t, fingerprints, 2)
+	seen := make(map[string]bool)
+	for _, fingerprint := range fingerprints {
+		assert.False(t, seen[fingerprint], "issues of the same check on the same object have the same fingerprint")
+		seen[fingerprint] = true
+	}
+
+	// Reordering the containers keeps the fingerprint of each issue.
+	reordered := codeClimateFingerprints(t, latestSidecarContainer, latestAppContainer)
+	assert.Equal(t, fingerprints, reordered)
+}
@@ -1,66 +1,252 @@
 package lint
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"sort"
+	"text/template"
+	"time"
 
+	"golang.stackrox.io/kube-linter/internal/fileutil"
 	"golang.stackrox.io/kube-linter/internal/flagutil"
+	"golang.stackrox.io/kube-linter/internal/logging"
+	"golang.stackrox.io/kube-linter/internal/set"
+	"golang.stackrox.io/kube-linter/internal/version"
+	"golang.stackrox.io/kube-linter/pkg/baseline"
 	"golang.stackrox.io/kube-linter/pkg/builtinchecks"
 	"golang.stackrox.io/kube-linter/pkg/checkregistry"
 	"golang.stackrox.io/kube-linter/pkg/command/common"
 	"golang.stackrox.io/kube-linter/pkg/config"
 	"golang.stackrox.io/kube-linter/pkg/configresolver"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
 	"golang.stackrox.io/kube-linter/pkg/lintcontext"
 	"golang.stackrox.io/kube-linter/pkg/run"
 
+	"github.com/ghodss/yaml"
+	"github.com/mattn/go-isatty"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
 const (
-	plainTemplateStr = `KubeLinter {{.Summary.KubeLinterVersion}}
+	// failOnNone is the --fail-on value that never fails the command because of lint errors.
+	failOnNone = "none"
 
-{{range .Reports}}
-{{- .Object.Metadata.FilePath | bold}}: (object: {{.Object.GetK8sObjectName | bold}}) {{.Diagnostic.Message | red}} (check: {{.Check | yellow}}, remediation: {{.Remediation | yellow}})
+	// loadErrorCheckName is the check name that formats which need one give to files that could not be loaded.
+	loadErrorCheckName = "load-error"
+	// loadErrorRemediation is the remediation that formats which need one give to files that could not be loaded.
+	loadErrorRemediation = "Fix the error, so that the objects in the file can be linted."
+
+	plainHeaderTemplateStr = `KubeLinter {{.Summary.KubeLinterVersion}}
+
+{{if not .Reports}}No lint errors found!
+{{end -}}
+`
+
+	plainReportsTemplateStr = `{{range .Reports}}
+{{- severityIcon .Severity}}{{.Object.Metadata.FilePath | bold}}: (object: {{.Object.GetK8sObjectName | bold}}) {{.Diagnostic.Message | red}} (check: {{.Check | yellow}}, remediation: {{.Remediation | yellow}}{{with .DocsURL}}, docs: {{. | yellow}}{{end}})
+{{with .Snippet}}{{indent 4 .}}
+{{end}}
+{{end -}}
+` + plainSuppressedTemplateStr
+
+	// plainSuppressedTemplateStr tells how many lint errors --max-findings left out of the output.
+	plainSuppressedTemplateStr = `{{with .Summary.SuppressedReports}}({{.}} more {{plural "finding" "findings" .}} suppressed by --max-findings)
+
+{{end -}}
+`
+
+	plainLoadErrorsTemplateStr = `{{range .LoadErrors}}
+{{- .FilePath | bold}}{{if .Line}}:{{.Line}}{{end}}: {{.Message | red}} (could not be loaded, so its objects were not linted)
 
-{{else}}No lint errors found!
 {{end -}}
 `
+
+	plainFooterTemplateStr = `{{- with .Summary.Counts}}{{if .Reports}}
+{{- .Reports}} {{plural "finding" "findings" .Reports}} across {{.Objects}} {{plural "object" "objects" .Objects}} in {{.Files}} {{plural "file" "files" .Files}}
+{{- ""}} ({{.Errors}} {{plural "error" "errors" .Errors}}, {{.Warnings}} {{plural "warning" "warnings" .Warnings}}{{if .Infos}}, {{.Infos}} info{{end}})
+{{end}}{{end -}}
+`
+
+	// quietPlainTemplateStr is the plain format with --quiet, which only prints the lint errors and load errors.
+	quietPlainTemplateStr = plainReportsTemplateStr + plainLoadErrorsTemplateStr
+
+	plainTemplateStr = plainHeaderTemplateStr + quietPlainTemplateStr + plainFooterTemplateStr
 )
 
 var (
-	plainTemplate = common.MustInstantiatePlainTemplate(plainTemplateStr, nil)
+	plainTemplate      = common.MustInstantiatePlainTemplate(plainTemplateStr, nil)
+	quietPlainTemplate = common.MustInstantiatePlainTemplate(quietPlainTemplateStr, nil)
+
+	customTemplateFuncs = template.FuncMap{
+		"json": func(v interface{}) (string, error) {
+			out, err := json.Marshal(v)
+			return string(out), err
+		},
+	}
 
 	formatters = common.Formatters{
 		Formatters: map[common.FormatType]common.FormatFunc{
-			common.JSONFormat:  common.FormatJSON,
-			common.SARIFFormat: formatLintSarif,
-			common.PlainFormat: plainTemplate.Execute,
+			common.JSONFormat:          common.FormatJSON,
+			common.SARIFFormat:         formatLintSarif,
+			common.JUnitFormat:         formatLintJUnit,
+			common.CSVFormat:           formatLintCSV,
+			common.GitHubActionsFormat: formatLintGitHubActions,
+			common.CodeClimateFormat:   formatLintCodeClimate,
+			common.HTMLFormat:          formatLintHTML,
+			common.JSONLinesFormat:     formatLintJSONLines,
+			common.MarkdownFormat:      formatLintMarkdown,
+			common.PlainFormat:         common.PlainTemplateFormatter(plainTemplate),
 		},
 	}
 )
 
 // Command is the command for the lint command.
 func Command() *cobra.Command {
-	var configPath string
-	var verbose bool
+	var configPaths []string
+	var printConfig, snippets bool
+	var verbose, quiet, progress, watch, noFail, stats, requireChecks bool
+	var fromCluster, jsonLinesSummary, relativePaths bool
+	var kubeconfig, namespace, labelSelector string
+	var outputFile, summaryFilePath string
+	var reportSpecs []string
+	var sarifAutomationID string
+	var relativeTo string
+	var templateStr, templateFile string
+	var baselinePath string
+	var sinceRef string
+	var writeBaseline bool
+	var timeout time.Duration
+	var workers, maxFindings int
+	var excludePaths []string
+	var helmValueFiles, helmSetValues []string
+	var skipHelmHooks bool
+	var includeObjects, excludeObjects []string
 	format := flagutil.NewEnumFlag("Output format", formatters.GetEnabledFormatters(), common.PlainFormat)
+	groupBy := flagutil.NewEnumFlag("How to group lint errors in the plain format. \"check\" lists the lint errors of every check together, "+
+		"starting with the check that affects the most objects", []string{groupByObject, groupByCheck}, groupByObject)
+	logLevel := flagutil.NewEnumFlag("Minimum level of the messages logged to stderr. --verbose is short for debug, and --quiet for error",
+		logging.AllLevels(), logging.LevelInfo.String())
+	logFormat := flagutil.NewEnumFlag("Format of the messages logged to stderr. \"json\" logs a JSON object per line, for machine ingestion",
+		logging.AllFormats(), string(logging.FormatText))
+	failOn := flagutil.NewEnumFlag("Minimum severity of lint errors that makes the command exit with code 1. "+
+		"If no lint error reaches it, the command exits with code 0. \"none\" never fails because of lint errors",
+		append(severityStrings(), failOnNone), string(config.SeverityInfo))
 
 	v := viper.New()
 
 	c := &cobra.Command{
-		Use:   "lint",
-		Args:  cobra.MinimumNArgs(1),
+		Use: "lint",
+		Args: func(cmd *cobra.Command, args []string) error {
+			// The effective config can be printed without linting anything.
+			if printConfig || fromCluster {
+				return nil
+			}
+			return cobra.MinimumNArgs(1)(cmd, args)
+		},
 		Short: "Lint Kubernetes YAML files and Helm charts",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if verbose && quiet {
+				return errors.New("only one of --verbose and --quiet can be specified")
+			}
+			logger, err := newLogger(cmd, logLevel.String(), logging.Format(logFormat.String()), verbose, quiet)
+			if err != nil {
+				return err
+			}
+			if watch && set.NewStringSet(args...).Contains(lintcontext.StdinArg) {
+				return errors.New("--watch cannot be used when reading from standard input")
+			}
+			if watch && writeBaseline {
+				// Every change would otherwise accept the lint errors that it introduces into the baseline.
+				return errors.New("--watch cannot be used with --write-baseline")
+			}
+			if fromCluster && watch {
+				return errors.New("--watch cannot be used with --from-cluster")
+			}
+			if fromCluster && sinceRef != "" {
+				return errors.New("--since cannot be used with --from-cluster")
+			}
+			if !fromCluster && (cmd.Flags().Changed("namespace") || cmd.Flags().Changed("selector") || cmd.Flags().Changed("kubeconfig")) {
+				return errors.New("--namespace, --selector and --kubeconfig can only be used with --from-cluster")
+			}
+			if maxFindings < 0 {
+				return errors.New("--max-findings cannot be negative")
+			}
+			if writeBaseline && baselinePath == "" {
+				return errors.New("--write-baseline requires --baseline to be set")
+			}
+			// Resolve the formatters up front, so that a broken custom template fails before any linting work.
+			customTemplate, err := loadCustomTemplate(templateStr, templateFile)
+			if err != nil {
+				return err
+			}
+			outputs, err := parseReportOutputs(reportOutput{format: format.String(), file: outputFile, customTemplate: customTemplate != nil},
+				reportSpecs, formatters.GetEnabledFormatters())
+			if err != nil {
+				return err
+			}
+			if jsonLinesSummary && !usesFormat(outputs, common.JSONLinesFormat) {
+				return errors.New("--jsonl-summary is only supported by the jsonl format")
+			}
+			if cmd.Flags().Changed("sarif-automation-id") && !usesFormat(outputs, common.SARIFFormat) {
+				return errors.New("--sarif-automation-id is only supported by the sarif format")
+			}
+			if groupBy.String() == groupByCheck && !usesFormat(outputs, common.PlainFormat) {
+				return errors.New("--group-by check is only supported by the plain format")
+			}
+			// The options of a format apply to all the outputs in it.
+			for i := range outputs {
+				output := &outputs[i]
+				switch {
+				case output.customTemplate:
+					output.formatter = common.PlainTemplateFormatter(customTemplate)
+				case output.format == common.PlainFormat && groupBy.String() == groupByCheck:
+					if quiet {
+						output.formatter = plainByCheckFormatter(quietPlainByCheckTemplate)
+					} else {
+						output.formatter = plainByCheckFormatter(plainByCheckTemplate)
+					}
+				case output.format == common.PlainFormat && quiet:
+					output.formatter = common.PlainTemplateFormatter(quietPlainTemplate)
+				case output.format == common.JSONLinesFormat && jsonLinesSummary:
+					output.formatter = formatLintJSONLinesWithSummary
+				default:
+					if output.formatter, err = formatters.FormatterByType(output.format); err != nil {
+						return err
+					}
+				}
+			}
+			includeSelectors, err := run.ParseObjectSelectors(includeObjects)
+			if err != nil {
+				return errors.Wrap(err, "invalid --include-objects")
+			}
+			excludeSelectors, err := run.ParseObjectSelectors(excludeObjects)
+			if err != nil {
+				return errors.Wrap(err, "invalid --exclude-objects")
+			}
+			// The base directory of --relative-paths, resolved once, so that changing directories cannot move it.
+			var pathBase string
+			if relativePaths || relativeTo != "" {
+				if relativeTo == "" {
+					relativeTo = "."
+				}
+				if pathBase, err = filepath.Abs(relativeTo); err != nil {
+					return errors.Wrap(err, "invalid --relative-to")
+				}
+			}
+
 			checkRegistry := checkregistry.New()
 			if err := builtinchecks.LoadInto(checkRegistry); err != nil {
 				return err
 			}
 
 			// Load Configuration
-			cfg, err := config.Load(v, configPath)
+			cfg, err := config.Load(v, configPaths...)
 			if err != nil {
 				return errors.Wrap(err, "failed to load config")
 			}
@@ -68,68 +254,451 @@ func Command() *cobra.Command {
 			if err := configresolver.LoadCustomChecksInto(&cfg, checkRegistry); err != nil {
 				return err
 			}
-			enabledChecks, err := configresolver.GetEnabledChecksAndValidate(&cfg, checkRegistry)
-			if err != nil {
+			if err := configresolver.RegisterCustomObjectKinds(&cfg); err != nil {
 				return err
 			}
-			if len(enabledChecks) == 0 {
-				fmt.Fprintln(os.Stderr, "Warning: no checks enabled.")
-				return nil
+			configresolver.ApplyOpenShiftKinds(&cfg)
+			if err := configresolver.ApplySeverityOverrides(&cfg, checkRegistry); err != nil {
+				return err
 			}
-			lintCtxs, err := lintcontext.CreateContexts(args...)
+			if err := configresolver.ApplyNamespaceScopes(&cfg, checkRegistry); err != nil {
+				return err
+			}
+			if err := configresolver.ApplyRemediationOverrides(&cfg, checkRegistry); err != nil {
+				return err
+			}
+			enabledChecks, err := configresolver.GetEnabledChecksAndValidate(&cfg, checkRegistry)
 			if err != nil {
 				return err
 			}
-			if verbose {
-				for _, lintCtx := range lintCtxs {
-					for _, invalidObj := range lintCtx.InvalidObjects() {
-						fmt.Fprintf(os.Stderr, "Warning: failed to load object from %s: %v\n", invalidObj.Metadata.FilePath, invalidObj.LoadErr)
-					}
-				}
+			if printConfig {
+				return printEffectiveConfig(cfg, checkRegistry, enabledChecks)
 			}
-			var atLeastOneObjectFound bool
-			for _, lintCtx := range lintCtxs {
-				if len(lintCtx.Objects()) > 0 {
-					atLeastOneObjectFound = true
-					break
+			if len(enabledChecks) == 0 {
+				reason := configresolver.DescribeNoEnabledChecks(&cfg)
+				if requireChecks {
+					return errors.Errorf("no checks enabled: %s", reason)
+				}
+				logger.Warn(fmt.Sprintf("no checks enabled, so nothing is linted: %s. Use --require-checks to make this an error.", reason))
+				if summaryFilePath != "" {
+					return writeSummaryFile(summaryFilePath, run.Result{Summary: run.Summary{KubeLinterVersion: version.Get()}}, false)
 				}
-			}
-			if !atLeastOneObjectFound {
-				fmt.Fprintln(os.Stderr, "Warning: no valid objects found.")
 				return nil
 			}
-			result, err := run.Run(lintCtxs, checkRegistry, enabledChecks)
-			if err != nil {
-				return err
-			}
+			// Everything from here on is repeated on every change in watch mode. Config and checks are only loaded once.
+			lint := func() error {
+				var changedFiles []string
+				if sinceRef != "" {
+					// Resolved on every run, so that watch mode picks up files as they change.
+					if changedFiles, err = changedFilesSince(sinceRef); err != nil {
+						return err
+					}
+				}
+				var lintCtxs []lintcontext.LintContext
+				if len(args) > 0 {
+					if lintCtxs, err = lintcontext.CreateContextsWithOptions(lintcontext.Options{
+						URLFetchTimeout: timeout,
+						Exclude:         excludePaths,
+						HelmValueFiles:  helmValueFiles,
+						HelmSetValues:   helmSetValues,
+						SkipHelmHooks:   skipHelmHooks,
+						OnlyFiles:       changedFiles,
+						SkippedArchiveEntry: func(filePath string) {
+							logger.Debug("skipping archive entry that is not a YAML file", "file", filePath)
+						},
+					}, args...); err != nil {
+						return err
+					}
+				}
+				if fromCluster {
+					clusterCtxs, err := lintcontext.CreateContextsFromCluster(lintcontext.ClusterOptions{
+						Kubeconfig:    kubeconfig,
+						Namespace:     namespace,
+						LabelSelector: labelSelector,
+						Timeout:       timeout,
+					})
+					if err != nil {
+						return err
+					}
+					lintCtxs = append(lintCtxs, clusterCtxs...)
+				}
+				if logger.Enabled(logging.LevelDebug) {
+					objects, invalidObjects := 0, 0
+					for _, lintCtx := range lintCtxs {
+						objects += len(lintCtx.Objects())
+						invalidObjects += len(lintCtx.InvalidObjects())
+						for _, invalidObj := range lintCtx.InvalidObjects() {
+							if invalidObj.Metadata.Line > 0 {
+								logger.Debug("failed to load object", "file", invalidObj.Metadata.FilePath, "line", invalidObj.Metadata.Line, "error", invalidObj.LoadErr)
+								continue
+							}
+							logger.Debug("failed to load object", "file", invalidObj.Metadata.FilePath, "error", invalidObj.LoadErr)
+						}
+					}
+					logger.Debug("loaded objects", "contexts", len(lintCtxs), "objects", objects, "invalidObjects", invalidObjects)
+				}
+				runOptions := run.Options{
+					Workers:        workers,
+					IncludeObjects: includeSelectors,
+					ExcludeObjects: excludeSelectors,
+				}
+				// Progress is only useful to humans watching, and would garble logs that stderr is redirected to.
+				var printer *progressPrinter
+				if progress && (isatty.IsTerminal(os.Stderr.Fd()) || isatty.IsCygwinTerminal(os.Stderr.Fd())) {
+					printer = &progressPrinter{out: os.Stderr}
+					runOptions.Progress = printer.update
+				}
+				start := time.Now()
+				result, err := run.RunWithOptions(runOptions, lintCtxs, checkRegistry, enabledChecks)
+				if printer != nil {
+					printer.finish()
+				}
+				if err != nil {
+					return err
+				}
+				logger.Debug("ran checks", "checks", len(enabledChecks), "objects", len(result.Objects), "reports", len(result.Reports),
+					"ignoredReports", len(result.IgnoredReports), "duration", time.Since(start))
+				if pathBase != "" {
+					if err := relativizeFilePaths(&result, pathBase, args); err != nil {
+						return err
+					}
+				}
+				// Documents that only fail to parse on their own are otherwise only listed in verbose mode, so their
+				// number is always warned about, to not silently lose coverage.
+				if unparsed := result.Summary.Objects.Unparsed; unparsed > 0 && !logger.Enabled(logging.LevelDebug) {
+					logger.Warn("some documents could not be parsed, so they were not linted. Use --verbose to see why.", "documents", unparsed)
+				}
+				// Only the objects that the selectors let through count, and files that failed to load are still
+				// reported, even if nothing else could be linted.
+				if len(result.Objects) == 0 && len(result.LoadErrors) == 0 {
+					logger.Warn("no valid objects found.")
+					if stats {
+						printStats(os.Stderr, result.Summary.Objects)
+					}
+					if summaryFilePath != "" {
+						return writeSummaryFile(summaryFilePath, result, false)
+					}
+					return nil
+				}
 
-			formatter, err := formatters.FormatterByType(format.String())
-			if err != nil {
-				return err
-			}
+				for _, ignored := range result.IgnoredReports {
+					logger.Debug("ignoring lint error", "check", ignored.Report.Check, "object", ignored.Report.Object.GetK8sObjectName(),
+						"file", ignored.Report.Object.Metadata.FilePath, "reason", ignored.Reason)
+				}
 
-			file, _ := os.OpenFile("output.json", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
-			err = formatter(file, result)
-			if err != nil {
-				return errors.Wrap(err, "output saving failed")
-			}
+				if baselinePath != "" {
+					if err := applyBaseline(&result, baselinePath, writeBaseline, logger); err != nil {
+						return err
+					}
+				}
 
-			err = formatter(os.Stdout, result)
-			if err != nil {
-				return errors.Wrap(err, "output formatting failed")
-			}
+				if snippets {
+					for i := range result.Reports {
+						report := &result.Reports[i]
+						report.Snippet = report.Object.Metadata.Snippet(report.Diagnostic.FieldPath)
+					}
+				}
+				// Whether the command fails depends on all lint errors, not only on the ones that are output.
+				failErr := failOnError(result, failOn.String())
+				if maxFindings > 0 {
+					truncateReports(&result, maxFindings)
+				}
+				if result.Summary.SuppressedReports > 0 && !onlyPlainOutputs(outputs) {
+					// The plain format prints this itself, after the lint errors.
+					logger.Warn("some lint errors were left out of the output because of --max-findings.", "suppressed", result.Summary.SuppressedReports)
+				}
 
-			if len(result.Reports) > 0 {
-				err = errors.Errorf("found %d lint errors", len(result.Reports))
+				// All outputs are rendered from the same result, so whether the command fails is decided once.
+				for _, output := range outputs {
+					formatter := output.formatter
+					if output.format == common.SARIFFormat && !output.customTemplate {
+						exitCode := 0
+						if failErr != nil && !noFail {
+							exitCode = 1
+						}
+						formatter = sarifFormatter(sarifRunDetails{
+							automationID: sarifAutomationID,
+							srcRoot:      pathBase,
+							commandLine:  quoteCommandLine(os.Args),
+							exitCode:     exitCode,
+						})
+					}
+					if err := writeOutput(output.file, formatter, result); err != nil {
+						return err
+					}
+				}
+				if stats {
+					printStats(os.Stderr, result.Summary.Objects)
+				}
+
+				if summaryFilePath != "" {
+					if err := writeSummaryFile(summaryFilePath, result, failErr != nil); err != nil {
+						return err
+					}
+				}
+				// --no-fail always wins over --fail-on.
+				if noFail {
+					return nil
+				}
+				return failErr
+			}
+			if watch {
+				var outputFiles []string
+				for _, output := range outputs {
+					if output.file != "" {
+						outputFiles = append(outputFiles, output.file)
+					}
+				}
+				if summaryFilePath != "" {
+					outputFiles = append(outputFiles, summaryFilePath)
+				}
+				return watchAndLint(args, outputFiles, logger, lint)
 			}
-			return err
+			return lint()
 		},
 	}
 
-	c.Flags().StringVar(&configPath, "config", "", "Path to config file")
-	c.Flags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose logging")
+	c.Flags().StringArrayVar(&configPaths, "config", nil, "Path to config file. Can be given multiple times, in which case the files are merged in order, "+
+		"with later files taking precedence")
+	c.Flags().BoolVar(&printConfig, "print-config", false, "Print the effective config, after merging all config files and flags, "+
+		"and the checks that it enables along with their params, instead of linting")
+	c.Flags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose logging, like --log-level debug")
+	c.Flags().BoolVarP(&quiet, "quiet", "q", false, "Suppress warnings, and the message that no lint errors were found in the plain format. Lint errors are still reported")
+	c.Flags().Var(logLevel, "log-level", logLevel.Usage())
+	c.Flags().Var(logFormat, "log-format", logFormat.Usage())
 	c.Flags().Var(format, "format", format.Usage())
+	c.Flags().Var(groupBy, "group-by", groupBy.Usage())
+	c.Flags().Var(failOn, "fail-on", failOn.Usage())
+	c.Flags().BoolVar(&requireChecks, "require-checks", false, "Fail if the config does not enable any check, instead of only warning, so that a broken config cannot make CI pass without any check")
+	c.Flags().BoolVar(&noFail, "no-fail", false, "Always exit with code 0, even if there are lint errors or files that could not be loaded, for advisory-only runs. Takes precedence over --fail-on")
+	c.Flags().BoolVar(&noFail, "exit-zero", false, "Same as --no-fail")
+	c.Flags().StringVar(&summaryFilePath, "summary-file", "", "Path to write a JSON summary of the run to, with the number of lint errors by severity and whether they reach --fail-on. "+
+		"It is written whatever the exit code is, even without lint errors, so that later CI steps can branch on it")
+	c.Flags().BoolVar(&jsonLinesSummary, "jsonl-summary", false, "Start the output of the jsonl format with a line holding the summary of the run, "+
+		"as a JSON object with a single summary field, like the Summary field of the json format")
+	c.Flags().StringVar(&templateStr, "template", "", "Go template to render the output with, overriding --format. The template is executed against the same data as the plain format")
+	c.Flags().StringVar(&templateFile, "template-file", "", "Path to a file containing a Go template to render the output with, overriding --format")
+	c.Flags().StringVar(&baselinePath, "baseline", "", "Path to a baseline file. Lint errors recorded in it are not reported")
+	c.Flags().BoolVar(&writeBaseline, "write-baseline", false, "Record all current lint errors in the file given by --baseline, so that they are not reported in subsequent runs")
+	c.Flags().DurationVar(&timeout, "timeout", lintcontext.DefaultURLFetchTimeout, "Timeout for fetching each manifest given as an HTTP(S) URL, pulling each Helm chart given as an oci:// reference, "+
+		"or each request to the API server with --from-cluster")
+	c.Flags().BoolVar(&fromCluster, "from-cluster", false, "Lint the objects deployed in the cluster of the current kubeconfig context, in addition to the files given as arguments. "+
+		"Objects controlled by other objects, like the pods of a deployment, are skipped")
+	c.Flags().StringVar(&kubeconfig, "kubeconfig", "", "Path to the kubeconfig file to use with --from-cluster. If empty, it is found like kubectl does")
+	c.Flags().StringVarP(&namespace, "namespace", "n", "", "Only lint the objects in this namespace with --from-cluster. If empty, objects in all namespaces and cluster-scoped objects are linted")
+	c.Flags().StringVarP(&labelSelector, "selector", "l", "", "Only lint the objects whose labels match this selector with --from-cluster, for example app=web")
+	c.Flags().BoolVar(&stats, "stats", false, "Print to stderr how many objects were parsed, linted and checked by at least one check, "+
+		"and how many documents could not be parsed, after the output")
+	c.Flags().BoolVar(&progress, "progress", false, "Print the number of objects checked so far to stderr while linting, if it is a terminal")
+	c.Flags().BoolVar(&watch, "watch", false, "After linting, keep watching the local files and directories given as arguments, and lint again whenever they change, until interrupted")
+	c.Flags().BoolVar(&snippets, "snippets", false, "Include the YAML of the field that each lint error is about, or of its object, in the plain, json and jsonl formats. "+
+		"Snippets are cut after 20 lines, and values that look like secrets are redacted")
+	c.Flags().IntVar(&maxFindings, "max-findings", 0, "Maximum number of lint errors to output. The ones after it, in the usual order, are left out and only counted. "+
+		"The exit code and the counts of the summary still take all lint errors into account. If 0, all lint errors are output")
+	c.Flags().IntVar(&workers, "workers", 0, "Number of objects to check concurrently. If 0, GOMAXPROCS is used")
+	c.Flags().StringVar(&sinceRef, "since", "", "Only lint the files that changed since this git ref, like a branch or commit, according to git diff, and the untracked files that are not ignored. "+
+		"Helm charts and Kustomize directories are linted if any of their files changed")
+	c.Flags().StringSliceVar(&excludePaths, "exclude-path", nil, "Glob patterns of files and directories to skip when walking directories, for example vendored or generated ones. "+
+		"Each pattern is matched against the path and each of its trailing parts, and ** matches any number of directories")
+	c.Flags().StringSliceVar(&helmValueFiles, "values", nil, "Path to a values file to render Helm charts with, on top of their values.yaml. Can be given multiple times, later files take precedence")
+	c.Flags().StringArrayVar(&helmSetValues, "set", nil, "Value to render Helm charts with, given as key=value like with helm --set. Can be given multiple times, and takes precedence over --values")
+	c.Flags().BoolVar(&skipHelmHooks, "skip-helm-hooks", false, "Do not lint the objects rendered from Helm charts that are hooks, like pre-install jobs, identified by the helm.sh/hook annotation")
+	c.Flags().StringSliceVar(&includeObjects, "include-objects", nil, "Only lint the objects matching any of these selectors, given as <kind>[:[<namespace>/]<name>], "+
+		"where each part can be a glob pattern, for example Deployment,StatefulSet or Deployment:prod/api-*")
+	c.Flags().StringSliceVar(&excludeObjects, "exclude-objects", nil, "Do not lint the objects matching any of these selectors, in the same syntax as --include-objects. "+
+		"Takes precedence over --include-objects")
+	c.Flags().StringVar(&sarifAutomationID, "sarif-automation-id", defaultSarifAutomationID, "The automationDetails.id of the run in the sarif format, "+
+		"which code scanning dashboards correlate runs by. Give every kind of run, like the ones on different directories, its own stable ID")
+	c.Flags().BoolVar(&relativePaths, "relative-paths", false, "Output the paths of the files that lint errors are in relative to the directory given by --relative-to, "+
+		"however the arguments were written, so that they are the same across runs. Paths of objects read from standard input, URLs or a cluster are left as they are")
+	c.Flags().StringVar(&relativeTo, "relative-to", "", "Directory that --relative-paths makes paths relative to, and implies it. If empty, the working directory is used")
+	c.Flags().StringVar(&outputFile, "output-file", "", "Path to write the formatted output to. If empty, output is written to stdout")
+	c.Flags().StringArrayVar(&reportSpecs, "report", nil, "Additional output of the same run, given as format=<format>[,file=<path>], like format=sarif,file=out.sarif. "+
+		"Can be given multiple times. If the file is left out, the output is written to stdout, which only one output can be")
 
 	config.AddFlags(c, v)
 	return c
 }
+
+// newLogger returns the logger for the logging flags. --verbose and --quiet are shorthands for log levels, so they
+// cannot be combined with --log-level.
+func newLogger(cmd *cobra.Command, levelName string, format logging.Format, verbose, quiet bool) (*logging.Logger, error) {
+	if (verbose || quiet) && cmd.Flags().Changed("log-level") {
+		return nil, errors.New("--log-level cannot be used with --verbose or --quiet")
+	}
+	level, err := logging.ParseLevel(levelName)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case verbose:
+		level = logging.LevelDebug
+	case quiet:
+		level = logging.LevelError
+	}
+	return logging.New(os.Stderr, level, format), nil
+}
+
+// applyBaseline removes the lint errors accepted by the baseline at the given path from the result.
+// If write is set, the baseline is first (re)generated from the current lint errors. Baseline entries that no longer
+// match any lint error are warned about.
+func applyBaseline(result *run.Result, path string, write bool, logger *logging.Logger) error {
+	if write {
+		if err := baseline.FromReports(result.Reports).Write(path); err != nil {
+			return errors.Wrap(err, "writing baseline")
+		}
+	}
+	b, err := baseline.Load(path)
+	if err != nil {
+		return err
+	}
+	var unmatched []baseline.Entry
+	result.Reports, unmatched = b.Filter(result.Reports)
+	for _, entry := range unmatched {
+		logger.Warn("baseline entry no longer matches any lint error and can be pruned.", "check", entry.Check, "object", entry.Object)
+	}
+	if len(result.Reports) == 0 {
+		result.Summary.ChecksStatus = run.ChecksPassed
+	}
+	result.Summary.Counts = run.CountReports(result.Reports)
+	return nil
+}
+
+// truncateReports leaves all but the first maxReports reports of the result out, recording how many were left out.
+// Reports are sorted by run, so the same reports are kept across runs.
+func truncateReports(result *run.Result, maxReports int) {
+	if len(result.Reports) <= maxReports {
+		return
+	}
+	result.Summary.SuppressedReports = len(result.Reports) - maxReports
+	result.Reports = result.Reports[:maxReports]
+}
+
+// printStats prints the given object counts for --stats.
+func printStats(out io.Writer, counts run.ObjectCounts) {
+	invalidShare := 0
+	if total := counts.Parsed + counts.Invalid; total > 0 {
+		invalidShare = counts.Invalid * 100 / total
+	}
+	fmt.Fprintf(out, "Objects parsed: %d\n", counts.Parsed)
+	fmt.Fprintf(out, "Objects linted: %d\n", counts.Linted)
+	fmt.Fprintf(out, "Objects checked by at least one check: %d\n", counts.Checked)
+	fmt.Fprintf(out, "Documents that could not be parsed: %d (%d%% of all documents)\n", counts.Invalid, invalidShare)
+}
+
+// effectiveConfig is the config that --print-config prints.
+type effectiveConfig struct {
+	// Config is the config after merging all config files and flags.
+	Config config.Config `json:"config"`
+	// EnabledChecks are the checks that are run, with their resolved severities and params, sorted by name.
+	EnabledChecks []config.Check `json:"enabledChecks"`
+}
+
+// printEffectiveConfig prints the given config, and the specs of the given enabled checks, to stdout in YAML.
+func printEffectiveConfig(cfg config.Config, registry checkregistry.CheckRegistry, enabledChecks []string) error {
+	effective := effectiveConfig{Config: cfg, EnabledChecks: make([]config.Check, 0, len(enabledChecks))}
+	for _, name := range enabledChecks {
+		effective.EnabledChecks = append(effective.EnabledChecks, registry.Load(name).Spec)
+	}
+	sort.Slice(effective.EnabledChecks, func(i, j int) bool {
+		return effective.EnabledChecks[i].Name < effective.EnabledChecks[j].Name
+	})
+	out, err := yaml.Marshal(effective)
+	if err != nil {
+		return errors.Wrap(err, "marshalling config")
+	}
+	_, err = os.Stdout.Write(out)
+	return err
+}
+
+func severityStrings() []string {
+	severities := config.AllSeverities()
+	out := make([]string, 0, len(severities))
+	for _, severity := range severities {
+		out = append(out, string(severity))
+	}
+	return out
+}
+
+// failOnError returns the error that the command fails with because of the lint errors or load errors of the given
+// result, or nil if they do not reach the given --fail-on value.
+func failOnError(result run.Result, failOn string) error {
+	if len(result.LoadErrors) > 0 && failOn != failOnNone {
+		return errors.Errorf("found %d lint errors, and %d files that could not be loaded", len(result.Reports), len(result.LoadErrors))
+	}
+	if shouldFail(result.Reports, failOn) {
+		return errors.Errorf("found %d lint errors", len(result.Reports))
+	}
+	return nil
+}
+
+// shouldFail returns whether the highest severity among the given reports reaches the failOn threshold.
+func shouldFail(reports []diagnostic.WithContext, failOn string) bool {
+	if failOn == failOnNone || len(reports) == 0 {
+		return false
+	}
+	highest := reports[0].Severity
+	for _, report := range reports[1:] {
+		if report.Severity.AtLeast(highest) {
+			highest = report.Severity
+		}
+	}
+	return highest.AtLeast(config.Severity(failOn))
+}
+
+// loadErrorLine returns the line that formats which need one attach a load error to.
+// Errors without a known line are attached to the first line of the file.
+func loadErrorLine(loadErr *run.LoadError) int {
+	if loadErr.Line > 0 {
+		return loadErr.Line
+	}
+	return 1
+}
+
+// reportPosition returns the line and column that formats which need a position attach a report to.
+// Reports without a known position are attached to the first line of the file.
+func reportPosition(report *diagnostic.WithContext) (int, int) {
+	if report.Diagnostic.Line > 0 {
+		return report.Diagnostic.Line, report.Diagnostic.Column
+	}
+	return 1, 1
+}
+
+// loadCustomTemplate compiles the user-supplied template given either inline or as a file.
+// It returns nil if the user supplied neither.
+func loadCustomTemplate(templateStr, templateFile string) (*template.Template, error) {
+	if templateStr != "" && templateFile != "" {
+		return nil, errors.New("only one of --template and --template-file can be specified")
+	}
+	if templateFile != "" {
+		contents, err := ioutil.ReadFile(templateFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading template file %s", templateFile)
+		}
+		templateStr = string(contents)
+	}
+	if templateStr == "" {
+		return nil, nil
+	}
+	tpl, err := common.InstantiatePlainTemplate(templateStr, customTemplateFuncs)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing custom template")
+	}
+	return tpl, nil
+}
+
+// writeOutput runs the formatter once, either against stdout or, if outputFile is set, against that file.
+// The output file is written atomically so that a failed run never leaves a half-written report behind.
+func writeOutput(outputFile string, formatter common.FormatFunc, result run.Result) error {
+	if outputFile == "" {
+		if err := formatter(os.Stdout, result); err != nil {
+			return errors.Wrap(err, "output formatting failed")
+		}
+		return nil
+	}
+
+	err := fileutil.WriteAtomically(outputFile, func(out io.Writer) error {
+		return formatter(out, result)
+	})
+	return errors.Wrapf(err, "writing output to %s", outputFile)
+}
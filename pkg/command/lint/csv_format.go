@@ -0,0 +1,57 @@
+package lint
+
+import (
+	"encoding/csv"
+	"io"
+
+	"github.com/pkg/errors"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/run"
+)
+
+var (
+	csvHeader = []string{"File Path", "Object Namespace", "Object Kind", "Object Name", "Check", "Severity", "Message", "Remediation", "Docs URL"}
+)
+
+// formatLintCSV implements common.CSVFormat.
+// Must be used only with lint.Command because it only understands run.Result as data parameter.
+func formatLintCSV(out io.Writer, data interface{}) error {
+	if res, ok := data.(run.Result); ok {
+		return formatCSV(out, res)
+	}
+	return errors.New("Provided data must be of run.Result type")
+}
+
+func formatCSV(out io.Writer, result run.Result) error {
+	w := csv.NewWriter(out)
+	// The header is written even if there are no reports, so that consumers never get an empty file.
+	if err := w.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, report := range result.Reports {
+		objectName := report.Object.GetK8sObjectName()
+		if err := w.Write([]string{
+			report.Object.Metadata.FilePath,
+			objectName.Namespace,
+			objectName.GroupVersionKind.Kind,
+			objectName.Name,
+			report.Check,
+			string(report.Severity),
+			report.Diagnostic.Message,
+			report.Remediation,
+			report.DocsURL,
+		}); err != nil {
+			return err
+		}
+	}
+	// Files that could not be loaded have no object to name.
+	for _, loadErr := range result.LoadErrors {
+		if err := w.Write([]string{
+			loadErr.FilePath, "", "", "", loadErrorCheckName, string(config.SeverityError), loadErr.Message, loadErrorRemediation, "",
+		}); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
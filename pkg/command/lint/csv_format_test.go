@@ -0,0 +1,40 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/run"
+)
+
+func TestCSVFormatWithoutReports(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, formatCSV(&out, run.Result{}))
+	assert.Equal(t, "File Path,Object Namespace,Object Kind,Object Name,Check,Severity,Message,Remediation,Docs URL\n", out.String())
+}
+
+func TestCSVFormatQuotesFields(t *testing.T) {
+	message := "container \"app\" uses image app:latest,\nwhich is mutable"
+	result := run.Result{
+		Reports: []diagnostic.WithContext{
+			fakeReport(fakeObject("apps.yaml", "app"), "latest-tag", config.SeverityWarning, message),
+		},
+		LoadErrors: []run.LoadError{{FilePath: "broken.yaml", Message: "could not parse"}},
+	}
+	var out bytes.Buffer
+	require.NoError(t, formatCSV(&out, result))
+	assert.Contains(t, out.String(), "\"container \"\"app\"\" uses image app:latest,\nwhich is mutable\"")
+
+	records, err := csv.NewReader(&out).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		csvHeader,
+		{"apps.yaml", "default", "Deployment", "app", "latest-tag", "warning", message, "Fix it.", ""},
+		{"broken.yaml", "", "", "", loadErrorCheckName, "error", "could not parse", loadErrorRemediation, ""},
+	}, records)
+}
@@ -0,0 +1,41 @@
+package lint
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	customTemplate = `{{range .Reports}}{{.Check}}: {{.Object.GetK8sObjectName.Name}}
+{{end}}`
+)
+
+func TestCustomTemplate(t *testing.T) {
+	templateFile := filepath.Join(t.TempDir(), "report.tmpl")
+	require.NoError(t, ioutil.WriteFile(templateFile, []byte(customTemplate), 0644))
+
+	for _, flag := range [][]string{{"--template", customTemplate}, {"--template-file", templateFile}} {
+		outputFile := filepath.Join(t.TempDir(), "report.txt")
+		assert.Error(t, runLintCommand(t, append(flag, "--output-file", outputFile, latestTagFixture)...), "%v", flag)
+		contents, err := ioutil.ReadFile(outputFile)
+		require.NoError(t, err)
+		assert.Contains(t, string(contents), "latest-tag: app\n", "%v", flag)
+	}
+}
+
+func TestCustomTemplateParseErrorFailsBeforeLinting(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "report.txt")
+	// Linting the missing file would fail too, so the error shows that the template failed first.
+	err := runLintCommand(t, "--template", "{{range .Reports}", "--output-file", outputFile, filepath.Join(t.TempDir(), "missing.yaml"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "parsing custom template")
+	assert.NoFileExists(t, outputFile)
+
+	err = runLintCommand(t, "--template", "{{.Reports}}", "--template-file", "report.tmpl", latestTagFixture)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "only one of --template and --template-file")
+}
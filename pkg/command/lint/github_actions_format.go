@@ -0,0 +1,74 @@
+package lint
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/run"
+)
+
+var (
+	// Workflow commands end at line breaks, and their properties at commas, so these are escaped like the toolkit of
+	// GitHub Actions does.
+	githubActionsDataEscaper     = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+	githubActionsPropertyEscaper = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C")
+)
+
+// formatLintGitHubActions implements common.GitHubActionsFormat.
+// Must be used only with lint.Command because it only understands run.Result as data parameter.
+func formatLintGitHubActions(out io.Writer, data interface{}) error {
+	if res, ok := data.(run.Result); ok {
+		return formatGitHubActions(out, res)
+	}
+	return errors.New("Provided data must be of run.Result type")
+}
+
+func formatGitHubActions(out io.Writer, result run.Result) error {
+	for i := range result.Reports {
+		report := &result.Reports[i]
+		message := fmt.Sprintf("%s (object: %s, check: %s, remediation: %s)",
+			report.Diagnostic.Message, report.Object.GetK8sObjectName(), report.Check, report.Remediation)
+		if report.DocsURL != "" {
+			message = fmt.Sprintf("%s (object: %s, check: %s, remediation: %s, docs: %s)",
+				report.Diagnostic.Message, report.Object.GetK8sObjectName(), report.Check, report.Remediation, report.DocsURL)
+		}
+		line, column := reportPosition(report)
+		if _, err := fmt.Fprintf(out, "::%s file=%s,line=%d,col=%d,title=%s::%s\n",
+			githubActionsCommand(report.Severity),
+			githubActionsPropertyEscaper.Replace(report.Object.Metadata.FilePath),
+			line,
+			column,
+			githubActionsPropertyEscaper.Replace(report.Check),
+			githubActionsDataEscaper.Replace(message),
+		); err != nil {
+			return err
+		}
+	}
+	for i := range result.LoadErrors {
+		loadErr := &result.LoadErrors[i]
+		if _, err := fmt.Fprintf(out, "::error file=%s,line=%d,col=1,title=%s::%s\n",
+			githubActionsPropertyEscaper.Replace(loadErr.FilePath),
+			loadErrorLine(loadErr),
+			loadErrorCheckName,
+			githubActionsDataEscaper.Replace(fmt.Sprintf("%s (check: %s, remediation: %s)", loadErr.Message, loadErrorCheckName, loadErrorRemediation)),
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// githubActionsCommand maps a severity to the workflow command that creates an annotation of the matching level.
+func githubActionsCommand(severity config.Severity) string {
+	switch severity {
+	case config.SeverityWarning:
+		return "warning"
+	case config.SeverityInfo:
+		return "notice"
+	default:
+		return "error"
+	}
+}
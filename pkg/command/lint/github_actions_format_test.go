@@ -0,0 +1,37 @@
+package lint
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/run"
+)
+
+func TestGitHubActionsFormatEscapes(t *testing.T) {
+	report := fakeReport(fakeObject("C:\\manifests\\app,web.yaml", "app"), "check:with,separators", config.SeverityWarning,
+		"100% of containers:\r\nuse app:latest, db:latest")
+	report.Diagnostic.Line, report.Diagnostic.Column = 12, 3
+	result := run.Result{
+		Reports:    []diagnostic.WithContext{report},
+		LoadErrors: []run.LoadError{{FilePath: "broken,1.yaml", Message: "line 1:\nunexpected %"}},
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, formatGitHubActions(&out, result))
+	assert.Equal(t,
+		"::warning file=C%3A\\manifests\\app%2Cweb.yaml,line=12,col=3,title=check%3Awith%2Cseparators::"+
+			"100%25 of containers:%0D%0Ause app:latest, db:latest (object: default/app apps/v1, Kind=Deployment, check: check:with,separators, remediation: Fix it.)\n"+
+			"::error file=broken%2C1.yaml,line=1,col=1,title=load-error::"+
+			"line 1:%0Aunexpected %25 (check: load-error, remediation: "+loadErrorRemediation+")\n",
+		out.String())
+}
+
+func TestGitHubActionsCommand(t *testing.T) {
+	assert.Equal(t, "error", githubActionsCommand(config.SeverityError))
+	assert.Equal(t, "warning", githubActionsCommand(config.SeverityWarning))
+	assert.Equal(t, "notice", githubActionsCommand(config.SeverityInfo))
+}
@@ -0,0 +1,122 @@
+package lint
+
+import (
+	"io"
+
+	"github.com/pkg/errors"
+	"golang.stackrox.io/kube-linter/pkg/command/common"
+	"golang.stackrox.io/kube-linter/pkg/run"
+)
+
+const (
+	htmlTemplateStr = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>KubeLinter report</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em; color: #24292e; }
+h1 { font-size: 1.6em; }
+table { border-collapse: collapse; margin-bottom: 1em; }
+th, td { border: 1px solid #d1d5da; padding: 0.3em 0.8em; text-align: left; vertical-align: top; }
+th { background: #f6f8fa; }
+details { margin-bottom: 0.5em; }
+summary { cursor: pointer; font-weight: 600; }
+code { background: #f6f8fa; padding: 0.1em 0.3em; }
+.severity-error { color: #cb2431; }
+.severity-warning { color: #b08800; }
+.severity-info { color: #0366d6; }
+.success { color: #22863a; }
+</style>
+</head>
+<body>
+<h1>KubeLinter {{ .Summary.KubeLinterVersion }} report</h1>
+<p>Generated at {{ .Summary.CheckEndTime.Format "2006-01-02 15:04:05 MST" }}.</p>
+{{- if not .Total }}
+<p class="success">No lint errors found!</p>
+{{- else }}
+<h2>Summary</h2>
+<table>
+<tr><th>Severity</th><th>Count</th></tr>
+{{- range .SeverityCounts }}
+<tr><td class="severity-{{ .Severity }}">{{ .Severity }}</td><td>{{ .Count }}</td></tr>
+{{- end }}
+<tr><th>Total</th><th>{{ .Total }}</th></tr>
+</table>
+<h2>Findings by file</h2>
+{{- range .ByFile }}
+<details>
+<summary>{{ .Name }} ({{ len .Reports }})</summary>
+<table>
+<tr><th>Severity</th><th>Object</th><th>Check</th><th>Message</th><th>Remediation</th></tr>
+{{- range .Reports }}
+<tr><td class="severity-{{ .Severity }}">{{ .Severity }}</td><td><code>{{ .Object.GetK8sObjectName }}</code></td><td>{{ .Check }}</td><td>{{ .Diagnostic.Message }}</td><td>{{ .Remediation }}{{ with .DocsURL }} <a href="{{ . }}">Documentation</a>{{ end }}</td></tr>
+{{- end }}
+</table>
+</details>
+{{- end }}
+<h2>Findings by check</h2>
+{{- range .ByCheck }}
+<details>
+<summary>{{ .Name }} ({{ len .Reports }})</summary>
+<table>
+<tr><th>Severity</th><th>File</th><th>Object</th><th>Message</th></tr>
+{{- range .Reports }}
+<tr><td class="severity-{{ .Severity }}">{{ .Severity }}</td><td>{{ .Object.Metadata.FilePath }}</td><td><code>{{ .Object.GetK8sObjectName }}</code></td><td>{{ .Diagnostic.Message }}</td></tr>
+{{- end }}
+</table>
+</details>
+{{- end }}
+{{- end }}
+{{- if .LoadErrors }}
+<h2>Files that could not be loaded</h2>
+<p>The objects in these files were not linted.</p>
+<table>
+<tr><th>File</th><th>Line</th><th>Error</th></tr>
+{{- range .LoadErrors }}
+<tr><td>{{ .FilePath }}</td><td>{{ if .Line }}{{ .Line }}{{ end }}</td><td class="severity-error">{{ .Message }}</td></tr>
+{{- end }}
+</table>
+{{- end }}
+</body>
+</html>
+`
+)
+
+var (
+	htmlTemplate = common.MustInstantiateHTMLTemplate(htmlTemplateStr, nil)
+)
+
+// formatLintHTML implements common.HTMLFormat.
+// Must be used only with lint.Command because it only understands run.Result as data parameter.
+func formatLintHTML(out io.Writer, data interface{}) error {
+	if res, ok := data.(run.Result); ok {
+		return formatHTML(out, res)
+	}
+	return errors.New("Provided data must be of run.Result type")
+}
+
+func formatHTML(out io.Writer, result run.Result) error {
+	var byFile, byCheck []reportGroup
+	for i := range result.Reports {
+		report := &result.Reports[i]
+		byFile = addToReportGroup(byFile, report.Object.Metadata.FilePath, report)
+		byCheck = addToReportGroup(byCheck, report.Check, report)
+	}
+
+	return htmlTemplate.Execute(out, struct {
+		Summary        run.Summary
+		SeverityCounts []severityCount
+		Total          int
+		ByFile         []reportGroup
+		ByCheck        []reportGroup
+		LoadErrors     []run.LoadError
+	}{
+		Summary:        result.Summary,
+		SeverityCounts: countBySeverity(result.Reports),
+		Total:          len(result.Reports),
+		ByFile:         sortReportGroups(byFile),
+		ByCheck:        sortReportGroups(byCheck),
+		LoadErrors:     result.LoadErrors,
+	})
+}
@@ -0,0 +1,100 @@
+package lint
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/pkg/errors"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/run"
+)
+
+// jsonLinesReport is a lint error, or a file that could not be loaded, as a line of the JSON Lines format.
+type jsonLinesReport struct {
+	FilePath    string          `json:"filePath"`
+	Line        int             `json:"line"`
+	Column      int             `json:"column"`
+	Namespace   string          `json:"namespace,omitempty"`
+	APIVersion  string          `json:"apiVersion,omitempty"`
+	Kind        string          `json:"kind,omitempty"`
+	Name        string          `json:"name,omitempty"`
+	Check       string          `json:"check"`
+	Severity    config.Severity `json:"severity"`
+	Message     string          `json:"message"`
+	Remediation string          `json:"remediation,omitempty"`
+	DocsURL     string          `json:"docsURL,omitempty"`
+	Snippet     string          `json:"snippet,omitempty"`
+}
+
+// jsonLinesSummary is the header line that the JSON Lines format starts with if --jsonl-summary is set. It is told
+// apart from the lines of lint errors by its only field.
+type jsonLinesSummary struct {
+	Summary run.Summary `json:"summary"`
+}
+
+// formatLintJSONLines implements common.JSONLinesFormat.
+// Must be used only with lint.Command because it only understands run.Result as data parameter.
+func formatLintJSONLines(out io.Writer, data interface{}) error {
+	if res, ok := data.(run.Result); ok {
+		return formatJSONLines(out, res, false)
+	}
+	return errors.New("Provided data must be of run.Result type")
+}
+
+// formatLintJSONLinesWithSummary is formatLintJSONLines, with a header line holding the summary of the run.
+func formatLintJSONLinesWithSummary(out io.Writer, data interface{}) error {
+	if res, ok := data.(run.Result); ok {
+		return formatJSONLines(out, res, true)
+	}
+	return errors.New("Provided data must be of run.Result type")
+}
+
+// formatJSONLines writes every lint error as soon as it is encoded, so that the output of huge runs is never held in
+// memory as a whole, and consumers can process it as it comes.
+func formatJSONLines(out io.Writer, result run.Result, withSummary bool) error {
+	// The encoder writes compact JSON, followed by a newline.
+	enc := json.NewEncoder(out)
+	if withSummary {
+		if err := enc.Encode(jsonLinesSummary{Summary: result.Summary}); err != nil {
+			return err
+		}
+	}
+	for i := range result.Reports {
+		report := &result.Reports[i]
+		objectName := report.Object.GetK8sObjectName()
+		line, column := reportPosition(report)
+		if err := enc.Encode(jsonLinesReport{
+			FilePath:    report.Object.Metadata.FilePath,
+			Line:        line,
+			Column:      column,
+			Namespace:   objectName.Namespace,
+			APIVersion:  objectName.GroupVersionKind.GroupVersion().String(),
+			Kind:        objectName.GroupVersionKind.Kind,
+			Name:        objectName.Name,
+			Check:       report.Check,
+			Severity:    report.Severity,
+			Message:     report.Diagnostic.Message,
+			Remediation: report.Remediation,
+			DocsURL:     report.DocsURL,
+			Snippet:     report.Snippet,
+		}); err != nil {
+			return err
+		}
+	}
+	// Files that could not be loaded have no object to name.
+	for i := range result.LoadErrors {
+		loadErr := &result.LoadErrors[i]
+		if err := enc.Encode(jsonLinesReport{
+			FilePath:    loadErr.FilePath,
+			Line:        loadErrorLine(loadErr),
+			Column:      1,
+			Check:       loadErrorCheckName,
+			Severity:    config.SeverityError,
+			Message:     loadErr.Message,
+			Remediation: loadErrorRemediation,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
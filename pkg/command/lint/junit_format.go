@@ -0,0 +1,164 @@
+package lint
+
+import (
+	"encoding/xml"
+	"io"
+	"sort"
+
+	"github.com/pkg/errors"
+	"golang.stackrox.io/kube-linter/internal/consts"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/run"
+)
+
+const (
+	// junitPassingTestName is the name of the testcase emitted for objects without any lint errors.
+	junitPassingTestName = "no lint errors"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name       string          `xml:"name,attr"`
+	Tests      int             `xml:"tests,attr"`
+	Failures   int             `xml:"failures,attr"`
+	Errors     int             `xml:"errors,attr"`
+	Timestamp  string          `xml:"timestamp,attr"`
+	Properties []junitProperty `xml:"properties>property"`
+	TestCases  []junitTestCase `xml:"testcase"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	// Error is set for files that could not be loaded, so that their objects could not be linted at all.
+	Error *junitFailure `xml:"error,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// formatLintJUnit implements common.JUnitFormat.
+// Must be used only with lint.Command because it only understands run.Result as data parameter.
+func formatLintJUnit(out io.Writer, data interface{}) error {
+	if res, ok := data.(run.Result); ok {
+		return formatJUnit(out, res)
+	}
+	return errors.New("Provided data must be of run.Result type")
+}
+
+func formatJUnit(out io.Writer, result run.Result) error {
+	timestamp := result.Summary.CheckEndTime.Format("2006-01-02T15:04:05")
+	suitesByPath := make(map[string]*junitTestSuite)
+	getSuite := func(path string) *junitTestSuite {
+		suite := suitesByPath[path]
+		if suite == nil {
+			suite = &junitTestSuite{
+				Name:      path,
+				Timestamp: timestamp,
+				Properties: []junitProperty{
+					{Name: "kubeLinterVersion", Value: result.Summary.KubeLinterVersion},
+				},
+			}
+			suitesByPath[path] = suite
+		}
+		return suite
+	}
+
+	objectsWithReports := make(map[string]struct{})
+	for _, report := range result.Reports {
+		objectName := report.Object.GetK8sObjectName().String()
+		objectsWithReports[report.Object.Metadata.FilePath+"\x00"+objectName] = struct{}{}
+
+		suite := getSuite(report.Object.Metadata.FilePath)
+		suite.TestCases = append(suite.TestCases, junitTestCase{
+			ClassName: objectName,
+			Name:      report.Check,
+			Failure: &junitFailure{
+				Message: report.Diagnostic.Message,
+				Type:    report.Check,
+				Text:    junitFailureText(&report),
+			},
+		})
+		suite.Failures++
+	}
+
+	// Emit passing testcases for objects without any reports, so that CI systems show the right counts.
+	for _, obj := range result.Objects {
+		objectName := obj.GetK8sObjectName().String()
+		if _, hasReports := objectsWithReports[obj.Metadata.FilePath+"\x00"+objectName]; hasReports {
+			continue
+		}
+		suite := getSuite(obj.Metadata.FilePath)
+		suite.TestCases = append(suite.TestCases, junitTestCase{
+			ClassName: objectName,
+			Name:      junitPassingTestName,
+		})
+	}
+
+	for _, loadErr := range result.LoadErrors {
+		suite := getSuite(loadErr.FilePath)
+		suite.TestCases = append(suite.TestCases, junitTestCase{
+			ClassName: loadErr.FilePath,
+			Name:      loadErrorCheckName,
+			Error: &junitFailure{
+				Message: loadErr.Message,
+				Type:    loadErrorCheckName,
+				Text:    loadErr.Message + "\nRemediation: " + loadErrorRemediation,
+			},
+		})
+		suite.Errors++
+	}
+
+	paths := make([]string, 0, len(suitesByPath))
+	for path := range suitesByPath {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	suites := junitTestSuites{Name: consts.ProgramName}
+	for _, path := range paths {
+		suite := suitesByPath[path]
+		suite.Tests = len(suite.TestCases)
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Errors += suite.Errors
+		suites.Suites = append(suites.Suites, *suite)
+	}
+
+	if _, err := io.WriteString(out, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(out)
+	enc.Indent("", "  ")
+	if err := enc.Encode(suites); err != nil {
+		return err
+	}
+	_, err := io.WriteString(out, "\n")
+	return err
+}
+
+// junitFailureText returns the text of the failure of the given report, with its remediation and documentation link.
+func junitFailureText(report *diagnostic.WithContext) string {
+	text := report.Diagnostic.Message + "\nRemediation: " + report.Remediation
+	if report.DocsURL != "" {
+		text += "\nDocumentation: " + report.DocsURL
+	}
+	return text
+}
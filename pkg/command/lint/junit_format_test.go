@@ -0,0 +1,132 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.stackrox.io/kube-linter/pkg/builtinchecks"
+	"golang.stackrox.io/kube-linter/pkg/checkregistry"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/run"
+	appsV1 "k8s.io/api/apps/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// fakeObject returns a deployment with the given name, in the given file.
+func fakeObject(filePath, name string) lintcontext.Object {
+	return lintcontext.Object{
+		Metadata: lintcontext.ObjectMetadata{FilePath: filePath},
+		K8sObject: &appsV1.Deployment{
+			TypeMeta:   metaV1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+			ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: "default"},
+		},
+	}
+}
+
+// fakeReport returns a report of the given check on the given object.
+func fakeReport(obj lintcontext.Object, check string, severity config.Severity, message string) diagnostic.WithContext {
+	return diagnostic.WithContext{
+		Diagnostic:  diagnostic.Diagnostic{Message: message},
+		Check:       check,
+		Severity:    severity,
+		Remediation: "Fix it.",
+		Object:      obj,
+	}
+}
+
+func formatJUnitSuites(t *testing.T, result run.Result) junitTestSuites {
+	var out bytes.Buffer
+	require.NoError(t, formatJUnit(&out, result))
+	var suites junitTestSuites
+	require.NoError(t, xml.Unmarshal(out.Bytes(), &suites))
+	return suites
+}
+
+func TestJUnitOfOverlappingPaths(t *testing.T) {
+	dir := t.TempDir()
+	manifest := filepath.Join(dir, "deployment.yaml")
+	require.NoError(t, ioutil.WriteFile(manifest, []byte(`apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        image: app:1.0.0
+`), 0644))
+	registry := checkregistry.New()
+	require.NoError(t, builtinchecks.LoadInto(registry))
+	lintCtxs, err := lintcontext.CreateContexts(dir, manifest)
+	require.NoError(t, err)
+	result, err := run.Run(lintCtxs, registry, []string{"latest-tag"})
+	require.NoError(t, err)
+
+	// The object is loaded through both arguments, but is a single passing testcase.
+	suites := formatJUnitSuites(t, result)
+	assert.Equal(t, 1, suites.Tests)
+	require.Len(t, suites.Suites, 1)
+	require.Len(t, suites.Suites[0].TestCases, 1)
+	assert.Equal(t, junitPassingTestName, suites.Suites[0].TestCases[0].Name)
+}
+
+func TestJUnitFormat(t *testing.T) {
+	app, web, db := fakeObject("apps.yaml", "app"), fakeObject("apps.yaml", "web"), fakeObject("db.yaml", "db")
+	result := run.Result{
+		Objects: []lintcontext.Object{app, web, db},
+		Reports: []diagnostic.WithContext{
+			fakeReport(app, "latest-tag", config.SeverityWarning, `container "app" uses image <app:latest> & more`),
+			fakeReport(app, "no-liveness-probe", config.SeverityWarning, `container "app" has no liveness probe`),
+			fakeReport(db, "latest-tag", config.SeverityWarning, `container "db" uses image db:latest`),
+		},
+		LoadErrors: []run.LoadError{{FilePath: "broken.yaml", Message: "could not parse <document>"}},
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, formatJUnit(&out, result))
+	assert.True(t, strings.HasPrefix(out.String(), xml.Header))
+	// Messages are escaped, both in attributes and in text.
+	assert.Contains(t, out.String(), `message="container &#34;app&#34; uses image &lt;app:latest&gt; &amp; more"`)
+	assert.Contains(t, out.String(), `could not parse &lt;document&gt;`)
+
+	suites := formatJUnitSuites(t, result)
+	assert.Equal(t, 5, suites.Tests)
+	assert.Equal(t, 3, suites.Failures)
+	assert.Equal(t, 1, suites.Errors)
+	// Suites are sorted by file path.
+	require.Len(t, suites.Suites, 3)
+
+	apps := suites.Suites[0]
+	assert.Equal(t, "apps.yaml", apps.Name)
+	assert.Equal(t, 3, apps.Tests)
+	assert.Equal(t, 2, apps.Failures)
+	require.Len(t, apps.TestCases, 3)
+	failure := apps.TestCases[0]
+	assert.Equal(t, "latest-tag", failure.Name)
+	require.NotNil(t, failure.Failure)
+	assert.Equal(t, `container "app" uses image <app:latest> & more`, failure.Failure.Message)
+	assert.Equal(t, "container \"app\" uses image <app:latest> & more\nRemediation: Fix it.", failure.Failure.Text)
+	passing := apps.TestCases[2]
+	assert.Equal(t, junitPassingTestName, passing.Name)
+	assert.Nil(t, passing.Failure)
+	assert.Nil(t, passing.Error)
+
+	broken := suites.Suites[1]
+	assert.Equal(t, "broken.yaml", broken.Name)
+	assert.Equal(t, 1, broken.Errors)
+	require.Len(t, broken.TestCases, 1)
+	require.NotNil(t, broken.TestCases[0].Error)
+	assert.Equal(t, "could not parse <document>", broken.TestCases[0].Error.Message)
+
+	assert.Equal(t, "db.yaml", suites.Suites[2].Name)
+	assert.Equal(t, 1, suites.Suites[2].Failures)
+}
@@ -0,0 +1,115 @@
+package lint
+
+import (
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+	"golang.stackrox.io/kube-linter/pkg/command/common"
+	"golang.stackrox.io/kube-linter/pkg/run"
+)
+
+const (
+	markdownTemplateStr = `{{- if not .Total -}}
+✅ No lint errors found
+{{ else -}}
+## KubeLinter found {{ .Total }} lint error{{ if ne .Total 1 }}s{{ end }}
+
+| Severity | Count |
+| --- | --- |
+{{- range .SeverityCounts }}
+| {{ .Severity }} | {{ .Count }} |
+{{- end }}
+{{- range .ByFile }}
+
+### {{ escape .Name }}
+{{ range .Reports }}
+- **{{ .Severity }}** [{{ escape .Check }}](#{{ anchor .Check }}) on {{ escape .Object.GetK8sObjectName.String }}: {{ escape .Diagnostic.Message }}
+{{- end }}
+{{- end }}
+
+### Remediation
+{{- range .ByCheck }}
+
+#### {{ escape .Name }}
+
+{{ with index .Reports 0 }}{{ escape .Remediation }}{{ with .DocsURL }} [Documentation]({{ . }}){{ end }}{{ end }}
+{{- end }}
+{{ end -}}
+{{- if .LoadErrors }}
+## Files that could not be loaded
+
+The objects in these files were not linted.
+{{ range .LoadErrors }}
+- {{ escape .FilePath }}{{ if .Line }}:{{ .Line }}{{ end }}: {{ escape .Message }}
+{{- end }}
+{{ end -}}
+`
+)
+
+var (
+	markdownReplacer = strings.NewReplacer(
+		"\\", "\\\\", "`", "\\`", "*", "\\*", "_", "\\_", "[", "\\[", "]", "\\]",
+		"<", "\\<", ">", "\\>", "|", "\\|", "~", "\\~", "#", "\\#",
+		"\r\n", " ", "\n", " ",
+	)
+
+	markdownTemplate = common.MustInstantiateMarkdownTemplate(markdownTemplateStr, template.FuncMap{
+		"escape": escapeMarkdown,
+		"anchor": markdownAnchor,
+	})
+)
+
+// escapeMarkdown escapes the characters that have a meaning in (GitHub flavoured) Markdown, including the pipes
+// that would break tables, and collapses newlines so that the text stays on a single line.
+func escapeMarkdown(s string) string {
+	return markdownReplacer.Replace(s)
+}
+
+// markdownAnchor returns the anchor that GitHub generates for a heading with the given text: lower-cased, with
+// spaces replaced by dashes, and all other punctuation apart from dashes and underscores removed.
+func markdownAnchor(heading string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(heading) {
+		switch {
+		case r == ' ':
+			sb.WriteRune('-')
+		case r == '-' || r == '_' || ('a' <= r && r <= 'z') || ('0' <= r && r <= '9'):
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// formatLintMarkdown implements common.MarkdownFormat.
+// Must be used only with lint.Command because it only understands run.Result as data parameter.
+func formatLintMarkdown(out io.Writer, data interface{}) error {
+	if res, ok := data.(run.Result); ok {
+		return formatMarkdown(out, res)
+	}
+	return errors.New("Provided data must be of run.Result type")
+}
+
+func formatMarkdown(out io.Writer, result run.Result) error {
+	var byFile, byCheck []reportGroup
+	for i := range result.Reports {
+		report := &result.Reports[i]
+		byFile = addToReportGroup(byFile, report.Object.Metadata.FilePath, report)
+		byCheck = addToReportGroup(byCheck, report.Check, report)
+	}
+
+	return markdownTemplate.Execute(out, struct {
+		SeverityCounts []severityCount
+		Total          int
+		ByFile         []reportGroup
+		ByCheck        []reportGroup
+		LoadErrors     []run.LoadError
+	}{
+		SeverityCounts: countBySeverity(result.Reports),
+		Total:          len(result.Reports),
+		ByFile:         sortReportGroups(byFile),
+		ByCheck:        sortReportGroups(byCheck),
+		LoadErrors:     result.LoadErrors,
+	})
+}
@@ -0,0 +1,43 @@
+package lint
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.stackrox.io/kube-linter/pkg/command/common"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/run"
+)
+
+func TestTruncateReports(t *testing.T) {
+	reports := []diagnostic.WithContext{{Check: "a"}, {Check: "b"}, {Check: "c"}}
+
+	result := run.Result{Reports: reports, Summary: run.Summary{Counts: run.ReportCounts{Reports: 3}}}
+	truncateReports(&result, 3)
+	assert.Len(t, result.Reports, 3)
+	assert.Zero(t, result.Summary.SuppressedReports)
+
+	truncateReports(&result, 2)
+	assert.Equal(t, reports[:2], result.Reports)
+	assert.Equal(t, 1, result.Summary.SuppressedReports)
+	assert.Equal(t, 3, result.Summary.Counts.Reports, "counts still include the suppressed reports")
+
+}
+
+func TestPlainFormatPrintsSuppressedReports(t *testing.T) {
+	for _, formatter := range []common.FormatFunc{
+		common.PlainTemplateFormatter(plainTemplate),
+		common.PlainTemplateFormatter(quietPlainTemplate),
+		plainByCheckFormatter(plainByCheckTemplate),
+	} {
+		var out bytes.Buffer
+		require.NoError(t, formatter(&out, run.Result{Summary: run.Summary{SuppressedReports: 2}}))
+		assert.Contains(t, out.String(), "(2 more findings suppressed by --max-findings)\n")
+
+		out.Reset()
+		require.NoError(t, formatter(&out, run.Result{}))
+		assert.NotContains(t, out.String(), "suppressed")
+	}
+}
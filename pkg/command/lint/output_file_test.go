@@ -0,0 +1,56 @@
+package lint
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// captureStdout returns what the given function writes to stdout.
+func captureStdout(t *testing.T, f func()) string {
+	stdout, err := ioutil.TempFile(t.TempDir(), "stdout")
+	require.NoError(t, err)
+	orig := os.Stdout
+	os.Stdout = stdout
+	defer func() {
+		os.Stdout = orig
+	}()
+	f()
+	require.NoError(t, stdout.Close())
+	contents, err := ioutil.ReadFile(stdout.Name())
+	require.NoError(t, err)
+	return string(contents)
+}
+
+func TestOutputFile(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "report.json")
+	var err error
+	stdout := captureStdout(t, func() {
+		err = runLintCommand(t, "--format", "json", "--output-file", outputFile, latestTagFixture)
+	})
+	// The lint errors still fail the command.
+	assert.Error(t, err)
+	assert.Empty(t, stdout)
+
+	contents, err := ioutil.ReadFile(outputFile)
+	require.NoError(t, err)
+	var report struct {
+		Reports []struct{ Check string }
+	}
+	require.NoError(t, json.Unmarshal(contents, &report))
+	require.NotEmpty(t, report.Reports)
+	assert.Equal(t, "latest-tag", report.Reports[0].Check)
+}
+
+func TestOutputFileInMissingDirectory(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "missing", "report.json")
+	err := runLintCommand(t, "--format", "json", "--output-file", outputFile, latestTagFixture)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not exist")
+	assert.NoFileExists(t, outputFile)
+}
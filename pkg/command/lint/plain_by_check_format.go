@@ -0,0 +1,64 @@
+package lint
+
+import (
+	"io"
+	"sort"
+	"text/template"
+
+	"github.com/pkg/errors"
+	"golang.stackrox.io/kube-linter/pkg/command/common"
+	"golang.stackrox.io/kube-linter/pkg/run"
+)
+
+const (
+	// groupByObject and groupByCheck are the values of --group-by.
+	groupByObject = "object"
+	groupByCheck  = "check"
+
+	plainByCheckReportsTemplateStr = `{{range .ByCheck}}
+{{- .Name | yellow | bold}}: {{.Objects}} {{plural "object" "objects" .Objects}} affected ({{len .Reports}} {{plural "finding" "findings" (len .Reports)}})
+{{range .Reports}}  {{severityIcon .Severity}}{{.Object.Metadata.FilePath | bold}}: (object: {{.Object.GetK8sObjectName | bold}}) {{.Diagnostic.Message | red}}
+{{with .Snippet}}{{indent 6 .}}
+{{end}}{{end}}  remediation: {{(index .Reports 0).Remediation | yellow}}{{with (index .Reports 0).DocsURL}}
+  docs: {{. | yellow}}{{end}}
+
+{{end -}}
+` + plainSuppressedTemplateStr
+
+	quietPlainByCheckTemplateStr = plainByCheckReportsTemplateStr + plainLoadErrorsTemplateStr
+
+	plainByCheckTemplateStr = plainHeaderTemplateStr + quietPlainByCheckTemplateStr + plainFooterTemplateStr
+)
+
+var (
+	plainByCheckTemplate      = common.MustInstantiatePlainTemplate(plainByCheckTemplateStr, nil)
+	quietPlainByCheckTemplate = common.MustInstantiatePlainTemplate(quietPlainByCheckTemplateStr, nil)
+)
+
+// plainByCheckFormatter returns a formatter that executes the given template against the result, along with its
+// reports grouped by check, from the check that affects the most objects to the one that affects the fewest.
+// Must be used only with lint.Command because it only understands run.Result as data parameter.
+func plainByCheckFormatter(tpl *template.Template) common.FormatFunc {
+	formatter := common.PlainTemplateFormatter(tpl)
+	return func(out io.Writer, data interface{}) error {
+		res, ok := data.(run.Result)
+		if !ok {
+			return errors.New("Provided data must be of run.Result type")
+		}
+		var byCheck []reportGroup
+		for i := range res.Reports {
+			report := &res.Reports[i]
+			byCheck = addToReportGroup(byCheck, report.Check, report)
+		}
+		sort.SliceStable(sortReportGroups(byCheck), func(i, j int) bool {
+			return byCheck[i].Objects() > byCheck[j].Objects()
+		})
+		return formatter(out, struct {
+			run.Result
+			ByCheck []reportGroup
+		}{
+			Result:  res,
+			ByCheck: byCheck,
+		})
+	}
+}
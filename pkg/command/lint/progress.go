@@ -0,0 +1,38 @@
+package lint
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+const (
+	// progressInterval is how often the progress counter is updated at most.
+	progressInterval = 100 * time.Millisecond
+)
+
+// progressPrinter prints a counter of the objects checked so far, updated in place on a terminal.
+type progressPrinter struct {
+	out         io.Writer
+	lastUpdate  time.Time
+	lastPrinted int
+}
+
+// update is a run.Options.Progress function. It skips updates that come in faster than progressInterval, except
+// for the last one.
+func (p *progressPrinter) update(done, total int) {
+	now := time.Now()
+	if done < total && now.Sub(p.lastUpdate) < progressInterval {
+		return
+	}
+	p.lastUpdate = now
+	p.lastPrinted = done
+	fmt.Fprintf(p.out, "\rChecked %d/%d objects", done, total)
+}
+
+// finish ends the line of the counter, if anything was printed.
+func (p *progressPrinter) finish() {
+	if p.lastPrinted > 0 {
+		fmt.Fprintln(p.out)
+	}
+}
@@ -0,0 +1,47 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/run"
+)
+
+// relativizeFilePaths rewrites the file paths of the result to be relative to the given absolute base directory,
+// whether the arguments that their files were loaded through were absolute or relative. Paths that do not name local
+// files, like the ones of objects read from standard input or listed from a cluster, are left as they are, and so are
+// paths that cannot be made relative to the base, like ones on other Windows volumes.
+func relativizeFilePaths(result *run.Result, base string, args []string) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	relativize := func(path *string) {
+		if !lintcontext.IsLocalFilePath(*path, args...) {
+			return
+		}
+		absolute := *path
+		if !filepath.IsAbs(absolute) {
+			absolute = filepath.Join(cwd, absolute)
+		}
+		if relative, err := filepath.Rel(base, absolute); err == nil {
+			*path = relative
+		}
+	}
+	for i := range result.Reports {
+		relativize(&result.Reports[i].Object.Metadata.FilePath)
+	}
+	for i := range result.IgnoredReports {
+		relativize(&result.IgnoredReports[i].Report.Object.Metadata.FilePath)
+	}
+	for i := range result.Objects {
+		relativize(&result.Objects[i].Metadata.FilePath)
+	}
+	for i := range result.LoadErrors {
+		relativize(&result.LoadErrors[i].FilePath)
+	}
+	// The same file may have been reached through differently written paths, which are only counted once now.
+	result.Summary.Counts = run.CountReports(result.Reports)
+	return nil
+}
@@ -0,0 +1,122 @@
+package lint
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.stackrox.io/kube-linter/pkg/builtinchecks"
+	"golang.stackrox.io/kube-linter/pkg/checkregistry"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/run"
+)
+
+const latestTagDeployment = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        image: app:latest
+`
+
+func lintNestedDirectories(t *testing.T, args ...string) run.Result {
+	registry := checkregistry.New()
+	require.NoError(t, builtinchecks.LoadInto(registry))
+	lintCtxs, err := lintcontext.CreateContexts(args...)
+	require.NoError(t, err)
+	result, err := run.Run(lintCtxs, registry, []string{"latest-tag"})
+	require.NoError(t, err)
+	return result
+}
+
+func reportFilePaths(result run.Result) []string {
+	var paths []string
+	for _, report := range result.Reports {
+		paths = append(paths, report.Object.Metadata.FilePath)
+	}
+	return paths
+}
+
+func TestRelativizeFilePathsOfNestedDirectories(t *testing.T) {
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+	for _, dir := range []string{"prod", "staging"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, "deploy", dir, "app"), 0755))
+		require.NoError(t, ioutil.WriteFile(filepath.Join(root, "deploy", dir, "app", "deployment.yaml"), []byte(latestTagDeployment), 0644))
+	}
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(filepath.Join(root, "deploy")))
+	defer func() {
+		require.NoError(t, os.Chdir(wd))
+	}()
+
+	// The same kind of file is given through an absolute and a relative, not cleaned, argument.
+	args := []string{filepath.Join(root, "deploy", "prod"), "./staging/app/"}
+	for _, testCase := range []struct {
+		base     string
+		expected []string
+	}{
+		{
+			base: root,
+			expected: []string{
+				filepath.Join("deploy", "prod", "app", "deployment.yaml"),
+				filepath.Join("deploy", "staging", "app", "deployment.yaml"),
+			},
+		},
+		{
+			base: filepath.Join(root, "deploy"),
+			expected: []string{
+				filepath.Join("prod", "app", "deployment.yaml"),
+				filepath.Join("staging", "app", "deployment.yaml"),
+			},
+		},
+		{
+			base: filepath.Join(root, "deploy", "staging"),
+			expected: []string{
+				filepath.Join("..", "prod", "app", "deployment.yaml"),
+				filepath.Join("app", "deployment.yaml"),
+			},
+		},
+	} {
+		t.Run(testCase.base, func(t *testing.T) {
+			result := lintNestedDirectories(t, args...)
+			require.NoError(t, relativizeFilePaths(&result, testCase.base, args))
+			assert.ElementsMatch(t, testCase.expected, reportFilePaths(result))
+			assert.Equal(t, 2, result.Summary.Counts.Files)
+		})
+	}
+}
+
+func TestRelativizeFilePathsLeavesSyntheticPathsAlone(t *testing.T) {
+	cwd, err := os.Getwd()
+	require.NoError(t, err)
+	args := []string{lintcontext.StdinArg, "https://example.com/deployment.yaml", "oci://registry.example.com/charts/chart:1.0.0"}
+	result := run.Result{LoadErrors: []run.LoadError{
+		{FilePath: lintcontext.StdinFilePath},
+		{FilePath: "https://example.com/deployment.yaml"},
+		{FilePath: lintcontext.ClusterFilePathPrefix + "default/Deployment/app"},
+		{FilePath: filepath.Join("registry.example.com", "charts", "chart:1.0.0", "templates", "deployment.yaml")},
+		{FilePath: filepath.Join(cwd, "deployment.yaml")},
+	}}
+
+	require.NoError(t, relativizeFilePaths(&result, filepath.Dir(cwd), args))
+	var paths []string
+	for _, loadErr := range result.LoadErrors {
+		paths = append(paths, loadErr.FilePath)
+	}
+	assert.Equal(t, []string{
+		lintcontext.StdinFilePath,
+		"https://example.com/deployment.yaml",
+		lintcontext.ClusterFilePathPrefix + "default/Deployment/app",
+		filepath.Join("registry.example.com", "charts", "chart:1.0.0", "templates", "deployment.yaml"),
+		filepath.Join(filepath.Base(cwd), "deployment.yaml"),
+	}, paths)
+}
@@ -0,0 +1,97 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.stackrox.io/kube-linter/pkg/builtinchecks"
+	"golang.stackrox.io/kube-linter/pkg/checkregistry"
+	"golang.stackrox.io/kube-linter/pkg/command/common"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/configresolver"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/run"
+
+	_ "golang.stackrox.io/kube-linter/pkg/templates/all"
+)
+
+const (
+	overriddenRemediation = "Pin the image to a digest, see the runbook."
+	overriddenDocsURL     = "https://wiki.example.com/kube-linter/latest-tag"
+)
+
+// lintWithRemediationOverride lints a fixture with the latest-tag check, whose remediation is overridden.
+func lintWithRemediationOverride(t *testing.T) run.Result {
+	registry := checkregistry.New()
+	require.NoError(t, builtinchecks.LoadInto(registry))
+	cfg := &config.Config{Checks: config.ChecksConfig{
+		Remediations: map[string]config.RemediationOverride{
+			"latest-tag": {Remediation: overriddenRemediation, DocsURL: overriddenDocsURL},
+		},
+	}}
+	require.NoError(t, configresolver.ApplyRemediationOverrides(cfg, registry))
+
+	lintCtxs, err := lintcontext.CreateContexts("../../../tests/checks/latest-tag.yml")
+	require.NoError(t, err)
+	result, err := run.Run(lintCtxs, registry, []string{"latest-tag"})
+	require.NoError(t, err)
+	require.NotEmpty(t, result.Reports)
+	return result
+}
+
+func TestRemediationOverrideInJSON(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, common.FormatJSON(&out, lintWithRemediationOverride(t)))
+
+	var decoded struct {
+		Checks  []config.Check
+		Reports []struct {
+			Remediation string
+			DocsURL     string
+		}
+	}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+	require.Len(t, decoded.Checks, 1)
+	assert.Equal(t, overriddenRemediation, decoded.Checks[0].Remediation)
+	assert.Equal(t, overriddenDocsURL, decoded.Checks[0].DocsURL)
+	for _, report := range decoded.Reports {
+		assert.Equal(t, overriddenRemediation, report.Remediation)
+		assert.Equal(t, overriddenDocsURL, report.DocsURL)
+	}
+}
+
+func TestRemediationOverrideInSARIF(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, formatLintSarif(&out, lintWithRemediationOverride(t)))
+
+	var decoded struct {
+		Runs []struct {
+			Tool struct {
+				Driver struct {
+					Rules []struct {
+						ID              string `json:"id"`
+						HelpURI         string `json:"helpUri"`
+						FullDescription struct {
+							Text string `json:"text"`
+						} `json:"fullDescription"`
+						Help struct {
+							Text string `json:"text"`
+						} `json:"help"`
+					} `json:"rules"`
+				} `json:"driver"`
+			} `json:"tool"`
+		} `json:"runs"`
+	}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+	require.Len(t, decoded.Runs, 1)
+	rules := decoded.Runs[0].Tool.Driver.Rules
+	require.Len(t, rules, 1)
+	assert.Equal(t, "latest-tag", rules[0].ID)
+	assert.Equal(t, overriddenDocsURL, rules[0].HelpURI)
+	assert.Equal(t, overriddenRemediation, rules[0].FullDescription.Text)
+	assert.Contains(t, rules[0].Help.Text, "Remediation: "+overriddenRemediation)
+	assert.Contains(t, rules[0].Help.Text, "Documentation: "+overriddenDocsURL)
+}
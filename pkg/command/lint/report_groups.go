@@ -0,0 +1,65 @@
+package lint
+
+import (
+	"sort"
+
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+)
+
+// A reportGroup is a named group of reports, e.g. all the reports for a file, used by the report-style formatters.
+type reportGroup struct {
+	Name    string
+	Reports []*diagnostic.WithContext
+}
+
+// Objects returns the number of distinct objects that the reports of the group are about.
+func (g reportGroup) Objects() int {
+	type objectKey struct {
+		filePath string
+		name     lintcontext.K8sObjectInfo
+	}
+	objects := make(map[objectKey]struct{})
+	for _, report := range g.Reports {
+		objects[objectKey{filePath: report.Object.Metadata.FilePath, name: report.Object.GetK8sObjectName()}] = struct{}{}
+	}
+	return len(objects)
+}
+
+type severityCount struct {
+	Severity config.Severity
+	Count    int
+}
+
+// countBySeverity counts the reports of every severity, from most to least severe, skipping severities without reports.
+func countBySeverity(reports []diagnostic.WithContext) []severityCount {
+	countsBySeverity := make(map[config.Severity]int)
+	for _, report := range reports {
+		countsBySeverity[report.Severity]++
+	}
+	var counts []severityCount
+	for _, severity := range config.AllSeverities() {
+		if count := countsBySeverity[severity]; count > 0 {
+			counts = append(counts, severityCount{Severity: severity, Count: count})
+		}
+	}
+	return counts
+}
+
+func addToReportGroup(groups []reportGroup, name string, report *diagnostic.WithContext) []reportGroup {
+	for i := range groups {
+		if groups[i].Name == name {
+			groups[i].Reports = append(groups[i].Reports, report)
+			return groups
+		}
+	}
+	return append(groups, reportGroup{Name: name, Reports: []*diagnostic.WithContext{report}})
+}
+
+func sortReportGroups(groups []reportGroup) []reportGroup {
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].Name < groups[j].Name
+	})
+	return groups
+}
@@ -0,0 +1,104 @@
+package lint
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.stackrox.io/kube-linter/internal/set"
+	"golang.stackrox.io/kube-linter/pkg/command/common"
+)
+
+// reportOutput is one of the outputs of the lint command: the one given by --format and --output-file, or one given
+// by --report. All outputs are rendered from the same result.
+type reportOutput struct {
+	format string
+	// file is the path to write the output to. If empty, the output is written to stdout.
+	file string
+	// customTemplate is whether the output is rendered with the template of --template or --template-file, instead
+	// of in its format.
+	customTemplate bool
+
+	formatter common.FormatFunc
+}
+
+// parseReportOutput parses an output given by --report, like format=sarif,file=out.sarif, whose format must be one of
+// the given ones.
+func parseReportOutput(spec string, formats []string) (reportOutput, error) {
+	var output reportOutput
+	seen := set.NewStringSet()
+	for _, field := range strings.Split(spec, ",") {
+		key, value := field, ""
+		if idx := strings.Index(field, "="); idx >= 0 {
+			key, value = strings.TrimSpace(field[:idx]), strings.TrimSpace(field[idx+1:])
+		}
+		if !seen.Add(key) {
+			return reportOutput{}, errors.Errorf("invalid --report %q: %s given more than once", spec, key)
+		}
+		switch key {
+		case "format":
+			output.format = value
+		case "file":
+			if value == "" {
+				return reportOutput{}, errors.Errorf("invalid --report %q: file must not be empty, leave it out to write to stdout", spec)
+			}
+			output.file = value
+		default:
+			return reportOutput{}, errors.Errorf("invalid --report %q: unknown key %q, expected format=<format>[,file=<path>]", spec, key)
+		}
+	}
+	if output.format == "" {
+		return reportOutput{}, errors.Errorf("invalid --report %q: format must be set", spec)
+	}
+	if !set.NewStringSet(formats...).Contains(output.format) {
+		return reportOutput{}, errors.Errorf("invalid --report %q: unknown format %q, expected one of %s", spec, output.format, strings.Join(formats, ", "))
+	}
+	return output, nil
+}
+
+// parseReportOutputs returns the primary output, followed by the ones given by --report. At most one of them can be
+// written to stdout, and no two of them to the same file.
+func parseReportOutputs(primary reportOutput, specs []string, formats []string) ([]reportOutput, error) {
+	outputs := []reportOutput{primary}
+	for _, spec := range specs {
+		output, err := parseReportOutput(spec, formats)
+		if err != nil {
+			return nil, err
+		}
+		outputs = append(outputs, output)
+	}
+	toStdout := 0
+	files := set.NewStringSet()
+	for _, output := range outputs {
+		if output.file == "" {
+			toStdout++
+			continue
+		}
+		if !files.Add(output.file) {
+			return nil, errors.Errorf("more than one output is written to %s", output.file)
+		}
+	}
+	if toStdout > 1 {
+		return nil, errors.New("more than one output is written to stdout, give a file to all but one of --output-file and the --report outputs")
+	}
+	return outputs, nil
+}
+
+// usesFormat returns whether any of the outputs is in the given format, and not rendered with a custom template.
+func usesFormat(outputs []reportOutput, format string) bool {
+	for _, output := range outputs {
+		if !output.customTemplate && output.format == format {
+			return true
+		}
+	}
+	return false
+}
+
+// onlyPlainOutputs returns whether all the outputs are in the plain format, and not rendered with a custom template.
+func onlyPlainOutputs(outputs []reportOutput) bool {
+	for _, output := range outputs {
+		if output.customTemplate || output.format != common.PlainFormat {
+			return false
+		}
+	}
+	return true
+}
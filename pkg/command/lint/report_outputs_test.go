@@ -0,0 +1,48 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.stackrox.io/kube-linter/pkg/command/common"
+)
+
+func TestParseReportOutputs(t *testing.T) {
+	formats := formatters.GetEnabledFormatters()
+	primary := reportOutput{format: common.PlainFormat}
+
+	outputs, err := parseReportOutputs(primary, []string{"format=sarif,file=out.sarif", "file=report.json, format=json"}, formats)
+	require.NoError(t, err)
+	assert.Equal(t, []reportOutput{
+		primary,
+		{format: common.SARIFFormat, file: "out.sarif"},
+		{format: common.JSONFormat, file: "report.json"},
+	}, outputs)
+	assert.True(t, usesFormat(outputs, common.SARIFFormat))
+	assert.False(t, usesFormat(outputs, common.JUnitFormat))
+	assert.False(t, onlyPlainOutputs(outputs))
+
+	outputs, err = parseReportOutputs(reportOutput{format: common.JSONFormat, file: "out.json"}, []string{"format=plain"}, formats)
+	require.NoError(t, err)
+	assert.Equal(t, []reportOutput{{format: common.JSONFormat, file: "out.json"}, {format: common.PlainFormat}}, outputs)
+
+	for _, testCase := range []struct {
+		specs []string
+		err   string
+	}{
+		{specs: []string{"format=sarif"}, err: "more than one output is written to stdout"},
+		{specs: []string{"format=sarif,file=out", "format=json,file=out"}, err: "more than one output is written to out"},
+		{specs: []string{"file=out.sarif"}, err: "format must be set"},
+		{specs: []string{"format=yaml,file=out.yaml"}, err: `unknown format "yaml"`},
+		{specs: []string{"format=sarif,path=out.sarif"}, err: `unknown key "path"`},
+		{specs: []string{"format=sarif,format=json,file=out"}, err: "format given more than once"},
+		{specs: []string{"format=sarif,file="}, err: "file must not be empty"},
+	} {
+		_, err := parseReportOutputs(primary, testCase.specs, formats)
+		assert.Error(t, err, "%v", testCase.specs)
+		if err != nil {
+			assert.Contains(t, err.Error(), testCase.err)
+		}
+	}
+}
@@ -2,15 +2,18 @@ package lint
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"text/template"
 
 	"github.com/owenrumney/go-sarif/sarif"
 	"github.com/pkg/errors"
 	"golang.stackrox.io/kube-linter/internal/consts"
+	"golang.stackrox.io/kube-linter/pkg/builtinchecks"
 	"golang.stackrox.io/kube-linter/pkg/command/checks"
 	"golang.stackrox.io/kube-linter/pkg/command/common"
 	"golang.stackrox.io/kube-linter/pkg/config"
@@ -23,15 +26,59 @@ const (
 	ruleHelpTemplateStr = `Check: {{.Name}}
 Description: {{.Description}}
 Remediation: {{.Remediation}}
+Severity: {{.Severity}}
+Documentation: {{checkURL .}}
 Template: {{checkTemplateURL .}}`
 
 	resultMessageTemplateStr = `{{.Report.Diagnostic.Message}}
 object: {{.ObjectName}}`
+
+	// defaultSarifAutomationID is the automationDetails.id of runs, unless --sarif-automation-id is given. Code scanning
+	// dashboards correlate the runs of a tool by it, so it must not change between runs.
+	defaultSarifAutomationID = "kube-linter"
+	// sarifSrcRootBaseID is the uriBaseId that the relative paths of artifacts are relative to, which is the working
+	// directory.
+	sarifSrcRootBaseID = "SRCROOT"
 )
 
+// sarifRunDetails is what the SARIF format records about the run that the result does not hold.
+type sarifRunDetails struct {
+	automationID string
+	commandLine  string
+	exitCode     int
+	// srcRoot is the absolute directory that paths are relative to, like the one of --relative-to. If empty, it is the
+	// working directory.
+	srcRoot string
+}
+
+// The following types add the fields that go-sarif does not support to the ones of its types.
+type sarifReport struct {
+	Version string          `json:"version"`
+	Schema  string          `json:"$schema"`
+	Runs    []sarifRunEntry `json:"runs"`
+}
+
+type sarifRunEntry struct {
+	*sarif.Run
+	// Invocations shadows the field of the same name of sarif.Run.
+	Invocations        []sarifInvocation                  `json:"invocations,omitempty"`
+	AutomationDetails  sarifAutomationDetails             `json:"automationDetails"`
+	OriginalURIBaseIDs map[string]*sarif.ArtifactLocation `json:"originalUriBaseIds,omitempty"`
+}
+
+type sarifInvocation struct {
+	*sarif.Invocation
+	CommandLine string `json:"commandLine,omitempty"`
+	ExitCode    int    `json:"exitCode"`
+}
+
+type sarifAutomationDetails struct {
+	ID string `json:"id"`
+}
+
 var (
 	ruleHelpTemplate = common.MustInstantiatePlainTemplate(ruleHelpTemplateStr,
-		template.FuncMap{"checkTemplateURL": getCheckTemplateURL})
+		template.FuncMap{"checkTemplateURL": getCheckTemplateURL, "checkURL": getCheckURL})
 
 	resultMessageTemplate = common.MustInstantiatePlainTemplate(resultMessageTemplateStr, nil)
 )
@@ -39,13 +86,20 @@ var (
 // formatLintSarif implements common.SARIFFormat.
 // Must be used only with lint.Command because it only understands run.Result as data parameter.
 func formatLintSarif(out io.Writer, data interface{}) error {
-	if res, ok := data.(run.Result); ok {
-		return formatSarif(out, res)
+	return sarifFormatter(sarifRunDetails{automationID: defaultSarifAutomationID})(out, data)
+}
+
+// sarifFormatter returns a formatter of the SARIF format that records the given details of the run.
+func sarifFormatter(details sarifRunDetails) common.FormatFunc {
+	return func(out io.Writer, data interface{}) error {
+		if res, ok := data.(run.Result); ok {
+			return formatSarif(out, res, details)
+		}
+		return errors.New("Provided data must be of run.Result type")
 	}
-	return errors.New("Provided data must be of run.Result type")
 }
 
-func formatSarif(out io.Writer, result run.Result) error {
+func formatSarif(out io.Writer, result run.Result, details sarifRunDetails) error {
 	sarifReport, err := sarif.New(sarif.Version210)
 	if err != nil {
 		return err
@@ -60,31 +114,97 @@ func formatSarif(out io.Writer, result run.Result) error {
 	if err != nil {
 		return err
 	}
+	srcRoot := cwd
+	if details.srcRoot != "" {
+		srcRoot = details.srcRoot
+	}
 
-	sarifRun.AddInvocation(result.Summary.ChecksStatus == run.ChecksPassed).
+	sarifRun.AddInvocation(result.Summary.ChecksStatus == run.ChecksPassed && len(result.LoadErrors) == 0).
 		WithEndTimeUTC(result.Summary.CheckEndTime).
 		// WithWorkingDirectory helps GitHub resolve artifact locations from repo root when their paths are absolute.
 		WithWorkingDirectory(sarif.NewArtifactLocation().WithUri("file://" + cwd))
 
+	// Results reference their rule by index too, which some consumers, like Azure DevOps, need to find it.
+	ruleIndices := make(map[string]int, len(result.Checks)+1)
 	for _, c := range result.Checks {
 		err = addSarifRule(sarifRun, &c)
 		if err != nil {
 			return err
 		}
+		ruleIndices[c.Name] = len(sarifRun.Tool.Driver.Rules) - 1
 	}
 
 	for _, r := range result.Reports {
-		err = addSarifResult(sarifRun, cwd, &r)
+		err = addSarifResult(sarifRun, srcRoot, &r, ruleIndices)
 		if err != nil {
 			return err
 		}
 	}
 
-	return sarifReport.Write(out)
+	if len(result.LoadErrors) > 0 {
+		sarifRun.AddRule(loadErrorCheckName).
+			WithName(sarifRuleName(loadErrorCheckName)).
+			WithDescription("Indicates files that could not be loaded, like Helm charts that failed to render, so that their objects could not be linted.").
+			WithFullDescription(sarif.NewMultiformatMessageString(loadErrorRemediation)).
+			WithHelpURI(consts.MainURL).
+			WithHelp(loadErrorRemediation).
+			WithProperties(sarif.Properties{"problem.severity": sarifProblemSeverity(config.SeverityError)})
+		ruleIndices[loadErrorCheckName] = len(sarifRun.Tool.Driver.Rules) - 1
+	}
+	for i := range result.LoadErrors {
+		loadErr := &result.LoadErrors[i]
+		sarifLocation := sarif.NewLocation()
+		sarifLocation.PhysicalLocation = sarif.NewPhysicalLocation().
+			WithArtifactLocation(sarifArtifactLocation(srcRoot, loadErr.FilePath)).
+			WithRegion(sarif.NewRegion().WithStartLine(loadErrorLine(loadErr)))
+		sarifRun.AddResult(loadErrorCheckName).
+			WithRuleIndex(ruleIndices[loadErrorCheckName]).
+			WithLevel(sarifLevel(config.SeverityError)).
+			WithMessage(sarif.NewTextMessage(loadErr.Message)).
+			WithLocation(sarifLocation)
+	}
+
+	return writeSarifReport(out, sarifReport, srcRoot, details)
+}
+
+// writeSarifReport writes the given report, adding the automation details, the command line and exit code of the
+// invocation and srcRoot, the base of the relative paths of artifacts, to its run.
+func writeSarifReport(out io.Writer, report *sarif.Report, srcRoot string, details sarifRunDetails) error {
+	srcRootURI := filepath.ToSlash(srcRoot)
+	if !strings.HasSuffix(srcRootURI, "/") {
+		srcRootURI += "/"
+	}
+	if !strings.HasPrefix(srcRootURI, "/") {
+		// Windows paths, like C:/src/, need a leading slash to be the path of a file URI.
+		srcRootURI = "/" + srcRootURI
+	}
+	withDetails := sarifReport{Version: report.Version, Schema: report.Schema}
+	for _, sarifRun := range report.Runs {
+		sarifRun.DedupeArtifacts()
+		entry := sarifRunEntry{
+			Run:                sarifRun,
+			AutomationDetails:  sarifAutomationDetails{ID: details.automationID},
+			OriginalURIBaseIDs: map[string]*sarif.ArtifactLocation{sarifSrcRootBaseID: sarif.NewSimpleArtifactLocation("file://" + srcRootURI)},
+		}
+		for _, invocation := range sarifRun.Invocations {
+			entry.Invocations = append(entry.Invocations, sarifInvocation{
+				Invocation:  invocation,
+				CommandLine: details.commandLine,
+				ExitCode:    details.exitCode,
+			})
+		}
+		withDetails.Runs = append(withDetails.Runs, entry)
+	}
+	marshalled, err := json.Marshal(withDetails)
+	if err != nil {
+		return err
+	}
+	_, err = out.Write(marshalled)
+	return err
 }
 
 func addSarifRule(sarifRun *sarif.Run, check *config.Check) error {
-	helpURL, err := getCheckTemplateURL(check)
+	helpURL, err := getCheckURL(check)
 	if err != nil {
 		return err
 	}
@@ -95,6 +215,7 @@ func addSarifRule(sarifRun *sarif.Run, check *config.Check) error {
 	}
 
 	sarifRun.AddRule(check.Name).
+		WithName(sarifRuleName(check.Name)).
 		WithDescription(check.Description).
 		WithFullDescription(sarif.NewMultiformatMessageString(check.Remediation)).
 		WithHelpURI(helpURL).
@@ -103,11 +224,47 @@ func addSarifRule(sarifRun *sarif.Run, check *config.Check) error {
 		// 2) Rule ID, short and full descriptions are shown at different spots on the screen but it is helpful to see
 		//    them together.
 		// Markdown format for Help seemed to be ignored therefore we only provide the plain text version.
-		WithHelp(helpText)
+		WithHelp(helpText).
+		// go-sarif does not support the default configuration of rules, so we provide their severity the way GitHub
+		// code scanning reads it instead.
+		WithProperties(sarif.Properties{"problem.severity": sarifProblemSeverity(check.Severity)})
 
 	return nil
 }
 
+// sarifRuleName returns the name of the SARIF rule for the given check, the name of the check in PascalCase, since
+// SARIF recommends rule names that are not just the rule ID and can be used as identifiers.
+// E.g. check name "latest-tag" becomes "LatestTag".
+func sarifRuleName(checkName string) string {
+	var name strings.Builder
+	for _, word := range strings.Split(checkName, "-") {
+		if word == "" {
+			continue
+		}
+		name.WriteString(strings.ToUpper(word[:1]))
+		name.WriteString(word[1:])
+	}
+	return name.String()
+}
+
+// getCheckURL returns the link to the documentation of the given check. Only built-in checks are documented, so
+// custom checks link to the documentation of their template instead, unless the check has its own link.
+func getCheckURL(check *config.Check) (string, error) {
+	if check.DocsURL != "" {
+		return check.DocsURL, nil
+	}
+	builtInChecks, err := builtinchecks.List()
+	if err != nil {
+		return "", err
+	}
+	for _, builtInCheck := range builtInChecks {
+		if builtInCheck.Name == check.Name {
+			return fmt.Sprintf(consts.CheckURLFormat, check.Name), nil
+		}
+	}
+	return getCheckTemplateURL(check)
+}
+
 func getCheckTemplateURL(check *config.Check) (string, error) {
 	anchor, err := checks.GetTemplateLink(check)
 	if err != nil {
@@ -125,15 +282,15 @@ func renderTemplate(t *template.Template, data interface{}) (string, error) {
 	return buf.String(), nil
 }
 
-func addSarifResult(sarifRun *sarif.Run, cwd string, report *diagnostic.WithContext) error {
+func addSarifResult(sarifRun *sarif.Run, srcRoot string, report *diagnostic.WithContext, ruleIndices map[string]int) error {
 	sarifLocation := sarif.NewLocation()
 
+	// Errors without a known position are assigned to the first line in the file, otherwise the absent region on the
+	// output does not pass GitHub validation rule GH1003.
+	line, column := reportPosition(report)
 	sarifLocation.PhysicalLocation = sarif.NewPhysicalLocation().
-		WithArtifactLocation(sarif.NewArtifactLocation().WithUri(getArtifactURI(cwd, report.Object.Metadata.FilePath))).
-		// We currently assign all errors to the first line in the file otherwise the absent region on the output
-		// does not pass GitHub validation rule GH1003.
-		// TODO: update region location with actual position in the file when it is available.
-		WithRegion(sarif.NewRegion().WithStartLine(1))
+		WithArtifactLocation(sarifArtifactLocation(srcRoot, report.Object.Metadata.FilePath)).
+		WithRegion(sarif.NewRegion().WithStartLine(line).WithStartColumn(column))
 
 	k8sObjectName := report.Object.GetK8sObjectName()
 
@@ -155,23 +312,70 @@ func addSarifResult(sarifRun *sarif.Run, cwd string, report *diagnostic.WithCont
 	}
 
 	sarifRun.AddResult(report.Check).
+		WithRuleIndex(ruleIndices[report.Check]).
+		WithLevel(sarifLevel(report.Severity)).
 		WithMessage(sarif.NewTextMessage(messageText)).
 		WithLocation(sarifLocation)
 
 	return nil
 }
 
-// getArtifactURI tries to resolve path relative to cwd; if that fails, tries to get the absolute path with appended
-// `file://` protocol; if that fails, returns the path as-is.
+// sarifLevel maps the severity of a report to one of the result levels defined by SARIF.
+func sarifLevel(severity config.Severity) string {
+	switch severity {
+	case config.SeverityWarning:
+		return "warning"
+	case config.SeverityInfo:
+		return "note"
+	default:
+		return "error"
+	}
+}
+
+// sarifProblemSeverity maps the severity of a check to one of the rule severities that GitHub code scanning supports.
+func sarifProblemSeverity(severity config.Severity) string {
+	if severity == config.SeverityInfo {
+		return "recommendation"
+	}
+	return sarifLevel(severity)
+}
+
+// quoteCommandLine returns the command line of the given arguments, quoting the ones that a shell would split or
+// interpret.
+func quoteCommandLine(args []string) string {
+	quoted := make([]string, 0, len(args))
+	for _, arg := range args {
+		if arg != "" && strings.Trim(arg, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_./=:,@%+") == "" {
+			quoted = append(quoted, arg)
+			continue
+		}
+		quoted = append(quoted, "'"+strings.ReplaceAll(arg, "'", `'\''`)+"'")
+	}
+	return strings.Join(quoted, " ")
+}
+
+// sarifArtifactLocation returns the location of the file with the given path. Paths relative to srcRoot are relative to
+// the SRCROOT base of the run.
+func sarifArtifactLocation(srcRoot, path string) *sarif.ArtifactLocation {
+	uri := getArtifactURI(srcRoot, path)
+	location := sarif.NewArtifactLocation().WithUri(uri)
+	if !strings.HasPrefix(uri, "file://") {
+		location.WithUriBaseId(sarifSrcRootBaseID)
+	}
+	return location
+}
+
+// getArtifactURI tries to resolve path relative to srcRoot, which relative paths are also taken to be relative to; if
+// that fails, returns the absolute path with appended `file://` protocol.
 // GitHub prefers file URIs to be provided relative to the repo root. Assuming that this tool is invoked from the repo
-// root, this function should resolve paths in a way GitHub likes them.
-func getArtifactURI(cwd, path string) string {
-	absolute, err := filepath.Abs(path)
-	if err != nil {
-		return path
+// root, or that --relative-to is the repo root, this function should resolve paths in a way GitHub likes them.
+func getArtifactURI(srcRoot, path string) string {
+	absolute := path
+	if !filepath.IsAbs(absolute) {
+		absolute = filepath.Join(srcRoot, absolute)
 	}
 
-	relative, err := filepath.Rel(cwd, absolute)
+	relative, err := filepath.Rel(srcRoot, absolute)
 	if err == nil {
 		return relative
 	}
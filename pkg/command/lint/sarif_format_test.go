@@ -0,0 +1,67 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.stackrox.io/kube-linter/pkg/builtinchecks"
+	"golang.stackrox.io/kube-linter/pkg/checkregistry"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/run"
+)
+
+const (
+	sarifGoldenFile = "testdata/sarif.golden.json"
+)
+
+var (
+	updateGolden = flag.Bool("update", false, "Update the golden files of the tests")
+)
+
+func TestSarifGolden(t *testing.T) {
+	registry := checkregistry.New()
+	require.NoError(t, builtinchecks.LoadInto(registry))
+	lintCtxs, err := lintcontext.CreateContexts("../../../tests/checks/latest-tag.yml")
+	require.NoError(t, err)
+	result, err := run.Run(lintCtxs, registry, []string{"latest-tag"})
+	require.NoError(t, err)
+	require.NotEmpty(t, result.Reports)
+	result.Summary.CheckEndTime = time.Date(2021, 11, 9, 10, 12, 45, 0, time.UTC)
+	result.LoadErrors = []run.LoadError{{FilePath: "manifests/broken.yaml", Line: 3, Message: "could not parse the document"}}
+
+	var out bytes.Buffer
+	require.NoError(t, formatSarif(&out, result, sarifRunDetails{
+		automationID: "ci/manifests",
+		commandLine:  quoteCommandLine([]string{"kube-linter", "lint", "--format", "sarif", "my manifests"}),
+		exitCode:     1,
+	}))
+
+	// The working directory is replaced, so that the golden file does not depend on where the tests run.
+	cwd, err := os.Getwd()
+	require.NoError(t, err)
+	normalized := strings.ReplaceAll(out.String(), "file://"+filepath.ToSlash(cwd), "file:///src")
+	var indented bytes.Buffer
+	require.NoError(t, json.Indent(&indented, []byte(normalized), "", "  "))
+	indented.WriteString("\n")
+
+	if *updateGolden {
+		require.NoError(t, ioutil.WriteFile(sarifGoldenFile, indented.Bytes(), 0644))
+	}
+	golden, err := ioutil.ReadFile(sarifGoldenFile)
+	require.NoError(t, err)
+	assert.Equal(t, string(golden), indented.String(), "run go test with -update to update %s", sarifGoldenFile)
+}
+
+func TestQuoteCommandLine(t *testing.T) {
+	assert.Equal(t, "kube-linter lint --format=sarif ./manifests", quoteCommandLine([]string{"kube-linter", "lint", "--format=sarif", "./manifests"}))
+	assert.Equal(t, `kube-linter lint 'my manifests' 'it'\''s' ''`, quoteCommandLine([]string{"kube-linter", "lint", "my manifests", "it's", ""}))
+}
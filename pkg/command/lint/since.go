@@ -0,0 +1,58 @@
+package lint
+
+import (
+	"bytes"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// changedFilesSince returns the files in the working tree that differ from the given git ref, including staged and
+// unstaged changes and untracked files that are not ignored, but not deleted files, as paths relative to the working
+// directory.
+func changedFilesSince(ref string) ([]string, error) {
+	// The path from the working directory to the top of the repository, since git diff names files relative to it.
+	cdup, err := runGit("rev-parse", "--show-cdup")
+	if err != nil {
+		return nil, errors.Wrap(err, "--since requires the working directory to be in a git repository")
+	}
+	if _, err := runGit("rev-parse", "--verify", "--quiet", ref+"^{commit}"); err != nil {
+		return nil, errors.Errorf("git ref %q not found", ref)
+	}
+	out, err := runGit("diff", "--name-only", "-z", "--diff-filter=d", ref, "--")
+	if err != nil {
+		return nil, errors.Wrapf(err, "listing files changed since %s", ref)
+	}
+	// New files that were not added yet are not known to git diff. The :/ pathspec lists them in the whole repository,
+	// and --full-name names them relative to its top like git diff does.
+	untracked, err := runGit("ls-files", "--others", "--exclude-standard", "--full-name", "-z", "--", ":/")
+	if err != nil {
+		return nil, errors.Wrap(err, "listing untracked files")
+	}
+	topLevel := strings.TrimSpace(cdup)
+	files := []string{}
+	for _, name := range strings.Split(out+untracked, "\x00") {
+		if name != "" {
+			files = append(files, filepath.Join(topLevel, filepath.FromSlash(name)))
+		}
+	}
+	return files, nil
+}
+
+// runGit runs git with the given arguments in the working directory, and returns its output. Errors include what git
+// printed to stderr.
+func runGit(args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("git", args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", errors.Wrap(err, msg)
+		}
+		return "", err
+	}
+	return stdout.String(), nil
+}
@@ -0,0 +1,60 @@
+package lint
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangedFilesSince(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	repo := t.TempDir()
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = repo
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	write := func(name, contents string) {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(repo, name)), 0755))
+		require.NoError(t, ioutil.WriteFile(filepath.Join(repo, name), []byte(contents), 0644))
+	}
+	git("init", "-q")
+	write("manifests/unchanged.yaml", "kind: ConfigMap\n")
+	write("manifests/changed.yaml", "kind: ConfigMap\n")
+	write(".gitignore", "*.rendered.yaml\n")
+	git("add", ".")
+	git("commit", "-q", "-m", "initial")
+
+	write("manifests/changed.yaml", "kind: Secret\n")
+	write("manifests/staged.yaml", "kind: ConfigMap\n")
+	git("add", "manifests/staged.yaml")
+	write("manifests/untracked.yaml", "kind: ConfigMap\n")
+	write("manifests/ignored.rendered.yaml", "kind: ConfigMap\n")
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(filepath.Join(repo, "manifests")))
+	defer func() {
+		require.NoError(t, os.Chdir(wd))
+	}()
+	files, err := changedFilesSince("HEAD")
+	require.NoError(t, err)
+	// Paths go through the top of the repository, and are relative to the working directory once cleaned.
+	var cleaned []string
+	for _, file := range files {
+		cleaned = append(cleaned, filepath.Clean(file))
+	}
+	assert.ElementsMatch(t, []string{
+		filepath.Join("..", "manifests", "changed.yaml"),
+		filepath.Join("..", "manifests", "staged.yaml"),
+		filepath.Join("..", "manifests", "untracked.yaml"),
+	}, cleaned)
+}
@@ -0,0 +1,49 @@
+package lint
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/pkg/errors"
+	"golang.stackrox.io/kube-linter/internal/fileutil"
+	"golang.stackrox.io/kube-linter/pkg/run"
+)
+
+// summaryFile is the content of the file given by --summary-file, which CI steps can read to branch on the counts
+// of lint errors, whatever the exit code of the command was.
+type summaryFile struct {
+	KubeLinterVersion string `json:"kubeLinterVersion"`
+	// Failed is whether the lint errors or load errors reach --fail-on, even if --no-fail kept the command from
+	// failing.
+	Failed     bool `json:"failed"`
+	Reports    int  `json:"reports"`
+	Errors     int  `json:"errors"`
+	Warnings   int  `json:"warnings"`
+	Infos      int  `json:"infos"`
+	LoadErrors int  `json:"loadErrors"`
+	// Objects and Files are the numbers of objects and files with at least one lint error.
+	Objects int `json:"objects"`
+	Files   int `json:"files"`
+}
+
+// writeSummaryFile writes the counts of the given result, in JSON, to the file at path.
+func writeSummaryFile(path string, result run.Result, failed bool) error {
+	counts := result.Summary.Counts
+	summary := summaryFile{
+		KubeLinterVersion: result.Summary.KubeLinterVersion,
+		Failed:            failed,
+		Reports:           counts.Reports,
+		Errors:            counts.Errors,
+		Warnings:          counts.Warnings,
+		Infos:             counts.Infos,
+		LoadErrors:        len(result.LoadErrors),
+		Objects:           counts.Objects,
+		Files:             counts.Files,
+	}
+	err := fileutil.WriteAtomically(path, func(out io.Writer) error {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(summary)
+	})
+	return errors.Wrapf(err, "writing summary to %s", path)
+}
@@ -0,0 +1,93 @@
+package lint
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.stackrox.io/kube-linter/pkg/run"
+)
+
+const (
+	latestTagFixture = "../../../tests/checks/latest-tag.yml"
+)
+
+func readSummaryFile(t *testing.T, path string) summaryFile {
+	contents, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	var summary summaryFile
+	require.NoError(t, json.Unmarshal(contents, &summary))
+	return summary
+}
+
+// runLintCommand runs the lint command with the latest-tag check and the given args, writing its output to a file so
+// that it does not clutter the test output.
+func runLintCommand(t *testing.T, args ...string) error {
+	c := Command()
+	c.SetArgs(append([]string{
+		"--do-not-auto-add-defaults", "--include", "latest-tag", "--quiet",
+		"--output-file", filepath.Join(t.TempDir(), "out.txt"),
+	}, args...))
+	c.SilenceUsage = true
+	c.SilenceErrors = true
+	return c.Execute()
+}
+
+func TestWriteSummaryFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "summary.json")
+
+	require.NoError(t, writeSummaryFile(path, run.Result{Summary: run.Summary{KubeLinterVersion: "v1"}}, false))
+	assert.Equal(t, summaryFile{KubeLinterVersion: "v1"}, readSummaryFile(t, path))
+
+	result := run.Result{
+		LoadErrors: []run.LoadError{{FilePath: "broken.yaml"}},
+		Summary: run.Summary{KubeLinterVersion: "v1", Counts: run.ReportCounts{
+			Reports: 4, Objects: 1, Files: 1, Errors: 1, Warnings: 2, Infos: 1,
+		}},
+	}
+	require.NoError(t, writeSummaryFile(path, result, true))
+	assert.Equal(t, summaryFile{
+		KubeLinterVersion: "v1",
+		Failed:            true,
+		Reports:           4,
+		Errors:            1,
+		Warnings:          2,
+		Infos:             1,
+		LoadErrors:        1,
+		Objects:           1,
+		Files:             1,
+	}, readSummaryFile(t, path))
+}
+
+func TestSummaryFileWithoutObjects(t *testing.T) {
+	summaryPath := filepath.Join(t.TempDir(), "summary.json")
+	require.NoError(t, runLintCommand(t, "--summary-file", summaryPath, t.TempDir()))
+	summary := readSummaryFile(t, summaryPath)
+	assert.False(t, summary.Failed)
+	assert.Zero(t, summary.Reports)
+}
+
+func TestSummaryFileWhenFindingsReachFailOn(t *testing.T) {
+	summaryPath := filepath.Join(t.TempDir(), "summary.json")
+	assert.Error(t, runLintCommand(t, "--summary-file", summaryPath, latestTagFixture))
+	summary := readSummaryFile(t, summaryPath)
+	assert.True(t, summary.Failed)
+	assert.NotZero(t, summary.Reports)
+	assert.Equal(t, summary.Reports, summary.Errors+summary.Warnings+summary.Infos)
+
+	// Findings below --fail-on are still counted.
+	require.NoError(t, runLintCommand(t, "--summary-file", summaryPath, "--fail-on", "none", latestTagFixture))
+	summary = readSummaryFile(t, summaryPath)
+	assert.False(t, summary.Failed)
+	assert.NotZero(t, summary.Reports)
+}
+
+func TestExitZero(t *testing.T) {
+	summaryPath := filepath.Join(t.TempDir(), "summary.json")
+	assert.NoError(t, runLintCommand(t, "--exit-zero", "--summary-file", summaryPath, latestTagFixture))
+	// The summary still records that the findings reach --fail-on.
+	assert.True(t, readSummaryFile(t, summaryPath).Failed)
+}
@@ -0,0 +1,215 @@
+package lint
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/mattn/go-isatty"
+	"github.com/pkg/errors"
+	"golang.stackrox.io/kube-linter/internal/logging"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+)
+
+const (
+	// watchDebounce is how long to wait after a change before linting again, so that editors saving several files,
+	// or writing a file in several steps, only cause one run.
+	watchDebounce = 200 * time.Millisecond
+
+	// clearScreen is the ANSI escape sequence that moves the cursor to the top left corner and clears the screen.
+	clearScreen = "\033[H\033[2J"
+)
+
+// fileWatcher watches the local files and directories that the objects to lint are loaded from.
+type fileWatcher struct {
+	watcher *fsnotify.Watcher
+	// files are the files given as arguments. They are watched through their parent directories, because editors often
+	// save files by replacing them, which ends watches on the files themselves.
+	files map[string]bool
+	// dirs are the directories given as arguments, which are watched recursively.
+	dirs []string
+	// outputFiles are the files that the command writes, like the one of --output-file. Changes to them, and to the
+	// temporary files they are written through, are ignored, since every run would otherwise cause another one.
+	outputFiles map[string]bool
+}
+
+// newFileWatcher watches the given paths, ignoring changes to the given output files.
+func newFileWatcher(paths []string, outputFiles []string) (*fileWatcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, errors.Wrap(err, "creating file watcher")
+	}
+	w := &fileWatcher{watcher: watcher, files: make(map[string]bool), outputFiles: make(map[string]bool)}
+	for _, path := range outputFiles {
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			_ = watcher.Close()
+			return nil, errors.Wrapf(err, "resolving output file %s", path)
+		}
+		w.outputFiles[absPath] = true
+	}
+	for _, path := range paths {
+		// Events are named after the watched paths, so absolute paths make them comparable to each other.
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			_ = watcher.Close()
+			return nil, errors.Wrapf(err, "watching %s", path)
+		}
+		path = absPath
+		info, err := os.Stat(path)
+		if err != nil {
+			_ = watcher.Close()
+			return nil, errors.Wrapf(err, "watching %s", path)
+		}
+		if info.IsDir() {
+			w.dirs = append(w.dirs, path)
+			err = w.addDir(path)
+		} else {
+			w.files[path] = true
+			err = watcher.Add(filepath.Dir(path))
+		}
+		if err != nil {
+			_ = watcher.Close()
+			return nil, errors.Wrapf(err, "watching %s", path)
+		}
+	}
+	return w, nil
+}
+
+// addDir watches the given directory and all directories below it, since fsnotify does not watch recursively.
+func (w *fileWatcher) addDir(dir string) error {
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			// Files can be deleted while we walk, which is fine since we only want to know about the ones that exist.
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if !info.IsDir() {
+			return nil
+		}
+		return w.watcher.Add(path)
+	})
+}
+
+// isRelevant returns whether the given event is about one of the watched files, or something in a watched directory.
+// Changes of permissions alone are ignored, since tools like indexers and virus scanners cause many of them, and so
+// are changes to the output files.
+func (w *fileWatcher) isRelevant(event fsnotify.Event) bool {
+	if event.Op == fsnotify.Chmod {
+		return false
+	}
+	path := filepath.Clean(event.Name)
+	if w.isOutputFile(path) {
+		return false
+	}
+	if w.files[path] {
+		return true
+	}
+	for _, dir := range w.dirs {
+		if path == dir || strings.HasPrefix(path, dir+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// isOutputFile returns whether the given absolute path is one of the output files, or one of the temporary files that
+// fileutil.WriteAtomically writes them through.
+func (w *fileWatcher) isOutputFile(path string) bool {
+	if w.outputFiles[path] {
+		return true
+	}
+	dir, base := filepath.Split(path)
+	if !strings.HasPrefix(base, ".") {
+		return false
+	}
+	if idx := strings.LastIndex(base, ".tmp-"); idx > 0 {
+		return w.outputFiles[filepath.Join(dir, base[1:idx])]
+	}
+	return false
+}
+
+// handle updates the watches for the given event, so that directories created under watched directories are watched
+// too. Deleted directories are dropped by fsnotify itself.
+func (w *fileWatcher) handle(event fsnotify.Event) error {
+	if event.Op&fsnotify.Create == 0 {
+		return nil
+	}
+	info, err := os.Stat(event.Name)
+	if err != nil || !info.IsDir() {
+		return nil
+	}
+	return w.addDir(event.Name)
+}
+
+func (w *fileWatcher) close() error {
+	return w.watcher.Close()
+}
+
+// watchAndLint calls lint, and calls it again whenever any of the local files or directories that the given
+// arguments refer to changes, until interrupted. Changes to the given output files, which lint writes, are ignored.
+// Errors from lint, including lint errors, are logged instead of ending the loop.
+func watchAndLint(args []string, outputFiles []string, logger *logging.Logger, lint func() error) error {
+	paths := lintcontext.LocalPaths(args...)
+	if len(paths) == 0 {
+		return errors.New("--watch requires at least one local file or directory to watch")
+	}
+	w, err := newFileWatcher(paths, outputFiles)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = w.close()
+	}()
+
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(interrupts)
+
+	clearOnRun := isatty.IsTerminal(os.Stdout.Fd()) || isatty.IsCygwinTerminal(os.Stdout.Fd())
+	runLint := func() {
+		if clearOnRun {
+			fmt.Fprint(os.Stdout, clearScreen)
+		}
+		if err := lint(); err != nil {
+			logger.Error(err.Error())
+		}
+		logger.Info("Watching for changes, press Ctrl-C to exit.")
+	}
+
+	runLint()
+	var debounce <-chan time.Time
+	for {
+		select {
+		case <-interrupts:
+			return nil
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return nil
+			}
+			if !w.isRelevant(event) {
+				continue
+			}
+			if err := w.handle(event); err != nil {
+				logger.Warn("failed to watch file", "file", event.Name, "error", err)
+			}
+			// Every change restarts the wait, so that a burst of changes only causes one run after it is over.
+			debounce = time.After(watchDebounce)
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return nil
+			}
+			logger.Warn("watching files failed", "error", err)
+		case <-debounce:
+			debounce = nil
+			runLint()
+		}
+	}
+}
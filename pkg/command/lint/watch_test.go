@@ -0,0 +1,67 @@
+package lint
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.stackrox.io/kube-linter/internal/fileutil"
+)
+
+// relevantEvents returns the names of the relevant events that the watcher receives within the given time.
+func relevantEvents(t *testing.T, w *fileWatcher, wait time.Duration) []string {
+	var names []string
+	timeout := time.After(wait)
+	for {
+		select {
+		case event := <-w.watcher.Events:
+			if w.isRelevant(event) {
+				names = append(names, event.Name)
+			}
+		case err := <-w.watcher.Errors:
+			require.NoError(t, err)
+		case <-timeout:
+			return names
+		}
+	}
+}
+
+func TestFileWatcherIgnoresOutputFiles(t *testing.T) {
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+	manifest := filepath.Join(dir, "deployment.yaml")
+	require.NoError(t, ioutil.WriteFile(manifest, []byte("kind: Deployment\n"), 0644))
+	outputFile := filepath.Join(dir, "out.txt")
+
+	// The output file is given relative to the working directory, like on the command line.
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	relOutputFile, err := filepath.Rel(wd, outputFile)
+	require.NoError(t, err)
+	w, err := newFileWatcher([]string{dir}, []string{relOutputFile})
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, w.close())
+	}()
+
+	assert.False(t, w.isRelevant(fsnotify.Event{Name: outputFile, Op: fsnotify.Write}))
+	assert.False(t, w.isRelevant(fsnotify.Event{Name: filepath.Join(dir, ".out.txt.tmp-123"), Op: fsnotify.Create}))
+	assert.True(t, w.isRelevant(fsnotify.Event{Name: filepath.Join(dir, ".other.txt.tmp-123"), Op: fsnotify.Create}))
+	assert.True(t, w.isRelevant(fsnotify.Event{Name: manifest, Op: fsnotify.Write}))
+
+	// Writing the output file like the lint command does must not cause another run.
+	require.NoError(t, fileutil.WriteAtomically(outputFile, func(out io.Writer) error {
+		_, err := io.WriteString(out, "No lint errors found!\n")
+		return err
+	}))
+	assert.Empty(t, relevantEvents(t, w, 2*watchDebounce))
+
+	require.NoError(t, ioutil.WriteFile(manifest, []byte("kind: Deployment\nmetadata: {}\n"), 0644))
+	assert.Contains(t, relevantEvents(t, w, 2*watchDebounce), manifest)
+}
@@ -6,18 +6,30 @@ import (
 
 	"github.com/spf13/cobra"
 	"golang.stackrox.io/kube-linter/pkg/command/checks"
+	"golang.stackrox.io/kube-linter/pkg/command/common"
 	"golang.stackrox.io/kube-linter/pkg/command/lint"
 	"golang.stackrox.io/kube-linter/pkg/command/templates"
 	"golang.stackrox.io/kube-linter/pkg/command/version"
+	templateregistry "golang.stackrox.io/kube-linter/pkg/templates"
 )
 
 // Command is the root command.
 func Command() *cobra.Command {
+	var plugins []string
+	var noColor bool
 	c := &cobra.Command{
 		Use:           filepath.Base(os.Args[0]),
 		SilenceUsage:  true,
 		SilenceErrors: true,
+		PersistentPreRunE: func(_ *cobra.Command, _ []string) error {
+			if noColor {
+				common.DisableColor()
+			}
+			return templateregistry.LoadPlugins(plugins...)
+		},
 	}
+	c.PersistentFlags().StringSliceVar(&plugins, "plugin", nil, "Path to a Go plugin that registers additional templates. Can be specified multiple times.")
+	c.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable colored output. Output is also not colored if the NO_COLOR environment variable is set, or if it is not written to a terminal")
 	c.AddCommand(
 		checks.Command(),
 		lint.Command(),
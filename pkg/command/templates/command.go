@@ -33,10 +33,14 @@ KubeLinter supports the following templates:
 	plainTemplateStr = `{{- define "Param" }}{{ $tabs := repeat .NestingLevel "\t" }}
 	{{$tabs}}{{.Name}}:
 		{{$tabs}}Description: {{.Description}}
-		{{$tabs}}Required: {{.Required}}{{if .Examples}}
+		{{$tabs}}Type: {{.Type}}{{if .ArrayElemType}} of {{.ArrayElemType}}{{end}}
+		{{$tabs}}Required: {{.Required}}{{if .Default}}
+		{{$tabs}}Default: {{.Default}}{{end}}{{if .Enum}}
+		{{$tabs}}Allowed values: {{ range $i, $_ := .Enum }}{{if $i}}, {{end}}{{ printf "%q" . }}{{end}}{{end}}{{if .RegexAllowed}}
+		{{$tabs}}Regexes allowed: {{.RegexAllowed}}{{end}}{{if .NegationAllowed}}
+		{{$tabs}}Negation allowed: {{.NegationAllowed}}{{end}}{{if .Examples}}
 		{{$tabs}}Example values: {{ range $i, $_ := .Examples }}{{if $i}}, {{end}}{{ printf "%q" . }}{{end}}{{end}}{{if .SubParameters}}
-		{{$tabs}}Sub-parameters: {{ range .SubParameters }}{{ template "Param" . }}{{end}}{{end}}{{if .ArrayElemType}}
-		{{$tabs}}Elem type: {{.ArrayElemType}}{{end}}
+		{{$tabs}}Sub-parameters: {{ range .SubParameters }}{{ template "Param" . }}{{end}}{{end}}
 {{- end -}}
 {{ range $i, $_ := . }}
 {{- if $i}}
@@ -58,9 +62,9 @@ var (
 
 	formatters = common.Formatters{
 		Formatters: map[common.FormatType]common.FormatFunc{
-			common.PlainFormat:    plainTemplate.Execute,
+			common.PlainFormat:    common.PlainTemplateFormatter(plainTemplate),
 			common.MarkdownFormat: markDownTemplate.Execute,
-			common.JSONFormat:     common.FormatJSON,
+			common.JSONFormat:     formatTemplatesJSON,
 		},
 	}
 )
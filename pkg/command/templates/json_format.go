@@ -0,0 +1,55 @@
+package templates
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/pkg/errors"
+	"golang.stackrox.io/kube-linter/pkg/check"
+)
+
+const (
+	// templatesJSONVersion is the version of the JSON document that lists templates. It must be incremented
+	// whenever a field is removed or changes meaning; adding fields is backwards compatible.
+	templatesJSONVersion = 1
+)
+
+type templatesJSON struct {
+	Version   int            `json:"version"`
+	Templates []templateJSON `json:"templates"`
+}
+
+type templateJSON struct {
+	Key                  string                         `json:"key"`
+	Name                 string                         `json:"name"`
+	Description          string                         `json:"description"`
+	SupportedObjectKinds []string                       `json:"supportedObjectKinds"`
+	Parameters           []check.HumanReadableParamDesc `json:"parameters"`
+}
+
+// formatTemplatesJSON implements common.JSONFormat.
+// Must be used only with the list command because it only understands []check.Template as data parameter.
+func formatTemplatesJSON(out io.Writer, data interface{}) error {
+	if templates, ok := data.([]check.Template); ok {
+		return formatJSON(out, templates)
+	}
+	return errors.New("Provided data must be of []check.Template type")
+}
+
+func formatJSON(out io.Writer, templates []check.Template) error {
+	doc := templatesJSON{
+		Version:   templatesJSONVersion,
+		Templates: make([]templateJSON, 0, len(templates)),
+	}
+	for i := range templates {
+		template := &templates[i]
+		doc.Templates = append(doc.Templates, templateJSON{
+			Key:                  template.Key,
+			Name:                 template.HumanName,
+			Description:          template.Description,
+			SupportedObjectKinds: template.SupportedObjectKinds.ObjectKinds,
+			Parameters:           template.HumanReadableParameters(),
+		})
+	}
+	return json.NewEncoder(out).Encode(doc)
+}
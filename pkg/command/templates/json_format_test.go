@@ -0,0 +1,46 @@
+package templates
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/config"
+)
+
+func TestFormatTemplatesJSON(t *testing.T) {
+	templates := []check.Template{
+		{
+			HumanName:            "Anti affinity not specified",
+			Key:                  "anti-affinity",
+			Description:          "description",
+			SupportedObjectKinds: config.ObjectKindsDesc{ObjectKinds: []string{"DeploymentLike"}},
+			Parameters: []check.ParameterDesc{
+				{Name: "minReplicas", Type: check.IntegerType, Description: "min replicas"},
+				{Name: "topologyKey", Type: check.StringType, Description: "topology key", Default: "kubernetes.io/hostname", XXXStructFieldName: "TopologyKey"},
+				{Name: "type", Type: check.StringType, Required: true, Enum: []string{"request", "limit"}, NoRegex: true, NotNegatable: true},
+			},
+		},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, formatTemplatesJSON(&buf, templates))
+
+	expected := `{
+		"version": 1,
+		"templates": [
+			{"key": "anti-affinity", "name": "Anti affinity not specified", "description": "description", "supportedObjectKinds": ["DeploymentLike"],
+			 "parameters": [
+				{"name": "minReplicas", "type": "integer", "description": "min replicas", "required": false},
+				{"name": "topologyKey", "type": "string", "description": "topology key", "required": false, "default": "kubernetes.io/hostname",
+				 "regexAllowed": true, "negationAllowed": true},
+				{"name": "type", "type": "string", "description": "", "required": true, "enum": ["request", "limit"],
+				 "regexAllowed": false, "negationAllowed": false}
+			 ]}
+		]
+	}`
+	assert.JSONEq(t, expected, buf.String())
+
+	assert.Error(t, formatTemplatesJSON(&buf, "not templates"))
+}
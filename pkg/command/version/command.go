@@ -1,21 +1,109 @@
 package version
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"runtime"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	"golang.stackrox.io/kube-linter/internal/flagutil"
 	"golang.stackrox.io/kube-linter/internal/version"
+	"golang.stackrox.io/kube-linter/pkg/builtinchecks"
+	"golang.stackrox.io/kube-linter/pkg/command/common"
 )
 
+var (
+	formatters = common.Formatters{
+		Formatters: map[common.FormatType]common.FormatFunc{
+			common.PlainFormat: formatPlain,
+			common.JSONFormat:  formatJSON,
+		},
+	}
+)
+
+// Info describes the build of KubeLinter and the built-in checks that it includes.
+type Info struct {
+	KubeLinterVersion string            `json:"kubeLinterVersion"`
+	GitCommit         string            `json:"gitCommit"`
+	BuildDate         string            `json:"buildDate"`
+	GoVersion         string            `json:"goVersion"`
+	Platform          string            `json:"platform"`
+	BuiltInChecks     BuiltInChecksInfo `json:"builtInChecks"`
+}
+
+// BuiltInChecksInfo describes the bundle of built-in checks.
+type BuiltInChecksInfo struct {
+	// BundleVersion is a digest of the definitions of the built-in checks, see builtinchecks.BundleVersion.
+	BundleVersion string `json:"bundleVersion"`
+	Count         int    `json:"count"`
+}
+
+// GetInfo returns the Info of this binary.
+func GetInfo() (Info, error) {
+	checks, err := builtinchecks.List()
+	if err != nil {
+		return Info{}, err
+	}
+	bundleVersion, err := builtinchecks.BundleVersion()
+	if err != nil {
+		return Info{}, err
+	}
+	return Info{
+		KubeLinterVersion: version.Get(),
+		GitCommit:         version.GitCommit(),
+		BuildDate:         version.BuildDate(),
+		GoVersion:         runtime.Version(),
+		Platform:          fmt.Sprintf("%s/%s", runtime.GOOS, runtime.GOARCH),
+		BuiltInChecks: BuiltInChecksInfo{
+			BundleVersion: bundleVersion,
+			Count:         len(checks),
+		},
+	}, nil
+}
+
+// formatPlain prints only the version, like KubeLinter always has, so that scripts that read it keep working.
+func formatPlain(out io.Writer, data interface{}) error {
+	info, ok := data.(Info)
+	if !ok {
+		return errors.New("Provided data must be of Info type")
+	}
+	_, err := fmt.Fprintln(out, info.KubeLinterVersion)
+	return err
+}
+
+func formatJSON(out io.Writer, data interface{}) error {
+	info, ok := data.(Info)
+	if !ok {
+		return errors.New("Provided data must be of Info type")
+	}
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(info)
+}
+
 // Command defines the version command
 func Command() *cobra.Command {
+	format := flagutil.NewEnumFlag("Output format. json also includes the git commit, build date, Go version and the version of the built-in checks",
+		formatters.GetEnabledFormatters(), common.PlainFormat)
 	c := &cobra.Command{
 		Use:   "version",
 		Short: "Print version and exit",
 		Args:  cobra.NoArgs,
-		Run: func(cmd *cobra.Command, _ []string) {
-			fmt.Println(version.Get())
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			info, err := GetInfo()
+			if err != nil {
+				return err
+			}
+			renderFunc, err := formatters.FormatterByType(format.String())
+			if err != nil {
+				return err
+			}
+			return renderFunc(os.Stdout, info)
 		},
 	}
+	c.Flags().Var(format, "format", format.Usage())
 	return c
 }
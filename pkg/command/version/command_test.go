@@ -0,0 +1,37 @@
+package version
+
+import (
+	"bytes"
+	"encoding/json"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormats(t *testing.T) {
+	info, err := GetInfo()
+	require.NoError(t, err)
+	assert.Equal(t, "development", info.KubeLinterVersion)
+	assert.Equal(t, runtime.Version(), info.GoVersion)
+	assert.NotEmpty(t, info.BuiltInChecks.BundleVersion)
+	assert.NotZero(t, info.BuiltInChecks.Count)
+
+	var plain bytes.Buffer
+	require.NoError(t, formatPlain(&plain, info))
+	assert.Equal(t, "development\n", plain.String())
+
+	var out bytes.Buffer
+	require.NoError(t, formatJSON(&out, info))
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &doc))
+	assert.Equal(t, "development", doc["kubeLinterVersion"])
+	assert.Equal(t, "unknown", doc["gitCommit"])
+	assert.Equal(t, "unknown", doc["buildDate"])
+	assert.Equal(t, runtime.GOOS+"/"+runtime.GOARCH, doc["platform"])
+	assert.Equal(t, map[string]interface{}{
+		"bundleVersion": info.BuiltInChecks.BundleVersion,
+		"count":         float64(info.BuiltInChecks.Count),
+	}, doc["builtInChecks"])
+}
@@ -2,12 +2,44 @@ package config
 
 // A Check represents a single check. It is serializable.
 type Check struct {
-	Name        string                 `json:"name"`
-	Description string                 `json:"description"`
-	Remediation string                 `json:"remediation"`
-	Scope       *ObjectKindsDesc       `json:"scope"`
-	Template    string                 `json:"template"`
-	Params      map[string]interface{} `json:"params,omitempty"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Remediation string `json:"remediation"`
+	// DocsURL, if set, links to the documentation of the check, instead of the KubeLinter documentation. It can be
+	// overridden through the checks config.
+	DocsURL string `json:"docsURL,omitempty"`
+	// Severity is the default severity of the check. It can be overridden through the checks config.
+	Severity Severity         `json:"severity,omitempty"`
+	Scope    *ObjectKindsDesc `json:"scope"`
+	// Namespaces, if set, restricts the objects that the check runs on by their namespaces. It can be overridden
+	// through the checks config.
+	Namespaces *NamespaceScope        `json:"namespaces,omitempty"`
+	Template   string                 `json:"template"`
+	Params     map[string]interface{} `json:"params,omitempty"`
+}
+
+// The values of NamespaceScope.WithoutNamespace.
+const (
+	WithoutNamespaceInclude = "include"
+	WithoutNamespaceExclude = "exclude"
+)
+
+// NamespaceScope restricts the objects that a check runs on by their namespaces. Regexes must match the whole
+// namespace.
+type NamespaceScope struct {
+	// Include, if set along with or instead of IncludeRegex, restricts the check to the objects in these namespaces,
+	// or in the namespaces matching IncludeRegex.
+	Include      []string `json:"include,omitempty"`
+	IncludeRegex string   `json:"includeRegex,omitempty"`
+	// Exclude and ExcludeRegex are namespaces whose objects the check does not run on. They take precedence over
+	// Include and IncludeRegex.
+	Exclude      []string `json:"exclude,omitempty"`
+	ExcludeRegex string   `json:"excludeRegex,omitempty"`
+	// WithoutNamespace is whether the check runs on the objects without a namespace, like cluster-scoped objects,
+	// which none of the namespaces above apply to. It is either "include" or "exclude". It defaults to "exclude" if
+	// Include or IncludeRegex is set, since the check is then meant for some namespaces only, and to "include"
+	// otherwise.
+	WithoutNamespace string `json:"withoutNamespace,omitempty"`
 }
 
 // ObjectKindsDesc describes a list of supported object kinds for a check template.
@@ -1,6 +1,9 @@
 package config
 
 import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
@@ -26,6 +29,37 @@ type ChecksConfig struct {
 	// Exclude wins.
 	// +flagName=include
 	Include []string `json:"include"`
+	// Severities overrides the severity of checks, keyed by check name.
+	// +flagName=-
+	Severities map[string]Severity `json:"severities"`
+	// Namespaces restricts the objects that checks run on by their namespaces, keyed by check name. It replaces the
+	// namespace scope of the check spec, if any.
+	// +flagName=-
+	Namespaces map[string]NamespaceScope `json:"namespaces,omitempty"`
+	// Remediations overrides the remediation and documentation link of checks, keyed by check name, for example to
+	// point at the guidance of an organization.
+	// +flagName=-
+	Remediations map[string]RemediationOverride `json:"remediations,omitempty"`
+}
+
+// RemediationOverride overrides the remediation and documentation link of a check. Fields that are empty keep the
+// ones of the check.
+type RemediationOverride struct {
+	Remediation string `json:"remediation,omitempty"`
+	DocsURL     string `json:"docsURL,omitempty"`
+}
+
+// CustomObjectKind describes an object kind that KubeLinter does not know about, typically defined by a CRD, whose
+// objects embed a pod template that the checks on pod templates should run against.
+type CustomObjectKind struct {
+	// Group is the API group of the kind, like example.com. It is empty for the core group.
+	Group string `json:"group"`
+	// Version is the API version of the kind, like v1. If it is empty, all versions match.
+	Version string `json:"version,omitempty"`
+	// Kind is the name of the kind, like WebApp.
+	Kind string `json:"kind"`
+	// PodTemplatePath is the JSONPath of the pod template in the objects, like .spec.template.
+	PodTemplatePath string `json:"podTemplatePath"`
 }
 
 // Config represents the config file format.
@@ -33,10 +67,16 @@ type Config struct {
 	// +flagName=-
 	CustomChecks []Check      `json:"customChecks,omitempty"`
 	Checks       ChecksConfig `json:"checks,omitempty"`
+	// +flagName=-
+	CustomObjectKinds []CustomObjectKind `json:"customObjectKinds,omitempty"`
+	// DisableOpenShiftKinds, if set, keeps objects of OpenShift kinds, like DeploymentConfigs and Routes, from matching
+	// the object kinds of checks, for users who do not deploy on OpenShift.
+	// +flagName=-
+	DisableOpenShiftKinds bool `json:"disableOpenShiftKinds,omitempty"`
 }
 
 // Defines the list of default config filenames to check if parameter isn't passed in
-var defaultConfigFilenames = [...]string{".kube-linter.yaml", ".kube-linter.yml"}
+var defaultConfigFilenames = [...]string{".kube-linter.yaml", ".kube-linter.yml", ".kube-linter.json"}
 
 // Get info on config file if it exists
 func fileExists(filename string) bool {
@@ -47,35 +87,111 @@ func fileExists(filename string) bool {
 	return !info.IsDir()
 }
 
-// Load loads the config from the given path.
-func Load(v *viper.Viper, configPath string) (Config, error) {
-	if configPath == "" {
+// Load loads the config from the given paths. If no paths are given, the first of the default config files that
+// exists is loaded, if any.
+// The config keys that are set in the environment, see EnvVar, come first, then the config files are merged in order,
+// as described on merge, and the values of the flags bound to v take precedence over all of them.
+func Load(v *viper.Viper, configPaths ...string) (Config, error) {
+	var paths []string
+	for _, p := range configPaths {
+		if p != "" {
+			paths = append(paths, p)
+		}
+	}
+	if len(paths) == 0 {
 		for _, p := range defaultConfigFilenames {
 			if fileExists(p) {
-				configPath = p
+				paths = append(paths, p)
 				break
 			}
 		}
 	}
 
-	if configPath != "" {
-		filename := filepath.Base(configPath)
-		ext := filepath.Ext(configPath)
-		path := filepath.Dir(configPath)
-
-		v.SetConfigName(strings.TrimSuffix(filename, ext))
-		v.AddConfigPath(path)
-		if err := v.ReadInConfig(); err != nil {
-			return Config{}, errors.Wrap(err, "reading file")
+	// The environment is the lowest layer, so that the config files and flags override it.
+	merged, err := loadEnv()
+	if err != nil {
+		return Config{}, err
+	}
+	for _, p := range paths {
+		fileViper, err := readFile(p)
+		if err != nil {
+			return Config{}, err
 		}
+		conf, err := unmarshal(fileViper)
+		if err != nil {
+			return Config{}, errors.Wrapf(err, "loading file %s", p)
+		}
+		merged = merge(merged, conf, fileViper.IsSet)
 	}
 
+	// Going through viper again is what lets the flags override the config files.
+	mergedMap, err := toMap(merged)
+	if err != nil {
+		return Config{}, err
+	}
+	if err := v.MergeConfigMap(mergedMap); err != nil {
+		return Config{}, errors.Wrap(err, "merging config files")
+	}
+	return unmarshal(v)
+}
+
+// readFile reads the config file at the given path into a new viper instance.
+func readFile(configPath string) (*viper.Viper, error) {
+	// The file is read once, by us, so that it can also be a pipe, like /dev/stdin.
+	contents, err := ioutil.ReadFile(configPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading file")
+	}
+	v := viper.New()
+	v.SetConfigType(configType(configPath, contents))
+	if err := v.ReadConfig(bytes.NewReader(contents)); err != nil {
+		return nil, errors.Wrapf(err, "parsing file %s", configPath)
+	}
+	if err := checkKeyCase(contents); err != nil {
+		return nil, errors.Wrapf(err, "loading file %s", configPath)
+	}
+	return v, nil
+}
+
+func unmarshal(v *viper.Viper) (Config, error) {
 	var conf Config
 	err := v.Unmarshal(&conf, viper.DecoderConfigOption(func(config *mapstructure.DecoderConfig) {
 		config.TagName = "json"
+		// Keys that do not correspond to any field are most likely typos or misplaced, so we fail instead of silently
+		// ignoring them.
+		config.ErrorUnused = true
 	}))
 	if err != nil {
-		return Config{}, errors.Wrap(err, "unmarshalling config File")
+		return Config{}, describeDecodeError(err)
 	}
 	return conf, nil
 }
+
+// toMap converts the given config to the generic form that viper holds configs in.
+func toMap(conf Config) (map[string]interface{}, error) {
+	marshalled, err := json.Marshal(conf)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshalling config")
+	}
+	var out map[string]interface{}
+	if err := json.Unmarshal(marshalled, &out); err != nil {
+		return nil, errors.Wrap(err, "unmarshalling config")
+	}
+	return out, nil
+}
+
+// configType returns the format of the config file at the given path, as a viper config type. It is determined by
+// the extension of the file, or if the extension is not a known one, like when the config is piped in, by whether the
+// contents look like a JSON object.
+func configType(configPath string, contents []byte) string {
+	switch strings.ToLower(filepath.Ext(configPath)) {
+	case ".json":
+		return "json"
+	case ".yaml", ".yml":
+		return "yaml"
+	}
+	if bytes.HasPrefix(bytes.TrimSpace(contents), []byte("{")) {
+		return "json"
+	}
+	return "yaml"
+}
@@ -0,0 +1,252 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadFromString(t *testing.T, contents string) (Config, error) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0644))
+	return Load(viper.New(), path)
+}
+
+func TestLoad(t *testing.T) {
+	cfg, err := loadFromString(t, `
+checks:
+  include: [latest-tag]
+customChecks:
+- name: required-label-owner
+  template: required-label
+  params:
+    key: owner
+`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"latest-tag"}, cfg.Checks.Include)
+	require.Len(t, cfg.CustomChecks, 1)
+	assert.Equal(t, map[string]interface{}{"key": "owner"}, cfg.CustomChecks[0].Params)
+}
+
+func TestLoadJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"checks": {"include": ["latest-tag"], "doNotAutoAddDefaults": true}}`), 0644))
+	cfg, err := Load(viper.New(), path)
+	require.NoError(t, err)
+	assert.Equal(t, ChecksConfig{Include: []string{"latest-tag"}, DoNotAutoAddDefaults: true}, cfg.Checks)
+}
+
+func TestConfigType(t *testing.T) {
+	for _, testCase := range []struct {
+		path     string
+		contents string
+		expected string
+	}{
+		{path: "config.json", contents: "checks: {}", expected: "json"},
+		{path: "config.YML", contents: `{"checks": {}}`, expected: "yaml"},
+		{path: "/dev/stdin", contents: "\n  {\"checks\": {}}", expected: "json"},
+		{path: "/dev/fd/63", contents: "checks:\n  include: [latest-tag]", expected: "yaml"},
+	} {
+		assert.Equal(t, testCase.expected, configType(testCase.path, []byte(testCase.contents)), testCase.path)
+	}
+}
+
+func TestLoadRejectsInvalidConfigs(t *testing.T) {
+	for _, testCase := range []struct {
+		desc     string
+		contents string
+		errMsg   string
+	}{
+		{
+			desc:     "misspelled top-level key",
+			contents: "check:\n  include: [latest-tag]\n",
+			errMsg:   "unknown key check (valid keys are checks (object), customChecks (list of object), customObjectKinds (list of object), disableOpenShiftKinds (bool))",
+		},
+		{
+			desc:     "misspelled key in checks",
+			contents: "checks:\n  includes: [latest-tag]\n",
+			errMsg:   "unknown key checks.includes (valid keys are addAllBuiltIn (bool), doNotAutoAddDefaults (bool), exclude (list of string), include (list of string), namespaces (map of object), remediations (map of object), severities (map of string))",
+		},
+		{
+			desc:     "top-level key in the wrong case",
+			contents: "Checks:\n  include: [latest-tag]\n",
+			errMsg:   "unknown key Checks (valid keys are checks (object), customChecks (list of object), customObjectKinds (list of object), disableOpenShiftKinds (bool))",
+		},
+		{
+			desc:     "key in checks in the wrong case",
+			contents: "checks:\n  Include: [latest-tag]\n",
+			errMsg:   "unknown key checks.Include (valid keys are addAllBuiltIn (bool), doNotAutoAddDefaults (bool), exclude (list of string), include (list of string), namespaces (map of object), remediations (map of object), severities (map of string))",
+		},
+		{
+			desc:     "key in a custom check in the wrong case",
+			contents: "customChecks:\n- name: required-label-owner\n  Template: required-label\n  params:\n    key: owner\n",
+			errMsg:   "unknown key customChecks[0].Template",
+		},
+		{
+			desc:     "key in the wrong case in JSON",
+			contents: `{"checks": {"doNotAutoAddDefaults": true, "addAllBuiltin": true}}`,
+			errMsg:   "unknown key checks.addAllBuiltin",
+		},
+		{
+			desc:     "template parameter outside of params",
+			contents: "customChecks:\n- name: required-label-owner\n  template: required-label\n  key: owner\n",
+			errMsg:   "unknown key customChecks[0].key",
+		},
+		{
+			desc:     "wrong type",
+			contents: "checks:\n  addAllBuiltIn: sometimes\n",
+			errMsg:   "cannot parse 'checks.addAllBuiltIn' as bool",
+		},
+	} {
+		c := testCase
+		t.Run(c.desc, func(t *testing.T) {
+			_, err := loadFromString(t, c.contents)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), c.errMsg)
+		})
+	}
+}
+
+func TestLoadMergesConfigFiles(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "base.yaml")
+	override := filepath.Join(dir, "override.json")
+	require.NoError(t, os.WriteFile(base, []byte(`
+checks:
+  addAllBuiltIn: true
+  exclude: [latest-tag]
+  severities:
+    host-ipc: info
+    host-pid: warning
+  namespaces:
+    host-ipc:
+      include: [kube-system]
+    host-pid:
+      excludeRegex: kube-.*
+      withoutNamespace: exclude
+  remediations:
+    host-ipc:
+      remediation: See the runbook.
+      docsURL: https://wiki.example.com/host-ipc
+    host-pid:
+      remediation: Do not share the PID namespace.
+customChecks:
+- name: required-label-team
+  template: required-label
+  params:
+    key: team
+- name: required-annotation-team
+  template: required-annotation
+  params:
+    key: team
+disableOpenShiftKinds: true
+`), 0644))
+	require.NoError(t, os.WriteFile(override, []byte(`{
+  "checks": {"addAllBuiltIn": false, "exclude": ["no-liveness-probe", "latest-tag"], "severities": {"host-ipc": "error"},
+    "namespaces": {"host-ipc": {"exclude": ["kube-system"]}},
+    "remediations": {"host-ipc": {"docsURL": "https://docs.example.com/host-ipc"}}},
+  "customChecks": [{"name": "required-label-team", "template": "required-label", "params": {"key": "squad"}}]
+}`), 0644))
+
+	cfg, err := Load(viper.New(), base, override)
+	require.NoError(t, err)
+	assert.Equal(t, ChecksConfig{
+		Exclude:    []string{"latest-tag", "no-liveness-probe"},
+		Severities: map[string]Severity{"host-ipc": SeverityError, "host-pid": SeverityWarning},
+		Namespaces: map[string]NamespaceScope{
+			"host-ipc": {Exclude: []string{"kube-system"}},
+			"host-pid": {ExcludeRegex: "kube-.*", WithoutNamespace: WithoutNamespaceExclude},
+		},
+		Remediations: map[string]RemediationOverride{
+			"host-ipc": {DocsURL: "https://docs.example.com/host-ipc"},
+			"host-pid": {Remediation: "Do not share the PID namespace."},
+		},
+	}, cfg.Checks)
+	require.Len(t, cfg.CustomChecks, 2)
+	assert.Equal(t, "required-label-team", cfg.CustomChecks[0].Name)
+	assert.Equal(t, map[string]interface{}{"key": "squad"}, cfg.CustomChecks[0].Params)
+	assert.Equal(t, "required-annotation-team", cfg.CustomChecks[1].Name)
+
+	// Booleans that a later file does not set are kept.
+	excludeOnly := filepath.Join(dir, "exclude-only.yaml")
+	require.NoError(t, os.WriteFile(excludeOnly, []byte("checks:\n  exclude: [host-ipc]\n"), 0644))
+	cfg, err = Load(viper.New(), base, excludeOnly)
+	require.NoError(t, err)
+	assert.True(t, cfg.Checks.AddAllBuiltIn)
+	assert.True(t, cfg.DisableOpenShiftKinds)
+	assert.Equal(t, []string{"latest-tag", "host-ipc"}, cfg.Checks.Exclude)
+}
+
+// setEnv sets the given environment variables for the rest of the test.
+func setEnv(t *testing.T, env map[string]string) {
+	for name, value := range env {
+		oldValue, wasSet := os.LookupEnv(name)
+		require.NoError(t, os.Setenv(name, value))
+		name := name
+		t.Cleanup(func() {
+			if wasSet {
+				_ = os.Setenv(name, oldValue)
+			} else {
+				_ = os.Unsetenv(name)
+			}
+		})
+	}
+}
+
+func TestEnvVar(t *testing.T) {
+	assert.Equal(t, "KUBELINTER_CHECKS_INCLUDE", EnvVar("checks.include"))
+	assert.Equal(t, "KUBELINTER_CHECKS_DONOTAUTOADDDEFAULTS", EnvVar("checks.doNotAutoAddDefaults"))
+	assert.Equal(t, "KUBELINTER_DISABLEOPENSHIFTKINDS", EnvVar("disableOpenShiftKinds"))
+}
+
+func TestLoadReadsEnvironment(t *testing.T) {
+	setEnv(t, map[string]string{
+		"KUBELINTER_CHECKS_ADDALLBUILTIN":        "true",
+		"KUBELINTER_CHECKS_DONOTAUTOADDDEFAULTS": "true",
+		"KUBELINTER_CHECKS_INCLUDE":              "latest-tag,privileged-container",
+		"KUBELINTER_CHECKS_EXCLUDE":              "no-liveness-probe",
+		"KUBELINTER_DISABLEOPENSHIFTKINDS":       "true",
+	})
+
+	cfg, err := Load(viper.New())
+	require.NoError(t, err)
+	assert.Equal(t, ChecksConfig{
+		AddAllBuiltIn:        true,
+		DoNotAutoAddDefaults: true,
+		Include:              []string{"latest-tag", "privileged-container"},
+		Exclude:              []string{"no-liveness-probe"},
+	}, cfg.Checks)
+	assert.True(t, cfg.DisableOpenShiftKinds)
+
+	// Config files override the environment, and their lists are appended to the ones of the environment.
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte("checks:\n  addAllBuiltIn: false\n  include: [host-ipc]\n"), 0644))
+	cfg, err = Load(viper.New(), configPath)
+	require.NoError(t, err)
+	assert.False(t, cfg.Checks.AddAllBuiltIn)
+	assert.True(t, cfg.Checks.DoNotAutoAddDefaults)
+	assert.Equal(t, []string{"latest-tag", "privileged-container", "host-ipc"}, cfg.Checks.Include)
+
+	// Flags override both.
+	c := &cobra.Command{}
+	v := viper.New()
+	AddFlags(c, v)
+	require.NoError(t, c.Flags().Parse([]string{"--do-not-auto-add-defaults=false", "--include", "host-pid"}))
+	cfg, err = Load(v, configPath)
+	require.NoError(t, err)
+	assert.False(t, cfg.Checks.DoNotAutoAddDefaults)
+	assert.Equal(t, []string{"host-pid"}, cfg.Checks.Include)
+}
+
+func TestLoadRejectsInvalidEnvironment(t *testing.T) {
+	setEnv(t, map[string]string{"KUBELINTER_CHECKS_ADDALLBUILTIN": "maybe"})
+
+	_, err := Load(viper.New())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "loading environment variables")
+}
@@ -0,0 +1,62 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+const (
+	// envPrefix is the prefix of the environment variables that set config keys.
+	envPrefix = "KUBELINTER"
+)
+
+var (
+	// envKeys are the config keys that can be set from the environment. Only keys with scalar or list values can be;
+	// the others, like checks.severities or customChecks, can only be set in config files.
+	envKeys = []string{
+		"checks.addAllBuiltIn",
+		"checks.doNotAutoAddDefaults",
+		"checks.exclude",
+		"checks.include",
+		"disableOpenShiftKinds",
+	}
+)
+
+// EnvVar returns the name of the environment variable that sets the given config key, which is the key in upper
+// case, with dots replaced by underscores and prefixed with KUBELINTER_, like KUBELINTER_CHECKS_INCLUDE for
+// checks.include.
+func EnvVar(key string) string {
+	return envPrefix + "_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+// newEnvViper returns a viper instance that reads envKeys from the environment. Lists are given as comma-separated
+// values, like KUBELINTER_CHECKS_INCLUDE=latest-tag,privileged-container.
+func newEnvViper() (*viper.Viper, error) {
+	v := viper.New()
+	v.SetEnvPrefix(envPrefix)
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.AutomaticEnv()
+	// AutomaticEnv only looks up the keys that viper is asked for, so the keys have to be bound for Unmarshal to see
+	// them.
+	for _, key := range envKeys {
+		if err := v.BindEnv(key); err != nil {
+			return nil, errors.Wrapf(err, "binding environment variable %s", EnvVar(key))
+		}
+	}
+	return v, nil
+}
+
+// loadEnv loads the config keys that are set in the environment.
+func loadEnv() (Config, error) {
+	v, err := newEnvViper()
+	if err != nil {
+		return Config{}, err
+	}
+	conf, err := unmarshal(v)
+	if err != nil {
+		return Config{}, errors.Wrap(err, "loading environment variables")
+	}
+	return conf, nil
+}
@@ -0,0 +1,107 @@
+package config
+
+// merge merges the override config into the base config, and returns the result. Neither input is modified.
+//   - The boolean fields of Checks are taken from override if isSet returns true for their key, like
+//     checks.addAllBuiltIn, and from base otherwise, so that an override can also turn them off.
+//   - Checks.Include and Checks.Exclude are appended to the ones of base, skipping duplicates.
+//   - Checks.Severities are merged, and override wins for checks that are in both.
+//   - Checks.Namespaces are merged the same way. The scopes of a check in base and override are not combined.
+//   - Checks.Remediations are merged the same way. The overrides of a check in base and override are not combined.
+//   - A custom check of override replaces the one of base with the same name, the others are appended.
+//   - A custom object kind of override replaces the one of base with the same group, version and kind, the others are
+//     appended.
+//   - DisableOpenShiftKinds is taken from override if isSet returns true for it, and from base otherwise.
+func merge(base, override Config, isSet func(key string) bool) Config {
+	merged := Config{
+		Checks: ChecksConfig{
+			AddAllBuiltIn:        base.Checks.AddAllBuiltIn,
+			DoNotAutoAddDefaults: base.Checks.DoNotAutoAddDefaults,
+			Include:              appendUnique(base.Checks.Include, override.Checks.Include),
+			Exclude:              appendUnique(base.Checks.Exclude, override.Checks.Exclude),
+		},
+	}
+	if isSet("checks.addAllBuiltIn") {
+		merged.Checks.AddAllBuiltIn = override.Checks.AddAllBuiltIn
+	}
+	if isSet("checks.doNotAutoAddDefaults") {
+		merged.Checks.DoNotAutoAddDefaults = override.Checks.DoNotAutoAddDefaults
+	}
+	merged.DisableOpenShiftKinds = base.DisableOpenShiftKinds
+	if isSet("disableOpenShiftKinds") {
+		merged.DisableOpenShiftKinds = override.DisableOpenShiftKinds
+	}
+	if len(base.Checks.Severities)+len(override.Checks.Severities) > 0 {
+		merged.Checks.Severities = make(map[string]Severity, len(base.Checks.Severities)+len(override.Checks.Severities))
+		for _, severities := range []map[string]Severity{base.Checks.Severities, override.Checks.Severities} {
+			for check, severity := range severities {
+				merged.Checks.Severities[check] = severity
+			}
+		}
+	}
+	if len(base.Checks.Namespaces)+len(override.Checks.Namespaces) > 0 {
+		merged.Checks.Namespaces = make(map[string]NamespaceScope, len(base.Checks.Namespaces)+len(override.Checks.Namespaces))
+		for _, namespaces := range []map[string]NamespaceScope{base.Checks.Namespaces, override.Checks.Namespaces} {
+			for check, scope := range namespaces {
+				merged.Checks.Namespaces[check] = scope
+			}
+		}
+	}
+
+	if len(base.Checks.Remediations)+len(override.Checks.Remediations) > 0 {
+		merged.Checks.Remediations = make(map[string]RemediationOverride, len(base.Checks.Remediations)+len(override.Checks.Remediations))
+		for _, remediations := range []map[string]RemediationOverride{base.Checks.Remediations, override.Checks.Remediations} {
+			for check, remediation := range remediations {
+				merged.Checks.Remediations[check] = remediation
+			}
+		}
+	}
+
+	merged.CustomChecks = append(merged.CustomChecks, base.CustomChecks...)
+	for _, check := range override.CustomChecks {
+		replaced := false
+		for i := range merged.CustomChecks {
+			if merged.CustomChecks[i].Name == check.Name {
+				merged.CustomChecks[i] = check
+				replaced = true
+			}
+		}
+		if !replaced {
+			merged.CustomChecks = append(merged.CustomChecks, check)
+		}
+	}
+
+	merged.CustomObjectKinds = append(merged.CustomObjectKinds, base.CustomObjectKinds...)
+	for _, kind := range override.CustomObjectKinds {
+		replaced := false
+		for i := range merged.CustomObjectKinds {
+			existing := &merged.CustomObjectKinds[i]
+			if existing.Group == kind.Group && existing.Version == kind.Version && existing.Kind == kind.Kind {
+				*existing = kind
+				replaced = true
+			}
+		}
+		if !replaced {
+			merged.CustomObjectKinds = append(merged.CustomObjectKinds, kind)
+		}
+	}
+	return merged
+}
+
+// appendUnique returns the elements of base followed by the ones of override that are not in base yet.
+func appendUnique(base, override []string) []string {
+	if len(base)+len(override) == 0 {
+		return nil
+	}
+	out := make([]string, 0, len(base)+len(override))
+	seen := make(map[string]struct{}, len(base)+len(override))
+	for _, list := range [][]string{base, override} {
+		for _, elem := range list {
+			if _, ok := seen[elem]; ok {
+				continue
+			}
+			seen[elem] = struct{}{}
+			out = append(out, elem)
+		}
+	}
+	return out
+}
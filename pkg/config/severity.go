@@ -0,0 +1,41 @@
+package config
+
+// Severity describes how serious the problems flagged by a check are.
+type Severity string
+
+// This block enumerates all known severities.
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+const (
+	// DefaultSeverity is the severity assigned to checks that do not specify one.
+	DefaultSeverity = SeverityError
+)
+
+var (
+	// severityRanks orders the known severities, from least to most severe.
+	severityRanks = map[Severity]int{
+		SeverityInfo:    1,
+		SeverityWarning: 2,
+		SeverityError:   3,
+	}
+)
+
+// AllSeverities returns the known severities, from most to least severe.
+func AllSeverities() []Severity {
+	return []Severity{SeverityError, SeverityWarning, SeverityInfo}
+}
+
+// IsValid returns whether s is a known severity.
+func (s Severity) IsValid() bool {
+	_, ok := severityRanks[s]
+	return ok
+}
+
+// AtLeast returns whether s is at least as severe as other.
+func (s Severity) AtLeast(other Severity) bool {
+	return severityRanks[s] >= severityRanks[other]
+}
@@ -0,0 +1,199 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/mitchellh/mapstructure"
+	"github.com/pkg/errors"
+	"golang.stackrox.io/kube-linter/internal/errorhelpers"
+)
+
+var (
+	// invalidKeysRegex matches the errors that mapstructure returns for keys that do not correspond to any field.
+	invalidKeysRegex = regexp.MustCompile(`^'(.*)' has invalid keys: (.*)$`)
+	// indexRegex matches the slice indices and map keys in the key paths of mapstructure errors.
+	indexRegex = regexp.MustCompile(`\[[^]]*]`)
+)
+
+// describeDecodeError turns the error that mapstructure returns when decoding the config into one that lists every
+// problem with the config, and for unknown keys, the keys that are valid in their place.
+func describeDecodeError(err error) error {
+	var decodeErr *mapstructure.Error
+	if !errors.As(err, &decodeErr) {
+		return errors.Wrap(err, "invalid config")
+	}
+	errorList := errorhelpers.NewErrorList("config validation")
+	for _, msg := range decodeErr.Errors {
+		matches := invalidKeysRegex.FindStringSubmatch(msg)
+		if matches == nil {
+			errorList.AddString(msg)
+			continue
+		}
+		parent, keys := matches[1], strings.Split(matches[2], ", ")
+		for _, key := range keys {
+			errorList.AddString(describeUnknownKey(parent, key))
+		}
+	}
+	return errorList.ToError()
+}
+
+// checkKeyCase checks that the keys of the given config file contents are in the same case as the valid keys. Viper
+// lower-cases keys before mapstructure sees them, so they have to be checked on the contents of the file. Keys that
+// do not match any valid key, even in another case, are left to mapstructure.
+func checkKeyCase(contents []byte) error {
+	var raw interface{}
+	if err := yaml.Unmarshal(contents, &raw); err != nil {
+		return errors.Wrap(err, "parsing config")
+	}
+	errorList := errorhelpers.NewErrorList("config validation")
+	checkKeyCaseAt(reflect.TypeOf(Config{}), "", raw, errorList)
+	return errorList.ToError()
+}
+
+// checkKeyCaseAt adds an error to errorList for every key of the value at the given mapstructure key path, and of the
+// values in it, that only matches a valid key of the type t in another case.
+func checkKeyCaseAt(t reflect.Type, path string, value interface{}, errorList *errorhelpers.ErrorList) {
+	switch t.Kind() {
+	case reflect.Ptr:
+		checkKeyCaseAt(t.Elem(), path, value, errorList)
+	case reflect.Slice:
+		list, _ := value.([]interface{})
+		for i, elem := range list {
+			checkKeyCaseAt(t.Elem(), fmt.Sprintf("%s[%d]", path, i), elem, errorList)
+		}
+	case reflect.Map:
+		// Map keys, like check names, are values rather than config keys, so only the values are checked.
+		obj, _ := value.(map[string]interface{})
+		for _, key := range sortedKeys(obj) {
+			checkKeyCaseAt(t.Elem(), fmt.Sprintf("%s[%s]", path, key), obj[key], errorList)
+		}
+	case reflect.Struct:
+		obj, _ := value.(map[string]interface{})
+		for _, key := range sortedKeys(obj) {
+			field, ok := fieldByKey(t, key)
+			if !ok {
+				continue
+			}
+			if jsonKey(field) != key {
+				errorList.AddString(describeUnknownKey(path, key))
+			}
+			childPath := key
+			if path != "" {
+				childPath = path + "." + key
+			}
+			checkKeyCaseAt(field.Type, childPath, obj[key], errorList)
+		}
+	}
+}
+
+func sortedKeys(obj map[string]interface{}) []string {
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// describeUnknownKey describes the unknown key at the given path, along with the keys that are valid there.
+func describeUnknownKey(parent, key string) string {
+	path := key
+	if parent != "" {
+		path = parent + "." + key
+	}
+	t := typeAtPath(reflect.TypeOf(Config{}), parent)
+	if t == nil {
+		return fmt.Sprintf("unknown key %s", path)
+	}
+	msg := fmt.Sprintf("unknown key %s (valid keys are %s)", path, strings.Join(validKeys(t), ", "))
+	if t == reflect.TypeOf(Check{}) {
+		msg += "; parameters of the template go under params"
+	}
+	return msg
+}
+
+// typeAtPath returns the struct type of the value at the given mapstructure key path in a value of type t, or nil if
+// the path does not lead to a struct.
+func typeAtPath(t reflect.Type, path string) reflect.Type {
+	path = indexRegex.ReplaceAllString(path, "")
+	if path != "" {
+		for _, part := range strings.Split(path, ".") {
+			t = elemType(t)
+			if t.Kind() != reflect.Struct {
+				return nil
+			}
+			field, ok := fieldByKey(t, part)
+			if !ok {
+				return nil
+			}
+			t = field.Type
+		}
+	}
+	t = elemType(t)
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	return t
+}
+
+// elemType returns the type of the elements of pointers, slices and maps of the given type, recursively.
+func elemType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	return t
+}
+
+// fieldByKey returns the field of the struct type t that the given key is decoded into. Like in mapstructure, keys
+// match case-insensitively, since the keys that viper passes to mapstructure are lower-cased.
+func fieldByKey(t reflect.Type, key string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if strings.EqualFold(jsonKey(field), key) {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// validKeys returns the keys that are valid in an object of the given struct type, along with their types.
+func validKeys(t reflect.Type) []string {
+	keys := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		keys = append(keys, fmt.Sprintf("%s (%s)", jsonKey(field), describeType(field.Type)))
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// describeType describes the given type in terms of YAML.
+func describeType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return describeType(t.Elem())
+	case reflect.Slice:
+		return "list of " + describeType(t.Elem())
+	case reflect.Map:
+		return "map of " + describeType(t.Elem())
+	case reflect.Struct:
+		return "object"
+	case reflect.Interface:
+		return "any value"
+	default:
+		return t.Kind().String()
+	}
+}
+
+func jsonKey(field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get("json"), ",")[0]
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
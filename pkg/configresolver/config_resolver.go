@@ -1,12 +1,20 @@
 package configresolver
 
 import (
+	"fmt"
+	"net/url"
+	"sort"
+
 	"golang.stackrox.io/kube-linter/internal/defaultchecks"
 	"golang.stackrox.io/kube-linter/internal/errorhelpers"
 	"golang.stackrox.io/kube-linter/internal/set"
+	"golang.stackrox.io/kube-linter/internal/stringutils"
 	"golang.stackrox.io/kube-linter/pkg/builtinchecks"
 	"golang.stackrox.io/kube-linter/pkg/checkregistry"
 	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/objectkinds"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 // LoadCustomChecksInto loads the custom checks from the config into the check registry.
@@ -20,12 +28,161 @@ func LoadCustomChecksInto(cfg *config.Config, checkRegistry checkregistry.CheckR
 	return errorList.ToError()
 }
 
+// RegisterCustomObjectKinds registers the custom object kinds from the config, so that they are loaded and linted
+// like the built-in object kinds that have pod templates.
+func RegisterCustomObjectKinds(cfg *config.Config) error {
+	errorList := errorhelpers.NewErrorList("custom object kind registration")
+	for _, kind := range cfg.CustomObjectKinds {
+		gvk := schema.GroupVersionKind{Group: kind.Group, Version: kind.Version, Kind: kind.Kind}
+		if err := objectkinds.RegisterCustomObjectKind(objectkinds.CustomObjectKind{
+			GroupVersionKind: gvk,
+			PodTemplatePath:  kind.PodTemplatePath,
+		}); err != nil {
+			errorList.AddWrapf(err, "failed to register custom object kind %s", gvk.GroupKind())
+		}
+	}
+	return errorList.ToError()
+}
+
+// ApplyOpenShiftKinds enables or disables the OpenShift object kinds according to the config.
+func ApplyOpenShiftKinds(cfg *config.Config) {
+	objectkinds.SetOpenShiftKindsEnabled(!cfg.DisableOpenShiftKinds)
+}
+
+// ApplySeverityOverrides applies the severity overrides from the config to the checks in the check registry.
+func ApplySeverityOverrides(cfg *config.Config, checkRegistry checkregistry.CheckRegistry) error {
+	errorList := errorhelpers.NewErrorList("severity overrides validation")
+	checkNames := make([]string, 0, len(cfg.Checks.Severities))
+	for checkName := range cfg.Checks.Severities {
+		checkNames = append(checkNames, checkName)
+	}
+	sort.Strings(checkNames)
+	for _, checkName := range checkNames {
+		severity := cfg.Checks.Severities[checkName]
+		if !severity.IsValid() {
+			errorList.AddStringf("invalid severity %q for check %q, must be one of %v", severity, checkName, config.AllSeverities())
+			continue
+		}
+		check := checkRegistry.Load(checkName)
+		if check == nil {
+			errorList.AddStringf("check %q not found", checkName)
+			continue
+		}
+		check.Spec.Severity = severity
+	}
+	return errorList.ToError()
+}
+
+// ApplyRemediationOverrides applies the remediation overrides from the config to the checks in the check registry.
+func ApplyRemediationOverrides(cfg *config.Config, checkRegistry checkregistry.CheckRegistry) error {
+	errorList := errorhelpers.NewErrorList("remediation overrides validation")
+	checkNames := make([]string, 0, len(cfg.Checks.Remediations))
+	for checkName := range cfg.Checks.Remediations {
+		checkNames = append(checkNames, checkName)
+	}
+	sort.Strings(checkNames)
+	for _, checkName := range checkNames {
+		check := checkRegistry.Load(checkName)
+		if check == nil {
+			errorList.AddStringf("check %q not found", checkName)
+			continue
+		}
+		override := cfg.Checks.Remediations[checkName]
+		if override.DocsURL != "" {
+			if u, err := url.Parse(override.DocsURL); err != nil || u.Scheme == "" || u.Host == "" {
+				errorList.AddStringf("invalid docsURL %q for check %q, must be an absolute URL", override.DocsURL, checkName)
+				continue
+			}
+			check.Spec.DocsURL = override.DocsURL
+		}
+		if override.Remediation != "" {
+			check.Spec.Remediation = override.Remediation
+		}
+	}
+	return errorList.ToError()
+}
+
+// ApplyNamespaceScopes applies the namespace scopes from the config to the checks in the check registry.
+func ApplyNamespaceScopes(cfg *config.Config, checkRegistry checkregistry.CheckRegistry) error {
+	errorList := errorhelpers.NewErrorList("namespace scopes validation")
+	checkNames := make([]string, 0, len(cfg.Checks.Namespaces))
+	for checkName := range cfg.Checks.Namespaces {
+		checkNames = append(checkNames, checkName)
+	}
+	sort.Strings(checkNames)
+	for _, checkName := range checkNames {
+		check := checkRegistry.Load(checkName)
+		if check == nil {
+			errorList.AddStringf("check %q not found", checkName)
+			continue
+		}
+		scope := cfg.Checks.Namespaces[checkName]
+		if err := check.SetNamespaceScope(&scope); err != nil {
+			errorList.AddWrapf(err, "check %q", checkName)
+		}
+	}
+	return errorList.ToError()
+}
+
+// ValidateCustomCheckParams validates the params of the custom checks in the config against the parameters that
+// their templates declare.
+func ValidateCustomCheckParams(cfg *config.Config) error {
+	errorList := errorhelpers.NewErrorList("custom check params validation")
+	for _, check := range cfg.CustomChecks {
+		template, found := templates.Get(check.Template)
+		if !found {
+			errorList.AddStringf("check %q: template %q not found%s", check.Name, check.Template, templates.DidYouMean(check.Template))
+			continue
+		}
+		if err := templates.ValidateParams(template, check.Params); err != nil {
+			errorList.AddWrapf(err, "check %q", check.Name)
+		}
+	}
+	return errorList.ToError()
+}
+
 // GetEnabledChecksAndValidate get the list of enabled checks based on the given config,
 // and validates that they exist in the given checkRegistry.
 func GetEnabledChecksAndValidate(cfg *config.Config, checkRegistry checkregistry.CheckRegistry) ([]string, error) {
-	enabledChecks := set.NewStringSet()
+	if err := ValidateCustomCheckParams(cfg); err != nil {
+		return nil, err
+	}
+
+	enabledChecks, err := addedChecks(cfg)
+	if err != nil {
+		return nil, err
+	}
+	enabledChecks.RemoveAll(cfg.Checks.Exclude...)
+
+	knownChecks, err := knownCheckNames(cfg)
+	if err != nil {
+		return nil, err
+	}
+	errorList := errorhelpers.NewErrorList("enabled checks validation")
+	for _, check := range enabledChecks.AsSortedSlice(func(i, j string) bool { return i < j }) {
+		if checkRegistry.Load(check) == nil {
+			errorList.AddString(checkNotFoundMessage("check", check, knownChecks))
+		}
+	}
+	// Excluding a check that does not exist has no effect, which is never what was intended.
+	for _, check := range cfg.Checks.Exclude {
+		if checkRegistry.Load(check) == nil {
+			errorList.AddString(checkNotFoundMessage("excluded check", check, knownChecks))
+		}
+	}
+	if err := errorList.ToError(); err != nil {
+		return nil, err
+	}
+	return enabledChecks.AsSortedSlice(func(i, j string) bool {
+		return i < j
+	}), nil
+}
+
+// addedChecks returns the checks that the given config adds, before removing the excluded ones.
+func addedChecks(cfg *config.Config) (set.StringSet, error) {
+	checks := set.NewStringSet()
 	if !cfg.Checks.DoNotAutoAddDefaults {
-		enabledChecks.AddAll(defaultchecks.List.AsSlice()...)
+		checks.AddAll(defaultchecks.List.AsSlice()...)
 	}
 	if cfg.Checks.AddAllBuiltIn {
 		builtInChecks, err := builtinchecks.List()
@@ -33,25 +190,48 @@ func GetEnabledChecksAndValidate(cfg *config.Config, checkRegistry checkregistry
 			return nil, err
 		}
 		for _, check := range builtInChecks {
-			enabledChecks.Add(check.Name)
+			checks.Add(check.Name)
 		}
 	}
 	for _, check := range cfg.CustomChecks {
-		enabledChecks.Add(check.Name)
+		checks.Add(check.Name)
 	}
-	enabledChecks.AddAll(cfg.Checks.Include...)
-	enabledChecks.RemoveAll(cfg.Checks.Exclude...)
+	checks.AddAll(cfg.Checks.Include...)
+	return checks, nil
+}
 
-	errorList := errorhelpers.NewErrorList("enabled checks validation")
-	for check := range enabledChecks {
-		if checkRegistry.Load(check) == nil {
-			errorList.AddStringf("check %q not found", check)
-		}
+// DescribeNoEnabledChecks explains why the given config, for which GetEnabledChecksAndValidate returned no checks,
+// does not enable any check.
+func DescribeNoEnabledChecks(cfg *config.Config) string {
+	added, err := addedChecks(cfg)
+	if err != nil || added.Cardinality() == 0 {
+		return "the default checks are disabled through doNotAutoAddDefaults, and no checks are added through " +
+			"include, addAllBuiltIn or customChecks"
 	}
-	if err := errorList.ToError(); err != nil {
+	if added.Cardinality() == 1 {
+		return fmt.Sprintf("the only check that the config adds, %s, is excluded through exclude", added.AsSlice()[0])
+	}
+	return fmt.Sprintf("all %d checks that the config adds are excluded through exclude", added.Cardinality())
+}
+
+// knownCheckNames returns the names of the built-in checks and of the custom checks in the given config.
+func knownCheckNames(cfg *config.Config) ([]string, error) {
+	builtInChecks, err := builtinchecks.List()
+	if err != nil {
 		return nil, err
 	}
-	return enabledChecks.AsSortedSlice(func(i, j string) bool {
-		return i < j
-	}), nil
+	names := make([]string, 0, len(builtInChecks)+len(cfg.CustomChecks))
+	for _, check := range builtInChecks {
+		names = append(names, check.Name)
+	}
+	for _, check := range cfg.CustomChecks {
+		names = append(names, check.Name)
+	}
+	return names, nil
+}
+
+// checkNotFoundMessage describes the check that was not found, suggesting the known checks that it is most likely a
+// typo of, if any.
+func checkNotFoundMessage(what, check string, knownChecks []string) string {
+	return fmt.Sprintf("%s %q not found%s", what, check, stringutils.DidYouMean(check, knownChecks))
 }
@@ -0,0 +1,181 @@
+package configresolver
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.stackrox.io/kube-linter/pkg/builtinchecks"
+	"golang.stackrox.io/kube-linter/pkg/checkregistry"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/objectkinds"
+	_ "golang.stackrox.io/kube-linter/pkg/templates/all"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestGetEnabledChecksAndValidateChecksCustomCheckParams(t *testing.T) {
+	registry := checkregistry.New()
+	require.NoError(t, builtinchecks.LoadInto(registry))
+
+	cfg := &config.Config{
+		Checks: config.ChecksConfig{DoNotAutoAddDefaults: true},
+		CustomChecks: []config.Check{
+			{Name: "typo", Template: "latest-tag", Params: map[string]interface{}{"blokList": []interface{}{".*:latest"}}},
+			{Name: "missing-template", Template: "does-not-exist"},
+			{Name: "template-typo", Template: "host-mount"},
+		},
+	}
+	_, err := GetEnabledChecksAndValidate(cfg, registry)
+	assert.EqualError(t, err, `custom check params validation errors: [check "typo": validating params for template "latest-tag" error: unknown parameter "blokList", valid parameters are [blockList allowList], check "missing-template": template "does-not-exist" not found, check "template-typo": template "host-mount" not found, did you mean "host-mounts"?]`)
+}
+
+func TestRegisterCustomObjectKinds(t *testing.T) {
+	cfg := &config.Config{
+		CustomObjectKinds: []config.CustomObjectKind{
+			{Group: "example.com", Version: "v1", Kind: "App", PodTemplatePath: ".spec.template"},
+			{Group: "apps", Kind: "Deployment", PodTemplatePath: ".spec.template"},
+			{Group: "example.com", Kind: "Job"},
+		},
+	}
+	err := RegisterCustomObjectKinds(cfg)
+	assert.EqualError(t, err, "custom object kind registration errors: [failed to register custom object kind Deployment.apps: Deployment.apps is a built-in object kind, failed to register custom object kind Job.example.com: pod template path of Job.example.com must be set]")
+
+	kind, found := objectkinds.GetCustomObjectKind(schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "App"})
+	require.True(t, found)
+	assert.Equal(t, "{.spec.template}", kind.PodTemplatePath)
+}
+
+func TestApplyNamespaceScopes(t *testing.T) {
+	registry := checkregistry.New()
+	require.NoError(t, builtinchecks.LoadInto(registry))
+
+	cfg := &config.Config{
+		Checks: config.ChecksConfig{
+			Namespaces: map[string]config.NamespaceScope{
+				"latest-tag":           {Include: []string{"app"}, ExcludeRegex: "kube-.*"},
+				"privileged-container": {IncludeRegex: "("},
+				"does-not-exist":       {Include: []string{"app"}},
+			},
+		},
+	}
+	err := ApplyNamespaceScopes(cfg, registry)
+	assert.EqualError(t, err, "namespace scopes validation errors: [check \"does-not-exist\" not found, check \"privileged-container\": invalid namespace scope: invalid includeRegex (: error parsing regexp: missing closing ): `^(?:()$`]")
+
+	check := registry.Load("latest-tag")
+	assert.Equal(t, &config.NamespaceScope{Include: []string{"app"}, ExcludeRegex: "kube-.*"}, check.Spec.Namespaces)
+	assert.True(t, check.MatchesNamespace("app"))
+	assert.False(t, check.MatchesNamespace("other"))
+	assert.False(t, check.MatchesNamespace(""), "objects without a namespace are excluded when namespaces are included")
+}
+
+func TestApplyRemediationOverrides(t *testing.T) {
+	registry := checkregistry.New()
+	require.NoError(t, builtinchecks.LoadInto(registry))
+	hostPIDRemediation := registry.Load("host-pid").Spec.Remediation
+
+	cfg := &config.Config{
+		Checks: config.ChecksConfig{
+			Remediations: map[string]config.RemediationOverride{
+				"latest-tag":     {Remediation: "Pin images, see the runbook.", DocsURL: "https://wiki.example.com/latest-tag"},
+				"host-pid":       {DocsURL: "https://wiki.example.com/host-pid"},
+				"host-ipc":       {DocsURL: "wiki/host-ipc"},
+				"does-not-exist": {Remediation: "Nothing."},
+			},
+		},
+	}
+	err := ApplyRemediationOverrides(cfg, registry)
+	assert.EqualError(t, err, "remediation overrides validation errors: [check \"does-not-exist\" not found, invalid docsURL \"wiki/host-ipc\" for check \"host-ipc\", must be an absolute URL]")
+
+	latestTag := registry.Load("latest-tag")
+	assert.Equal(t, "Pin images, see the runbook.", latestTag.Spec.Remediation)
+	assert.Equal(t, "https://wiki.example.com/latest-tag", latestTag.Spec.DocsURL)
+	hostPID := registry.Load("host-pid")
+	assert.Equal(t, hostPIDRemediation, hostPID.Spec.Remediation, "the remediation is kept if only the docs URL is overridden")
+	assert.Equal(t, "https://wiki.example.com/host-pid", hostPID.Spec.DocsURL)
+	assert.Empty(t, registry.Load("host-ipc").Spec.DocsURL)
+}
+
+func TestDescribeNoEnabledChecks(t *testing.T) {
+	registry := checkregistry.New()
+	require.NoError(t, builtinchecks.LoadInto(registry))
+
+	for _, testCase := range []struct {
+		checks   config.ChecksConfig
+		expected string
+	}{
+		{
+			checks:   config.ChecksConfig{DoNotAutoAddDefaults: true},
+			expected: "the default checks are disabled through doNotAutoAddDefaults, and no checks are added through include, addAllBuiltIn or customChecks",
+		},
+		{
+			checks:   config.ChecksConfig{DoNotAutoAddDefaults: true, Include: []string{"latest-tag"}, Exclude: []string{"latest-tag"}},
+			expected: "the only check that the config adds, latest-tag, is excluded through exclude",
+		},
+		{
+			checks:   config.ChecksConfig{DoNotAutoAddDefaults: true, Include: []string{"latest-tag", "host-ipc"}, Exclude: []string{"host-ipc", "latest-tag"}},
+			expected: "all 2 checks that the config adds are excluded through exclude",
+		},
+	} {
+		cfg := &config.Config{Checks: testCase.checks}
+		enabledChecks, err := GetEnabledChecksAndValidate(cfg, registry)
+		require.NoError(t, err)
+		require.Empty(t, enabledChecks)
+		assert.Equal(t, testCase.expected, DescribeNoEnabledChecks(cfg))
+	}
+}
+
+func TestGetEnabledChecksIsIndependentOfConfigFormat(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"config.yaml": `
+checks:
+  addAllBuiltIn: true
+  exclude: [latest-tag, no-liveness-probe]
+customChecks:
+- name: required-label-team
+  template: required-label
+  params:
+    key: team
+`,
+		"config.json": `{
+  "checks": {"addAllBuiltIn": true, "exclude": ["latest-tag", "no-liveness-probe"]},
+  "customChecks": [{"name": "required-label-team", "template": "required-label", "params": {"key": "team"}}]
+}`,
+	}
+
+	enabledChecks := make(map[string][]string)
+	for name, contents := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(contents), 0644))
+		cfg, err := config.Load(viper.New(), path)
+		require.NoError(t, err)
+		registry := checkregistry.New()
+		require.NoError(t, builtinchecks.LoadInto(registry))
+		require.NoError(t, LoadCustomChecksInto(&cfg, registry))
+		checks, err := GetEnabledChecksAndValidate(&cfg, registry)
+		require.NoError(t, err)
+		sort.Strings(checks)
+		enabledChecks[name] = checks
+	}
+	assert.Contains(t, enabledChecks["config.yaml"], "required-label-team")
+	assert.NotContains(t, enabledChecks["config.yaml"], "latest-tag")
+	assert.Equal(t, enabledChecks["config.yaml"], enabledChecks["config.json"])
+}
+
+func TestGetEnabledChecksAndValidateSuggestsChecks(t *testing.T) {
+	registry := checkregistry.New()
+	require.NoError(t, builtinchecks.LoadInto(registry))
+
+	cfg := &config.Config{
+		Checks: config.ChecksConfig{
+			Include: []string{"lates-tag", "something-else"},
+			Exclude: []string{"no-livenes-probe"},
+		},
+	}
+	_, err := GetEnabledChecksAndValidate(cfg, registry)
+	assert.EqualError(t, err, `enabled checks validation errors: [check "lates-tag" not found, did you mean "latest-tag"?, check "something-else" not found, excluded check "no-livenes-probe" not found, did you mean "no-liveness-probe"?]`)
+}
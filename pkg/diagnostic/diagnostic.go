@@ -1,14 +1,21 @@
 package diagnostic
 
 import (
+	"golang.stackrox.io/kube-linter/pkg/config"
 	"golang.stackrox.io/kube-linter/pkg/lintcontext"
 )
 
 // A Diagnostic represents one specific problem diagnosed by a check.
 type Diagnostic struct {
 	Message string
-
-	// TODO: add line number/col number
+	// FieldPath is the path of the field that the diagnostic is about, like spec.template.spec.containers[0].image,
+	// if the check knows it. See lintcontext.ObjectMetadata.Position for the syntax.
+	FieldPath string `json:",omitempty"`
+	// Line and Column are the position of the field that the diagnostic is about in the file of the object, or of the
+	// object itself if the field is not known. They are filled in when the checks are run, and are 0 if the position
+	// is not known.
+	Line   int `json:",omitempty"`
+	Column int `json:",omitempty"`
 }
 
 // WithContext puts a diagnostic in the context of which check emitted it,
@@ -16,6 +23,12 @@ type Diagnostic struct {
 type WithContext struct {
 	Diagnostic  Diagnostic
 	Check       string
+	Severity    config.Severity
 	Remediation string
-	Object      lintcontext.Object
+	// DocsURL links to the documentation of the check, if the check sets one instead of the KubeLinter documentation.
+	DocsURL string `json:",omitempty"`
+	// Snippet is the YAML of the field that the diagnostic is about, or of the object, if it was asked for.
+	// See lintcontext.ObjectMetadata.Snippet.
+	Snippet string `json:",omitempty"`
+	Object  lintcontext.Object
 }
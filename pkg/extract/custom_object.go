@@ -0,0 +1,63 @@
+package extract
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+	"golang.stackrox.io/kube-linter/internal/jsonpathutil"
+	"golang.stackrox.io/kube-linter/pkg/objectkinds"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var (
+	// podTemplatePaths caches the parsed pod template paths of custom object kinds by the paths, so that they are not
+	// parsed again for every object.
+	podTemplatePaths sync.Map
+)
+
+func podTemplatePath(kind objectkinds.CustomObjectKind) (*jsonpathutil.Path, error) {
+	if path, ok := podTemplatePaths.Load(kind.PodTemplatePath); ok {
+		return path.(*jsonpathutil.Path), nil
+	}
+	path, err := jsonpathutil.Parse(kind.GroupVersionKind.Kind, kind.PodTemplatePath, true)
+	if err != nil {
+		return nil, err
+	}
+	actual, _ := podTemplatePaths.LoadOrStore(kind.PodTemplatePath, path)
+	return actual.(*jsonpathutil.Path), nil
+}
+
+// CustomObjectPodTemplateSpec extracts the pod template spec from an object of a custom object kind, at the pod
+// template path that the kind was registered with. It returns false if the object is not of a registered custom
+// object kind, or does not have a pod template at the path, and an error if what is at the path is not a pod template.
+func CustomObjectPodTemplateSpec(obj *unstructured.Unstructured) (coreV1.PodTemplateSpec, bool, error) {
+	kind, ok := objectkinds.GetCustomObjectKind(obj.GroupVersionKind())
+	if !ok {
+		return coreV1.PodTemplateSpec{}, false, nil
+	}
+	path, err := podTemplatePath(kind)
+	if err != nil {
+		return coreV1.PodTemplateSpec{}, false, errors.Wrapf(err, "parsing pod template path %s", kind.PodTemplatePath)
+	}
+	results, err := path.FindResults(obj.Object)
+	if err != nil {
+		return coreV1.PodTemplateSpec{}, false, errors.Wrapf(err, "evaluating pod template path %s", kind.PodTemplatePath)
+	}
+	if len(results) == 0 || len(results[0]) == 0 {
+		return coreV1.PodTemplateSpec{}, false, nil
+	}
+	if len(results) > 1 || len(results[0]) > 1 {
+		return coreV1.PodTemplateSpec{}, false, errors.Errorf("pod template path %s matches more than one value", kind.PodTemplatePath)
+	}
+	template, ok := results[0][0].Interface().(map[string]interface{})
+	if !ok {
+		return coreV1.PodTemplateSpec{}, false, errors.Errorf("pod template path %s does not point to an object", kind.PodTemplatePath)
+	}
+	var podTemplate coreV1.PodTemplateSpec
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(template, &podTemplate); err != nil {
+		return coreV1.PodTemplateSpec{}, false, errors.Wrapf(err, "decoding pod template at %s", kind.PodTemplatePath)
+	}
+	return podTemplate, true, nil
+}
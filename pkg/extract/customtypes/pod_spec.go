@@ -1,6 +1,8 @@
 package customtypes
 
 import (
+	"fmt"
+
 	v1 "k8s.io/api/core/v1"
 )
 
@@ -13,12 +15,17 @@ type PodSpec struct {
 	v1.PodSpec
 }
 
-// AllContainers returns a list of all containers in the Pod, both Init and Regular
+// AllContainers returns a list of all containers in the Pod: Init, Regular and Ephemeral, in that order.
 func (p *PodSpec) AllContainers() []v1.Container {
-	allContainers := make([]v1.Container, 0, len(p.PodSpec.InitContainers)+len(p.PodSpec.Containers))
-	allContainers = append(allContainers, p.PodSpec.InitContainers...)
-	allContainers = append(allContainers, p.PodSpec.Containers...)
-	return allContainers
+	return append(p.NonEphemeralContainers(), p.EphemeralContainers()...)
+}
+
+// NonEphemeralContainers returns a list of all Init and Regular containers in the Pod, in that order.
+func (p *PodSpec) NonEphemeralContainers() []v1.Container {
+	containers := make([]v1.Container, 0, len(p.PodSpec.InitContainers)+len(p.PodSpec.Containers)+len(p.PodSpec.EphemeralContainers))
+	containers = append(containers, p.PodSpec.InitContainers...)
+	containers = append(containers, p.PodSpec.Containers...)
+	return containers
 }
 
 // NonInitContainers returns a list of all regular (non-init) containers in the Pod
@@ -30,3 +37,27 @@ func (p *PodSpec) NonInitContainers() []v1.Container {
 func (p *PodSpec) InitContainers() []v1.Container {
 	return p.PodSpec.InitContainers
 }
+
+// EphemeralContainers returns a list of all ephemeral containers in the Pod, as containers. Ephemeral containers
+// have the same fields as containers, although some of them, like probes and resources, are not allowed.
+func (p *PodSpec) EphemeralContainers() []v1.Container {
+	containers := make([]v1.Container, 0, len(p.PodSpec.EphemeralContainers))
+	for _, container := range p.PodSpec.EphemeralContainers {
+		containers = append(containers, v1.Container(container.EphemeralContainerCommon))
+	}
+	return containers
+}
+
+// ContainerFieldPath returns the field path, relative to the pod spec, of the container at the given index of
+// AllContainers, or of NonEphemeralContainers, in which the containers are in the same order.
+func (p *PodSpec) ContainerFieldPath(i int) string {
+	numInit, numRegular := len(p.PodSpec.InitContainers), len(p.PodSpec.Containers)
+	switch {
+	case i < numInit:
+		return fmt.Sprintf("initContainers[%d]", i)
+	case i < numInit+numRegular:
+		return fmt.Sprintf("containers[%d]", i-numInit)
+	default:
+		return fmt.Sprintf("ephemeralContainers[%d]", i-numInit-numRegular)
+	}
+}
@@ -2,14 +2,17 @@ package extract
 
 import (
 	"reflect"
+	"strings"
 
 	ocsAppsV1 "github.com/openshift/api/apps/v1"
 	"golang.stackrox.io/kube-linter/pkg/extract/customtypes"
 	"golang.stackrox.io/kube-linter/pkg/k8sutil"
+	"golang.stackrox.io/kube-linter/pkg/objectkinds"
 	batchV1 "k8s.io/api/batch/v1"
 	batchV1Beta1 "k8s.io/api/batch/v1beta1"
 	coreV1 "k8s.io/api/core/v1"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 // PodTemplateSpec extracts a pod template spec from the given object, if available.
@@ -24,6 +27,10 @@ func PodTemplateSpec(obj k8sutil.Object) (coreV1.PodTemplateSpec, bool) {
 		return obj.Spec.JobTemplate.Spec.Template, true
 	case *batchV1.CronJob:
 		return obj.Spec.JobTemplate.Spec.Template, true
+	case *unstructured.Unstructured:
+		// Objects of custom object kinds were validated when they were loaded, so errors are not expected here.
+		podTemplate, found, err := CustomObjectPodTemplateSpec(obj)
+		return podTemplate, found && err == nil
 	default:
 		objValue := reflect.Indirect(reflect.ValueOf(obj))
 		spec := objValue.FieldByName("Spec")
@@ -108,3 +115,25 @@ func Replicas(obj k8sutil.Object) (int32, bool) {
 	}
 	return 0, false
 }
+
+// PodSpecFieldPath returns the path of the pod spec in the given object, as used for diagnostic.Diagnostic.FieldPath,
+// if the object has one.
+func PodSpecFieldPath(obj k8sutil.Object) (string, bool) {
+	switch obj := obj.(type) {
+	case *coreV1.Pod:
+		return "spec", true
+	case *batchV1Beta1.CronJob, *batchV1.CronJob:
+		return "spec.jobTemplate.spec.template.spec", true
+	case *unstructured.Unstructured:
+		kind, ok := objectkinds.GetCustomObjectKind(obj.GroupVersionKind())
+		if !ok {
+			return "", false
+		}
+		return strings.TrimPrefix(strings.Trim(kind.PodTemplatePath, "{}"), ".") + ".spec", true
+	default:
+		if _, found := PodTemplateSpec(obj); !found {
+			return "", false
+		}
+		return "spec.template.spec", true
+	}
+}
@@ -0,0 +1,39 @@
+package extract
+
+import (
+	"golang.stackrox.io/kube-linter/pkg/k8sutil"
+	autoscalingV1 "k8s.io/api/autoscaling/v1"
+	autoscalingV2beta1 "k8s.io/api/autoscaling/v2beta1"
+	autoscalingV2beta2 "k8s.io/api/autoscaling/v2beta2"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ScaleTargetRef extracts the reference to the object that the given horizontal pod autoscaler scales, independent of
+// the API version of the autoscaler, if the object is one.
+func ScaleTargetRef(obj k8sutil.Object) (autoscalingV1.CrossVersionObjectReference, bool) {
+	switch hpa := obj.(type) {
+	case *autoscalingV1.HorizontalPodAutoscaler:
+		return hpa.Spec.ScaleTargetRef, true
+	case *autoscalingV2beta1.HorizontalPodAutoscaler:
+		ref := hpa.Spec.ScaleTargetRef
+		return autoscalingV1.CrossVersionObjectReference{APIVersion: ref.APIVersion, Kind: ref.Kind, Name: ref.Name}, true
+	case *autoscalingV2beta2.HorizontalPodAutoscaler:
+		ref := hpa.Spec.ScaleTargetRef
+		return autoscalingV1.CrossVersionObjectReference{APIVersion: ref.APIVersion, Kind: ref.Kind, Name: ref.Name}, true
+	}
+	return autoscalingV1.CrossVersionObjectReference{}, false
+}
+
+// ScaleTargetRefMatches returns whether the given scale target reference references the object with the given GVK and
+// name. Only the group of the reference's API version is compared, since the target can be referenced in any of the
+// versions that the API server serves.
+func ScaleTargetRefMatches(ref autoscalingV1.CrossVersionObjectReference, gvk schema.GroupVersionKind, name string) bool {
+	if ref.Kind != gvk.Kind || ref.Name != name {
+		return false
+	}
+	if ref.APIVersion == "" {
+		return true
+	}
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
+	return err == nil && gv.Group == gvk.Group
+}
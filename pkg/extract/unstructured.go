@@ -0,0 +1,20 @@
+package extract
+
+import (
+	"golang.stackrox.io/kube-linter/pkg/k8sutil"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Unstructured returns the fields of the given object, the way they appear in its YAML, for templates that look at
+// fields by name rather than through the typed object.
+func Unstructured(object k8sutil.Object) (map[string]interface{}, error) {
+	fields, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+	if err != nil {
+		return nil, err
+	}
+	// Typed objects do not necessarily have their type meta set, but the YAML of every object has it.
+	if gvk := GVK(object); !gvk.Empty() {
+		fields["apiVersion"], fields["kind"] = gvk.GroupVersion().String(), gvk.Kind
+	}
+	return fields, nil
+}
@@ -1,27 +1,31 @@
 package ignore
 
-import (
-	"golang.stackrox.io/kube-linter/internal/stringutils"
-)
-
 const (
 	// AnnotationKeyPrefix is the prefix for annotations for kube-linter check ignores.
 	AnnotationKeyPrefix = "ignore-check.kube-linter.io/"
 
 	// AllAnnotationKey is used to ignore all checks for a given object.
 	AllAnnotationKey = "kube-linter.io/ignore-all"
+
+	// AllChecksWildcard can be used in place of a check name after AnnotationKeyPrefix to ignore all checks
+	// for a given object, equivalently to AllAnnotationKey.
+	AllChecksWildcard = "all"
 )
 
 // ObjectForCheck returns whether to ignore the given object for the passed check name.
 func ObjectForCheck(annotations map[string]string, checkName string) bool {
-	for k := range annotations {
-		if k == AllAnnotationKey {
-			return true
-		}
-		key := k
-		if stringutils.ConsumePrefix(&key, AnnotationKeyPrefix) && key == checkName {
-			return true
+	_, ignored := ReasonForCheck(annotations, checkName)
+	return ignored
+}
+
+// ReasonForCheck returns whether to ignore the given object for the passed check name and, if so,
+// the reason given as the value of the matching annotation. If several annotations match, the reason of the most
+// specific one is returned: the one of the check, then the one of AllChecksWildcard, then the one of AllAnnotationKey.
+func ReasonForCheck(annotations map[string]string, checkName string) (string, bool) {
+	for _, key := range []string{AnnotationKeyPrefix + checkName, AnnotationKeyPrefix + AllChecksWildcard, AllAnnotationKey} {
+		if reason, ok := annotations[key]; ok {
+			return reason, true
 		}
 	}
-	return false
+	return "", false
 }
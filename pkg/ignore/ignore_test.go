@@ -68,6 +68,14 @@ func TestObjectForCheck(t *testing.T) {
 			checkName:    "some-other-check",
 			shouldIgnore: true,
 		},
+		{
+			annotations: map[string]string{
+				"random-unrelated":                "blah",
+				"ignore-check.kube-linter.io/all": "Too much of a mess",
+			},
+			checkName:    "some-other-check",
+			shouldIgnore: true,
+		},
 	} {
 		c := testCase
 		t.Run(fmt.Sprintf("%+v", c), func(t *testing.T) {
@@ -75,3 +83,40 @@ func TestObjectForCheck(t *testing.T) {
 		})
 	}
 }
+
+func TestReasonForCheck(t *testing.T) {
+	reason, ignored := ReasonForCheck(map[string]string{
+		"ignore-check.kube-linter.io/privileged": "Needs kernel access",
+	}, "privileged")
+	assert.True(t, ignored)
+	assert.Equal(t, "Needs kernel access", reason)
+
+	reason, ignored = ReasonForCheck(map[string]string{
+		"ignore-check.kube-linter.io/privileged": "Needs kernel access",
+	}, "latest-tag")
+	assert.False(t, ignored)
+	assert.Empty(t, reason)
+}
+
+func TestReasonForCheckPrefersTheMostSpecificAnnotation(t *testing.T) {
+	annotations := map[string]string{
+		"ignore-check.kube-linter.io/privileged": "Needs kernel access",
+		"ignore-check.kube-linter.io/all":        "Legacy workload",
+		"kube-linter.io/ignore-all":              "Too much of a mess",
+	}
+	// Maps are iterated in a random order, so the reason is looked up several times.
+	for i := 0; i < 20; i++ {
+		reason, ignored := ReasonForCheck(annotations, "privileged")
+		assert.True(t, ignored)
+		assert.Equal(t, "Needs kernel access", reason)
+
+		reason, ignored = ReasonForCheck(annotations, "latest-tag")
+		assert.True(t, ignored)
+		assert.Equal(t, "Legacy workload", reason)
+	}
+
+	delete(annotations, "ignore-check.kube-linter.io/all")
+	reason, ignored := ReasonForCheck(annotations, "latest-tag")
+	assert.True(t, ignored)
+	assert.Equal(t, "Too much of a mess", reason)
+}
@@ -7,6 +7,7 @@ import (
 	"golang.stackrox.io/kube-linter/internal/errorhelpers"
 	"golang.stackrox.io/kube-linter/pkg/check"
 	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
 	"golang.stackrox.io/kube-linter/pkg/objectkinds"
 	"golang.stackrox.io/kube-linter/pkg/templates"
 )
@@ -14,10 +15,16 @@ import (
 // An InstantiatedCheck is the runtime instantiation of a check, which fuses the metadata in a check
 // spec with the runtime information from a template.
 type InstantiatedCheck struct {
-	Func    check.Func
-	Matcher objectkinds.Matcher
+	// Exactly one of Func and ContextMatcher is set, depending on how the template of the check is instantiated.
+	// Use FuncForContext to get the function that checks the objects of a LintContext.
+	Func           check.Func
+	ContextMatcher check.ContextMatcher
+	Matcher        objectkinds.Matcher
 
 	Spec config.Check
+
+	// namespaces is nil if the check runs on objects in all namespaces.
+	namespaces *namespaceMatcher
 }
 
 var (
@@ -34,12 +41,18 @@ func ValidateAndInstantiate(c *config.Check) (*InstantiatedCheck, error) {
 	if !validCheckNameRegex.MatchString(c.Name) {
 		validationErrs.AddStringf("invalid name %s, must match regex %s", c.Name, validCheckNameRegex.String())
 	}
+	if c.Severity != "" && !c.Severity.IsValid() {
+		validationErrs.AddStringf("invalid severity %q, must be one of %v", c.Severity, config.AllSeverities())
+	}
 	template, found := templates.Get(c.Template)
 	if !found {
-		validationErrs.AddStringf("template %q not found", c.Template)
+		validationErrs.AddStringf("template %q not found%s", c.Template, templates.DidYouMean(c.Template))
 		return nil, validationErrs.ToError()
 	}
 
+	if err := templates.ValidateParams(template, c.Params); err != nil {
+		return nil, err
+	}
 	params, err := template.ParseAndValidateParams(c.Params)
 	if err != nil {
 		return nil, errors.Wrap(err, "validating and instantiating params")
@@ -50,6 +63,9 @@ func ValidateAndInstantiate(c *config.Check) (*InstantiatedCheck, error) {
 	}
 
 	i := &InstantiatedCheck{Spec: *c}
+	if i.Spec.Severity == "" {
+		i.Spec.Severity = config.DefaultSeverity
+	}
 	var objectKinds config.ObjectKindsDesc
 	if c.Scope != nil {
 		objectKinds = *c.Scope
@@ -61,6 +77,17 @@ func ValidateAndInstantiate(c *config.Check) (*InstantiatedCheck, error) {
 		return nil, err
 	}
 	i.Matcher = matcher
+	if err := i.SetNamespaceScope(c.Namespaces); err != nil {
+		return nil, err
+	}
+	if template.InstantiateContextMatcher != nil {
+		contextMatcher, err := template.InstantiateContextMatcher(params)
+		if err != nil {
+			return nil, errors.Wrap(err, "instantiating check")
+		}
+		i.ContextMatcher = contextMatcher
+		return i, nil
+	}
 	checkFunc, err := template.Instantiate(params)
 	if err != nil {
 		return nil, errors.Wrap(err, "instantiating check")
@@ -68,3 +95,34 @@ func ValidateAndInstantiate(c *config.Check) (*InstantiatedCheck, error) {
 	i.Func = checkFunc
 	return i, nil
 }
+
+// FuncForContext returns the function that checks the objects in the given LintContext.
+// For checks that correlate objects, it must be called once per LintContext, before checking any of its objects.
+func (i *InstantiatedCheck) FuncForContext(lintCtx lintcontext.LintContext) check.Func {
+	if i.ContextMatcher != nil {
+		return i.ContextMatcher(lintCtx)
+	}
+	return i.Func
+}
+
+// SetNamespaceScope restricts the objects that the check runs on to the ones in the namespaces of the given scope,
+// replacing the scope of its spec. If scope is nil, the check runs on objects in all namespaces.
+func (i *InstantiatedCheck) SetNamespaceScope(scope *config.NamespaceScope) error {
+	if scope == nil {
+		i.Spec.Namespaces, i.namespaces = nil, nil
+		return nil
+	}
+	namespaces, err := newNamespaceMatcher(scope)
+	if err != nil {
+		return errors.Wrap(err, "invalid namespace scope")
+	}
+	scopeCopy := *scope
+	i.Spec.Namespaces, i.namespaces = &scopeCopy, namespaces
+	return nil
+}
+
+// MatchesNamespace returns whether the check runs on objects in the given namespace, which is empty for objects
+// without a namespace.
+func (i *InstantiatedCheck) MatchesNamespace(namespace string) bool {
+	return i.namespaces == nil || i.namespaces.matches(namespace)
+}
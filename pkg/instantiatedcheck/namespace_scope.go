@@ -0,0 +1,68 @@
+package instantiatedcheck
+
+import (
+	"regexp"
+
+	"github.com/pkg/errors"
+	"golang.stackrox.io/kube-linter/internal/set"
+	"golang.stackrox.io/kube-linter/pkg/config"
+)
+
+// namespaceMatcher is a compiled config.NamespaceScope.
+type namespaceMatcher struct {
+	include, exclude           set.FrozenStringSet
+	includeRegex, excludeRegex *regexp.Regexp
+	hasInclude                 bool
+	withoutNamespace           bool
+}
+
+// compileNamespaceRegex compiles the given regex so that it must match the whole namespace.
+func compileNamespaceRegex(name, regex string) (*regexp.Regexp, error) {
+	if regex == "" {
+		return nil, nil
+	}
+	r, err := regexp.Compile("^(?:" + regex + ")$")
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid %s %s", name, regex)
+	}
+	return r, nil
+}
+
+func newNamespaceMatcher(scope *config.NamespaceScope) (*namespaceMatcher, error) {
+	m := &namespaceMatcher{
+		include:    set.NewFrozenStringSet(scope.Include...),
+		exclude:    set.NewFrozenStringSet(scope.Exclude...),
+		hasInclude: len(scope.Include) > 0 || scope.IncludeRegex != "",
+	}
+	var err error
+	if m.includeRegex, err = compileNamespaceRegex("includeRegex", scope.IncludeRegex); err != nil {
+		return nil, err
+	}
+	if m.excludeRegex, err = compileNamespaceRegex("excludeRegex", scope.ExcludeRegex); err != nil {
+		return nil, err
+	}
+	switch scope.WithoutNamespace {
+	case config.WithoutNamespaceInclude:
+		m.withoutNamespace = true
+	case config.WithoutNamespaceExclude:
+	case "":
+		m.withoutNamespace = !m.hasInclude
+	default:
+		return nil, errors.Errorf("invalid withoutNamespace %q, must be %q or %q", scope.WithoutNamespace,
+			config.WithoutNamespaceInclude, config.WithoutNamespaceExclude)
+	}
+	return m, nil
+}
+
+func (m *namespaceMatcher) matches(namespace string) bool {
+	if namespace == "" {
+		return m.withoutNamespace
+	}
+	if m.exclude.Contains(namespace) || (m.excludeRegex != nil && m.excludeRegex.MatchString(namespace)) {
+		return false
+	}
+	if !m.hasInclude {
+		return true
+	}
+	return m.include.Contains(namespace) || (m.includeRegex != nil && m.includeRegex.MatchString(namespace))
+}
@@ -0,0 +1,263 @@
+package lintcontext
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// maxArchiveSizeBytes is the maximum size, in bytes, of the archives that we load.
+	maxArchiveSizeBytes = 100 * 1024 * 1024
+	// maxArchiveContentBytes is the maximum total size, in bytes, of the uncompressed YAML files of an archive, so
+	// that archives that decompress to much more than their size cannot exhaust memory.
+	maxArchiveContentBytes = 100 * 1024 * 1024
+	// maxArchiveEntries is the maximum number of entries of the archives that we load.
+	maxArchiveEntries = 10000
+)
+
+var (
+	// archiveExtensions are the extensions of the archives of manifests that are loaded, which are matched case
+	// insensitively.
+	archiveExtensions = []string{".tar", ".tar.gz", ".tgz", ".zip"}
+)
+
+// ArchiveError is the load error of an archive of manifests that could not be read, for example because it is
+// corrupt, too large, or has entries with paths outside of it.
+type ArchiveError struct {
+	// Archive is the path of the archive.
+	Archive string
+	// Err is the error that reading the archive failed with.
+	Err error
+}
+
+func (e *ArchiveError) Error() string {
+	return fmt.Sprintf("reading archive %s: %v", e.Archive, e.Err)
+}
+
+// Unwrap returns the error that reading the archive failed with.
+func (e *ArchiveError) Unwrap() error {
+	return e.Err
+}
+
+// isArchive returns whether the given file is an archive of manifests, judging by its extension.
+func isArchive(filePath string) bool {
+	lowerPath := strings.ToLower(filePath)
+	for _, ext := range archiveExtensions {
+		if strings.HasSuffix(lowerPath, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// isGzippedTar returns whether the given archive is a gzipped tarball, which Helm charts are packaged as.
+func isGzippedTar(filePath string) bool {
+	lowerPath := strings.ToLower(filePath)
+	return strings.HasSuffix(lowerPath, ".tgz") || strings.HasSuffix(lowerPath, ".tar.gz")
+}
+
+// archiveEntry is a YAML file of an archive.
+type archiveEntry struct {
+	// path is the path of the file within the archive, with forward slashes.
+	path string
+	data []byte
+}
+
+// archiveReader reads the YAML files of an archive, enforcing the limits on their number and size.
+type archiveReader struct {
+	entries      []archiveEntry
+	numEntries   int
+	contentBytes int
+	// skipped is called with the paths of the regular files that are not YAML files.
+	skipped func(path string)
+}
+
+// add reads the entry with the given name and contents, where size is the size that the archive declares for it.
+func (r *archiveReader) add(name string, size int64, contents io.Reader) error {
+	r.numEntries++
+	if r.numEntries > maxArchiveEntries {
+		return errors.Errorf("archive has more than the maximum of %d entries", maxArchiveEntries)
+	}
+	entryPath, err := archiveEntryPath(name)
+	if err != nil {
+		return err
+	}
+	if !knownYAMLExtensions.Contains(strings.ToLower(path.Ext(entryPath))) {
+		if r.skipped != nil {
+			r.skipped(entryPath)
+		}
+		return nil
+	}
+	// Like YAML files on disk, files larger than the maximum are skipped.
+	if size > maxFileSizeBytes {
+		return nil
+	}
+	// The declared size cannot be trusted, so no more than the maximum is ever read.
+	data, err := ioutil.ReadAll(io.LimitReader(contents, maxFileSizeBytes+1))
+	if err != nil {
+		return errors.Wrapf(err, "reading %s", entryPath)
+	}
+	if len(data) > maxFileSizeBytes {
+		return nil
+	}
+	r.contentBytes += len(data)
+	if r.contentBytes > maxArchiveContentBytes {
+		return errors.Errorf("archive content is larger than the maximum of %d bytes", maxArchiveContentBytes)
+	}
+	r.entries = append(r.entries, archiveEntry{path: entryPath, data: data})
+	return nil
+}
+
+// archiveEntryPath returns the cleaned path of the archive entry with the given name, rejecting paths that point
+// outside of the archive, like ../../etc/passwd or /etc/passwd.
+func archiveEntryPath(name string) (string, error) {
+	slashed := strings.ReplaceAll(name, `\`, "/")
+	cleaned := path.Clean(slashed)
+	if path.IsAbs(slashed) || filepath.VolumeName(name) != "" || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", errors.Errorf("entry %q has a path outside of the archive", name)
+	}
+	return cleaned, nil
+}
+
+func (r *archiveReader) readTar(reader io.Reader) error {
+	tarReader := tar.NewReader(reader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if header.Typeflag != tar.TypeReg && header.Typeflag != tar.TypeRegA {
+			continue
+		}
+		if err := r.add(header.Name, header.Size, tarReader); err != nil {
+			return err
+		}
+	}
+}
+
+func (r *archiveReader) readZip(data []byte) error {
+	zipReader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return err
+	}
+	for _, file := range zipReader.File {
+		if !file.Mode().IsRegular() {
+			continue
+		}
+		if err := r.addZipFile(file); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *archiveReader) addZipFile(file *zip.File) error {
+	contents, err := file.Open()
+	if err != nil {
+		return errors.Wrapf(err, "opening %s", file.Name)
+	}
+	defer func() {
+		_ = contents.Close()
+	}()
+	return r.add(file.Name, int64(file.UncompressedSize64), contents)
+}
+
+// archiveEntryFilePath returns the file path that the objects of the given entry of the given archive are recorded with.
+func archiveEntryFilePath(archivePath, entryPath string) string {
+	return filepath.Join(archivePath, filepath.FromSlash(entryPath))
+}
+
+// isHelmChartArchive returns whether the given gzipped tarball is a packaged Helm chart, which has a Chart.yaml in
+// its top-level directory.
+func isHelmChartArchive(data []byte) bool {
+	gzipReader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return false
+	}
+	tarReader := tar.NewReader(gzipReader)
+	for i := 0; i < maxArchiveEntries; i++ {
+		header, err := tarReader.Next()
+		if err != nil {
+			return false
+		}
+		segments := strings.Split(path.Clean(header.Name), "/")
+		if len(segments) == 2 && segments[1] == "Chart.yaml" {
+			return true
+		}
+	}
+	return false
+}
+
+// readArchive reads the YAML files of the archive with the given path and contents, in the order they are stored in.
+func readArchive(filePath string, data []byte, skipped func(path string)) (*archiveReader, error) {
+	r := &archiveReader{skipped: skipped}
+	lowerPath := strings.ToLower(filePath)
+	switch {
+	case strings.HasSuffix(lowerPath, ".zip"):
+		return r, r.readZip(data)
+	case isGzippedTar(filePath):
+		gzipReader, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		return r, r.readTar(gzipReader)
+	default:
+		return r, r.readTar(bytes.NewReader(data))
+	}
+}
+
+// loadObjectsFromArchive loads the objects in the YAML files of the given archive, recording them with the path of
+// the archive followed by their path within it, like <archive>/manifests/deployment.yaml. Gzipped tarballs that are
+// packaged Helm charts are rendered instead. Archives that cannot be read are recorded as invalid objects with an
+// ArchiveError.
+func (l *lintContextImpl) loadObjectsFromArchive(filePath string, info os.FileInfo) error {
+	addArchiveError := func(err error) {
+		l.addInvalidObjects(InvalidObject{
+			Metadata: ObjectMetadata{FilePath: filePath},
+			LoadErr:  &ArchiveError{Archive: filePath, Err: err},
+		})
+	}
+	if info.Size() > maxArchiveSizeBytes {
+		addArchiveError(errors.Errorf("archive is larger than the maximum of %d bytes", maxArchiveSizeBytes))
+		return nil
+	}
+	data, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return errors.Wrapf(err, "opening file at %s", filePath)
+	}
+	if isGzippedTar(filePath) && isHelmChartArchive(data) {
+		l.loadObjectsFromTgzHelmChart(filePath)
+		return nil
+	}
+	var skipped func(path string)
+	if l.skippedArchiveEntry != nil {
+		skipped = func(entryPath string) {
+			l.skippedArchiveEntry(archiveEntryFilePath(filePath, entryPath))
+		}
+	}
+	archive, err := readArchive(filePath, data, skipped)
+	if err != nil {
+		addArchiveError(err)
+		return nil
+	}
+	for _, entry := range archive.entries {
+		if err := l.loadObjectsFromReader(archiveEntryFilePath(filePath, entry.path), bytes.NewReader(entry.data)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+package lintcontext
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	archivedDeployment = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+`
+	archivedService = `---
+apiVersion: v1
+kind: Service
+metadata:
+  name: app
+`
+)
+
+// archiveFile is a file of an archive written by a test.
+type archiveFile struct {
+	name     string
+	contents string
+}
+
+func tarArchive(t *testing.T, files []archiveFile) []byte {
+	var buf bytes.Buffer
+	tarWriter := tar.NewWriter(&buf)
+	for _, file := range files {
+		require.NoError(t, tarWriter.WriteHeader(&tar.Header{Name: file.name, Mode: 0644, Size: int64(len(file.contents)), Typeflag: tar.TypeReg}))
+		_, err := tarWriter.Write([]byte(file.contents))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tarWriter.Close())
+	return buf.Bytes()
+}
+
+func gzipped(t *testing.T, data []byte) []byte {
+	var buf bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buf)
+	_, err := gzipWriter.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, gzipWriter.Close())
+	return buf.Bytes()
+}
+
+func zipArchive(t *testing.T, files []archiveFile) []byte {
+	var buf bytes.Buffer
+	zipWriter := zip.NewWriter(&buf)
+	for _, file := range files {
+		w, err := zipWriter.Create(file.name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(file.contents))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zipWriter.Close())
+	return buf.Bytes()
+}
+
+func writeArchive(t *testing.T, name string, data []byte) string {
+	archivePath := filepath.Join(t.TempDir(), name)
+	require.NoError(t, ioutil.WriteFile(archivePath, data, 0644))
+	return archivePath
+}
+
+func TestCreateContextsFromArchives(t *testing.T) {
+	files := []archiveFile{
+		{name: "manifests/deployment.yaml", contents: archivedDeployment},
+		{name: "./manifests/service.YML", contents: "\n" + archivedService},
+		{name: "README.md", contents: "# Rendered manifests"},
+	}
+	for _, tc := range []struct {
+		name string
+		data []byte
+	}{
+		{name: "manifests.tar", data: tarArchive(t, files)},
+		{name: "manifests.tar.gz", data: gzipped(t, tarArchive(t, files))},
+		{name: "manifests.tgz", data: gzipped(t, tarArchive(t, files))},
+		{name: "manifests.ZIP", data: zipArchive(t, files)},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			archivePath := writeArchive(t, tc.name, tc.data)
+			var skipped []string
+			lintCtxs, err := CreateContextsWithOptions(Options{
+				SkippedArchiveEntry: func(filePath string) {
+					skipped = append(skipped, filePath)
+				},
+			}, archivePath)
+			require.NoError(t, err)
+			require.Len(t, lintCtxs, 1)
+			assert.Empty(t, lintCtxs[0].InvalidObjects())
+
+			objects := lintCtxs[0].Objects()
+			require.Len(t, objects, 2)
+			assert.Equal(t, filepath.Join(archivePath, "manifests", "deployment.yaml"), objects[0].Metadata.FilePath)
+			assert.Equal(t, 1, objects[0].Metadata.Line)
+			assert.Equal(t, "Deployment", objects[0].K8sObject.GetObjectKind().GroupVersionKind().Kind)
+			assert.Equal(t, filepath.Join(archivePath, "manifests", "service.YML"), objects[1].Metadata.FilePath)
+			assert.Equal(t, 3, objects[1].Metadata.Line, "lines are relative to the file in the archive")
+			assert.Equal(t, []string{filepath.Join(archivePath, "README.md")}, skipped)
+		})
+	}
+}
+
+func TestCreateContextsOnlyLoadsTgzArchivesFromDirectories(t *testing.T) {
+	dir := t.TempDir()
+	files := []archiveFile{{name: "deployment.yaml", contents: archivedDeployment}}
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "manifests.tgz"), gzipped(t, tarArchive(t, files)), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "backup.zip"), zipArchive(t, files), 0644))
+
+	lintCtxs, err := CreateContexts(dir)
+	require.NoError(t, err)
+	require.Len(t, lintCtxs, 1)
+	require.Len(t, lintCtxs[0].Objects(), 1)
+	assert.Equal(t, filepath.Join(dir, "manifests.tgz", "deployment.yaml"), lintCtxs[0].Objects()[0].Metadata.FilePath)
+}
+
+func TestCreateContextsFromArchivesStillRendersHelmCharts(t *testing.T) {
+	lintCtxs, err := CreateContexts(chartTarball)
+	require.NoError(t, err)
+	require.Len(t, lintCtxs, 1)
+	assert.Empty(t, lintCtxs[0].InvalidObjects())
+	assert.NotEmpty(t, lintCtxs[0].Objects())
+	for _, obj := range lintCtxs[0].Objects() {
+		assert.Contains(t, obj.Metadata.FilePath, filepath.Join("mychart", "templates"))
+	}
+}
+
+func TestCreateContextsRecordsArchiveErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		archiveName string
+		data        []byte
+		expectedErr string
+	}{
+		{
+			name:        "zip slip",
+			archiveName: "manifests.zip",
+			data:        zipArchive(t, []archiveFile{{name: "../../deployment.yaml", contents: archivedDeployment}}),
+			expectedErr: `entry "../../deployment.yaml" has a path outside of the archive`,
+		},
+		{
+			name:        "absolute path",
+			archiveName: "manifests.tar",
+			data:        tarArchive(t, []archiveFile{{name: "/etc/deployment.yaml", contents: archivedDeployment}}),
+			expectedErr: `entry "/etc/deployment.yaml" has a path outside of the archive`,
+		},
+		{
+			name:        "corrupt",
+			archiveName: "manifests.tgz",
+			data:        []byte("not an archive"),
+			expectedErr: "gzip: invalid header",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			archivePath := writeArchive(t, tc.archiveName, tc.data)
+			lintCtxs, err := CreateContexts(archivePath)
+			require.NoError(t, err)
+			require.Len(t, lintCtxs, 1)
+			assert.Empty(t, lintCtxs[0].Objects())
+			require.Len(t, lintCtxs[0].InvalidObjects(), 1)
+
+			invalidObj := lintCtxs[0].InvalidObjects()[0]
+			assert.Equal(t, archivePath, invalidObj.Metadata.FilePath)
+			var archiveErr *ArchiveError
+			require.True(t, errors.As(invalidObj.LoadErr, &archiveErr))
+			assert.EqualError(t, archiveErr.Err, tc.expectedErr)
+		})
+	}
+}
+
+func TestArchiveReaderLimits(t *testing.T) {
+	r := &archiveReader{numEntries: maxArchiveEntries}
+	assert.EqualError(t, r.add("deployment.yaml", 0, bytes.NewReader(nil)), "archive has more than the maximum of 10000 entries")
+
+	r = &archiveReader{contentBytes: maxArchiveContentBytes}
+	assert.EqualError(t, r.add("deployment.yaml", int64(len(archivedDeployment)), bytes.NewReader([]byte(archivedDeployment))),
+		"archive content is larger than the maximum of 104857600 bytes")
+
+	// Entries that are larger than they claim to be are not read past the maximum file size.
+	r = &archiveReader{}
+	require.NoError(t, r.add("large.yaml", 1, bytes.NewReader(make([]byte, maxFileSizeBytes+10))))
+	assert.Empty(t, r.entries)
+}
@@ -0,0 +1,224 @@
+package lintcontext
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	ocsAppsV1 "github.com/openshift/api/apps/v1"
+	"github.com/pkg/errors"
+	appsV1 "k8s.io/api/apps/v1"
+	autoscalingV1 "k8s.io/api/autoscaling/v1"
+	autoscalingV2beta2 "k8s.io/api/autoscaling/v2beta2"
+	batchV1 "k8s.io/api/batch/v1"
+	batchV1Beta1 "k8s.io/api/batch/v1beta1"
+	v1 "k8s.io/api/core/v1"
+	networkingV1 "k8s.io/api/networking/v1"
+	policyV1 "k8s.io/api/policy/v1"
+	policyV1beta1 "k8s.io/api/policy/v1beta1"
+	rbacV1 "k8s.io/api/rbac/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const (
+	// ClusterFilePathPrefix is the prefix of the file paths recorded in the metadata of objects listed from a cluster,
+	// which read like cluster://<namespace>/<kind>/<name>, or cluster://<kind>/<name> for cluster-scoped objects.
+	ClusterFilePathPrefix = "cluster://"
+
+	// clusterListPageSize is the number of objects that are listed per request.
+	clusterListPageSize = 500
+)
+
+// clusterResource is a kind of objects that are listed from clusters, with the resources that it is served as, from
+// the most to the least preferred. Only the first resource that the cluster serves is listed, so that every object
+// is only linted once.
+type clusterResource struct {
+	versions   []schema.GroupVersionResource
+	namespaced bool
+}
+
+var (
+	// clusterResources are the kinds of objects that checks look at.
+	clusterResources = []clusterResource{
+		{versions: []schema.GroupVersionResource{appsV1.SchemeGroupVersion.WithResource("deployments")}, namespaced: true},
+		{versions: []schema.GroupVersionResource{appsV1.SchemeGroupVersion.WithResource("statefulsets")}, namespaced: true},
+		{versions: []schema.GroupVersionResource{appsV1.SchemeGroupVersion.WithResource("daemonsets")}, namespaced: true},
+		{versions: []schema.GroupVersionResource{appsV1.SchemeGroupVersion.WithResource("replicasets")}, namespaced: true},
+		{versions: []schema.GroupVersionResource{ocsAppsV1.SchemeGroupVersion.WithResource("deploymentconfigs")}, namespaced: true},
+		{versions: []schema.GroupVersionResource{batchV1.SchemeGroupVersion.WithResource("jobs")}, namespaced: true},
+		{versions: []schema.GroupVersionResource{
+			batchV1.SchemeGroupVersion.WithResource("cronjobs"),
+			batchV1Beta1.SchemeGroupVersion.WithResource("cronjobs"),
+		}, namespaced: true},
+		{versions: []schema.GroupVersionResource{v1.SchemeGroupVersion.WithResource("pods")}, namespaced: true},
+		{versions: []schema.GroupVersionResource{v1.SchemeGroupVersion.WithResource("replicationcontrollers")}, namespaced: true},
+		{versions: []schema.GroupVersionResource{v1.SchemeGroupVersion.WithResource("services")}, namespaced: true},
+		{versions: []schema.GroupVersionResource{v1.SchemeGroupVersion.WithResource("serviceaccounts")}, namespaced: true},
+		{versions: []schema.GroupVersionResource{v1.SchemeGroupVersion.WithResource("configmaps")}, namespaced: true},
+		{versions: []schema.GroupVersionResource{v1.SchemeGroupVersion.WithResource("secrets")}, namespaced: true},
+		{versions: []schema.GroupVersionResource{networkingV1.SchemeGroupVersion.WithResource("ingresses")}, namespaced: true},
+		{versions: []schema.GroupVersionResource{networkingV1.SchemeGroupVersion.WithResource("networkpolicies")}, namespaced: true},
+		{versions: []schema.GroupVersionResource{
+			policyV1.SchemeGroupVersion.WithResource("poddisruptionbudgets"),
+			policyV1beta1.SchemeGroupVersion.WithResource("poddisruptionbudgets"),
+		}, namespaced: true},
+		{versions: []schema.GroupVersionResource{
+			autoscalingV2beta2.SchemeGroupVersion.WithResource("horizontalpodautoscalers"),
+			autoscalingV1.SchemeGroupVersion.WithResource("horizontalpodautoscalers"),
+		}, namespaced: true},
+		{versions: []schema.GroupVersionResource{rbacV1.SchemeGroupVersion.WithResource("roles")}, namespaced: true},
+		{versions: []schema.GroupVersionResource{rbacV1.SchemeGroupVersion.WithResource("rolebindings")}, namespaced: true},
+		{versions: []schema.GroupVersionResource{rbacV1.SchemeGroupVersion.WithResource("clusterroles")}},
+		{versions: []schema.GroupVersionResource{rbacV1.SchemeGroupVersion.WithResource("clusterrolebindings")}},
+	}
+)
+
+// ClusterOptions represent values that can be provided to modify which objects are listed from a cluster.
+type ClusterOptions struct {
+	// Kubeconfig is the path of the kubeconfig file. If it is empty, the kubeconfig is loaded like kubectl does, from
+	// the files in the KUBECONFIG environment variable or from ~/.kube/config, and from the service account of the pod
+	// if running in a cluster.
+	Kubeconfig string
+	// Namespace, if set, restricts the objects to the ones in this namespace. Cluster-scoped objects, like
+	// ClusterRoles, are then not listed. If it is empty, objects in all namespaces are listed.
+	Namespace string
+	// LabelSelector, if set, restricts the objects to the ones whose labels match it, like with kubectl --selector.
+	LabelSelector string
+	// Timeout bounds the time spent on each request to the API server. If it is 0, requests do not time out.
+	Timeout time.Duration
+}
+
+// ClusterListError is the load error of a kind of objects that could not be listed from a cluster, for example
+// because access to them is forbidden.
+type ClusterListError struct {
+	// Resource is the resource that could not be listed, like apps/v1/deployments.
+	Resource string
+	// Err is the error that the API server returned.
+	Err error
+}
+
+func (e *ClusterListError) Error() string {
+	return fmt.Sprintf("listing %s: %v", e.Resource, e.Err)
+}
+
+// Unwrap returns the error that the API server returned.
+func (e *ClusterListError) Unwrap() error {
+	return e.Err
+}
+
+// CreateContextsFromCluster creates a context holding the objects of the kinds that checks look at, listed from the
+// API server of the cluster of the current kubeconfig context.
+// Objects that are controlled by other objects, like the pods of a deployment, are skipped, since the checks on
+// their controllers already cover them. Kinds that the cluster does not serve are skipped, and kinds that cannot be
+// listed, for example because access to them is forbidden, are recorded as invalid objects with a ClusterListError.
+// Errors that do not come from the API server, like failing to connect to it, are returned.
+func CreateContextsFromCluster(options ClusterOptions) ([]LintContext, error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = options.Kubeconfig
+	restConfig, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{}).ClientConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, "loading kubeconfig")
+	}
+	restConfig.Timeout = options.Timeout
+	client, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating client")
+	}
+	return CreateContextsFromClusterClient(client, options)
+}
+
+// CreateContextsFromClusterClient is like CreateContextsFromCluster, but lists the objects with the given client,
+// ignoring the Kubeconfig and Timeout options.
+func CreateContextsFromClusterClient(client dynamic.Interface, options ClusterOptions) ([]LintContext, error) {
+	ctx := newCtx(Options{})
+	for _, resource := range clusterResources {
+		if options.Namespace != "" && !resource.namespaced {
+			continue
+		}
+		if err := ctx.loadObjectsFromCluster(client, resource, options); err != nil {
+			return nil, err
+		}
+	}
+	return []LintContext{ctx}, nil
+}
+
+// loadObjectsFromCluster loads the objects of the given kind from the cluster.
+func (l *lintContextImpl) loadObjectsFromCluster(client dynamic.Interface, resource clusterResource, options ClusterOptions) error {
+	for _, gvr := range resource.versions {
+		resourcePath := gvr.GroupVersion().String() + "/" + gvr.Resource
+		items, err := listAll(client, gvr, options)
+		if k8sErrors.IsNotFound(err) {
+			continue
+		}
+		if _, isAPIError := err.(k8sErrors.APIStatus); err != nil && !isAPIError {
+			return errors.Wrapf(err, "listing %s", resourcePath)
+		}
+		if err != nil {
+			l.addInvalidObjects(InvalidObject{
+				Metadata: ObjectMetadata{FilePath: ClusterFilePathPrefix + resourcePath},
+				LoadErr:  &ClusterListError{Resource: resourcePath, Err: err},
+			})
+			return nil
+		}
+		for i := range items {
+			l.addClusterObject(&items[i])
+		}
+		return nil
+	}
+	return nil
+}
+
+// listAll lists all the objects of the given resource, page by page.
+func listAll(client dynamic.Interface, gvr schema.GroupVersionResource, options ClusterOptions) ([]unstructured.Unstructured, error) {
+	var items []unstructured.Unstructured
+	listOptions := metaV1.ListOptions{LabelSelector: options.LabelSelector, Limit: clusterListPageSize}
+	for {
+		list, err := client.Resource(gvr).Namespace(options.Namespace).List(context.Background(), listOptions)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, list.Items...)
+		listOptions.Continue = list.GetContinue()
+		if listOptions.Continue == "" {
+			return items, nil
+		}
+	}
+}
+
+// addClusterObject adds the given object listed from a cluster, unless it is controlled by another object.
+func (l *lintContextImpl) addClusterObject(obj *unstructured.Unstructured) {
+	if metaV1.GetControllerOf(obj) != nil {
+		return
+	}
+	// Managed fields are bookkeeping of the API server, which no check looks at.
+	unstructured.RemoveNestedField(obj.Object, "metadata", "managedFields")
+	// Checks only look at which Secrets exist, so their values are not kept in memory, nor printed in the output.
+	if obj.GetAPIVersion() == "v1" && obj.GetKind() == "Secret" {
+		unstructured.RemoveNestedField(obj.Object, "data")
+		unstructured.RemoveNestedField(obj.Object, "stringData")
+	}
+
+	filePath := fmt.Sprintf("%s%s/%s", ClusterFilePathPrefix, obj.GetKind(), obj.GetName())
+	if obj.GetNamespace() != "" {
+		filePath = fmt.Sprintf("%s%s/%s/%s", ClusterFilePathPrefix, obj.GetNamespace(), obj.GetKind(), obj.GetName())
+	}
+	data, err := obj.MarshalJSON()
+	if err != nil {
+		l.addInvalidObjects(InvalidObject{Metadata: ObjectMetadata{FilePath: filePath}, LoadErr: errors.Wrap(err, "serializing object")})
+		return
+	}
+	metadata := ObjectMetadata{FilePath: filePath, Raw: data}
+	objs, err := parseObjects(data, l.customDecoder)
+	if err != nil {
+		l.addInvalidObjects(InvalidObject{Metadata: metadata, LoadErr: err})
+		return
+	}
+	for _, k8sObj := range objs {
+		l.addObjects(Object{Metadata: metadata, K8sObject: k8sObj})
+	}
+}
@@ -0,0 +1,158 @@
+package lintcontext
+
+import (
+	"errors"
+
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+	k8sTesting "k8s.io/client-go/testing"
+)
+
+func newClusterObject(apiVersion, kind, namespace, name string, labels map[string]interface{}) *unstructured.Unstructured {
+	metadata := map[string]interface{}{"name": name}
+	if namespace != "" {
+		metadata["namespace"] = namespace
+	}
+	if labels != nil {
+		metadata["labels"] = labels
+	}
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": apiVersion,
+		"kind":       kind,
+		"metadata":   metadata,
+	}}
+}
+
+func newFakeClusterClient(objs ...runtime.Object) *dynamicFake.FakeDynamicClient {
+	listKinds := make(map[schema.GroupVersionResource]string)
+	for _, resource := range clusterResources {
+		for _, gvr := range resource.versions {
+			listKinds[gvr] = "List"
+		}
+	}
+	return dynamicFake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds, objs...)
+}
+
+func clusterFilePaths(t *testing.T, lintCtxs []LintContext) []string {
+	require.Len(t, lintCtxs, 1)
+	var filePaths []string
+	for _, obj := range lintCtxs[0].Objects() {
+		filePaths = append(filePaths, obj.Metadata.FilePath)
+	}
+	return filePaths
+}
+
+func TestCreateContextsFromCluster(t *testing.T) {
+	deployment := newClusterObject("apps/v1", "Deployment", "app", "web", map[string]interface{}{"team": "a"})
+	// The pods of the deployment are covered by the checks on the deployment.
+	pod := newClusterObject("v1", "Pod", "app", "web-1234", map[string]interface{}{"team": "a"})
+	pod.SetOwnerReferences([]metaV1.OwnerReference{{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "web-12", UID: "1", Controller: &[]bool{true}[0]}})
+	standalonePod := newClusterObject("v1", "Pod", "app", "debug", nil)
+	service := newClusterObject("v1", "Service", "other", "web", nil)
+	clusterRole := newClusterObject("rbac.authorization.k8s.io/v1", "ClusterRole", "", "admin", nil)
+	client := newFakeClusterClient(deployment, pod, standalonePod, service, clusterRole)
+
+	for _, testCase := range []struct {
+		desc     string
+		options  ClusterOptions
+		expected []string
+	}{
+		{
+			desc:     "all objects",
+			expected: []string{"cluster://app/Deployment/web", "cluster://app/Pod/debug", "cluster://other/Service/web", "cluster://ClusterRole/admin"},
+		},
+		{
+			desc:     "namespace",
+			options:  ClusterOptions{Namespace: "app"},
+			expected: []string{"cluster://app/Deployment/web", "cluster://app/Pod/debug"},
+		},
+		{
+			desc:     "label selector",
+			options:  ClusterOptions{LabelSelector: "team=a"},
+			expected: []string{"cluster://app/Deployment/web"},
+		},
+	} {
+		t.Run(testCase.desc, func(t *testing.T) {
+			lintCtxs, err := CreateContextsFromClusterClient(client, testCase.options)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, clusterFilePaths(t, lintCtxs))
+			assert.Empty(t, lintCtxs[0].InvalidObjects())
+		})
+	}
+
+	lintCtxs, err := CreateContextsFromClusterClient(client, ClusterOptions{Namespace: "app"})
+	require.NoError(t, err)
+	obj := lintCtxs[0].Objects()[0]
+	assert.Equal(t, "web", obj.K8sObject.GetName())
+	assert.Equal(t, schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, obj.K8sObject.GetObjectKind().GroupVersionKind())
+}
+
+func TestCreateContextsFromClusterDropsSecretData(t *testing.T) {
+	secret := newClusterObject("v1", "Secret", "app", "credentials", nil)
+	secret.Object["data"] = map[string]interface{}{"password": "Y2hhbmdlbWU="}
+	secret.Object["stringData"] = map[string]interface{}{"token": "changeme"}
+	lintCtxs, err := CreateContextsFromClusterClient(newFakeClusterClient(secret), ClusterOptions{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"cluster://app/Secret/credentials"}, clusterFilePaths(t, lintCtxs))
+
+	obj := lintCtxs[0].Objects()[0]
+	k8sSecret, ok := obj.K8sObject.(*v1.Secret)
+	require.True(t, ok)
+	assert.Empty(t, k8sSecret.Data)
+	assert.Empty(t, k8sSecret.StringData)
+	assert.NotContains(t, string(obj.Metadata.Raw), "changeme")
+}
+
+func TestCreateContextsFromClusterFallsBackToOlderVersions(t *testing.T) {
+	cronJob := newClusterObject("batch/v1beta1", "CronJob", "app", "backup", nil)
+	client := newFakeClusterClient(cronJob)
+	// Clusters before Kubernetes 1.21 do not serve batch/v1 cronjobs.
+	client.PrependReactor("list", "cronjobs", func(action k8sTesting.Action) (bool, runtime.Object, error) {
+		if action.GetResource().Version == "v1" {
+			return true, nil, k8sErrors.NewNotFound(action.GetResource().GroupResource(), "")
+		}
+		return false, nil, nil
+	})
+
+	lintCtxs, err := CreateContextsFromClusterClient(client, ClusterOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cluster://app/CronJob/backup"}, clusterFilePaths(t, lintCtxs))
+	assert.Empty(t, lintCtxs[0].InvalidObjects())
+}
+
+func TestCreateContextsFromClusterRecordsListErrors(t *testing.T) {
+	client := newFakeClusterClient(newClusterObject("v1", "Service", "app", "web", nil))
+	client.PrependReactor("list", "services", func(action k8sTesting.Action) (bool, runtime.Object, error) {
+		return true, nil, k8sErrors.NewForbidden(action.GetResource().GroupResource(), "", nil)
+	})
+
+	lintCtxs, err := CreateContextsFromClusterClient(client, ClusterOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, clusterFilePaths(t, lintCtxs))
+	invalidObjects := lintCtxs[0].InvalidObjects()
+	require.Len(t, invalidObjects, 1)
+	assert.Equal(t, "cluster://v1/services", invalidObjects[0].Metadata.FilePath)
+	var listErr *ClusterListError
+	require.True(t, errors.As(invalidObjects[0].LoadErr, &listErr))
+	assert.Equal(t, "v1/services", listErr.Resource)
+	assert.True(t, k8sErrors.IsForbidden(listErr.Err))
+}
+
+func TestCreateContextsFromClusterFailsIfUnreachable(t *testing.T) {
+	client := newFakeClusterClient()
+	client.PrependReactor("list", "*", func(action k8sTesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("connection refused")
+	})
+
+	_, err := CreateContextsFromClusterClient(client, ClusterOptions{})
+	assert.EqualError(t, err, "listing apps/v1/deployments: connection refused")
+}
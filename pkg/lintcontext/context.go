@@ -5,6 +5,7 @@ import (
 
 	"golang.stackrox.io/kube-linter/internal/stringutils"
 	"golang.stackrox.io/kube-linter/pkg/k8sutil"
+	yamlv3 "gopkg.in/yaml.v3"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
@@ -12,7 +13,14 @@ import (
 // ObjectMetadata is metadata about an object.
 type ObjectMetadata struct {
 	FilePath string
-	Raw      []byte `json:"-"`
+	// Line is the line of the file that the object starts on, or 0 if it is not known, like for objects rendered from
+	// Helm charts, whose positions in the rendered output do not correspond to the ones in the templates.
+	Line int    `json:",omitempty"`
+	Raw  []byte `json:"-"`
+
+	// node is the YAML node of the object, with positions relative to the start of the file. It is nil if positions
+	// are not known.
+	node *yamlv3.Node
 }
 
 // An Object references an object that is loaded from a YAML file.
@@ -78,6 +86,12 @@ type lintContextImpl struct {
 	invalidObjects []InvalidObject
 
 	customDecoder runtime.Decoder
+	// helmValues are merged over the values of every Helm chart that is rendered.
+	helmValues map[string]interface{}
+	// skipHelmHooks skips the objects rendered from Helm charts that are hooks.
+	skipHelmHooks bool
+	// skippedArchiveEntry is called with the files of archives that are skipped because they are not YAML files.
+	skippedArchiveEntry func(path string)
 }
 
 // Objects returns the (valid) objects loaded from this LintContext.
@@ -103,6 +117,9 @@ func (l *lintContextImpl) addInvalidObjects(objs ...InvalidObject) {
 // new returns a ready-to-use, empty, lintContextImpl.
 func newCtx(options Options) *lintContextImpl {
 	return &lintContextImpl{
-		customDecoder: options.CustomDecoder,
+		customDecoder:       options.CustomDecoder,
+		helmValues:          options.helmValues,
+		skipHelmHooks:       options.SkipHelmHooks,
+		skippedArchiveEntry: options.SkippedArchiveEntry,
 	}
 }
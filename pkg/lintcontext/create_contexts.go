@@ -2,17 +2,27 @@ package lintcontext
 
 import (
 	"io"
+	"net/http"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"golang.stackrox.io/kube-linter/internal/set"
 	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/cli/values"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+const (
+	// StdinArg is the argument that makes CreateContexts read objects from standard input.
+	StdinArg = "-"
+	// StdinFilePath is the file path recorded in the metadata of objects read from standard input.
+	StdinFilePath = "<stdin>"
+)
+
 var (
 	knownYAMLExtensions = set.NewFrozenStringSet(".yaml", ".yml")
 )
@@ -22,11 +32,49 @@ type Options struct {
 	// CustomDecoder allows users to supply a non-default decoder to parse k8s objects. This can be used
 	// to allow the linter to create contexts for k8s custom resources
 	CustomDecoder runtime.Decoder
+	// Stdin is the reader that objects are read from when StdinArg is passed. Defaults to os.Stdin.
+	Stdin io.Reader
+	// URLFetchTimeout bounds the time spent fetching each HTTP(S) URL, or pulling each Helm chart from an OCI
+	// registry. Defaults to DefaultURLFetchTimeout.
+	URLFetchTimeout time.Duration
+	// Exclude holds glob patterns of files and directories to skip when walking directories and expanding glob
+	// patterns. Each pattern is matched against the path and each of its trailing parts, and "**" matches any
+	// number of directories.
+	Exclude []string
+	// HelmValueFiles are paths to values files that Helm charts are rendered with, on top of the values.yaml of the
+	// chart. Later files take precedence over earlier ones, like with `helm install --values`.
+	HelmValueFiles []string
+	// HelmSetValues are values that Helm charts are rendered with, given as key=value pairs like with
+	// `helm install --set`. They take precedence over HelmValueFiles.
+	HelmSetValues []string
+	// SkipHelmHooks skips the objects rendered from Helm charts that are hooks, like pre-install jobs, which are
+	// not part of the release.
+	SkipHelmHooks bool
+	// OnlyFiles, if not nil, restricts loading to these files, for example the ones changed since a git ref. Helm
+	// charts and Kustomize kustomizations are loaded as a whole if any of the given files is in their directory.
+	// Standard input, URLs and OCI references are not affected.
+	OnlyFiles []string
+	// SkippedArchiveEntry, if set, is called with the path of every file of an archive that is skipped because it is
+	// not a YAML file, as the objects in the archive would be reported with, for example to log it.
+	SkippedArchiveEntry func(filePath string)
+
+	// onlyFiles holds the absolute paths of OnlyFiles.
+	onlyFiles set.StringSet
+	// helmValues holds the values that HelmValueFiles and HelmSetValues merge into.
+	helmValues map[string]interface{}
 }
 
 // CreateContexts creates a context. Each context contains a set of files that should be linted
 // as a group.
-// Currently, each directory of Kube YAML files (or Helm charts) are treated as a separate context.
+// Currently, each directory of Kube YAML files (or Helm charts, or Kustomize kustomizations) are treated as a
+// separate context. Directories are walked recursively, following symbolic links, and arguments containing glob
+// patterns that do not name an existing file are expanded, with "**" matching any number of directories.
+// Files and directories that are ignored by a .kubelinterignore file in one of the walked directories are skipped.
+// Archives ending in .tar, .tar.gz, .tgz or .zip are unpacked in memory, and the YAML files in them are loaded with
+// the path of the archive followed by their path within it, unless they are packaged Helm charts. Each of them is
+// treated as a separate context, and only .tgz files are loaded from walked directories.
+// Arguments starting with http:// or https:// are fetched, and arguments starting with oci:// are pulled from OCI
+// registries as Helm charts. Each of them is treated as a separate context.
 // TODO: Figure out if it's useful to allow people to specify that files spanning different directories
 // should be treated as being in the same context.
 func CreateContexts(filesOrDirs ...string) ([]LintContext, error) {
@@ -35,36 +83,65 @@ func CreateContexts(filesOrDirs ...string) ([]LintContext, error) {
 
 // CreateContextsWithOptions creates a context with additional Options
 func CreateContextsWithOptions(options Options, filesOrDirs ...string) ([]LintContext, error) {
-	contextsByDir := make(map[string]*lintContextImpl)
-	for _, fileOrDir := range filesOrDirs {
-		// Stdin
-		if fileOrDir == "-" {
-			if _, alreadyExists := contextsByDir["-"]; alreadyExists {
-				continue
-			}
-			ctx := newCtx(options)
-			if err := ctx.loadObjectsFromReader("<standard input>", os.Stdin); err != nil {
-				return nil, err
+	for _, pattern := range options.Exclude {
+		if err := validateGlob(pattern); err != nil {
+			return nil, err
+		}
+	}
+	// Merge the Helm values up front, so that invalid ones are reported before anything is rendered.
+	if len(options.HelmValueFiles) > 0 || len(options.HelmSetValues) > 0 {
+		valOpts := &values.Options{ValueFiles: options.HelmValueFiles, Values: options.HelmSetValues}
+		helmValues, err := valOpts.MergeValues(nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "loading Helm values")
+		}
+		options.helmValues = helmValues
+	}
+	if options.OnlyFiles != nil {
+		options.onlyFiles = set.NewStringSet()
+		for _, file := range options.OnlyFiles {
+			absFile, err := filepath.Abs(file)
+			if err != nil {
+				return nil, errors.Wrapf(err, "resolving %s", file)
 			}
-			contextsByDir["-"] = ctx
-			continue
+			options.onlyFiles.Add(absFile)
 		}
+	}
+	contextsByDir := make(map[string]*lintContextImpl)
+	var httpClient *http.Client
 
-		err := filepath.Walk(fileOrDir, func(currentPath string, info os.FileInfo, walkErr error) error {
+	// loadPath loads the objects from the given file or directory, walking directories recursively.
+	loadPath := func(fileOrDir string) error {
+		ignores := newIgnoreTracker(fileOrDir)
+		return walkFollowingSymlinks(fileOrDir, func(currentPath string, info os.FileInfo, walkErr error) error {
 			if walkErr != nil {
 				return walkErr
 			}
+			ignored, err := ignores.isIgnored(currentPath, info)
+			if err != nil {
+				return err
+			}
+			if ignored || isExcluded(currentPath, options.Exclude) {
+				if info.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
 
 			if _, exists := contextsByDir[currentPath]; exists {
 				return nil
 			}
 
 			if !info.IsDir() {
-				if strings.HasSuffix(strings.ToLower(currentPath), ".tgz") {
+				if !options.includesFile(currentPath) {
+					return nil
+				}
+				// Archives are loaded if they were explicitly passed by the user, except for .tgz files, which are
+				// always loaded, since they are usually packaged Helm charts.
+				if isArchive(currentPath) && (fileOrDir == currentPath || strings.HasSuffix(strings.ToLower(currentPath), ".tgz")) {
 					ctx := newCtx(options)
-					ctx.loadObjectsFromTgzHelmChart(currentPath)
 					contextsByDir[currentPath] = ctx
-					return nil
+					return ctx.loadObjectsFromArchive(currentPath, info)
 				}
 
 				dirName := filepath.Dir(currentPath)
@@ -86,15 +163,85 @@ func CreateContextsWithOptions(options Options, filesOrDirs ...string) ([]LintCo
 				if _, alreadyExists := contextsByDir[currentPath]; alreadyExists {
 					return nil
 				}
+				if !options.includesDir(currentPath) {
+					return filepath.SkipDir
+				}
 				ctx := newCtx(options)
 				contextsByDir[currentPath] = ctx
 				ctx.loadObjectsFromHelmChart(currentPath)
 				return filepath.SkipDir
 			}
+			if kustomizationFile, isKustomization := getKustomizationFile(currentPath); isKustomization {
+				if !options.includesDir(currentPath) {
+					return filepath.SkipDir
+				}
+				ctx := newCtx(options)
+				contextsByDir[currentPath] = ctx
+				ctx.loadObjectsFromKustomization(currentPath, kustomizationFile)
+				return filepath.SkipDir
+			}
 			return nil
 		})
-		if err != nil {
-			return nil, errors.Wrapf(err, "loading from path %q", fileOrDir)
+	}
+
+	for _, fileOrDir := range filesOrDirs {
+		if fileOrDir == StdinArg {
+			// Stdin can only be consumed once, so passing it several times is the same as passing it once.
+			if _, alreadyExists := contextsByDir[StdinFilePath]; alreadyExists {
+				continue
+			}
+			stdin := options.Stdin
+			if stdin == nil {
+				stdin = os.Stdin
+			}
+			ctx := newCtx(options)
+			if err := ctx.loadObjectsFromReader(StdinFilePath, stdin); err != nil {
+				return nil, errors.Wrap(err, "loading from stdin")
+			}
+			contextsByDir[StdinFilePath] = ctx
+			continue
+		}
+
+		if isURL(fileOrDir) {
+			if _, alreadyExists := contextsByDir[fileOrDir]; alreadyExists {
+				continue
+			}
+			if httpClient == nil {
+				httpClient = newHTTPClient(options.URLFetchTimeout)
+			}
+			ctx := newCtx(options)
+			if err := ctx.loadObjectsFromURL(httpClient, fileOrDir); err != nil {
+				return nil, errors.Wrapf(err, "loading from URL %q", fileOrDir)
+			}
+			contextsByDir[fileOrDir] = ctx
+			continue
+		}
+
+		if isOCIReference(fileOrDir) {
+			if _, alreadyExists := contextsByDir[fileOrDir]; alreadyExists {
+				continue
+			}
+			ctx := newCtx(options)
+			ctx.loadObjectsFromOCIHelmChart(fileOrDir, options.URLFetchTimeout)
+			contextsByDir[fileOrDir] = ctx
+			continue
+		}
+
+		paths := []string{fileOrDir}
+		// Expand glob patterns that the shell did not expand, unless a file with that literal name exists.
+		if hasGlobMeta(fileOrDir) {
+			if _, err := os.Lstat(fileOrDir); os.IsNotExist(err) {
+				matches, err := expandGlob(fileOrDir, options.Exclude)
+				if err != nil {
+					return nil, err
+				}
+				paths = matches
+			}
+		}
+		for _, path := range paths {
+			if err := loadPath(path); err != nil {
+				return nil, errors.Wrapf(err, "loading from path %q", path)
+			}
 		}
 	}
 	dirs := make([]string, 0, len(contextsByDir))
@@ -109,6 +256,91 @@ func CreateContextsWithOptions(options Options, filesOrDirs ...string) ([]LintCo
 	return contexts, nil
 }
 
+// includesFile returns whether the given file is to be loaded according to OnlyFiles.
+func (o *Options) includesFile(path string) bool {
+	if o.onlyFiles == nil {
+		return true
+	}
+	absPath, err := filepath.Abs(path)
+	return err == nil && o.onlyFiles.Contains(absPath)
+}
+
+// includesDir returns whether any file in the given directory, or below it, is to be loaded according to OnlyFiles.
+func (o *Options) includesDir(dir string) bool {
+	if o.onlyFiles == nil {
+		return true
+	}
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return false
+	}
+	for file := range o.onlyFiles {
+		if strings.HasPrefix(file, absDir+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// LocalPaths returns the files and directories on the local filesystem that CreateContexts would load objects from for
+// the given arguments, for example to watch them for changes. Standard input, URLs and OCI references are skipped, and
+// glob patterns are replaced by the directory that they are matched in.
+func LocalPaths(filesOrDirs ...string) []string {
+	var paths []string
+	seen := make(map[string]bool)
+	for _, fileOrDir := range filesOrDirs {
+		if fileOrDir == StdinArg || isURL(fileOrDir) || isOCIReference(fileOrDir) {
+			continue
+		}
+		path := filepath.Clean(fileOrDir)
+		if hasGlobMeta(fileOrDir) {
+			if _, err := os.Lstat(fileOrDir); os.IsNotExist(err) {
+				path = filepath.FromSlash(globBase(fileOrDir))
+			}
+		}
+		if !seen[path] {
+			seen[path] = true
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// IsLocalFilePath returns whether the given file path, recorded in the metadata of an object that CreateContexts loaded
+// from the given arguments, names a file on the local filesystem. The paths of objects read from standard input,
+// fetched from URLs, rendered from Helm charts pulled from OCI registries or listed from a cluster do not.
+func IsLocalFilePath(filePath string, filesOrDirs ...string) bool {
+	if filePath == StdinFilePath || strings.HasPrefix(filePath, ClusterFilePathPrefix) || isURL(filePath) {
+		return false
+	}
+	for _, fileOrDir := range filesOrDirs {
+		if !isOCIReference(fileOrDir) {
+			continue
+		}
+		// The paths of objects rendered from the chart start with the reference, without its scheme.
+		chartPath := filepath.FromSlash(strings.TrimPrefix(fileOrDir, ociScheme))
+		if filePath == chartPath || strings.HasPrefix(filePath, chartPath+string(filepath.Separator)) {
+			return false
+		}
+	}
+	return true
+}
+
+// CreateContextsFromReader creates a context from a reader of a stream of Kube YAML documents, for example a string
+// of YAML held in memory. The given file path is recorded in the metadata of every object.
+func CreateContextsFromReader(filePath string, reader io.Reader) ([]LintContext, error) {
+	return CreateContextsFromReaderWithOptions(Options{}, filePath, reader)
+}
+
+// CreateContextsFromReaderWithOptions creates a context from a reader of YAML documents with additional Options.
+func CreateContextsFromReaderWithOptions(options Options, filePath string, reader io.Reader) ([]LintContext, error) {
+	ctx := newCtx(options)
+	if err := ctx.loadObjectsFromReader(filePath, reader); err != nil {
+		return nil, errors.Wrapf(err, "loading from %s", filePath)
+	}
+	return []LintContext{ctx}, nil
+}
+
 // CreateContextsFromHelmArchive creates a context from TGZ reader of Helm Chart.
 // Note: although this function is not used in CLI, it is exposed from kube-linter library and therefore should stay.
 // See https://github.com/stackrox/kube-linter/pull/173
@@ -1,19 +1,25 @@
 package lintcontext
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	appsV1 "k8s.io/api/apps/v1"
 )
 
 const (
 	chartTarball    = "../../tests/testdata/mychart-0.1.0.tgz"
 	chartDirectory  = "../../tests/testdata/mychart"
+	umbrellaChart   = "../../tests/testdata/umbrella-chart"
 	renamedTarball  = "../../tests/testdata/my-renamed-chart-0.1.0.tgz"
 	renamedChartDir = "../../tests/testdata/my-renamed-chart"
 	mockPath        = "mock path"
@@ -114,3 +120,361 @@ func checkObjectPaths(t *testing.T, objects []Object, expectedPrefix string) {
 	}
 	assert.ElementsMatchf(t, expectedPaths, actualPaths, "expected and actual template paths don't match")
 }
+
+func TestCreateContextsFromStdin(t *testing.T) {
+	stdin := strings.NewReader(`apiVersion: v1
+kind: Service
+metadata:
+  name: first
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: second
+`)
+	lintCtxs, err := CreateContextsWithOptions(Options{Stdin: stdin}, StdinArg, chartDirectory, StdinArg)
+	require.NoError(t, err)
+	require.Len(t, lintCtxs, 2)
+
+	var stdinObjects []Object
+	for _, lintCtx := range lintCtxs {
+		for _, obj := range lintCtx.Objects() {
+			if obj.Metadata.FilePath == StdinFilePath {
+				stdinObjects = append(stdinObjects, obj)
+			}
+		}
+	}
+	require.Len(t, stdinObjects, 2)
+	assert.Equal(t, "first", stdinObjects[0].K8sObject.GetName())
+	assert.Equal(t, "second", stdinObjects[1].K8sObject.GetName())
+}
+
+func TestCreateContextsFromEmptyStdin(t *testing.T) {
+	lintCtxs, err := CreateContextsWithOptions(Options{Stdin: strings.NewReader("")}, StdinArg)
+	require.NoError(t, err)
+	require.Len(t, lintCtxs, 1)
+	assert.Empty(t, lintCtxs[0].Objects())
+	assert.Empty(t, lintCtxs[0].InvalidObjects())
+}
+
+func TestCreateContextsKeepsTheValidDocumentsOfMalformedFiles(t *testing.T) {
+	manifests := `apiVersion: v1
+kind: Service
+metadata:
+  name: first
+--- kind: Service
+metadata:
+  name: broken-separator
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: [unterminated
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: second
+`
+	file := filepath.Join(t.TempDir(), "manifests.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(manifests), 0644))
+
+	lintCtxs, err := CreateContexts(file)
+	require.NoError(t, err)
+	require.Len(t, lintCtxs, 1)
+
+	objects := lintCtxs[0].Objects()
+	require.Len(t, objects, 2)
+	assert.Equal(t, "first", objects[0].K8sObject.GetName())
+	assert.Equal(t, "second", objects[1].K8sObject.GetName())
+	assert.Equal(t, 14, objects[1].Metadata.Line)
+
+	invalidObjects := lintCtxs[0].InvalidObjects()
+	require.Len(t, invalidObjects, 2)
+	assert.Equal(t, 5, invalidObjects[0].Metadata.Line)
+	assert.EqualError(t, invalidObjects[0].LoadErr, "invalid Yaml document separator: kind: Service")
+	assert.Equal(t, 9, invalidObjects[1].Metadata.Line)
+	assert.Error(t, invalidObjects[1].LoadErr)
+}
+
+func TestCreateContextsFromKustomization(t *testing.T) {
+	const kustomizeDir = "../../tests/testdata/kustomize"
+
+	t.Run("overlay", func(t *testing.T) {
+		overlayDir := filepath.Join(kustomizeDir, "overlay")
+		lintCtxs, err := CreateContexts(overlayDir)
+		require.NoError(t, err)
+		lintCtx := verifyAndGetContext(t, lintCtxs)
+
+		pathsByName := make(map[string]string)
+		for _, obj := range lintCtx.Objects() {
+			assert.Equal(t, "prod", obj.K8sObject.GetNamespace())
+			pathsByName[obj.K8sObject.GetObjectKind().GroupVersionKind().Kind+"/"+obj.K8sObject.GetName()] = obj.Metadata.FilePath
+		}
+		assert.Len(t, pathsByName, 3)
+		assert.Equal(t, filepath.Join(kustomizeDir, "base", "deployment.yaml"), pathsByName["Deployment/prod-app"])
+		assert.Equal(t, filepath.Join(kustomizeDir, "base", "service.yaml"), pathsByName["Service/prod-app"])
+		// Generated resources have no file of their own, so they are attributed to the kustomization.
+		for name, path := range pathsByName {
+			if strings.HasPrefix(name, "ConfigMap/") {
+				assert.Equal(t, filepath.Join(overlayDir, "kustomization.yml"), path)
+			}
+		}
+	})
+
+	t.Run("broken", func(t *testing.T) {
+		lintCtxs, err := CreateContexts(filepath.Join(kustomizeDir, "broken"))
+		require.NoError(t, err)
+		require.Len(t, lintCtxs, 1)
+		assert.Empty(t, lintCtxs[0].Objects())
+		require.Len(t, lintCtxs[0].InvalidObjects(), 1)
+		assert.Equal(t, filepath.Join(kustomizeDir, "broken", "kustomization.yaml"), lintCtxs[0].InvalidObjects()[0].Metadata.FilePath)
+	})
+}
+
+// writeManifestTree writes a Service manifest named after each of the given paths, relative to a new temporary
+// directory, and returns that directory.
+func writeManifestTree(t *testing.T, paths ...string) string {
+	root := t.TempDir()
+	for _, p := range paths {
+		fullPath := filepath.Join(root, filepath.FromSlash(p))
+		require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0755))
+		manifest := fmt.Sprintf("apiVersion: v1\nkind: Service\nmetadata:\n  name: %s\n", strings.NewReplacer("/", "-", ".", "-").Replace(p))
+		require.NoError(t, os.WriteFile(fullPath, []byte(manifest), 0644))
+	}
+	return root
+}
+
+func objectFilePaths(t *testing.T, root string, lintCtxs []LintContext) []string {
+	var paths []string
+	for _, lintCtx := range lintCtxs {
+		for _, obj := range lintCtx.Objects() {
+			rel, err := filepath.Rel(root, obj.Metadata.FilePath)
+			require.NoError(t, err)
+			paths = append(paths, filepath.ToSlash(rel))
+		}
+	}
+	return paths
+}
+
+func TestCreateContextsExpandsGlobs(t *testing.T) {
+	root := writeManifestTree(t, "top.yaml", "a/one.yaml", "a/b/two.yml", "a/b/notes.txt", "vendor/three.yaml", "a/vendor/four.yaml")
+
+	lintCtxs, err := CreateContexts(filepath.Join(root, "**", "*.yaml"))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"top.yaml", "a/one.yaml", "vendor/three.yaml", "a/vendor/four.yaml"}, objectFilePaths(t, root, lintCtxs))
+
+	lintCtxs, err = CreateContextsWithOptions(Options{Exclude: []string{"vendor"}}, filepath.Join(root, "a", "**", "*.y*ml"))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"a/one.yaml", "a/b/two.yml"}, objectFilePaths(t, root, lintCtxs))
+
+	_, err = CreateContexts(filepath.Join(root, "**", "*.json"))
+	assert.Error(t, err)
+}
+
+func TestLocalPaths(t *testing.T) {
+	root := writeManifestTree(t, "top.yaml", "a/one.yaml")
+
+	paths := LocalPaths(
+		StdinArg,
+		"https://example.com/deployment.yaml",
+		"oci://registry.example.com/charts/chart:1.0.0",
+		filepath.Join(root, "top.yaml"),
+		filepath.Join(root, "a")+"/",
+		filepath.Join(root, "a", "**", "*.yaml"),
+		filepath.Join(root, "top.yaml"),
+	)
+	assert.Equal(t, []string{filepath.Join(root, "top.yaml"), filepath.Join(root, "a")}, paths)
+}
+
+func TestIsLocalFilePath(t *testing.T) {
+	args := []string{"manifests", "oci://registry.example.com/charts/chart:1.0.0"}
+
+	assert.True(t, IsLocalFilePath(filepath.Join("manifests", "app.yaml"), args...))
+	assert.True(t, IsLocalFilePath("/abs/app.yaml", args...))
+	assert.True(t, IsLocalFilePath(filepath.Join("registry.example.com", "charts", "other.yaml"), args...))
+	assert.False(t, IsLocalFilePath(StdinFilePath, args...))
+	assert.False(t, IsLocalFilePath(ClusterFilePathPrefix+"default/Deployment/app", args...))
+	assert.False(t, IsLocalFilePath("https://example.com/deployment.yaml", args...))
+	assert.False(t, IsLocalFilePath(filepath.Join("registry.example.com", "charts", "chart:1.0.0", "templates", "app.yaml"), args...))
+}
+
+func TestCreateContextsWalksDirectories(t *testing.T) {
+	root := writeManifestTree(t, "top.yaml", "a/one.yaml", "a/b/two.yml", "a/b/notes.txt", "a/vendor/three.yaml", "a/b/deployment.generated.yaml")
+
+	lintCtxs, err := CreateContextsWithOptions(Options{Exclude: []string{"vendor", "*.generated.yaml"}}, root)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"top.yaml", "a/one.yaml", "a/b/two.yml"}, objectFilePaths(t, root, lintCtxs))
+
+	_, err = CreateContextsWithOptions(Options{Exclude: []string{"[vendor"}}, root)
+	assert.Error(t, err)
+}
+
+func TestCreateContextsWithOnlyFiles(t *testing.T) {
+	root := writeManifestTree(t, "top.yaml", "a/one.yaml", "a/b/two.yml")
+
+	lintCtxs, err := CreateContextsWithOptions(Options{OnlyFiles: []string{filepath.Join(root, "a", "one.yaml"), filepath.Join(root, "deleted.yaml")}}, root)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"a/one.yaml"}, objectFilePaths(t, root, lintCtxs))
+
+	// Helm charts are rendered as a whole if any of their files changed.
+	lintCtxs, err = CreateContextsWithOptions(Options{OnlyFiles: []string{filepath.Join(chartDirectory, "values.yaml")}}, chartDirectory)
+	require.NoError(t, err)
+	require.Len(t, lintCtxs, 1)
+	assert.NotEmpty(t, lintCtxs[0].Objects())
+
+	lintCtxs, err = CreateContextsWithOptions(Options{OnlyFiles: []string{}}, root, chartDirectory)
+	require.NoError(t, err)
+	assert.Empty(t, lintCtxs)
+}
+
+func TestCreateContextsFollowsSymlinksWithoutLooping(t *testing.T) {
+	root := writeManifestTree(t, "a/one.yaml", "b/two.yaml")
+	// A link back up to the root would make a naive walk go on forever.
+	if err := os.Symlink(root, filepath.Join(root, "a", "loop")); err != nil {
+		t.Skipf("symbolic links are not supported: %v", err)
+	}
+	require.NoError(t, os.Symlink(filepath.Join(root, "b"), filepath.Join(root, "a", "linked")))
+
+	lintCtxs, err := CreateContexts(filepath.Join(root, "a"))
+	require.NoError(t, err)
+	paths := objectFilePaths(t, root, lintCtxs)
+	assert.Contains(t, paths, "a/one.yaml")
+	// b is reached through both links, but its files are loaded only once.
+	assert.Len(t, paths, 2)
+}
+
+func deploymentReplicas(t *testing.T, lintCtxs []LintContext) int32 {
+	for _, obj := range verifyAndGetContext(t, lintCtxs).Objects() {
+		if deployment, ok := obj.K8sObject.(*appsV1.Deployment); ok {
+			require.NotNil(t, deployment.Spec.Replicas)
+			return *deployment.Spec.Replicas
+		}
+	}
+	require.FailNow(t, "no deployment rendered")
+	return 0
+}
+
+func TestCreateContextsWithHelmValues(t *testing.T) {
+	valuesDir := t.TempDir()
+	writeValues := func(name, contents string) string {
+		valuesFile := filepath.Join(valuesDir, name)
+		require.NoError(t, os.WriteFile(valuesFile, []byte(contents), 0644))
+		return valuesFile
+	}
+	prodValues := writeValues("prod.yaml", "replicaCount: 3\nimage:\n  tag: \"1.21\"\n")
+	scaleValues := writeValues("scale.yaml", "replicaCount: 5\n")
+
+	for _, chartPath := range []string{chartDirectory, chartTarball} {
+		t.Run(chartPath, func(t *testing.T) {
+			lintCtxs, err := CreateContexts(chartPath)
+			require.NoError(t, err)
+			assert.Equal(t, int32(1), deploymentReplicas(t, lintCtxs))
+
+			// Later files take precedence over earlier ones.
+			lintCtxs, err = CreateContextsWithOptions(Options{HelmValueFiles: []string{prodValues, scaleValues}}, chartPath)
+			require.NoError(t, err)
+			assert.Equal(t, int32(5), deploymentReplicas(t, lintCtxs))
+
+			// --set values take precedence over files, and nested values not set keep their defaults.
+			lintCtxs, err = CreateContextsWithOptions(Options{HelmValueFiles: []string{scaleValues}, HelmSetValues: []string{"replicaCount=7"}}, chartPath)
+			require.NoError(t, err)
+			assert.Equal(t, int32(7), deploymentReplicas(t, lintCtxs))
+			for _, obj := range lintCtxs[0].Objects() {
+				if deployment, ok := obj.K8sObject.(*appsV1.Deployment); ok {
+					assert.Equal(t, "IfNotPresent", string(deployment.Spec.Template.Spec.Containers[0].ImagePullPolicy))
+				}
+			}
+		})
+	}
+}
+
+func TestCreateContextsAttributesHelmSubchartsAndHooks(t *testing.T) {
+	lintCtxs, err := CreateContexts(umbrellaChart)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		"templates/deployment.yaml",
+		"templates/pre-install-job.yaml",
+		// The directory of the subchart is not named after the chart.
+		"charts/db/templates/service.yaml",
+	}, objectFilePaths(t, umbrellaChart, lintCtxs))
+
+	lintCtxs, err = CreateContextsWithOptions(Options{SkipHelmHooks: true}, umbrellaChart)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"templates/deployment.yaml", "charts/db/templates/service.yaml"}, objectFilePaths(t, umbrellaChart, lintCtxs))
+}
+
+func TestCreateContextsAttributesArchivedHelmSubcharts(t *testing.T) {
+	chartDir := filepath.Join(t.TempDir(), "umbrella")
+	require.NoError(t, os.MkdirAll(filepath.Join(chartDir, "charts"), 0755))
+	for _, file := range []string{"Chart.yaml", "values.yaml", "templates/deployment.yaml"} {
+		contents, err := os.ReadFile(filepath.Join(umbrellaChart, file))
+		require.NoError(t, err)
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(chartDir, file)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(chartDir, file), contents, 0644))
+	}
+	subchart, err := loader.Load(filepath.Join(umbrellaChart, "charts", "db"))
+	require.NoError(t, err)
+	_, err = chartutil.Save(subchart, filepath.Join(chartDir, "charts"))
+	require.NoError(t, err)
+
+	lintCtxs, err := CreateContexts(chartDir)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		"templates/deployment.yaml",
+		"charts/database-0.1.0.tgz/database/templates/service.yaml",
+	}, objectFilePaths(t, chartDir, lintCtxs))
+}
+
+func TestCreateContextsWithInvalidHelmValues(t *testing.T) {
+	_, err := CreateContextsWithOptions(Options{HelmSetValues: []string{"replicaCount"}}, chartDirectory)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed parsing --set data")
+
+	_, err = CreateContextsWithOptions(Options{HelmValueFiles: []string{filepath.Join(t.TempDir(), "missing.yaml")}}, chartDirectory)
+	assert.Error(t, err)
+}
+
+func TestCreateContextsRecordsHelmRenderErrors(t *testing.T) {
+	writeChart := func(dir, template string) {
+		files := map[string]string{
+			"Chart.yaml":               "apiVersion: v2\nname: broken\nversion: 0.1.0\n",
+			"values.yaml":              "name: app\n",
+			"templates/configmap.yaml": template,
+		}
+		for name, contents := range files {
+			require.NoError(t, os.MkdirAll(filepath.Join(dir, filepath.Dir(name)), 0755))
+			require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(contents), 0644))
+		}
+	}
+	root := t.TempDir()
+	parseErrorChart := filepath.Join(root, "parse-error")
+	writeChart(parseErrorChart, "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{ notAFunction .Values.name }}\n")
+	execErrorChart := filepath.Join(root, "exec-error")
+	writeChart(execErrorChart, "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: app\ndata:\n  key: {{ .Values.missing.key }}\n")
+
+	for _, testCase := range []struct {
+		chart string
+		line  int
+	}{
+		{chart: parseErrorChart, line: 4},
+		{chart: execErrorChart, line: 6},
+	} {
+		t.Run(filepath.Base(testCase.chart), func(t *testing.T) {
+			lintCtxs, err := CreateContexts(testCase.chart)
+			require.NoError(t, err)
+			require.Len(t, lintCtxs, 1)
+			assert.Empty(t, lintCtxs[0].Objects())
+			require.Len(t, lintCtxs[0].InvalidObjects(), 1)
+
+			invalidObj := lintCtxs[0].InvalidObjects()[0]
+			templatePath := filepath.Join(testCase.chart, "templates", "configmap.yaml")
+			assert.Equal(t, templatePath, invalidObj.Metadata.FilePath)
+			var renderErr *HelmRenderError
+			require.True(t, errors.As(invalidObj.LoadErr, &renderErr))
+			assert.Equal(t, testCase.chart, renderErr.Chart)
+			assert.Equal(t, templatePath, renderErr.FilePath)
+			assert.Equal(t, testCase.line, renderErr.Line)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package lintcontext
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.stackrox.io/kube-linter/pkg/extract"
+	"golang.stackrox.io/kube-linter/pkg/objectkinds"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	customObjectKindsDirectory = "../../tests/testdata/custom-object-kinds"
+)
+
+func TestCreateContextsWithCustomObjectKind(t *testing.T) {
+	require.NoError(t, objectkinds.RegisterCustomObjectKind(objectkinds.CustomObjectKind{
+		GroupVersionKind: schema.GroupVersionKind{Group: "platform.example.com", Kind: "WebApp"},
+		PodTemplatePath:  ".spec.template",
+	}))
+
+	lintCtxs, err := CreateContexts(filepath.Join(customObjectKindsDirectory, "webapp.yaml"))
+	require.NoError(t, err)
+	require.Len(t, lintCtxs, 1)
+	lintCtx := lintCtxs[0]
+
+	objects := lintCtx.Objects()
+	require.Len(t, objects, 1)
+	webApp := objects[0].K8sObject
+	assert.Equal(t, "storefront", webApp.GetName())
+	deploymentLike, err := objectkinds.ConstructMatcher(objectkinds.DeploymentLike)
+	require.NoError(t, err)
+	assert.True(t, deploymentLike.Matches(webApp.GetObjectKind().GroupVersionKind()))
+	podSpec, found := extract.PodSpec(webApp)
+	require.True(t, found)
+	require.Len(t, podSpec.AllContainers(), 1)
+	assert.Equal(t, "storefront:latest", podSpec.AllContainers()[0].Image)
+
+	// The Database kind is not registered, so it cannot be decoded.
+	require.Len(t, lintCtx.InvalidObjects(), 1)
+	assert.Contains(t, lintCtx.InvalidObjects()[0].LoadErr.Error(), `no kind "Database" is registered`)
+}
+
+func TestCreateContextsWithInvalidCustomObjectPodTemplate(t *testing.T) {
+	require.NoError(t, objectkinds.RegisterCustomObjectKind(objectkinds.CustomObjectKind{
+		GroupVersionKind: schema.GroupVersionKind{Group: "platform.example.com", Version: "v1", Kind: "Worker"},
+		PodTemplatePath:  "{.spec.template}",
+	}))
+	dir := t.TempDir()
+	manifest := `apiVersion: platform.example.com/v1
+kind: Worker
+metadata:
+  name: worker
+spec:
+  template:
+    spec:
+      containers: not-a-list
+---
+apiVersion: platform.example.com/v1
+kind: Worker
+metadata:
+  name: idle-worker
+spec: {}
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "worker.yaml"), []byte(manifest), 0644))
+
+	lintCtxs, err := CreateContexts(dir)
+	require.NoError(t, err)
+	require.Len(t, lintCtxs, 1)
+	lintCtx := lintCtxs[0]
+
+	// Objects without a pod template at the path are still loaded, they just do not have a pod spec.
+	require.Len(t, lintCtx.Objects(), 1)
+	_, found := extract.PodSpec(lintCtx.Objects()[0].K8sObject)
+	assert.False(t, found)
+	require.Len(t, lintCtx.InvalidObjects(), 1)
+	assert.Contains(t, lintCtx.InvalidObjects()[0].LoadErr.Error(), "decoding pod template at {.spec.template}")
+}
@@ -0,0 +1,76 @@
+package lintcontext
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultURLFetchTimeout is the timeout used to fetch remote manifests if none is specified.
+	DefaultURLFetchTimeout = 30 * time.Second
+
+	// maxRedirects is the number of redirects we follow when fetching a remote manifest.
+	maxRedirects = 10
+)
+
+// isURL returns whether the given argument should be fetched over HTTP(S), rather than read from the filesystem.
+func isURL(fileOrDir string) bool {
+	return strings.HasPrefix(fileOrDir, "http://") || strings.HasPrefix(fileOrDir, "https://")
+}
+
+func newHTTPClient(timeout time.Duration) *http.Client {
+	if timeout <= 0 {
+		timeout = DefaultURLFetchTimeout
+	}
+	return &http.Client{
+		Timeout: timeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return errors.Errorf("stopped after %d redirects", maxRedirects)
+			}
+			return nil
+		},
+	}
+}
+
+// fetchURL downloads the content at the given URL.
+func fetchURL(client *http.Client, url string) ([]byte, error) {
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetching manifest")
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, errors.Errorf("fetching manifest: unexpected HTTP status %s", resp.Status)
+	}
+	data, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxFileSizeBytes+1))
+	if err != nil {
+		return nil, errors.Wrap(err, "reading manifest")
+	}
+	if len(data) > maxFileSizeBytes {
+		return nil, errors.Errorf("manifest is larger than the maximum of %d bytes", maxFileSizeBytes)
+	}
+	return data, nil
+}
+
+// loadObjectsFromURL fetches the manifest at the given URL, and loads the objects in it, using the URL as their file
+// path. Failures to fetch the manifest are recorded as an invalid object, so that they do not abort the whole run.
+func (l *lintContextImpl) loadObjectsFromURL(client *http.Client, url string) error {
+	data, err := fetchURL(client, url)
+	if err != nil {
+		l.addInvalidObjects(InvalidObject{
+			Metadata: ObjectMetadata{FilePath: url},
+			LoadErr:  err,
+		})
+		return nil
+	}
+	return l.loadObjectsFromReader(url, bytes.NewReader(data))
+}
@@ -0,0 +1,66 @@
+package lintcontext
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const serviceYAML = `apiVersion: v1
+kind: Service
+metadata:
+  name: my-service
+`
+
+func TestCreateContextsFromURL(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/service.yaml", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(serviceYAML))
+	})
+	mux.HandleFunc("/redirect", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/service.yaml", http.StatusFound)
+	})
+	mux.HandleFunc("/loop", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/loop", http.StatusFound)
+	})
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(time.Second)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	for _, testCase := range []struct {
+		path        string
+		expectValid bool
+	}{
+		{path: "/service.yaml", expectValid: true},
+		{path: "/redirect", expectValid: true},
+		{path: "/loop"},
+		{path: "/missing"},
+		{path: "/slow"},
+	} {
+		c := testCase
+		t.Run(c.path, func(t *testing.T) {
+			url := server.URL + c.path
+			lintCtxs, err := CreateContextsWithOptions(Options{URLFetchTimeout: 100 * time.Millisecond}, url)
+			require.NoError(t, err)
+			require.Len(t, lintCtxs, 1)
+			lintCtx := lintCtxs[0]
+			if c.expectValid {
+				require.Len(t, lintCtx.Objects(), 1)
+				assert.Empty(t, lintCtx.InvalidObjects())
+				assert.Equal(t, url, lintCtx.Objects()[0].Metadata.FilePath)
+				assert.Equal(t, "my-service", lintCtx.Objects()[0].K8sObject.GetName())
+			} else {
+				assert.Empty(t, lintCtx.Objects())
+				require.Len(t, lintCtx.InvalidObjects(), 1)
+				assert.Equal(t, url, lintCtx.InvalidObjects()[0].Metadata.FilePath)
+				assert.Error(t, lintCtx.InvalidObjects()[0].LoadErr)
+			}
+		})
+	}
+}
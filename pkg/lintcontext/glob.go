@@ -0,0 +1,153 @@
+package lintcontext
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	globstar = "**"
+)
+
+// hasGlobMeta returns whether the given path contains any of the characters that have a special meaning in glob
+// patterns.
+func hasGlobMeta(p string) bool {
+	return strings.ContainsAny(p, "*?[")
+}
+
+// splitGlob splits a glob pattern into its slash separated segments, after cleaning it.
+func splitGlob(pattern string) []string {
+	return strings.Split(path.Clean(filepath.ToSlash(pattern)), "/")
+}
+
+// validateGlob returns an error if the given glob pattern is malformed.
+func validateGlob(pattern string) error {
+	for _, segment := range splitGlob(pattern) {
+		if _, err := path.Match(segment, ""); err != nil {
+			return errors.Errorf("invalid glob pattern %q", pattern)
+		}
+	}
+	return nil
+}
+
+// matchGlobSegments returns whether the given path segments match the given pattern segments.
+// A "**" segment matches any number of path segments, including none, unless it is the last one. The other segments are matched like
+// path.Match does, so "*" never matches across a slash.
+func matchGlobSegments(patternSegments, pathSegments []string) bool {
+	for len(patternSegments) > 0 {
+		if patternSegments[0] == globstar {
+			// Collapse consecutive globstars, they match the same paths as a single one.
+			for len(patternSegments) > 0 && patternSegments[0] == globstar {
+				patternSegments = patternSegments[1:]
+			}
+			if len(patternSegments) == 0 {
+				// Like in .gitignore files, a trailing "**" matches everything inside, but not the directory itself.
+				return len(pathSegments) > 0
+			}
+			for i := range pathSegments {
+				if matchGlobSegments(patternSegments, pathSegments[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(pathSegments) == 0 {
+			return false
+		}
+		if matched, _ := path.Match(patternSegments[0], pathSegments[0]); !matched {
+			return false
+		}
+		patternSegments, pathSegments = patternSegments[1:], pathSegments[1:]
+	}
+	return len(pathSegments) == 0
+}
+
+// matchGlob returns whether the given path matches the given glob pattern, which can contain "**" segments.
+func matchGlob(pattern, p string) bool {
+	return matchGlobSegments(splitGlob(pattern), splitGlob(p))
+}
+
+// isExcluded returns whether the given path matches any of the given exclude patterns.
+// The patterns are matched against the path and each of its trailing parts, so that "vendor" excludes every
+// file or directory that is named vendor, and "charts/*/tests" excludes the tests of every chart under a charts
+// directory, wherever they are in the tree.
+func isExcluded(p string, excludePatterns []string) bool {
+	if len(excludePatterns) == 0 {
+		return false
+	}
+	pathSegments := splitGlob(p)
+	for _, pattern := range excludePatterns {
+		patternSegments := splitGlob(pattern)
+		for i := range pathSegments {
+			if matchGlobSegments(patternSegments, pathSegments[i:]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// globBase returns the directory that all paths matching the given glob pattern are in, the longest prefix of the
+// pattern without glob characters, using slashes as separators.
+func globBase(pattern string) string {
+	var baseSegments []string
+	for _, segment := range splitGlob(pattern) {
+		if hasGlobMeta(segment) {
+			break
+		}
+		baseSegments = append(baseSegments, segment)
+	}
+	base := strings.Join(baseSegments, "/")
+	switch {
+	case len(baseSegments) == 0:
+		base = "."
+	case base == "":
+		// The pattern is an absolute path with a glob in its first segment.
+		base = "/"
+	}
+	return base
+}
+
+// expandGlob returns the files that match the given glob pattern, in lexical order.
+// Unlike filepath.Glob, "**" segments match any number of directories, since the shell does not expand them
+// everywhere. Symbolic links to directories are followed, and files and directories matching any of the
+// exclude patterns, or ignored by an ignore file, are skipped.
+func expandGlob(pattern string, excludePatterns []string) ([]string, error) {
+	patternSegments := splitGlob(pattern)
+	// Only walk the part of the tree that can match.
+	base := globBase(pattern)
+
+	var matches []string
+	ignores := newIgnoreTracker(filepath.FromSlash(base))
+	err := walkFollowingSymlinks(filepath.FromSlash(base), func(currentPath string, info os.FileInfo, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		ignored, err := ignores.isIgnored(currentPath, info)
+		if err != nil {
+			return err
+		}
+		if ignored || isExcluded(currentPath, excludePatterns) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !info.IsDir() && matchGlobSegments(patternSegments, splitGlob(currentPath)) {
+			matches = append(matches, currentPath)
+		}
+		return nil
+	})
+	// A base that does not exist simply means that nothing matches.
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if len(matches) == 0 {
+		return nil, errors.Errorf("no files match pattern %q", pattern)
+	}
+	return matches, nil
+}
@@ -0,0 +1,53 @@
+package lintcontext
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchGlob(t *testing.T) {
+	for _, testCase := range []struct {
+		pattern string
+		path    string
+		matches bool
+	}{
+		{pattern: "*.yaml", path: "deployment.yaml", matches: true},
+		{pattern: "*.yaml", path: "dir/deployment.yaml", matches: false},
+		{pattern: "./manifests/*.yaml", path: "manifests/deployment.yaml", matches: true},
+		{pattern: "manifests/**/*.yaml", path: "manifests/deployment.yaml", matches: true},
+		{pattern: "manifests/**/*.yaml", path: "manifests/a/b/deployment.yaml", matches: true},
+		{pattern: "manifests/**/*.yaml", path: "other/a/deployment.yaml", matches: false},
+		{pattern: "manifests/**/**/*.yml", path: "manifests/a/deployment.yml", matches: true},
+		{pattern: "manifests/**", path: "manifests/a/b/c", matches: true},
+		{pattern: "manifests/**", path: "manifests", matches: false},
+		{pattern: "/abs/**/*.yaml", path: "/abs/x/deployment.yaml", matches: true},
+		{pattern: "/abs/**/*.yaml", path: "abs/x/deployment.yaml", matches: false},
+		{pattern: "manifests/dep?.yaml", path: "manifests/dep1.yaml", matches: true},
+		{pattern: "manifests/[ab].yaml", path: "manifests/c.yaml", matches: false},
+	} {
+		assert.Equal(t, testCase.matches, matchGlob(testCase.pattern, testCase.path), "pattern %q, path %q", testCase.pattern, testCase.path)
+	}
+}
+
+func TestIsExcluded(t *testing.T) {
+	for _, testCase := range []struct {
+		path     string
+		excluded bool
+	}{
+		{path: "manifests/vendor", excluded: true},
+		{path: "vendor", excluded: true},
+		{path: "manifests/vendored", excluded: false},
+		{path: "manifests/app/deployment.generated.yaml", excluded: true},
+		{path: "manifests/charts/app/tests", excluded: true},
+		{path: "manifests/charts/app/templates", excluded: false},
+	} {
+		assert.Equal(t, testCase.excluded, isExcluded(testCase.path, []string{"vendor", "*.generated.yaml", "charts/*/tests"}), "path %q", testCase.path)
+	}
+	assert.False(t, isExcluded("vendor", nil))
+}
+
+func TestValidateGlob(t *testing.T) {
+	assert.NoError(t, validateGlob("manifests/**/*.yaml"))
+	assert.Error(t, validateGlob("manifests/[.yaml"))
+}
@@ -0,0 +1,102 @@
+package lintcontext
+
+import (
+	"io/ioutil"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+)
+
+const (
+	helmChartsDir = "charts"
+	// helmHookAnnotation is the annotation that makes Helm run an object as a hook instead of installing it with
+	// the rest of the release.
+	helmHookAnnotation = "helm.sh/hook"
+)
+
+// subchartLocations returns where the subcharts of the chart in the given directory are, by the path that Helm
+// renders their templates under, relative to the chart and using slashes as separators.
+// Helm names subcharts after the name in their Chart.yaml, which can differ from the name of their directory in
+// charts/, and subcharts can also be archives, in which case their templates are located within the archive, as
+// <archive>/<chart name>/templates/..., like for charts that are archives themselves.
+func subchartLocations(dir string) map[string]string {
+	locations := make(map[string]string)
+	addSubchartLocations(locations, dir, "", "")
+	return locations
+}
+
+func addSubchartLocations(locations map[string]string, dir, renderedPrefix, locationPrefix string) {
+	entries, err := ioutil.ReadDir(filepath.Join(dir, helmChartsDir))
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		// Helm ignores the entries of charts/ that start with an underscore or a dot.
+		if strings.IndexAny(entry.Name(), "_.") == 0 {
+			continue
+		}
+		entryPath := filepath.Join(dir, helmChartsDir, entry.Name())
+		location := path.Join(locationPrefix, helmChartsDir, entry.Name())
+		if entry.IsDir() {
+			metadata, err := chartutil.LoadChartfile(filepath.Join(entryPath, chartutil.ChartfileName))
+			if err != nil {
+				continue
+			}
+			rendered := path.Join(renderedPrefix, helmChartsDir, metadata.Name)
+			locations[rendered] = location
+			addSubchartLocations(locations, entryPath, rendered, location)
+			continue
+		}
+		if filepath.Ext(entry.Name()) != ".tgz" {
+			continue
+		}
+		subchart, err := loader.Load(entryPath)
+		if err != nil {
+			continue
+		}
+		locations[path.Join(renderedPrefix, helmChartsDir, subchart.Name())] = path.Join(location, subchart.Name())
+	}
+}
+
+// relocateSubchartTemplates rewrites the paths of the templates that were rendered from subcharts, relative to the
+// chart, to where the templates really are, according to the given subchart locations.
+func relocateSubchartTemplates(renderedFiles map[string]string, locations map[string]string) map[string]string {
+	if len(locations) == 0 {
+		return renderedFiles
+	}
+	relocated := make(map[string]string, len(renderedFiles))
+	for file, contents := range renderedFiles {
+		relocated[relocateSubchartTemplate(file, locations)] = contents
+	}
+	return relocated
+}
+
+// relocateSubchartTemplate rewrites the path of a single template like relocateSubchartTemplates does.
+func relocateSubchartTemplate(file string, locations map[string]string) string {
+	slashFile := filepath.ToSlash(file)
+	// The longest matching prefix is the most nested subchart that the template is in.
+	longestPrefix := ""
+	for prefix := range locations {
+		if len(prefix) > len(longestPrefix) && strings.HasPrefix(slashFile, prefix+"/") {
+			longestPrefix = prefix
+		}
+	}
+	if longestPrefix == "" {
+		return file
+	}
+	return filepath.FromSlash(locations[longestPrefix] + strings.TrimPrefix(slashFile, longestPrefix))
+}
+
+// withoutHelmHooks returns the given objects without the ones from the given index on that are Helm hooks.
+func withoutHelmHooks(objs []Object, from int) []Object {
+	kept := objs[:from]
+	for _, obj := range objs[from:] {
+		if _, isHook := obj.K8sObject.GetAnnotations()[helmHookAnnotation]; !isHook {
+			kept = append(kept, obj)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,65 @@
+package lintcontext
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// Helm reports the template that failed as "template: <file>:<line>:<column>: ..." when executing it fails,
+	// and as "... at (<file>:<line>[:<column>]): ..." when parsing it fails, or when it calls fail or required.
+	helmErrorLocationRegexes = []*regexp.Regexp{
+		regexp.MustCompile(`template: ([^\s:]+):(\d+)`),
+		regexp.MustCompile(`at \(([^\s():]+):(\d+)[:)]`),
+	}
+)
+
+// HelmRenderError is the load error of a Helm chart that could not be rendered.
+type HelmRenderError struct {
+	// Chart is the path of the chart, as the objects rendered from it would be reported with.
+	Chart string
+	// FilePath is the path of the template that failed to render, as the objects rendered from it would be reported
+	// with. It is empty if it is not known.
+	FilePath string
+	// Line is the line of the template that failed to render, or 0 if it is not known.
+	Line int
+	// Err is the error that Helm returned.
+	Err error
+}
+
+func (e *HelmRenderError) Error() string {
+	return fmt.Sprintf("failed to render: %v", e.Err)
+}
+
+// Unwrap returns the error that Helm returned.
+func (e *HelmRenderError) Unwrap() error {
+	return e.Err
+}
+
+// locate fills in the chart path, and the file path and line of the template that failed to render, from the
+// error that Helm returned, if it contains them.
+// Helm reports templates by the name of the chart followed by their path within the chart. If stripChartName is
+// set, the name of the chart is stripped from it, and templates of subcharts are relocated to where they really are,
+// like when objects from chart directories are loaded.
+func (e *HelmRenderError) locate(chartPath string, stripChartName bool) {
+	e.Chart = chartPath
+	for _, re := range helmErrorLocationRegexes {
+		match := re.FindStringSubmatch(e.Err.Error())
+		if match == nil {
+			continue
+		}
+		templatePath := match[1]
+		if stripChartName {
+			if idx := strings.Index(templatePath, "/"); idx >= 0 {
+				templatePath = templatePath[idx+1:]
+			}
+			templatePath = relocateSubchartTemplate(templatePath, subchartLocations(chartPath))
+		}
+		e.FilePath = filepath.Join(chartPath, filepath.FromSlash(templatePath))
+		e.Line, _ = strconv.Atoi(match[2])
+		return
+	}
+}
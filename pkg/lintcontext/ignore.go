@@ -0,0 +1,136 @@
+package lintcontext
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// IgnoreFileName is the name of the files that exclude paths from linting, with patterns like .gitignore files.
+	IgnoreFileName = ".kubelinterignore"
+)
+
+// ignoreRule is a single pattern from an ignore file.
+type ignoreRule struct {
+	// dir is the directory of the ignore file, which anchored patterns are relative to.
+	dir      string
+	segments []string
+	negate   bool
+	dirOnly  bool
+	anchored bool
+}
+
+// matches returns whether the rule matches the given path, which must be inside the directory of the rule.
+func (r *ignoreRule) matches(p string, isDir bool) bool {
+	if r.dirOnly && !isDir {
+		return false
+	}
+	rel, err := filepath.Rel(r.dir, p)
+	if err != nil {
+		return false
+	}
+	relSegments := splitGlob(rel)
+	if !r.anchored {
+		// Patterns without a slash match the name of a file or directory at any depth.
+		relSegments = relSegments[len(relSegments)-1:]
+	}
+	return matchGlobSegments(r.segments, relSegments)
+}
+
+// parseIgnoreFile parses the ignore file in the given directory, if there is one.
+func parseIgnoreFile(dir string) ([]ignoreRule, error) {
+	ignoreFilePath := filepath.Join(dir, IgnoreFileName)
+	file, err := os.Open(ignoreFilePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "opening %s", ignoreFilePath)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	var rules []ignoreRule
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		rule := ignoreRule{dir: dir}
+		if strings.HasPrefix(line, "!") {
+			rule.negate = true
+			line = line[1:]
+		} else if strings.HasPrefix(line, `\#`) || strings.HasPrefix(line, `\!`) {
+			// A leading backslash escapes the characters that otherwise start comments and negations.
+			line = line[1:]
+		}
+		if strings.HasSuffix(line, "/") {
+			rule.dirOnly = true
+			line = strings.TrimRight(line, "/")
+		}
+		// Patterns with a slash anywhere but at the end are relative to the directory of the ignore file.
+		rule.anchored = strings.Contains(line, "/")
+		line = strings.TrimLeft(line, "/")
+		if line == "" {
+			continue
+		}
+		if err := validateGlob(line); err != nil {
+			return nil, errors.Wrapf(err, "%s:%d", ignoreFilePath, lineNumber)
+		}
+		rule.segments = splitGlob(line)
+		rules = append(rules, rule)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrapf(err, "reading %s", ignoreFilePath)
+	}
+	return rules, nil
+}
+
+// ignoreTracker applies the ignore files found while walking a directory tree.
+// The rules of an ignore file apply to the directory that contains it and everything below. Like in .gitignore
+// files, the last matching rule wins, so rules in deeper directories take precedence over the ones above them, and
+// a path that is ignored can be re-included with a negated rule, unless one of its parent directories is ignored.
+type ignoreTracker struct {
+	root       string
+	rulesByDir map[string][]ignoreRule
+}
+
+func newIgnoreTracker(root string) *ignoreTracker {
+	return &ignoreTracker{root: filepath.Clean(root), rulesByDir: make(map[string][]ignoreRule)}
+}
+
+// isIgnored returns whether the given path is ignored. It must be called for every path that is walked, in the
+// order they are walked, so that the ignore files of the parent directories have been read by the time their
+// contents are checked. The root of the walk is never ignored, since it was asked for explicitly.
+func (t *ignoreTracker) isIgnored(p string, info os.FileInfo) (bool, error) {
+	p = filepath.Clean(p)
+	var rules []ignoreRule
+	if p != t.root {
+		rules = t.rulesByDir[filepath.Dir(p)]
+		ignored := false
+		for i := range rules {
+			if rules[i].matches(p, info.IsDir()) {
+				ignored = !rules[i].negate
+			}
+		}
+		if ignored {
+			return true, nil
+		}
+	}
+	if info.IsDir() {
+		dirRules, err := parseIgnoreFile(p)
+		if err != nil {
+			return false, err
+		}
+		// Copy the rules of the parent, so that siblings do not see each other's rules.
+		allRules := make([]ignoreRule, 0, len(rules)+len(dirRules))
+		t.rulesByDir[p] = append(append(allRules, rules...), dirRules...)
+	}
+	return false, nil
+}
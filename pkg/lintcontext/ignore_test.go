@@ -0,0 +1,72 @@
+package lintcontext
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeIgnoreFile(t *testing.T, dir, contents string) {
+	require.NoError(t, os.WriteFile(filepath.Join(dir, IgnoreFileName), []byte(contents), 0644))
+}
+
+func TestCreateContextsRespectsIgnoreFiles(t *testing.T) {
+	root := writeManifestTree(t,
+		"top.yaml",
+		"generated.yaml",
+		"build/out.yaml",
+		"app/deployment.yaml",
+		"app/generated.yaml",
+		"app/keep/generated.yaml",
+		"app/tests/test.yaml",
+		"app/tests/important.yaml",
+		"app/docs/build/example.yaml",
+		"app/build/out.yaml",
+	)
+	writeIgnoreFile(t, root, `
+# Generated manifests are checked where they are generated.
+generated.yaml
+/build/
+tests/
+`)
+	// Deeper ignore files take precedence, and patterns with a slash are relative to the ignore file.
+	writeIgnoreFile(t, filepath.Join(root, "app"), `
+!keep/generated.yaml
+!tests/
+tests/*.yaml
+!tests/important.yaml
+docs/**
+`)
+
+	lintCtxs, err := CreateContexts(root)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		"top.yaml",
+		"app/deployment.yaml",
+		"app/keep/generated.yaml",
+		"app/tests/important.yaml",
+		// The build directory is only ignored at the top, since the pattern starts with a slash.
+		"app/build/out.yaml",
+	}, objectFilePaths(t, root, lintCtxs))
+
+	lintCtxs, err = CreateContexts(filepath.Join(root, "**", "*.yaml"))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		"top.yaml",
+		"app/deployment.yaml",
+		"app/keep/generated.yaml",
+		"app/tests/important.yaml",
+		"app/build/out.yaml",
+	}, objectFilePaths(t, root, lintCtxs))
+}
+
+func TestCreateContextsRejectsInvalidIgnoreFiles(t *testing.T) {
+	root := writeManifestTree(t, "top.yaml")
+	writeIgnoreFile(t, root, "[invalid\n")
+
+	_, err := CreateContexts(root)
+	assert.Error(t, err)
+}
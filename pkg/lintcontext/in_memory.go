@@ -0,0 +1,55 @@
+package lintcontext
+
+import (
+	"bytes"
+
+	y "github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"golang.stackrox.io/kube-linter/pkg/k8sutil"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+// CreateContextsFromBytes creates a context from a byte slice holding one or more Kube YAML documents.
+// The given file path, which may be synthetic, is recorded in the metadata of every object.
+func CreateContextsFromBytes(filePath string, data []byte) ([]LintContext, error) {
+	return CreateContextsFromReader(filePath, bytes.NewReader(data))
+}
+
+// CreateContextsFromObjects creates a context from objects that have already been decoded.
+// The given file path, which may be synthetic, is recorded in the metadata of every object.
+// The objects are copied, so that they can be modified by the caller afterwards. Objects that
+// do not have their kind set, like typed objects built in code usually do not, get it filled in
+// from the scheme of known types.
+func CreateContextsFromObjects(filePath string, objs ...runtime.Object) ([]LintContext, error) {
+	ctx := newCtx(Options{})
+	for i, obj := range objs {
+		if obj == nil {
+			return nil, errors.Errorf("object %d is nil", i)
+		}
+		obj = obj.DeepCopyObject()
+		if obj.GetObjectKind().GroupVersionKind().Empty() {
+			gvks, _, err := scheme.Scheme.ObjectKinds(obj)
+			if err != nil {
+				return nil, errors.Wrapf(err, "determining kind of object %d", i)
+			}
+			obj.GetObjectKind().SetGroupVersionKind(gvks[0])
+		}
+		k8sObj, ok := obj.(k8sutil.Object)
+		if !ok {
+			return nil, errors.Errorf("object %d of kind %s has no object metadata", i, obj.GetObjectKind().GroupVersionKind())
+		}
+		raw, err := y.Marshal(obj)
+		if err != nil {
+			return nil, errors.Wrapf(err, "serializing object %d", i)
+		}
+		ctx.addObjects(Object{
+			Metadata: ObjectMetadata{
+				FilePath: filePath,
+				Raw:      raw,
+			},
+			K8sObject: k8sObj,
+		})
+	}
+	return []LintContext{ctx}, nil
+}
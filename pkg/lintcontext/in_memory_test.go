@@ -0,0 +1,66 @@
+package lintcontext
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsV1 "k8s.io/api/apps/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestCreateContextsFromBytes(t *testing.T) {
+	data := []byte(`apiVersion: v1
+kind: Service
+metadata:
+  name: my-service
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: my-deployment
+---
+not: a kubernetes object
+`)
+	lintCtxs, err := CreateContextsFromBytes("in-memory.yaml", data)
+	require.NoError(t, err)
+	require.Len(t, lintCtxs, 1)
+
+	objects := lintCtxs[0].Objects()
+	require.Len(t, objects, 2)
+	assert.Equal(t, "my-service", objects[0].K8sObject.GetName())
+	assert.Equal(t, "my-deployment", objects[1].K8sObject.GetName())
+	for _, obj := range objects {
+		assert.Equal(t, "in-memory.yaml", obj.Metadata.FilePath)
+		assert.NotEmpty(t, obj.Metadata.Raw)
+	}
+
+	invalidObjects := lintCtxs[0].InvalidObjects()
+	require.Len(t, invalidObjects, 1)
+	assert.Equal(t, "in-memory.yaml", invalidObjects[0].Metadata.FilePath)
+}
+
+func TestCreateContextsFromObjects(t *testing.T) {
+	// Typed objects built in code usually have no TypeMeta.
+	deployment := &appsV1.Deployment{ObjectMeta: metaV1.ObjectMeta{Name: "my-deployment"}}
+
+	lintCtxs, err := CreateContextsFromObjects("generated/deployment.yaml", deployment)
+	require.NoError(t, err)
+	require.Len(t, lintCtxs, 1)
+
+	objects := lintCtxs[0].Objects()
+	require.Len(t, objects, 1)
+	obj := objects[0]
+	assert.Equal(t, "generated/deployment.yaml", obj.Metadata.FilePath)
+	assert.Contains(t, string(obj.Metadata.Raw), "name: my-deployment")
+	assert.Equal(t, "my-deployment", obj.K8sObject.GetName())
+	assert.Equal(t, schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, obj.K8sObject.GetObjectKind().GroupVersionKind())
+
+	// The object was copied, so the caller's object is untouched.
+	assert.True(t, deployment.GetObjectKind().GroupVersionKind().Empty())
+	assert.NotSame(t, deployment, obj.K8sObject)
+
+	_, err = CreateContextsFromObjects("nil.yaml", nil)
+	assert.Error(t, err)
+}
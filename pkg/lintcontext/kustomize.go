@@ -0,0 +1,156 @@
+package lintcontext
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	y "github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/kustomize/api/filesys"
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/api/provider"
+	"sigs.k8s.io/kustomize/api/resource"
+	"sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/kustomize/kyaml/resid"
+)
+
+// kustomizationFileNames are the names of the files that mark a directory as a Kustomize kustomization.
+var kustomizationFileNames = []string{"kustomization.yaml", "kustomization.yml"}
+
+// getKustomizationFile returns the path to the kustomization file in the given directory, if there is one.
+func getKustomizationFile(dir string) (string, bool) {
+	for _, name := range kustomizationFileNames {
+		path := filepath.Join(dir, name)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, true
+		}
+	}
+	return "", false
+}
+
+// resourceOrigin records the local file that a resource of a kustomization was originally defined in.
+type resourceOrigin struct {
+	id   resid.ResId
+	path string
+}
+
+// loadObjectsFromKustomization renders the kustomization in the given directory, and loads the resulting objects.
+// Objects are attributed to the resource file they were originally defined in where it can be determined, and to
+// the kustomization file otherwise. Rendering errors are recorded as an invalid object.
+func (l *lintContextImpl) loadObjectsFromKustomization(dir, kustomizationFile string) {
+	resMap, err := krusty.MakeKustomizer(krusty.MakeDefaultOptions()).Run(filesys.MakeFsOnDisk(), dir)
+	if err != nil {
+		l.addInvalidObjects(InvalidObject{
+			Metadata: ObjectMetadata{FilePath: kustomizationFile},
+			LoadErr:  errors.Wrap(err, "rendering kustomization"),
+		})
+		return
+	}
+
+	factory := provider.NewDefaultDepProvider().GetResourceFactory()
+	origins := findResourceOrigins(factory, dir, make(map[string]struct{}))
+
+	for _, res := range resMap.Resources() {
+		doc, err := res.AsYAML()
+		if err != nil {
+			l.addInvalidObjects(InvalidObject{
+				Metadata: ObjectMetadata{FilePath: kustomizationFile},
+				LoadErr:  errors.Wrapf(err, "serializing rendered resource %s", res.CurId()),
+			})
+			continue
+		}
+		filePath := kustomizationFile
+		if originPath, found := findOrigin(origins, res.OrgId()); found {
+			filePath = originPath
+		}
+		metadata := ObjectMetadata{
+			FilePath: filePath,
+			Raw:      doc,
+		}
+		objs, err := parseObjects(doc, l.customDecoder)
+		if err != nil {
+			l.addInvalidObjects(InvalidObject{
+				Metadata: metadata,
+				LoadErr:  err,
+			})
+			continue
+		}
+		for _, obj := range objs {
+			l.addObjects(Object{
+				Metadata:  metadata,
+				K8sObject: obj,
+			})
+		}
+	}
+}
+
+// findOrigin returns the path of the file that the rendered resource with the given id was defined in.
+// Kustomize does not keep track of the ids a resource had before it was transformed, so if there is no exact match,
+// we fall back to the only resource of the same kind whose name is part of the rendered name (as is the case when a
+// name prefix or suffix was added), if there is exactly one.
+func findOrigin(origins []resourceOrigin, id resid.ResId) (string, bool) {
+	var candidates []resourceOrigin
+	for _, origin := range origins {
+		if origin.id.Equals(id) {
+			return origin.path, true
+		}
+		if origin.id.Gvk.Equals(id.Gvk) && strings.Contains(id.Name, origin.id.Name) {
+			candidates = append(candidates, origin)
+		}
+	}
+	if len(candidates) == 1 {
+		return candidates[0].path, true
+	}
+	return "", false
+}
+
+// findResourceOrigins collects the ids of the resources defined in the local resource files of the kustomization in
+// the given directory, following local bases recursively. It is best-effort: anything it cannot read is skipped.
+func findResourceOrigins(factory *resource.Factory, dir string, visited map[string]struct{}) []resourceOrigin {
+	if _, ok := visited[dir]; ok {
+		return nil
+	}
+	visited[dir] = struct{}{}
+
+	kustomizationFile, found := getKustomizationFile(dir)
+	if !found {
+		return nil
+	}
+	contents, err := ioutil.ReadFile(kustomizationFile)
+	if err != nil {
+		return nil
+	}
+	var kustomization types.Kustomization
+	if err := y.Unmarshal(contents, &kustomization); err != nil {
+		return nil
+	}
+	kustomization.FixKustomizationPostUnmarshalling()
+
+	var origins []resourceOrigin
+	for _, res := range kustomization.Resources {
+		path := filepath.Join(dir, res)
+		info, err := os.Stat(path)
+		if err != nil {
+			// Most likely a remote resource.
+			continue
+		}
+		if info.IsDir() {
+			origins = append(origins, findResourceOrigins(factory, path, visited)...)
+			continue
+		}
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		resources, err := factory.SliceFromBytes(data)
+		if err != nil {
+			continue
+		}
+		for _, r := range resources {
+			origins = append(origins, resourceOrigin{id: r.CurId(), path: path})
+		}
+	}
+	return origins
+}
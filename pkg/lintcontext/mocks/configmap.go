@@ -0,0 +1,28 @@
+package mocks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AddMockConfigMap adds a mock ConfigMap to LintContext
+func (l *MockLintContext) AddMockConfigMap(t *testing.T, name string) {
+	require.NotEmpty(t, name)
+	l.objects[name] = &v1.ConfigMap{
+		TypeMeta: metaV1.TypeMeta{
+			Kind:       "ConfigMap",
+			APIVersion: v1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metaV1.ObjectMeta{Name: name},
+	}
+}
+
+// ModifyConfigMap modifies a given config map in the context via the passed function.
+func (l *MockLintContext) ModifyConfigMap(t *testing.T, name string, f func(cm *v1.ConfigMap)) {
+	r, ok := l.objects[name].(*v1.ConfigMap)
+	require.True(t, ok)
+	f(r)
+}
@@ -15,3 +15,11 @@ func (l *MockLintContext) AddContainerToDeployment(t *testing.T, deploymentName
 	// TODO: keep supporting other fields
 	deployment.Spec.Template.Spec.Containers = append(deployment.Spec.Template.Spec.Containers, container)
 }
+
+// AddEphemeralContainerToDeployment adds a mock ephemeral container to the specified pod under context
+func (l *MockLintContext) AddEphemeralContainerToDeployment(t *testing.T, deploymentName string, container v1.Container) {
+	deployment, ok := l.objects[deploymentName].(*appsV1.Deployment)
+	require.True(t, ok, "deployment with name %s not found", deploymentName)
+	deployment.Spec.Template.Spec.EphemeralContainers = append(deployment.Spec.Template.Spec.EphemeralContainers,
+		v1.EphemeralContainer{EphemeralContainerCommon: v1.EphemeralContainerCommon(container)})
+}
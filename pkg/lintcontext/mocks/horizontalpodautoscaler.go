@@ -0,0 +1,29 @@
+package mocks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.stackrox.io/kube-linter/pkg/objectkinds"
+	autoscalingV1 "k8s.io/api/autoscaling/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AddMockHorizontalPodAutoscaler adds a mock HorizontalPodAutoscaler to LintContext
+func (l *MockLintContext) AddMockHorizontalPodAutoscaler(t *testing.T, name string) {
+	require.NotEmpty(t, name)
+	l.objects[name] = &autoscalingV1.HorizontalPodAutoscaler{
+		TypeMeta: metaV1.TypeMeta{
+			Kind:       objectkinds.HorizontalPodAutoscaler,
+			APIVersion: objectkinds.GetHorizontalPodAutoscalerAPIVersion(),
+		},
+		ObjectMeta: metaV1.ObjectMeta{Name: name},
+	}
+}
+
+// ModifyHorizontalPodAutoscaler modifies a given horizontalpodautoscaler in the context via the passed function.
+func (l *MockLintContext) ModifyHorizontalPodAutoscaler(t *testing.T, name string, f func(hpa *autoscalingV1.HorizontalPodAutoscaler)) {
+	r, ok := l.objects[name].(*autoscalingV1.HorizontalPodAutoscaler)
+	require.True(t, ok)
+	f(r)
+}
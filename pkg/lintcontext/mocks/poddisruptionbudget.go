@@ -0,0 +1,29 @@
+package mocks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.stackrox.io/kube-linter/pkg/objectkinds"
+	policyV1 "k8s.io/api/policy/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AddMockPodDisruptionBudget adds a mock PodDisruptionBudget to LintContext
+func (l *MockLintContext) AddMockPodDisruptionBudget(t *testing.T, name string) {
+	require.NotEmpty(t, name)
+	l.objects[name] = &policyV1.PodDisruptionBudget{
+		TypeMeta: metaV1.TypeMeta{
+			Kind:       objectkinds.PodDisruptionBudget,
+			APIVersion: objectkinds.GetPodDisruptionBudgetAPIVersion(),
+		},
+		ObjectMeta: metaV1.ObjectMeta{Name: name},
+	}
+}
+
+// ModifyPodDisruptionBudget modifies a given poddisruptionbudget in the context via the passed function.
+func (l *MockLintContext) ModifyPodDisruptionBudget(t *testing.T, name string, f func(pdb *policyV1.PodDisruptionBudget)) {
+	r, ok := l.objects[name].(*policyV1.PodDisruptionBudget)
+	require.True(t, ok)
+	f(r)
+}
@@ -0,0 +1,28 @@
+package mocks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AddMockSecret adds a mock Secret to LintContext
+func (l *MockLintContext) AddMockSecret(t *testing.T, name string) {
+	require.NotEmpty(t, name)
+	l.objects[name] = &v1.Secret{
+		TypeMeta: metaV1.TypeMeta{
+			Kind:       "Secret",
+			APIVersion: v1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metaV1.ObjectMeta{Name: name},
+	}
+}
+
+// ModifySecret modifies a given secret in the context via the passed function.
+func (l *MockLintContext) ModifySecret(t *testing.T, name string, f func(secret *v1.Secret)) {
+	r, ok := l.objects[name].(*v1.Secret)
+	require.True(t, ok)
+	f(r)
+}
@@ -0,0 +1,97 @@
+package lintcontext
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	dockerconfig "github.com/docker/cli/cli/config"
+	"github.com/pkg/errors"
+	"helm.sh/helm/v3/pkg/helmpath"
+	"oras.land/oras-go/pkg/auth"
+	dockerauth "oras.land/oras-go/pkg/auth/docker"
+	"oras.land/oras-go/pkg/content"
+	orascontext "oras.land/oras-go/pkg/context"
+	"oras.land/oras-go/pkg/oras"
+)
+
+const (
+	ociScheme = "oci://"
+
+	// These are the media types that Helm uses when it stores charts in OCI registries.
+	helmChartConfigMediaType       = "application/vnd.cncf.helm.config.v1+json"
+	helmChartContentLayerMediaType = "application/vnd.cncf.helm.chart.content.v1.tar+gzip"
+)
+
+// isOCIReference returns whether the given argument refers to a Helm chart stored in an OCI registry.
+func isOCIReference(fileOrDir string) bool {
+	return strings.HasPrefix(fileOrDir, ociScheme)
+}
+
+// registryConfigPaths returns the config files that registry credentials are read from, in order of precedence:
+// the Helm registry config, as written by `helm registry login`, and the Docker config, which may also point to
+// credential helpers.
+func registryConfigPaths() []string {
+	helmRegistryConfig := os.Getenv("HELM_REGISTRY_CONFIG")
+	if helmRegistryConfig == "" {
+		helmRegistryConfig = helmpath.ConfigPath("registry.json")
+	}
+	return []string{helmRegistryConfig, filepath.Join(dockerconfig.Dir(), dockerconfig.ConfigFileName)}
+}
+
+// pullOCIHelmChart pulls the chart archive at the given OCI reference. The chart is kept in memory, so that nothing
+// has to be cleaned up afterwards.
+func pullOCIHelmChart(ref string, timeout time.Duration) ([]byte, error) {
+	if timeout <= 0 {
+		timeout = DefaultURLFetchTimeout
+	}
+	authClient, err := dockerauth.NewClient(registryConfigPaths()...)
+	if err != nil {
+		return nil, errors.Wrap(err, "loading registry credentials")
+	}
+	resolver, err := authClient.ResolverWithOpts(auth.WithResolverClient(&http.Client{Timeout: timeout}))
+	if err != nil {
+		return nil, errors.Wrap(err, "creating registry resolver")
+	}
+
+	// The oras background context discards the logs of the registry client, which would otherwise spam stderr.
+	ctx, cancel := context.WithTimeout(orascontext.Background(), timeout)
+	defer cancel()
+
+	store := content.NewMemoryStore()
+	_, descriptors, err := oras.Pull(ctx, resolver, strings.TrimPrefix(ref, ociScheme), store,
+		oras.WithPullEmptyNameAllowed(),
+		oras.WithAllowedMediaTypes([]string{helmChartConfigMediaType, helmChartContentLayerMediaType}))
+	if err != nil {
+		return nil, err
+	}
+	for _, desc := range descriptors {
+		if desc.MediaType != helmChartContentLayerMediaType {
+			continue
+		}
+		if _, data, found := store.Get(desc); found {
+			return data, nil
+		}
+	}
+	return nil, errors.Errorf("no layer with media type %s found, the artifact is not a Helm chart", helmChartContentLayerMediaType)
+}
+
+// loadObjectsFromOCIHelmChart pulls the Helm chart at the given OCI reference, and loads the objects it renders.
+// Failures to pull the chart are recorded as an invalid object.
+func (l *lintContextImpl) loadObjectsFromOCIHelmChart(ref string, timeout time.Duration) {
+	// Strip the scheme, since the reference is used as a prefix of file paths.
+	chartPath := strings.TrimPrefix(ref, ociScheme)
+	data, err := pullOCIHelmChart(ref, timeout)
+	if err != nil {
+		l.addInvalidObjects(InvalidObject{
+			Metadata: ObjectMetadata{FilePath: chartPath},
+			LoadErr:  errors.Wrapf(err, "pulling Helm chart %s", ref),
+		})
+		return
+	}
+	l.readObjectsFromTgzHelmChart(chartPath, bytes.NewReader(data))
+}
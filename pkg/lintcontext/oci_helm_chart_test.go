@@ -0,0 +1,29 @@
+package lintcontext
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateContextsFromOCIHelmChartPullFailure(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	ref := "oci://" + strings.TrimPrefix(server.URL, "http://") + "/charts/mychart:0.1.0"
+	lintCtxs, err := CreateContextsWithOptions(Options{URLFetchTimeout: 5 * time.Second}, ref)
+	require.NoError(t, err)
+	require.Len(t, lintCtxs, 1)
+
+	lintCtx := lintCtxs[0]
+	assert.Empty(t, lintCtx.Objects())
+	require.Len(t, lintCtx.InvalidObjects(), 1)
+	invalidObj := lintCtx.InvalidObjects()[0]
+	assert.Equal(t, strings.TrimPrefix(ref, "oci://"), invalidObj.Metadata.FilePath)
+	assert.Contains(t, invalidObj.LoadErr.Error(), "pulling Helm chart")
+}
@@ -0,0 +1,62 @@
+package lintcontext
+
+import (
+	"testing"
+
+	ocsAppsV1 "github.com/openshift/api/apps/v1"
+	ocsBuildV1 "github.com/openshift/api/build/v1"
+	ocsRouteV1 "github.com/openshift/api/route/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.stackrox.io/kube-linter/pkg/extract"
+	"golang.stackrox.io/kube-linter/pkg/objectkinds"
+)
+
+const (
+	openShiftDirectory = "../../tests/testdata/openshift"
+)
+
+func TestCreateContextsWithOpenShiftKinds(t *testing.T) {
+	lintCtxs, err := CreateContexts(openShiftDirectory)
+	require.NoError(t, err)
+	require.Len(t, lintCtxs, 1)
+	assert.Empty(t, lintCtxs[0].InvalidObjects())
+
+	objectsByKind := make(map[string]Object)
+	for _, obj := range lintCtxs[0].Objects() {
+		objectsByKind[obj.K8sObject.GetObjectKind().GroupVersionKind().Kind] = obj
+	}
+	require.Len(t, objectsByKind, 3)
+
+	depConfig, ok := objectsByKind["DeploymentConfig"].K8sObject.(*ocsAppsV1.DeploymentConfig)
+	require.True(t, ok)
+	podSpec, found := extract.PodSpec(depConfig)
+	require.True(t, found)
+	require.Len(t, podSpec.AllContainers(), 1)
+	assert.Equal(t, "frontend", podSpec.AllContainers()[0].Name)
+
+	route, ok := objectsByKind["Route"].K8sObject.(*ocsRouteV1.Route)
+	require.True(t, ok)
+	assert.Equal(t, "frontend", route.Spec.To.Name)
+	require.NotNil(t, route.Spec.TLS)
+
+	buildConfig, ok := objectsByKind["BuildConfig"].K8sObject.(*ocsBuildV1.BuildConfig)
+	require.True(t, ok)
+	require.NotNil(t, buildConfig.Spec.Source.Git)
+	assert.Equal(t, "https://git.example.com/shop/frontend.git", buildConfig.Spec.Source.Git.URI)
+
+	for kind, objectKind := range map[string]string{
+		"DeploymentConfig": objectkinds.DeploymentLike,
+		"Route":            objectkinds.Route,
+		"BuildConfig":      objectkinds.BuildConfig,
+	} {
+		matcher, err := objectkinds.ConstructMatcher(objectKind)
+		require.NoError(t, err)
+		gvk := objectsByKind[kind].K8sObject.GetObjectKind().GroupVersionKind()
+		assert.True(t, matcher.Matches(gvk), kind)
+
+		objectkinds.SetOpenShiftKindsEnabled(false)
+		assert.False(t, matcher.Matches(gvk), "%s with OpenShift kinds disabled", kind)
+		objectkinds.SetOpenShiftKindsEnabled(true)
+	}
+}
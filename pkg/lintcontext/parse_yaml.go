@@ -1,7 +1,6 @@
 package lintcontext
 
 import (
-	"bufio"
 	"bytes"
 	"fmt"
 	"io"
@@ -12,14 +11,20 @@ import (
 
 	y "github.com/ghodss/yaml"
 	ocsAppsV1 "github.com/openshift/api/apps/v1"
+	ocsBuildV1 "github.com/openshift/api/build/v1"
+	ocsRouteV1 "github.com/openshift/api/route/v1"
 	"github.com/pkg/errors"
+	"golang.stackrox.io/kube-linter/pkg/extract"
 	"golang.stackrox.io/kube-linter/pkg/k8sutil"
+	"golang.stackrox.io/kube-linter/pkg/objectkinds"
+	yamlv3 "gopkg.in/yaml.v3"
 	"helm.sh/helm/v3/pkg/chart"
 	"helm.sh/helm/v3/pkg/chart/loader"
 	"helm.sh/helm/v3/pkg/chartutil"
 	"helm.sh/helm/v3/pkg/cli/values"
 	"helm.sh/helm/v3/pkg/engine"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	"k8s.io/apimachinery/pkg/util/yaml"
@@ -40,7 +45,7 @@ func init() {
 	clientScheme := scheme.Scheme
 
 	// Add OpenShift schema
-	schemeBuilder := runtime.NewSchemeBuilder(ocsAppsV1.AddToScheme)
+	schemeBuilder := runtime.NewSchemeBuilder(ocsAppsV1.AddToScheme, ocsBuildV1.AddToScheme, ocsRouteV1.AddToScheme)
 	if err := schemeBuilder.AddToScheme(clientScheme); err != nil {
 		panic(fmt.Sprintf("Can not add OpenShift schema %v", err))
 	}
@@ -51,14 +56,14 @@ func parseObjects(data []byte, d runtime.Decoder) ([]k8sutil.Object, error) {
 	if d == nil {
 		d = decoder
 	}
-	obj, _, err := d.Decode(data, nil, nil)
+	obj, err := decodeObject(data, d)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to decode")
 	}
 	if list, ok := obj.(*v1.List); ok {
 		objs := make([]k8sutil.Object, 0, len(list.Items))
 		for i, item := range list.Items {
-			obj, _, err := d.Decode(item.Raw, nil, nil)
+			obj, err := decodeObject(item.Raw, d)
 			if err != nil {
 				return nil, errors.Wrapf(err, "decoding item %d in the list", i)
 			}
@@ -78,6 +83,43 @@ func parseObjects(data []byte, d runtime.Decoder) ([]k8sutil.Object, error) {
 	return []k8sutil.Object{asK8sObj}, nil
 }
 
+// decodeObject decodes a single object with the given decoder, falling back to decoding objects of kinds the decoder
+// does not know about generically if they are of a registered custom object kind.
+func decodeObject(data []byte, d runtime.Decoder) (runtime.Object, error) {
+	obj, _, err := d.Decode(data, nil, nil)
+	if err == nil || !runtime.IsNotRegisteredError(err) {
+		return obj, err
+	}
+	customObj, customErr := decodeCustomObject(data)
+	if customErr != nil {
+		return nil, customErr
+	}
+	if customObj == nil {
+		return nil, err
+	}
+	return customObj, nil
+}
+
+// decodeCustomObject decodes an object of a registered custom object kind. It returns nil if the object is not of a
+// registered custom object kind, and an error if it is, but its pod template is invalid.
+func decodeCustomObject(data []byte) (*unstructured.Unstructured, error) {
+	jsonData, err := yaml.ToJSON(data)
+	if err != nil {
+		return nil, nil
+	}
+	obj := &unstructured.Unstructured{}
+	if err := obj.UnmarshalJSON(jsonData); err != nil {
+		return nil, nil
+	}
+	if _, ok := objectkinds.GetCustomObjectKind(obj.GroupVersionKind()); !ok {
+		return nil, nil
+	}
+	if _, _, err := extract.CustomObjectPodTemplateSpec(obj); err != nil {
+		return nil, errors.Wrapf(err, "invalid %v", obj.GroupVersionKind())
+	}
+	return obj, nil
+}
+
 type nopWriter struct{}
 
 func (w nopWriter) Write(p []byte) (n int, err error) {
@@ -105,6 +147,9 @@ func (l *lintContextImpl) renderHelmChart(dir string) (map[string]string, error)
 }
 
 func (l *lintContextImpl) renderValues(chrt *chart.Chart, values map[string]interface{}) (map[string]string, error) {
+	if l.helmValues != nil {
+		values = mergeValues(values, l.helmValues)
+	}
 	valuesToRender, err := chartutil.ToRenderValues(chrt, values, chartutil.ReleaseOptions{Name: "test-release", Namespace: "default"}, nil)
 	if err != nil {
 		return nil, err
@@ -113,26 +158,59 @@ func (l *lintContextImpl) renderValues(chrt *chart.Chart, values map[string]inte
 	e := engine.Engine{LintMode: true}
 	rendered, err := e.Render(chrt, valuesToRender)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to render")
+		return nil, &HelmRenderError{Err: err}
 	}
 
 	return rendered, nil
 }
 
+// addHelmChartLoadError records the error of loading the Helm chart at the given path as an invalid object.
+// If the chart failed to render, the object is attributed to the template that failed, if Helm reported it.
+func (l *lintContextImpl) addHelmChartLoadError(chartPath string, stripChartName bool, err error) {
+	metadata := ObjectMetadata{FilePath: chartPath}
+	var renderErr *HelmRenderError
+	if errors.As(err, &renderErr) {
+		renderErr.locate(chartPath, stripChartName)
+		if renderErr.FilePath != "" {
+			metadata.FilePath = renderErr.FilePath
+		}
+	}
+	l.addInvalidObjects(InvalidObject{Metadata: metadata, LoadErr: err})
+}
+
+// mergeValues returns the values of base with the ones of overrides merged over them, recursively merging nested
+// tables. Like Helm does for values files and --set flags, neither map is modified.
+func mergeValues(base, overrides map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(base))
+	for k, v := range base {
+		out[k] = v
+	}
+	for k, v := range overrides {
+		if overrideTable, ok := v.(map[string]interface{}); ok {
+			if baseTable, ok := out[k].(map[string]interface{}); ok {
+				out[k] = mergeValues(baseTable, overrideTable)
+				continue
+			}
+		}
+		out[k] = v
+	}
+	return out
+}
+
 func (l *lintContextImpl) loadObjectsFromHelmChart(dir string) {
 	renderedFiles, err := l.renderHelmChart(dir)
 	if err != nil {
-		l.addInvalidObjects(InvalidObject{Metadata: ObjectMetadata{FilePath: dir}, LoadErr: err})
+		l.addHelmChartLoadError(dir, true, err)
 		return
 	}
 	// Paths returned by helm include redundant directory in front, therefore we strip it out.
-	l.loadHelmRenderedTemplates(dir, normalizeDirectoryPaths(renderedFiles))
+	l.loadHelmRenderedTemplates(dir, relocateSubchartTemplates(normalizeDirectoryPaths(renderedFiles), subchartLocations(dir)))
 }
 
 func (l *lintContextImpl) loadObjectsFromTgzHelmChart(tgzFile string) {
 	renderedFiles, err := l.renderTgzHelmChart(tgzFile)
 	if err != nil {
-		l.addInvalidObjects(InvalidObject{Metadata: ObjectMetadata{FilePath: tgzFile}, LoadErr: err})
+		l.addHelmChartLoadError(tgzFile, false, err)
 		return
 	}
 	l.loadHelmRenderedTemplates(tgzFile, renderedFiles)
@@ -150,13 +228,16 @@ func (l *lintContextImpl) renderTgzHelmChart(tgzFile string) (map[string]string,
 	return l.renderChart(tgzFile, chrt)
 }
 
-func (l *lintContextImpl) loadObjectFromYAMLReader(filePath string, r *yaml.YAMLReader) error {
-	doc, err := r.Read()
-	if err != nil {
+// loadObjectFromYAMLReader loads the objects of the next document of the given reader. If trackPositions is set,
+// the positions of the objects in the file are recorded.
+func (l *lintContextImpl) loadObjectFromYAMLReader(filePath string, r *yamlDocumentReader, trackPositions bool) error {
+	rawDoc, startLine, err := r.Read()
+	var docErr *yamlDocumentError
+	if err != nil && !errors.As(err, &docErr) {
 		return err
 	}
-	doc = bytes.TrimSpace(doc)
-	if len(doc) == 0 {
+	doc := bytes.TrimSpace(rawDoc)
+	if len(doc) == 0 && docErr == nil {
 		return nil
 	}
 
@@ -164,18 +245,39 @@ func (l *lintContextImpl) loadObjectFromYAMLReader(filePath string, r *yaml.YAML
 		FilePath: filePath,
 		Raw:      doc,
 	}
+	// Documents that cannot be loaded are recorded with the line they start on, so that they can be told apart.
+	invalidMetadata := metadata
+	if trackPositions {
+		invalidMetadata.Line = startLine
+	}
+	if docErr != nil {
+		l.addInvalidObjects(InvalidObject{
+			Metadata: invalidMetadata,
+			LoadErr:  docErr,
+		})
+		return nil
+	}
 
 	objs, err := parseObjects(doc, l.customDecoder)
 	if err != nil {
 		l.addInvalidObjects(InvalidObject{
-			Metadata: metadata,
+			Metadata: invalidMetadata,
 			LoadErr:  err,
 		})
 		return nil
 	}
-	for _, obj := range objs {
+	var nodes []*yamlv3.Node
+	if trackPositions {
+		nodes = parseObjectNodes(rawDoc, startLine)
+	}
+	for i, obj := range objs {
+		objMetadata := metadata
+		if len(nodes) == len(objs) {
+			objMetadata.node = nodes[i]
+			objMetadata.Line = nodes[i].Line
+		}
 		l.addObjects(Object{
-			Metadata:  metadata,
+			Metadata:  objMetadata,
 			K8sObject: obj,
 		})
 	}
@@ -198,9 +300,15 @@ func (l *lintContextImpl) loadObjectsFromYAMLFile(filePath string, info os.FileI
 }
 
 func (l *lintContextImpl) loadObjectsFromReader(filePath string, reader io.Reader) error {
-	yamlReader := yaml.NewYAMLReader(bufio.NewReader(reader))
+	return l.loadDocumentsFromReader(filePath, reader, true)
+}
+
+// loadDocumentsFromReader loads the objects of all the documents of the given reader. If trackPositions is set, the
+// positions of the objects in the file are recorded.
+func (l *lintContextImpl) loadDocumentsFromReader(filePath string, reader io.Reader, trackPositions bool) error {
+	yamlReader := newYAMLDocumentReader(reader)
 	for {
-		if err := l.loadObjectFromYAMLReader(filePath, yamlReader); err != nil {
+		if err := l.loadObjectFromYAMLReader(filePath, yamlReader, trackPositions); err != nil {
 			if err == io.EOF {
 				return nil
 			}
@@ -251,7 +359,7 @@ func (l *lintContextImpl) renderTgzHelmChartReader(fileName string, tgzReader io
 func (l *lintContextImpl) readObjectsFromTgzHelmChart(fileName string, tgzReader io.Reader) {
 	renderedFiles, err := l.renderTgzHelmChartReader(fileName, tgzReader)
 	if err != nil {
-		l.addInvalidObjects(InvalidObject{Metadata: ObjectMetadata{FilePath: fileName}, LoadErr: err})
+		l.addHelmChartLoadError(fileName, false, err)
 		return
 	}
 	l.loadHelmRenderedTemplates(fileName, renderedFiles)
@@ -266,10 +374,15 @@ func (l *lintContextImpl) loadHelmRenderedTemplates(chartPath string, renderedFi
 			continue
 		}
 
-		if err := l.loadObjectsFromReader(pathToTemplate, strings.NewReader(contents)); err != nil {
+		// The positions of rendered objects do not correspond to the ones in the templates, so they are not recorded.
+		firstObject := len(l.objects)
+		if err := l.loadDocumentsFromReader(pathToTemplate, strings.NewReader(contents), false); err != nil {
 			loadErr := errors.Wrapf(err, "loading object %s from rendered helm chart %s", pathToTemplate, chartPath)
 			l.addInvalidObjects(InvalidObject{Metadata: ObjectMetadata{FilePath: pathToTemplate}, LoadErr: loadErr})
 		}
+		if l.skipHelmHooks {
+			l.objects = withoutHelmHooks(l.objects, firstObject)
+		}
 	}
 }
 
@@ -0,0 +1,112 @@
+package lintcontext
+
+import (
+	"strconv"
+	"strings"
+
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// Position returns the line and column, in the file of the object, of the field at the given path in the object.
+// The path is made of field names separated by dots, and list indices in brackets, like
+// spec.template.spec.containers[0].image. If the field is not in the object, the position of the innermost field on
+// the path that is, or of the object itself, is returned. Position returns 0, 0 if positions are not known, like for
+// objects rendered from Helm charts.
+func (m *ObjectMetadata) Position(fieldPath string) (line, column int) {
+	if m.node == nil {
+		return 0, 0
+	}
+	line, column = m.node.Line, m.node.Column
+	node := m.node
+	for _, segment := range splitFieldPath(fieldPath) {
+		var position *yamlv3.Node
+		node, position = childNode(node, segment)
+		if node == nil {
+			break
+		}
+		line, column = position.Line, position.Column
+	}
+	return line, column
+}
+
+// splitFieldPath splits a field path like spec.containers[0].image into the segments spec, containers, [0] and image.
+func splitFieldPath(fieldPath string) []string {
+	var segments []string
+	for _, field := range strings.Split(fieldPath, ".") {
+		for {
+			idx := strings.Index(field, "[")
+			if idx < 0 {
+				break
+			}
+			if idx > 0 {
+				segments = append(segments, field[:idx])
+			}
+			end := strings.Index(field, "]")
+			if end < idx {
+				break
+			}
+			segments = append(segments, field[idx:end+1])
+			field = field[end+1:]
+		}
+		if field != "" {
+			segments = append(segments, field)
+		}
+	}
+	return segments
+}
+
+// childNode returns the node of the given path segment in the given node, and the node whose position the segment
+// is reported at, which is the key for fields of mappings. It returns nil if there is no such node.
+func childNode(node *yamlv3.Node, segment string) (*yamlv3.Node, *yamlv3.Node) {
+	if node.Kind == yamlv3.AliasNode {
+		node = node.Alias
+	}
+	if strings.HasPrefix(segment, "[") {
+		idx, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(segment, "["), "]"))
+		if err != nil || node.Kind != yamlv3.SequenceNode || idx < 0 || idx >= len(node.Content) {
+			return nil, nil
+		}
+		return node.Content[idx], node.Content[idx]
+	}
+	if node.Kind != yamlv3.MappingNode {
+		return nil, nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == segment {
+			return node.Content[i+1], node.Content[i]
+		}
+	}
+	return nil, nil
+}
+
+// parseObjectNodes parses the given YAML document, which starts at the given line of its file, and returns the nodes
+// of the objects in it, with their positions relative to the start of the file. A List has the nodes of its items.
+// It returns nil if the document cannot be parsed.
+func parseObjectNodes(doc []byte, startLine int) []*yamlv3.Node {
+	var document yamlv3.Node
+	if err := yamlv3.Unmarshal(doc, &document); err != nil || len(document.Content) == 0 {
+		return nil
+	}
+	shiftLines(&document, startLine-1)
+	root := document.Content[0]
+	if root.Kind != yamlv3.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == "kind" && root.Content[i+1].Value == "List" {
+			items, _ := childNode(root, "items")
+			if items == nil || items.Kind != yamlv3.SequenceNode {
+				return nil
+			}
+			return items.Content
+		}
+	}
+	return []*yamlv3.Node{root}
+}
+
+func shiftLines(node *yamlv3.Node, offset int) {
+	node.Line += offset
+	for _, child := range node.Content {
+		shiftLines(child, offset)
+	}
+}
@@ -0,0 +1,98 @@
+package lintcontext
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	positionsManifest = `# A service.
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  template:
+    spec:
+      initContainers:
+      - name: init
+        image: init:latest
+      containers:
+      - name: app
+        image: app:latest
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: first
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: second
+`
+)
+
+func TestObjectPositions(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "manifest.yaml"), []byte(positionsManifest), 0644))
+	lintCtxs, err := CreateContexts(dir)
+	require.NoError(t, err)
+	lintCtx := verifyAndGetContext(t, lintCtxs)
+
+	objectsByName := make(map[string]Object)
+	for _, obj := range lintCtx.Objects() {
+		objectsByName[obj.K8sObject.GetObjectKind().GroupVersionKind().Kind+"/"+obj.K8sObject.GetName()] = obj
+	}
+	require.Len(t, objectsByName, 4)
+
+	service := objectsByName["Service/web"]
+	assert.Equal(t, 2, service.Metadata.Line)
+	line, column := service.Metadata.Position("")
+	assert.Equal(t, []int{2, 1}, []int{line, column})
+
+	deployment := objectsByName["Deployment/web"]
+	assert.Equal(t, 7, deployment.Metadata.Line)
+	for _, testCase := range []struct {
+		fieldPath    string
+		line, column int
+	}{
+		{fieldPath: "metadata.name", line: 10, column: 3},
+		{fieldPath: "spec.template.spec.initContainers[0].image", line: 16, column: 9},
+		{fieldPath: "spec.template.spec.containers[0]", line: 18, column: 9},
+		{fieldPath: "spec.template.spec.containers[0].image", line: 19, column: 9},
+		// Fields that are not there are attributed to the innermost field that is.
+		{fieldPath: "spec.template.spec.containers[0].securityContext.privileged", line: 18, column: 9},
+		{fieldPath: "spec.template.spec.containers[1].image", line: 17, column: 7},
+		{fieldPath: "spec.replicas", line: 11, column: 1},
+	} {
+		line, column := deployment.Metadata.Position(testCase.fieldPath)
+		assert.Equal(t, []int{testCase.line, testCase.column}, []int{line, column}, testCase.fieldPath)
+	}
+
+	assert.Equal(t, 24, objectsByName["ConfigMap/first"].Metadata.Line)
+	second := objectsByName["ConfigMap/second"]
+	line, column = second.Metadata.Position("metadata.name")
+	assert.Equal(t, []int{31, 5}, []int{line, column})
+}
+
+func TestObjectPositionsAreUnknownForHelmCharts(t *testing.T) {
+	lintCtxs, err := CreateContexts(chartDirectory)
+	require.NoError(t, err)
+	for _, obj := range verifyAndGetContext(t, lintCtxs).Objects() {
+		assert.Zero(t, obj.Metadata.Line)
+		line, column := obj.Metadata.Position("metadata.name")
+		assert.Equal(t, []int{0, 0}, []int{line, column})
+	}
+}
@@ -0,0 +1,197 @@
+package lintcontext
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+const (
+	// maxSnippetLines is the maximum number of lines of a snippet. Longer snippets are cut, and end with a line telling
+	// how many lines were left out.
+	maxSnippetLines = 20
+	// maxSnippetLineLength is the maximum length of a line of a snippet, so that long values like certificates do not
+	// flood the output.
+	maxSnippetLineLength = 160
+	// redactedValue replaces the values that look like secrets in snippets.
+	redactedValue = "<redacted>"
+	// lastAppliedConfigAnnotation is the annotation in which kubectl apply records the whole object, including the
+	// data of Secrets, so its value is always redacted.
+	lastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+)
+
+var (
+	// sensitiveKeyPattern matches the keys whose string values are redacted from snippets, like password or
+	// clientSecret. Keys that refer to secrets, like secretName or the key of a secretKeyRef, do not match.
+	sensitiveKeyPattern = regexp.MustCompile(`(?i)(password|passwd|secret|token|credentials?|(api|access|private|secret)_?key)$`)
+	// sensitiveEnvVarPattern matches the names of the environment variables whose values are redacted from snippets,
+	// like DB_PASSWORD or SECRET_KEY_BASE.
+	sensitiveEnvVarPattern = regexp.MustCompile(`(?i)(password|passwd|secret|token|credential|(api|access|private)_?key)`)
+)
+
+// Snippet returns the YAML of the field at the given path in the object, in the syntax of Position, along with its
+// key, or of the whole object if the path is empty. If the field is not in the object, the innermost field on the path
+// that is, or the whole object, is returned instead. The values of Secrets and values that look like secrets, like
+// passwords, are redacted, and snippets are cut after maxSnippetLines lines. Snippet returns an empty string if the
+// source of the object is not known.
+func (m *ObjectMetadata) Snippet(fieldPath string) string {
+	root := m.node
+	if root == nil {
+		root = parseRawObjectNode(m.Raw)
+	}
+	if root == nil {
+		return ""
+	}
+	root = copyNode(root)
+	redact(root, isSecretNode(root))
+
+	node, snippet := root, root
+	for _, segment := range splitFieldPath(fieldPath) {
+		child, key := childNode(node, segment)
+		if child == nil {
+			break
+		}
+		node = child
+		if strings.HasPrefix(segment, "[") {
+			snippet = &yamlv3.Node{Kind: yamlv3.SequenceNode, Content: []*yamlv3.Node{child}}
+		} else {
+			snippet = &yamlv3.Node{Kind: yamlv3.MappingNode, Content: []*yamlv3.Node{key, child}}
+		}
+	}
+
+	var buf bytes.Buffer
+	enc := yamlv3.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(snippet); err != nil {
+		return ""
+	}
+	return boundSnippet(strings.TrimRight(buf.String(), "\n"))
+}
+
+// parseRawObjectNode parses the raw YAML or JSON of an object whose positions are not known, like one rendered from a
+// Helm chart. It returns nil if the raw source is not a single object, like a List.
+func parseRawObjectNode(raw []byte) *yamlv3.Node {
+	var document yamlv3.Node
+	if err := yamlv3.Unmarshal(raw, &document); err != nil || len(document.Content) == 0 {
+		return nil
+	}
+	root := document.Content[0]
+	if root.Kind != yamlv3.MappingNode {
+		return nil
+	}
+	if kind, _ := childNode(root, "kind"); kind != nil && kind.Value == "List" {
+		return nil
+	}
+	// The raw source of objects listed from clusters is JSON, which reads better in the block style of YAML.
+	resetStyles(root)
+	return root
+}
+
+func resetStyles(node *yamlv3.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetStyles(child)
+	}
+}
+
+// copyNode returns a deep copy of the given node, so that it can be redacted without changing the object's node.
+// Aliases keep pointing to the original nodes.
+func copyNode(node *yamlv3.Node) *yamlv3.Node {
+	copied := *node
+	copied.Content = make([]*yamlv3.Node, len(node.Content))
+	for i, child := range node.Content {
+		copied.Content[i] = copyNode(child)
+	}
+	return &copied
+}
+
+func isSecretNode(root *yamlv3.Node) bool {
+	kind, _ := childNode(root, "kind")
+	apiVersion, _ := childNode(root, "apiVersion")
+	return kind != nil && kind.Value == "Secret" && apiVersion != nil && apiVersion.Value == "v1"
+}
+
+// redact replaces the values that look like secrets in the given node: the values of the mapping keys that match
+// sensitiveKeyPattern, of the environment variables that match sensitiveEnvVarPattern, of the
+// lastAppliedConfigAnnotation, and all the values of the data, stringData and annotations of Secrets.
+func redact(root *yamlv3.Node, isSecret bool) {
+	var annotations *yamlv3.Node
+	if metadata, _ := childNode(root, "metadata"); metadata != nil {
+		annotations, _ = childNode(metadata, "annotations")
+	}
+	if isSecret {
+		redactMappingValues(annotations)
+		for _, field := range []string{"data", "stringData"} {
+			values, _ := childNode(root, field)
+			redactMappingValues(values)
+		}
+	} else if annotations != nil {
+		if lastApplied, _ := childNode(annotations, lastAppliedConfigAnnotation); lastApplied != nil {
+			redactScalar(lastApplied)
+		}
+	}
+	redactSensitiveValues(root)
+}
+
+// redactMappingValues redacts all the non-empty scalar values of the given node, if it is a mapping.
+func redactMappingValues(node *yamlv3.Node) {
+	if node == nil || node.Kind != yamlv3.MappingNode {
+		return
+	}
+	for i := 1; i < len(node.Content); i += 2 {
+		if value := node.Content[i]; value.Kind == yamlv3.ScalarNode && value.Value != "" {
+			redactScalarValue(value)
+		}
+	}
+}
+
+func redactSensitiveValues(node *yamlv3.Node) {
+	if node.Kind == yamlv3.MappingNode {
+		// Environment variables are mappings of their name and value.
+		if name, _ := childNode(node, "name"); name != nil && sensitiveEnvVarPattern.MatchString(name.Value) {
+			if value, _ := childNode(node, "value"); value != nil {
+				redactScalar(value)
+			}
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if sensitiveKeyPattern.MatchString(node.Content[i].Value) {
+				redactScalar(node.Content[i+1])
+			}
+		}
+	}
+	for _, child := range node.Content {
+		redactSensitiveValues(child)
+	}
+}
+
+// redactScalar redacts the given node if it is a non-empty string, leaving booleans, like automountServiceAccountToken,
+// and numbers as they are.
+func redactScalar(node *yamlv3.Node) {
+	if node.Kind == yamlv3.ScalarNode && node.ShortTag() == "!!str" && node.Value != "" {
+		redactScalarValue(node)
+	}
+}
+
+func redactScalarValue(node *yamlv3.Node) {
+	node.Value = redactedValue
+	node.Tag = "!!str"
+	node.Style = 0
+}
+
+// boundSnippet cuts the given snippet to maxSnippetLines lines of at most maxSnippetLineLength characters.
+func boundSnippet(snippet string) string {
+	lines := strings.Split(snippet, "\n")
+	for i, line := range lines {
+		if runes := []rune(line); len(runes) > maxSnippetLineLength {
+			lines[i] = string(runes[:maxSnippetLineLength]) + "..."
+		}
+	}
+	if len(lines) > maxSnippetLines {
+		omitted := len(lines) - maxSnippetLines
+		lines = append(lines[:maxSnippetLines], fmt.Sprintf("# ... %d more lines", omitted))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,140 @@
+package lintcontext
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	snippetsManifest = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  template:
+    spec:
+      automountServiceAccountToken: false
+      containers:
+      - name: app
+        image: app:latest
+        env:
+        - name: DB_PASSWORD
+          value: hunter2
+        - name: PASSWORD_FROM_SECRET
+          valueFrom:
+            secretKeyRef:
+              name: credentials
+              key: password
+        - name: LOG_LEVEL
+          value: debug
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: credentials
+  annotations:
+    example.com/api-token: abc123
+data:
+  password: aHVudGVyMg==
+stringData:
+  username: admin
+`
+)
+
+func loadSnippetObjects(t *testing.T) []Object {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "manifest.yaml"), []byte(snippetsManifest), 0644))
+	lintCtxs, err := CreateContexts(dir)
+	require.NoError(t, err)
+	require.Len(t, lintCtxs, 1)
+	objects := lintCtxs[0].Objects()
+	require.Len(t, objects, 2)
+	return objects
+}
+
+func TestSnippet(t *testing.T) {
+	objects := loadSnippetObjects(t)
+	deployment, secret := objects[0].Metadata, objects[1].Metadata
+
+	assert.Equal(t, "image: app:latest", deployment.Snippet("spec.template.spec.containers[0].image"))
+	assert.Equal(t, "automountServiceAccountToken: false", deployment.Snippet("spec.template.spec.automountServiceAccountToken"))
+	assert.Equal(t, `- name: DB_PASSWORD
+  value: <redacted>`, deployment.Snippet("spec.template.spec.containers[0].env[0]"))
+	assert.Equal(t, `- name: PASSWORD_FROM_SECRET
+  valueFrom:
+    secretKeyRef:
+      name: credentials
+      key: password`, deployment.Snippet("spec.template.spec.containers[0].env[1]"))
+	assert.Equal(t, `- name: LOG_LEVEL
+  value: debug`, deployment.Snippet("spec.template.spec.containers[0].env[2]"))
+	// Fields that are not in the object fall back to the innermost field that is.
+	assert.Equal(t, "image: app:latest", deployment.Snippet("spec.template.spec.containers[0].image.tag"))
+
+	assert.Equal(t, `apiVersion: v1
+kind: Secret
+metadata:
+  name: credentials
+  annotations:
+    example.com/api-token: <redacted>
+data:
+  password: <redacted>
+stringData:
+  username: <redacted>`, secret.Snippet(""))
+
+	// The objects themselves are not redacted.
+	assert.Equal(t, "image: app:latest", deployment.Snippet("spec.template.spec.containers[0].image"))
+	assert.Contains(t, secret.Snippet("metadata"), "name: credentials")
+}
+
+func TestSnippetOfObjectsWithoutPositions(t *testing.T) {
+	metadata := ObjectMetadata{Raw: []byte(`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "config"}}`)}
+	assert.Equal(t, "metadata:\n  name: config", metadata.Snippet("metadata"))
+
+	metadata = ObjectMetadata{Raw: []byte("apiVersion: v1\nkind: List\nitems: []\n")}
+	assert.Empty(t, metadata.Snippet(""))
+}
+
+func TestSnippetRedactsLastAppliedConfiguration(t *testing.T) {
+	// Objects listed from clusters carry the configuration that kubectl apply recorded, which has the data of Secrets.
+	metadata := ObjectMetadata{Raw: []byte(`{"apiVersion": "v1", "kind": "Secret", "metadata": {"name": "credentials", "annotations": {` +
+		`"kubectl.kubernetes.io/last-applied-configuration": "{\"apiVersion\":\"v1\",\"kind\":\"Secret\",\"stringData\":{\"password\":\"hunter2\"}}\n", ` +
+		`"example.com/owner": "team-a"}}, "data": {"password": "aHVudGVyMg=="}}`)}
+	snippet := metadata.Snippet("")
+	assert.NotContains(t, snippet, "hunter2")
+	assert.Equal(t, `apiVersion: v1
+kind: Secret
+metadata:
+  name: credentials
+  annotations:
+    kubectl.kubernetes.io/last-applied-configuration: <redacted>
+    example.com/owner: <redacted>
+data:
+  password: <redacted>`, snippet)
+
+	// Other objects can hold secrets in their configuration too, but their other annotations are kept.
+	metadata = ObjectMetadata{Raw: []byte(`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "config", "annotations": {` +
+		`"kubectl.kubernetes.io/last-applied-configuration": "{\"data\":{\"dsn\":\"postgres://admin:hunter2@db\"}}\n", ` +
+		`"example.com/owner": "team-a"}}}`)}
+	assert.Equal(t, `annotations:
+  kubectl.kubernetes.io/last-applied-configuration: <redacted>
+  example.com/owner: team-a`, metadata.Snippet("metadata.annotations"))
+}
+
+func TestBoundSnippet(t *testing.T) {
+	lines := make([]string, 0, 25)
+	for i := 0; i < 25; i++ {
+		lines = append(lines, fmt.Sprintf("line%d: %d", i, i))
+	}
+	bounded := strings.Split(boundSnippet(strings.Join(lines, "\n")), "\n")
+	require.Len(t, bounded, maxSnippetLines+1)
+	assert.Equal(t, lines[:maxSnippetLines], bounded[:maxSnippetLines])
+	assert.Equal(t, "# ... 5 more lines", bounded[maxSnippetLines])
+
+	assert.Equal(t, "cert: "+strings.Repeat("é", maxSnippetLineLength-6)+"...", boundSnippet("cert: "+strings.Repeat("é", 200)))
+}
@@ -0,0 +1,81 @@
+package lintcontext
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+
+	"golang.stackrox.io/kube-linter/internal/set"
+)
+
+// walkFollowingSymlinks walks the file tree rooted at root like filepath.Walk, but follows symbolic links to
+// directories. Every directory is visited at most once, determined by its path with all symbolic links resolved,
+// so that symbolic link loops do not make the walk go on forever.
+func walkFollowingSymlinks(root string, walkFn filepath.WalkFunc) error {
+	info, err := os.Stat(root)
+	if err != nil {
+		return walkFn(root, nil, err)
+	}
+	visitedDirs := set.NewStringSet()
+	err = walkPath(root, info, visitedDirs, walkFn)
+	if err == filepath.SkipDir {
+		return nil
+	}
+	return err
+}
+
+func walkPath(path string, info os.FileInfo, visitedDirs set.StringSet, walkFn filepath.WalkFunc) error {
+	if !info.IsDir() {
+		return walkFn(path, info, nil)
+	}
+
+	realPath, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return walkFn(path, info, err)
+	}
+	if !visitedDirs.Add(realPath) {
+		// Already visited through another path, most likely a symbolic link pointing back up the tree.
+		return nil
+	}
+
+	if err := walkFn(path, info, nil); err != nil {
+		return err
+	}
+
+	dir, err := os.Open(path)
+	if err != nil {
+		return walkFn(path, info, err)
+	}
+	names, err := dir.Readdirnames(-1)
+	_ = dir.Close()
+	if err != nil {
+		return walkFn(path, info, err)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		childPath := filepath.Join(path, name)
+		childInfo, err := os.Stat(childPath)
+		if err != nil {
+			// Dangling symbolic links are passed on as they are, like filepath.Walk does.
+			lstatInfo, lstatErr := os.Lstat(childPath)
+			if lstatErr != nil {
+				if err := walkFn(childPath, nil, lstatErr); err != nil && err != filepath.SkipDir {
+					return err
+				}
+				continue
+			}
+			childInfo = lstatInfo
+		}
+		if err := walkPath(childPath, childInfo, visitedDirs, walkFn); err != nil {
+			if err != filepath.SkipDir {
+				return err
+			}
+			if !childInfo.IsDir() {
+				// Returning SkipDir for a file skips the remaining files in its directory.
+				return nil
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package lintcontext
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	yamlDocumentSeparator = "---"
+)
+
+// yamlDocumentError is the error of a document that cannot be split from the stream cleanly, like one that starts
+// with an invalid separator. It does not keep the documents after it from being read.
+type yamlDocumentError struct {
+	msg string
+}
+
+func (e *yamlDocumentError) Error() string {
+	return e.msg
+}
+
+// yamlDocumentReader splits a stream of YAML documents like yaml.YAMLReader does, but also keeps track of the line
+// that each document starts at, so that positions within a document can be mapped to positions in the stream.
+type yamlDocumentReader struct {
+	reader *bufio.Reader
+	// line is the number of lines read so far.
+	line int
+	// nextErr is the error of the next document, and nextLine the line that it starts at, if the separator that
+	// ended the previous document was invalid.
+	nextErr  error
+	nextLine int
+}
+
+func newYAMLDocumentReader(r io.Reader) *yamlDocumentReader {
+	return &yamlDocumentReader{reader: bufio.NewReader(r)}
+}
+
+// Read returns the next document, and the line of the stream that its first line is on. It returns io.EOF when
+// there are no more documents.
+// Like with yaml.YAMLReader, the separator that ends a document is not part of it, but a separator at the start of
+// the stream is part of the first document.
+// Unlike with yaml.YAMLReader, an invalid separator ends the document before it, and the document after it is
+// returned, up to the next separator, along with a *yamlDocumentError, so that the documents around it can still be read.
+func (r *yamlDocumentReader) Read() ([]byte, int, error) {
+	var buffer bytes.Buffer
+	startLine, docErr := r.nextLine, r.nextErr
+	r.nextLine, r.nextErr = 0, nil
+	for {
+		line, err := r.reader.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return nil, 0, err
+		}
+		if len(line) > 0 {
+			r.line++
+			if err == io.EOF {
+				// Treat a final line without a newline like any other, the next read returns io.EOF.
+				line, err = append(line, '\n'), nil
+			}
+		}
+
+		if bytes.HasPrefix(line, []byte(yamlDocumentSeparator)) {
+			var separatorErr error
+			// Only comments and spaces are allowed after a document separator.
+			trimmed := strings.TrimSpace(string(line[len(yamlDocumentSeparator):]))
+			if len(trimmed) > 0 && trimmed[0] != '#' {
+				separatorErr = &yamlDocumentError{msg: fmt.Sprintf("invalid Yaml document separator: %s", trimmed)}
+			}
+			if buffer.Len() != 0 || docErr != nil {
+				r.nextLine, r.nextErr = r.line, separatorErr
+				return buffer.Bytes(), startLine, docErr
+			}
+			if separatorErr != nil {
+				startLine, docErr = r.line, separatorErr
+				continue
+			}
+			if err == io.EOF {
+				return nil, 0, err
+			}
+		}
+		if err == io.EOF {
+			if buffer.Len() != 0 || docErr != nil {
+				return buffer.Bytes(), startLine, docErr
+			}
+			return nil, 0, err
+		}
+		if buffer.Len() == 0 && docErr == nil {
+			startLine = r.line
+		}
+		buffer.Write(line)
+	}
+}
@@ -0,0 +1,83 @@
+package lintcontext
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+func TestYAMLDocumentReaderSplitsLikeYAMLReader(t *testing.T) {
+	for _, input := range []string{
+		"",
+		"a: 1",
+		"a: 1\n",
+		"---\na: 1\n---\nb: 2\n",
+		"a: 1\n--- # comment\n\n# leading comment\nb: 2",
+		"---\n---\na: 1\n---\n",
+		"a: 1\n---\n---\nb: 2\n",
+	} {
+		t.Run(input, func(t *testing.T) {
+			expected := yaml.NewYAMLReader(bufio.NewReader(strings.NewReader(input)))
+			actual := newYAMLDocumentReader(strings.NewReader(input))
+			for {
+				expectedDoc, expectedErr := expected.Read()
+				actualDoc, _, actualErr := actual.Read()
+				require.Equal(t, expectedErr, actualErr)
+				if expectedErr == io.EOF {
+					return
+				}
+				assert.Equal(t, string(expectedDoc), string(actualDoc))
+			}
+		})
+	}
+}
+
+func TestYAMLDocumentReaderStartLines(t *testing.T) {
+	reader := newYAMLDocumentReader(strings.NewReader("---\na: 1\n---\n\n# comment\nb: 2\n---\nc: 3"))
+	var startLines []int
+	for {
+		_, startLine, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		startLines = append(startLines, startLine)
+	}
+	assert.Equal(t, []int{1, 4, 8}, startLines)
+}
+
+func TestYAMLDocumentReaderInvalidSeparator(t *testing.T) {
+	reader := newYAMLDocumentReader(strings.NewReader("a: 1\n--- b: 2\nc: 3\n---\nd: 4\n--- e: 5"))
+	type document struct {
+		data      string
+		startLine int
+		err       string
+	}
+	var docs []document
+	for {
+		data, startLine, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		doc := document{data: string(data), startLine: startLine}
+		if err != nil {
+			var docErr *yamlDocumentError
+			require.True(t, errors.As(err, &docErr))
+			doc.err = err.Error()
+		}
+		docs = append(docs, doc)
+	}
+	// The documents around the invalid separators are still read.
+	assert.Equal(t, []document{
+		{data: "a: 1\n", startLine: 1},
+		{data: "c: 3\n", startLine: 2, err: "invalid Yaml document separator: b: 2"},
+		{data: "d: 4\n", startLine: 5},
+		{data: "", startLine: 6, err: "invalid Yaml document separator: e: 5"},
+	}, docs)
+}
@@ -0,0 +1,90 @@
+package objectkinds
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var (
+	// kindAliases are the Kubernetes kinds that KubeLinter knows, with the short names and plurals that kubectl
+	// accepts for them.
+	kindAliases = []struct {
+		kind    string
+		aliases []string
+	}{
+		{kind: "Deployment", aliases: []string{"deploy", "deployments"}},
+		{kind: "StatefulSet", aliases: []string{"sts", "statefulsets"}},
+		{kind: "DaemonSet", aliases: []string{"ds", "daemonsets"}},
+		{kind: "ReplicaSet", aliases: []string{"rs", "replicasets"}},
+		{kind: "DeploymentConfig", aliases: []string{"dc", "deploymentconfigs"}},
+		{kind: "Pod", aliases: []string{"po", "pods"}},
+		{kind: "ReplicationController", aliases: []string{"rc", "replicationcontrollers"}},
+		{kind: "Job", aliases: []string{"jobs"}},
+		{kind: "CronJob", aliases: []string{"cj", "cronjobs"}},
+		{kind: "Service", aliases: []string{"svc", "services"}},
+		{kind: "ServiceAccount", aliases: []string{"sa", "serviceaccounts"}},
+		{kind: "Ingress", aliases: []string{"ing", "ingresses"}},
+		{kind: "NetworkPolicy", aliases: []string{"netpol", "networkpolicies"}},
+		{kind: "PodDisruptionBudget", aliases: []string{"pdb", "poddisruptionbudgets"}},
+		{kind: "HorizontalPodAutoscaler", aliases: []string{"hpa", "horizontalpodautoscalers"}},
+		{kind: "Role", aliases: []string{"roles"}},
+		{kind: "RoleBinding", aliases: []string{"rolebindings"}},
+		{kind: "ClusterRole", aliases: []string{"clusterroles"}},
+		{kind: "ClusterRoleBinding", aliases: []string{"clusterrolebindings"}},
+		{kind: "ConfigMap", aliases: []string{"cm", "configmaps"}},
+		{kind: "Secret", aliases: []string{"secrets"}},
+		{kind: "Namespace", aliases: []string{"ns", "namespaces"}},
+		{kind: "PersistentVolumeClaim", aliases: []string{"pvc", "persistentvolumeclaims"}},
+		{kind: "Route", aliases: []string{"routes"}},
+		{kind: "BuildConfig", aliases: []string{"bc", "buildconfigs"}},
+	}
+
+	// kindsByAlias maps the lower-case kinds and their aliases to the kinds.
+	kindsByAlias = func() map[string]string {
+		m := make(map[string]string)
+		for _, kindAlias := range kindAliases {
+			for _, alias := range append([]string{strings.ToLower(kindAlias.kind)}, kindAlias.aliases...) {
+				if _, ok := m[alias]; ok {
+					panic("duplicate kind alias: " + alias)
+				}
+				m[alias] = kindAlias.kind
+			}
+		}
+		return m
+	}()
+)
+
+// CanonicalKind returns the Kubernetes kind that the given name refers to, case-insensitively, like Deployment for
+// deploy, deployments or DEPLOYMENT, mirroring kubectl. Names that are neither a known kind nor an alias of one are
+// returned as they are.
+func CanonicalKind(name string) string {
+	if kind, ok := kindsByAlias[strings.ToLower(name)]; ok {
+		return kind
+	}
+	return name
+}
+
+// lookupObjectKind returns the matcher of the object kind with the given name. Names are matched case-insensitively,
+// and Kubernetes kinds and their aliases are accepted too: kinds that are object kinds of their own, like Service, stand
+// for those, and deployment-like kinds, like Deployment, only match objects of that kind.
+func lookupObjectKind(name string) Matcher {
+	if matcher := allObjectKinds[name]; matcher != nil {
+		return matcher
+	}
+	kind := CanonicalKind(name)
+	for objectKind, matcher := range allObjectKinds {
+		if strings.EqualFold(objectKind, kind) {
+			return matcher
+		}
+	}
+	for gk := range deploymentLikeGroupKinds {
+		if gk.Kind == kind {
+			groupKind := gk
+			return matcherFunc(func(gvk schema.GroupVersionKind) bool {
+				return gvk.GroupKind() == groupKind
+			})
+		}
+	}
+	return nil
+}
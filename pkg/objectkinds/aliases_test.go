@@ -0,0 +1,68 @@
+package objectkinds
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestCanonicalKind(t *testing.T) {
+	for name, expected := range map[string]string{
+		"deploy":       "Deployment",
+		"deployments":  "Deployment",
+		"DEPLOYMENT":   "Deployment",
+		"sts":          "StatefulSet",
+		"ds":           "DaemonSet",
+		"rs":           "ReplicaSet",
+		"po":           "Pod",
+		"cj":           "CronJob",
+		"svc":          "Service",
+		"sa":           "ServiceAccount",
+		"ing":          "Ingress",
+		"netpol":       "NetworkPolicy",
+		"pdb":          "PodDisruptionBudget",
+		"hpa":          "HorizontalPodAutoscaler",
+		"clusterroles": "ClusterRole",
+		// Names that are not kinds are kept as they are.
+		"WebApp":         "WebApp",
+		"DeploymentLike": "DeploymentLike",
+	} {
+		assert.Equal(t, expected, CanonicalKind(name), "name %q", name)
+	}
+}
+
+func TestConstructMatcherAcceptsAliases(t *testing.T) {
+	deployment := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+	statefulSet := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "StatefulSet"}
+	service := schema.GroupVersionKind{Version: "v1", Kind: "Service"}
+	secret := schema.GroupVersionKind{Version: "v1", Kind: "Secret"}
+
+	for _, testCase := range []struct {
+		objectKind string
+		matches    []schema.GroupVersionKind
+		misses     []schema.GroupVersionKind
+	}{
+		{objectKind: "deploymentlike", matches: []schema.GroupVersionKind{deployment, statefulSet}, misses: []schema.GroupVersionKind{service}},
+		{objectKind: "svc", matches: []schema.GroupVersionKind{service}, misses: []schema.GroupVersionKind{deployment}},
+		{objectKind: "services", matches: []schema.GroupVersionKind{service}, misses: []schema.GroupVersionKind{deployment}},
+		{objectKind: "deploy", matches: []schema.GroupVersionKind{deployment}, misses: []schema.GroupVersionKind{statefulSet, service}},
+		{objectKind: "StatefulSet", matches: []schema.GroupVersionKind{statefulSet}, misses: []schema.GroupVersionKind{deployment}},
+		{objectKind: "secrets", matches: []schema.GroupVersionKind{secret}, misses: []schema.GroupVersionKind{service}},
+	} {
+		t.Run(testCase.objectKind, func(t *testing.T) {
+			matcher, err := ConstructMatcher(testCase.objectKind)
+			require.NoError(t, err)
+			for _, gvk := range testCase.matches {
+				assert.True(t, matcher.Matches(gvk), "%v", gvk)
+			}
+			for _, gvk := range testCase.misses {
+				assert.False(t, matcher.Matches(gvk), "%v", gvk)
+			}
+		})
+	}
+
+	_, err := ConstructMatcher("configmap")
+	assert.EqualError(t, err, "unknown object kind: configmap")
+}
@@ -0,0 +1,77 @@
+package objectkinds
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// CustomObjectKind is an object kind that KubeLinter does not know about, typically defined by a CRD, whose objects
+// embed a pod template. Objects of custom kinds match DeploymentLike, so that the checks on pod templates apply to them.
+type CustomObjectKind struct {
+	// GroupVersionKind identifies the objects of the kind. If the version is empty, all versions match.
+	GroupVersionKind schema.GroupVersionKind
+	// PodTemplatePath is the JSONPath of the pod template in the objects, like {.spec.template}.
+	PodTemplatePath string
+}
+
+var (
+	customObjectKindsLock sync.RWMutex
+	customObjectKinds     []CustomObjectKind
+)
+
+// RegisterCustomObjectKind registers the given custom object kind. The braces around the pod template path can be
+// omitted, like in kubectl.
+func RegisterCustomObjectKind(kind CustomObjectKind) error {
+	gvk := kind.GroupVersionKind
+	if gvk.Kind == "" {
+		return errors.New("kind must be set")
+	}
+	if isDeploymentLike(gvk) {
+		return errors.Errorf("%v is a built-in object kind", gvk.GroupKind())
+	}
+	if !strings.HasPrefix(kind.PodTemplatePath, "{") {
+		kind.PodTemplatePath = "{" + kind.PodTemplatePath + "}"
+	}
+	if kind.PodTemplatePath == "{}" {
+		return errors.Errorf("pod template path of %v must be set", gvk.GroupKind())
+	}
+	if err := jsonpath.New(gvk.Kind).Parse(kind.PodTemplatePath); err != nil {
+		return errors.Wrapf(err, "invalid pod template path of %v", gvk.GroupKind())
+	}
+
+	customObjectKindsLock.Lock()
+	defer customObjectKindsLock.Unlock()
+	for _, existing := range customObjectKinds {
+		if existing.GroupVersionKind != gvk {
+			continue
+		}
+		if existing.PodTemplatePath != kind.PodTemplatePath {
+			return errors.Errorf("custom object kind %v already registered with pod template path %s", gvk, existing.PodTemplatePath)
+		}
+		return nil
+	}
+	customObjectKinds = append(customObjectKinds, kind)
+	return nil
+}
+
+// GetCustomObjectKind returns the custom object kind that the given GVK belongs to, if it was registered.
+func GetCustomObjectKind(gvk schema.GroupVersionKind) (CustomObjectKind, bool) {
+	customObjectKindsLock.RLock()
+	defer customObjectKindsLock.RUnlock()
+	for _, kind := range customObjectKinds {
+		registered := kind.GroupVersionKind
+		if registered.GroupKind() == gvk.GroupKind() && (registered.Version == "" || registered.Version == gvk.Version) {
+			return kind, true
+		}
+	}
+	return CustomObjectKind{}, false
+}
+
+func isCustomObjectKind(gvk schema.GroupVersionKind) bool {
+	_, ok := GetCustomObjectKind(gvk)
+	return ok
+}
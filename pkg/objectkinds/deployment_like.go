@@ -44,5 +44,7 @@ const (
 )
 
 func init() {
-	registerObjectKind(DeploymentLike, matcherFunc(isDeploymentLike))
+	registerObjectKind(DeploymentLike, matcherFunc(func(gvk schema.GroupVersionKind) bool {
+		return (isDeploymentLike(gvk) && !isDisabledOpenShiftKind(gvk)) || isCustomObjectKind(gvk)
+	}))
 }
@@ -0,0 +1,33 @@
+package objectkinds
+
+import (
+	autoscalingV1 "k8s.io/api/autoscaling/v1"
+	autoscalingV2beta1 "k8s.io/api/autoscaling/v2beta1"
+	autoscalingV2beta2 "k8s.io/api/autoscaling/v2beta2"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// HorizontalPodAutoscaler represents Kubernetes HorizontalPodAutoscaler objects.
+	HorizontalPodAutoscaler = "HorizontalPodAutoscaler"
+)
+
+var (
+	horizontalPodAutoscalerGVKs = map[schema.GroupVersionKind]struct{}{
+		autoscalingV1.SchemeGroupVersion.WithKind("HorizontalPodAutoscaler"):      {},
+		autoscalingV2beta1.SchemeGroupVersion.WithKind("HorizontalPodAutoscaler"): {},
+		autoscalingV2beta2.SchemeGroupVersion.WithKind("HorizontalPodAutoscaler"): {},
+	}
+)
+
+func init() {
+	registerObjectKind(HorizontalPodAutoscaler, matcherFunc(func(gvk schema.GroupVersionKind) bool {
+		_, ok := horizontalPodAutoscalerGVKs[gvk]
+		return ok
+	}))
+}
+
+// GetHorizontalPodAutoscalerAPIVersion returns the preferred apiversion of horizontalpodautoscalers.
+func GetHorizontalPodAutoscalerAPIVersion() string {
+	return autoscalingV1.SchemeGroupVersion.String()
+}
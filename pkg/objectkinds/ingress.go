@@ -0,0 +1,26 @@
+package objectkinds
+
+import (
+	networkingV1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// Ingress represents Kubernetes Ingress objects.
+	Ingress = "Ingress"
+)
+
+var (
+	ingressGVK = networkingV1.SchemeGroupVersion.WithKind("Ingress")
+)
+
+func init() {
+	registerObjectKind(Ingress, matcherFunc(func(gvk schema.GroupVersionKind) bool {
+		return gvk == ingressGVK
+	}))
+}
+
+// GetIngressAPIVersion returns the preferred apiversion of ingresses.
+func GetIngressAPIVersion() string {
+	return networkingV1.SchemeGroupVersion.String()
+}
@@ -0,0 +1,58 @@
+package objectkinds
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// Namespaced represents the ObjectKind that matches any object that lives in a namespace, that is any object whose
+	// kind is not known to be cluster-scoped. Objects of unknown kinds, like ones defined by CRDs, are taken to be
+	// namespaced, which most of them are.
+	Namespaced = "Namespaced"
+)
+
+var (
+	// clusterScopedGroupKinds are the kinds of objects that do not live in a namespace. Versions are left out, since
+	// the scope of a kind does not change between them.
+	clusterScopedGroupKinds = map[schema.GroupKind]struct{}{
+		{Kind: "Namespace"}:        {},
+		{Kind: "Node"}:             {},
+		{Kind: "PersistentVolume"}: {},
+		{Kind: "ComponentStatus"}:  {},
+		{Group: "rbac.authorization.k8s.io", Kind: "ClusterRole"}:                       {},
+		{Group: "rbac.authorization.k8s.io", Kind: "ClusterRoleBinding"}:                {},
+		{Group: "storage.k8s.io", Kind: "StorageClass"}:                                 {},
+		{Group: "storage.k8s.io", Kind: "CSIDriver"}:                                    {},
+		{Group: "storage.k8s.io", Kind: "CSINode"}:                                      {},
+		{Group: "storage.k8s.io", Kind: "VolumeAttachment"}:                             {},
+		{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}:               {},
+		{Group: "apiregistration.k8s.io", Kind: "APIService"}:                           {},
+		{Group: "admissionregistration.k8s.io", Kind: "MutatingWebhookConfiguration"}:   {},
+		{Group: "admissionregistration.k8s.io", Kind: "ValidatingWebhookConfiguration"}: {},
+		{Group: "scheduling.k8s.io", Kind: "PriorityClass"}:                             {},
+		{Group: "node.k8s.io", Kind: "RuntimeClass"}:                                    {},
+		{Group: "networking.k8s.io", Kind: "IngressClass"}:                              {},
+		{Group: "policy", Kind: "PodSecurityPolicy"}:                                    {},
+		{Group: "extensions", Kind: "PodSecurityPolicy"}:                                {},
+		{Group: "certificates.k8s.io", Kind: "CertificateSigningRequest"}:               {},
+		{Group: "flowcontrol.apiserver.k8s.io", Kind: "FlowSchema"}:                     {},
+		{Group: "flowcontrol.apiserver.k8s.io", Kind: "PriorityLevelConfiguration"}:     {},
+		{Group: "project.openshift.io", Kind: "Project"}:                                {},
+		{Group: "security.openshift.io", Kind: "SecurityContextConstraints"}:            {},
+		{Group: "quota.openshift.io", Kind: "ClusterResourceQuota"}:                     {},
+		{Group: "config.openshift.io", Kind: "ClusterOperator"}:                         {},
+	}
+)
+
+// IsClusterScoped returns whether objects of the given GVK are known not to live in a namespace, like ClusterRoles or
+// Namespaces themselves.
+func IsClusterScoped(gvk schema.GroupVersionKind) bool {
+	_, ok := clusterScopedGroupKinds[gvk.GroupKind()]
+	return ok
+}
+
+func init() {
+	registerObjectKind(Namespaced, matcherFunc(func(gvk schema.GroupVersionKind) bool {
+		return !IsClusterScoped(gvk)
+	}))
+}
@@ -0,0 +1,60 @@
+package objectkinds
+
+import (
+	"sync/atomic"
+
+	ocsAppsV1 "github.com/openshift/api/apps/v1"
+	ocsBuildV1 "github.com/openshift/api/build/v1"
+	ocsRouteV1 "github.com/openshift/api/route/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// Route represents OpenShift Route objects.
+	Route = "Route"
+	// BuildConfig represents OpenShift BuildConfig objects.
+	BuildConfig = "BuildConfig"
+)
+
+var (
+	routeGVK       = ocsRouteV1.SchemeGroupVersion.WithKind("Route")
+	buildConfigGVK = ocsBuildV1.SchemeGroupVersion.WithKind("BuildConfig")
+
+	openShiftGroups = map[string]struct{}{
+		ocsAppsV1.GroupName:  {},
+		ocsBuildV1.GroupName: {},
+		ocsRouteV1.GroupName: {},
+	}
+
+	// openShiftKindsDisabled is 1 if SetOpenShiftKindsEnabled disabled the OpenShift kinds, and 0 otherwise.
+	openShiftKindsDisabled int32
+)
+
+// SetOpenShiftKindsEnabled sets whether objects of OpenShift kinds, like DeploymentConfigs, Routes and BuildConfigs,
+// match the object kinds of checks. They do by default. Objects of disabled kinds are still loaded, and still match
+// the Any object kind.
+func SetOpenShiftKindsEnabled(enabled bool) {
+	var disabled int32
+	if !enabled {
+		disabled = 1
+	}
+	atomic.StoreInt32(&openShiftKindsDisabled, disabled)
+}
+
+// isDisabledOpenShiftKind returns whether the given GVK is an OpenShift kind, and OpenShift kinds are disabled.
+func isDisabledOpenShiftKind(gvk schema.GroupVersionKind) bool {
+	if atomic.LoadInt32(&openShiftKindsDisabled) == 0 {
+		return false
+	}
+	_, isOpenShift := openShiftGroups[gvk.Group]
+	return isOpenShift
+}
+
+func init() {
+	registerObjectKind(Route, matcherFunc(func(gvk schema.GroupVersionKind) bool {
+		return gvk == routeGVK && !isDisabledOpenShiftKind(gvk)
+	}))
+	registerObjectKind(BuildConfig, matcherFunc(func(gvk schema.GroupVersionKind) bool {
+		return gvk == buildConfigGVK && !isDisabledOpenShiftKind(gvk)
+	}))
+}
@@ -0,0 +1,31 @@
+package objectkinds
+
+import (
+	policyV1 "k8s.io/api/policy/v1"
+	policyV1beta1 "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// PodDisruptionBudget represents Kubernetes PodDisruptionBudget objects.
+	PodDisruptionBudget = "PodDisruptionBudget"
+)
+
+var (
+	podDisruptionBudgetGVKs = map[schema.GroupVersionKind]struct{}{
+		policyV1.SchemeGroupVersion.WithKind("PodDisruptionBudget"):      {},
+		policyV1beta1.SchemeGroupVersion.WithKind("PodDisruptionBudget"): {},
+	}
+)
+
+func init() {
+	registerObjectKind(PodDisruptionBudget, matcherFunc(func(gvk schema.GroupVersionKind) bool {
+		_, ok := podDisruptionBudgetGVKs[gvk]
+		return ok
+	}))
+}
+
+// GetPodDisruptionBudgetAPIVersion returns the preferred apiversion of poddisruptionbudgets.
+func GetPodDisruptionBudgetAPIVersion() string {
+	return policyV1.SchemeGroupVersion.String()
+}
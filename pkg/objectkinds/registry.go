@@ -40,11 +40,11 @@ func (o orMatcher) Matches(gvk schema.GroupVersionKind) bool {
 }
 
 // ConstructMatcher constructs a matcher that matches objects that fall
-// into one of the given object kinds.
+// into one of the given object kinds. See lookupObjectKind for the names that are accepted.
 func ConstructMatcher(objectKinds ...string) (Matcher, error) {
 	var matchers []Matcher
 	for _, obj := range objectKinds {
-		matcher := allObjectKinds[obj]
+		matcher := lookupObjectKind(obj)
 		if matcher == nil {
 			return nil, errors.Errorf("unknown object kind: %v", obj)
 		}
@@ -0,0 +1,21 @@
+package objectkinds
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// Secret represents Kubernetes Secret objects.
+	Secret = "Secret"
+)
+
+var (
+	secretGVK = v1.SchemeGroupVersion.WithKind("Secret")
+)
+
+func init() {
+	registerObjectKind(Secret, matcherFunc(func(gvk schema.GroupVersionKind) bool {
+		return gvk == secretGVK
+	}))
+}
@@ -0,0 +1,48 @@
+package run_test
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.stackrox.io/kube-linter/pkg/builtinchecks"
+	"golang.stackrox.io/kube-linter/pkg/checkregistry"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/run"
+	_ "golang.stackrox.io/kube-linter/pkg/templates/all" // Register all the templates that the built-in checks use.
+)
+
+const manifest = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        image: app:latest
+`
+
+func ExampleRun() {
+	registry := checkregistry.New()
+	if err := builtinchecks.LoadInto(registry); err != nil {
+		panic(err)
+	}
+
+	lintCtxs, err := lintcontext.CreateContextsFromReader("deployment.yaml", strings.NewReader(manifest))
+	if err != nil {
+		panic(err)
+	}
+
+	result, err := run.Run(lintCtxs, registry, []string{"latest-tag", "privileged-container"})
+	if err != nil {
+		panic(err)
+	}
+	for _, report := range result.Reports {
+		fmt.Printf("%s: %s (%s)\n", report.Check, report.Diagnostic.Message, report.Severity)
+	}
+	fmt.Println(result.Summary.ChecksStatus)
+	// Output:
+	// latest-tag: The container "app" is using an invalid container image, "app:latest". Please use images that are not blocked by the `BlockList` criteria : [".*:(latest)$" "^[^:]*$" "(.*/[^:]+)$"] (warning)
+	// Failed
+}
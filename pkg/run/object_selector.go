@@ -0,0 +1,93 @@
+package run
+
+import (
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/objectkinds"
+)
+
+// ObjectSelector selects objects by kind, and optionally by namespace and name.
+// Its textual form is <kind>[:[<namespace>/]<name>], where each part can be a glob pattern, for example
+// "Deployment", "Deployment:api-*" or "*:kube-system/*". Kinds are matched case-insensitively, and can also be given
+// as the short names and plurals that kubectl accepts, like deploy or deployments.
+type ObjectSelector struct {
+	kind      string
+	namespace string
+	name      string
+}
+
+// ParseObjectSelector parses an ObjectSelector from its textual form.
+func ParseObjectSelector(s string) (ObjectSelector, error) {
+	selector := ObjectSelector{namespace: "*", name: "*"}
+	kindAndRest := strings.SplitN(strings.TrimSpace(s), ":", 2)
+	selector.kind = strings.ToLower(kindAndRest[0])
+	// Short names and plurals, like deploy or deployments, stand for their kinds, like with kubectl.
+	if !strings.ContainsAny(selector.kind, `*?[\`) {
+		selector.kind = strings.ToLower(objectkinds.CanonicalKind(selector.kind))
+	}
+	if len(kindAndRest) == 2 {
+		namespaceAndName := strings.SplitN(kindAndRest[1], "/", 2)
+		if len(namespaceAndName) == 2 {
+			selector.namespace, selector.name = namespaceAndName[0], namespaceAndName[1]
+		} else {
+			selector.name = namespaceAndName[0]
+		}
+	}
+	if selector.kind == "" || selector.name == "" {
+		return ObjectSelector{}, errors.Errorf("invalid object selector %q, expected <kind>[:[<namespace>/]<name>]", s)
+	}
+	for _, pattern := range []string{selector.kind, selector.namespace, selector.name} {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return ObjectSelector{}, errors.Errorf("invalid object selector %q: malformed pattern %q", s, pattern)
+		}
+	}
+	return selector, nil
+}
+
+// ParseObjectSelectors parses each of the given ObjectSelectors, as given on the command line.
+func ParseObjectSelectors(selectors []string) ([]ObjectSelector, error) {
+	out := make([]ObjectSelector, 0, len(selectors))
+	for _, s := range selectors {
+		selector, err := ParseObjectSelector(s)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, selector)
+	}
+	return out, nil
+}
+
+// Matches returns whether the selector selects the given object.
+func (s ObjectSelector) Matches(obj lintcontext.Object) bool {
+	gvk := obj.K8sObject.GetObjectKind().GroupVersionKind()
+	// The patterns were validated when the selector was parsed, so matching cannot fail.
+	kindMatches, _ := path.Match(s.kind, strings.ToLower(gvk.Kind))
+	namespaceMatches, _ := path.Match(s.namespace, obj.K8sObject.GetNamespace())
+	nameMatches, _ := path.Match(s.name, obj.K8sObject.GetName())
+	return kindMatches && namespaceMatches && nameMatches
+}
+
+// isSelected returns whether the object should be linted, according to the given include and exclude selectors.
+// Objects are linted if they match any of the include selectors, or if there are none, and do not match any of the
+// exclude selectors.
+func isSelected(obj lintcontext.Object, include, exclude []ObjectSelector) bool {
+	included := len(include) == 0
+	for _, selector := range include {
+		if selector.Matches(obj) {
+			included = true
+			break
+		}
+	}
+	if !included {
+		return false
+	}
+	for _, selector := range exclude {
+		if selector.Matches(obj) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,73 @@
+package run
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	appsV1 "k8s.io/api/apps/v1"
+	batchV1 "k8s.io/api/batch/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestObjectSelectorMatches(t *testing.T) {
+	deployment := lintcontext.Object{K8sObject: &appsV1.Deployment{
+		TypeMeta:   metaV1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: metaV1.ObjectMeta{Name: "api-server", Namespace: "prod"},
+	}}
+
+	for _, testCase := range []struct {
+		selector string
+		matches  bool
+	}{
+		{selector: "Deployment", matches: true},
+		{selector: "deployment", matches: true},
+		{selector: "StatefulSet", matches: false},
+		{selector: "deploy", matches: true},
+		{selector: "Deployments:prod/api-server", matches: true},
+		{selector: "sts", matches: false},
+		{selector: "*", matches: true},
+		{selector: "Deployment:api-*", matches: true},
+		{selector: "Deployment:worker-*", matches: false},
+		{selector: "Deployment:prod/api-server", matches: true},
+		{selector: "*:prod/*", matches: true},
+		{selector: "*:staging/*", matches: false},
+	} {
+		t.Run(testCase.selector, func(t *testing.T) {
+			selector, err := ParseObjectSelector(testCase.selector)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.matches, selector.Matches(deployment))
+		})
+	}
+}
+
+func TestParseObjectSelectorInvalid(t *testing.T) {
+	for _, selector := range []string{"", ":prod/app", "Deployment:", "Deployment:prod/", "Deployment:[prod/app"} {
+		_, err := ParseObjectSelector(selector)
+		assert.Error(t, err, "selector %q", selector)
+	}
+}
+
+func TestIsSelected(t *testing.T) {
+	deployment := lintcontext.Object{K8sObject: &appsV1.Deployment{
+		TypeMeta:   metaV1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: metaV1.ObjectMeta{Name: "app"},
+	}}
+	cronJob := lintcontext.Object{K8sObject: &batchV1.CronJob{
+		TypeMeta:   metaV1.TypeMeta{APIVersion: "batch/v1", Kind: "CronJob"},
+		ObjectMeta: metaV1.ObjectMeta{Name: "app"},
+	}}
+	mustParse := func(selectors ...string) []ObjectSelector {
+		parsed, err := ParseObjectSelectors(selectors)
+		require.NoError(t, err)
+		return parsed
+	}
+
+	assert.True(t, isSelected(cronJob, nil, nil))
+	assert.True(t, isSelected(deployment, mustParse("Deployment", "StatefulSet"), nil))
+	assert.False(t, isSelected(cronJob, mustParse("Deployment", "StatefulSet"), nil))
+	assert.True(t, isSelected(deployment, nil, mustParse("CronJob")))
+	assert.False(t, isSelected(cronJob, nil, mustParse("CronJob")))
+	assert.False(t, isSelected(deployment, mustParse("*"), mustParse("Deployment:app")), "exclusions take precedence")
+}
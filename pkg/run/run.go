@@ -1,16 +1,23 @@
 package run
 
 import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"sync"
 	"time"
 
-	"github.com/pkg/errors"
 	"golang.stackrox.io/kube-linter/internal/version"
+	"golang.stackrox.io/kube-linter/pkg/check"
 	"golang.stackrox.io/kube-linter/pkg/checkregistry"
 	"golang.stackrox.io/kube-linter/pkg/config"
 	"golang.stackrox.io/kube-linter/pkg/diagnostic"
 	"golang.stackrox.io/kube-linter/pkg/ignore"
 	"golang.stackrox.io/kube-linter/pkg/instantiatedcheck"
 	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 // CheckStatus is enum type.
@@ -28,6 +35,31 @@ type Result struct {
 	Checks  []config.Check
 	Reports []diagnostic.WithContext
 	Summary Summary
+	// LoadErrors are the errors that kept objects from being linted at all, like Helm charts that failed to render, or
+	// kinds of objects that could not be listed from a cluster.
+	LoadErrors []LoadError `json:",omitempty"`
+
+	// Objects are all the valid objects that were linted, including the ones without any reports, but not the ones
+	// that were filtered out by the object selectors in the Options.
+	// They are not serialized because that would be too much data.
+	Objects []lintcontext.Object `json:"-"`
+	// IgnoredReports are the reports that were suppressed through ignore annotations on the objects.
+	IgnoredReports []IgnoredReport `json:"-"`
+}
+
+// IgnoredReport is a report that was suppressed through an ignore annotation on the object it applied to.
+type IgnoredReport struct {
+	Report diagnostic.WithContext
+	// Reason is the value of the ignore annotation.
+	Reason string
+}
+
+// LoadError is an error that kept the objects of a file from being linted at all.
+type LoadError struct {
+	FilePath string
+	// Line is the line of the file that the error is about, or 0 if it is not known.
+	Line    int `json:",omitempty"`
+	Message string
 }
 
 // Summary holds information about the linter run overall.
@@ -35,51 +67,439 @@ type Summary struct {
 	ChecksStatus      CheckStatus
 	CheckEndTime      time.Time
 	KubeLinterVersion string
+	Counts            ReportCounts
+	Objects           ObjectCounts
+	// SuppressedReports is the number of reports that were left out of the output because of a cap on the number of
+	// reports, like --max-findings. Counts still include them.
+	SuppressedReports int `json:",omitempty"`
+}
+
+// ObjectCounts holds statistics about the objects of a run, which tell how much of the input was actually linted.
+// Objects that were loaded more than once, like through overlapping paths, are only counted once.
+type ObjectCounts struct {
+	// Parsed is the number of objects that were loaded, including the ones filtered out by the object selectors.
+	Parsed int
+	// Invalid is the number of documents that could not be loaded as objects.
+	Invalid int
+	// Unparsed is the number of the Invalid documents that are not reported as load errors, like malformed YAML
+	// documents or documents that are not Kubernetes objects, whose errors are only logged in verbose mode.
+	Unparsed int
+	// Linted is the number of objects that were not filtered out by the object selectors.
+	Linted int
+	// Checked is the number of linted objects that at least one of the checks applies to.
+	Checked int
+}
+
+// ReportCounts holds statistics about the reports of a run.
+type ReportCounts struct {
+	Reports int
+	// Objects is the number of objects with at least one report.
+	Objects int
+	// Files is the number of files with at least one report.
+	Files    int
+	Errors   int
+	Warnings int
+	Infos    int
+}
+
+// CountReports computes the statistics about the given reports.
+func CountReports(reports []diagnostic.WithContext) ReportCounts {
+	counts := ReportCounts{Reports: len(reports)}
+	objects := make(map[string]struct{})
+	files := make(map[string]struct{})
+	for i := range reports {
+		report := &reports[i]
+		name := report.Object.GetK8sObjectName()
+		objects[report.Object.Metadata.FilePath+"|"+name.String()] = struct{}{}
+		files[report.Object.Metadata.FilePath] = struct{}{}
+		switch report.Severity {
+		case config.SeverityError:
+			counts.Errors++
+		case config.SeverityWarning:
+			counts.Warnings++
+		case config.SeverityInfo:
+			counts.Infos++
+		}
+	}
+	counts.Objects = len(objects)
+	counts.Files = len(files)
+	return counts
+}
+
+// Options represent values that can be provided to modify how the linter is run.
+type Options struct {
+	// Workers is the number of objects that are checked concurrently. Defaults to GOMAXPROCS.
+	Workers int
+	// IncludeObjects, if set, restricts the objects that are linted to the ones matching any of the selectors.
+	IncludeObjects []ObjectSelector
+	// ExcludeObjects are the selectors of the objects that are not linted. They take precedence over IncludeObjects.
+	// Objects that are not linted are still visible to checks that correlate objects, like the ones looking for
+	// dangling references.
+	ExcludeObjects []ObjectSelector
+	// Progress, if set, is called with the number of objects checked so far and the total number of objects to check,
+	// after each object has been checked. It is never called concurrently.
+	Progress func(done, total int)
+}
+
+// CheckNotFoundError is returned when one of the checks to run is not in the check registry.
+type CheckNotFoundError struct {
+	Check string
+}
+
+func (e *CheckNotFoundError) Error() string {
+	return fmt.Sprintf("check %q not found", e.Check)
 }
 
-// Run runs the linter on the given context, with the given config.
+// Run runs the given checks, which must be registered in the given registry, on the objects in the given contexts.
+// It is the entry point for using KubeLinter as a library: it does not write anything to stdout or stderr, and does
+// not modify its inputs, so it is safe to call concurrently with the same contexts and registry, as long as the
+// registry is not modified at the same time.
 func Run(lintCtxs []lintcontext.LintContext, registry checkregistry.CheckRegistry, checks []string) (Result, error) {
+	return RunWithOptions(Options{}, lintCtxs, registry, checks)
+}
+
+// objectToCheck is an object to run the checks on, along with the context it belongs to, and the functions of the
+// checks for that context, in the same order as the checks.
+type objectToCheck struct {
+	lintCtx    lintcontext.LintContext
+	checkFuncs []check.Func
+	obj        lintcontext.Object
+}
+
+// objectResult holds the reports produced by running the checks on a single object.
+type objectResult struct {
+	reports        []diagnostic.WithContext
+	ignoredReports []IgnoredReport
+}
+
+// RunWithOptions runs the linter on the given context, with the given config and additional Options.
+// Objects are checked concurrently, but the reports in the result are always sorted by file path, object, check and
+// message. Objects that were loaded more than once, like through overlapping paths, and identical reports on them, are
+// only included once.
+// The guarantees documented on Run apply.
+func RunWithOptions(options Options, lintCtxs []lintcontext.LintContext, registry checkregistry.CheckRegistry, checks []string) (Result, error) {
 	var result Result
 
 	instantiatedChecks := make([]*instantiatedcheck.InstantiatedCheck, 0, len(checks))
 	for _, checkName := range checks {
 		instantiatedCheck := registry.Load(checkName)
 		if instantiatedCheck == nil {
-			return Result{}, errors.Errorf("check %q not found", checkName)
+			return Result{}, &CheckNotFoundError{Check: checkName}
 		}
 		instantiatedChecks = append(instantiatedChecks, instantiatedCheck)
 		result.Checks = append(result.Checks, instantiatedCheck.Spec)
 	}
 
+	var objects []objectToCheck
+	counter := newObjectCounter()
 	for _, lintCtx := range lintCtxs {
+		for _, invalidObj := range lintCtx.InvalidObjects() {
+			loadErr, isLoadErr := loadErrorOf(invalidObj)
+			if isLoadErr {
+				result.LoadErrors = append(result.LoadErrors, loadErr)
+			}
+			counter.countInvalid(invalidObj, isLoadErr)
+		}
+		// Checks that correlate objects index the objects of the context here, once, before any object is checked.
+		checkFuncs := make([]check.Func, 0, len(instantiatedChecks))
+		for _, instantiatedCheck := range instantiatedChecks {
+			checkFuncs = append(checkFuncs, instantiatedCheck.FuncForContext(lintCtx))
+		}
 		for _, obj := range lintCtx.Objects() {
-			for _, check := range instantiatedChecks {
-				if !check.Matcher.Matches(obj.K8sObject.GetObjectKind().GroupVersionKind()) {
-					continue
-				}
-				if ignore.ObjectForCheck(obj.K8sObject.GetAnnotations(), check.Spec.Name) {
-					continue
-				}
-				diagnostics := check.Func(lintCtx, obj)
-				for _, d := range diagnostics {
-					result.Reports = append(result.Reports, diagnostic.WithContext{
-						Diagnostic:  d,
-						Check:       check.Spec.Name,
-						Remediation: check.Spec.Remediation,
-						Object:      obj,
-					})
+			selected := isSelected(obj, options.IncludeObjects, options.ExcludeObjects)
+			isNew := counter.count(obj, selected, selected && anyCheckApplies(instantiatedChecks, obj))
+			if !selected {
+				continue
+			}
+			// Objects loaded more than once are still checked in every context, since checks that correlate objects
+			// can report differently in each, but are only listed once.
+			if isNew {
+				result.Objects = append(result.Objects, obj)
+			}
+			objects = append(objects, objectToCheck{lintCtx: lintCtx, checkFuncs: checkFuncs, obj: obj})
+		}
+	}
+
+	workers := options.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	// Every worker writes to its own elements of objectResults, so no locking is needed.
+	objectResults := make([]objectResult, len(objects))
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	var progressLock sync.Mutex
+	done := 0
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range indices {
+				objectResults[idx] = runChecks(instantiatedChecks, objects[idx])
+				if options.Progress != nil {
+					progressLock.Lock()
+					done++
+					options.Progress(done, len(objects))
+					progressLock.Unlock()
 				}
 			}
+		}()
+	}
+	for idx := range objects {
+		indices <- idx
+	}
+	close(indices)
+	wg.Wait()
+
+	// The same file can be reached through several arguments, like a directory and a file in it, in which case every
+	// report on its objects comes up once per argument.
+	seenReports := make(map[reportFingerprint]struct{})
+	seenIgnoredReports := make(map[reportFingerprint]struct{})
+	for _, objectResult := range objectResults {
+		for _, report := range objectResult.reports {
+			if isDuplicate(seenReports, &report) {
+				continue
+			}
+			result.Reports = append(result.Reports, report)
+		}
+		for _, ignored := range objectResult.ignoredReports {
+			if isDuplicate(seenIgnoredReports, &ignored.Report) {
+				continue
+			}
+			result.IgnoredReports = append(result.IgnoredReports, ignored)
 		}
 	}
+	result.LoadErrors = dedupeLoadErrors(result.LoadErrors)
+	sort.SliceStable(result.Reports, func(i, j int) bool {
+		return reportLess(&result.Reports[i], &result.Reports[j])
+	})
+	sort.SliceStable(result.LoadErrors, func(i, j int) bool {
+		return result.LoadErrors[i].FilePath < result.LoadErrors[j].FilePath
+	})
+	sort.SliceStable(result.IgnoredReports, func(i, j int) bool {
+		return reportLess(&result.IgnoredReports[i].Report, &result.IgnoredReports[j].Report)
+	})
 
 	if len(result.Reports) > 0 {
 		result.Summary.ChecksStatus = ChecksFailed
 	} else {
 		result.Summary.ChecksStatus = ChecksPassed
 	}
+	result.Summary.Counts = CountReports(result.Reports)
+	result.Summary.Objects = counter.counts
 	result.Summary.CheckEndTime = time.Now().UTC()
 	result.Summary.KubeLinterVersion = version.Get()
 
 	return result, nil
 }
+
+// loadErrorOf returns the error of the given invalid object if it kept a whole file or archive, or a whole kind of
+// objects listed from a cluster, from being linted.
+// Other invalid objects are only reported in verbose mode, since they are often documents that are not
+// Kubernetes objects at all.
+func loadErrorOf(invalidObj lintcontext.InvalidObject) (LoadError, bool) {
+	var renderErr *lintcontext.HelmRenderError
+	var listErr *lintcontext.ClusterListError
+	var archiveErr *lintcontext.ArchiveError
+	switch {
+	case errors.As(invalidObj.LoadErr, &renderErr):
+		return LoadError{
+			FilePath: invalidObj.Metadata.FilePath,
+			Line:     renderErr.Line,
+			Message:  renderErr.Error(),
+		}, true
+	case errors.As(invalidObj.LoadErr, &listErr):
+		return LoadError{
+			FilePath: invalidObj.Metadata.FilePath,
+			Message:  listErr.Error(),
+		}, true
+	case errors.As(invalidObj.LoadErr, &archiveErr):
+		return LoadError{
+			FilePath: invalidObj.Metadata.FilePath,
+			Message:  archiveErr.Error(),
+		}, true
+	}
+	return LoadError{}, false
+}
+
+// objectCounter computes the ObjectCounts of a run, counting every object only once.
+type objectCounter struct {
+	counts ObjectCounts
+	seen   map[objectFingerprint]struct{}
+}
+
+// objectFingerprint identifies an object independently of the argument that it was loaded through, like
+// reportFingerprint. Invalid objects are told apart by their errors instead of their names.
+type objectFingerprint struct {
+	filePath string
+	line     int
+	gvk      schema.GroupVersionKind
+	object   string
+	loadErr  string
+}
+
+func newObjectCounter() *objectCounter {
+	return &objectCounter{seen: make(map[objectFingerprint]struct{})}
+}
+
+func (c *objectCounter) isDuplicate(fingerprint objectFingerprint) bool {
+	if _, ok := c.seen[fingerprint]; ok {
+		return true
+	}
+	c.seen[fingerprint] = struct{}{}
+	return false
+}
+
+// count counts the given object, and returns whether it is the first time that it is seen.
+func (c *objectCounter) count(obj lintcontext.Object, linted, checked bool) bool {
+	name := obj.GetK8sObjectName()
+	if c.isDuplicate(objectFingerprint{
+		filePath: filepath.Clean(obj.Metadata.FilePath),
+		line:     obj.Metadata.Line,
+		gvk:      name.GroupVersionKind,
+		object:   name.Namespace + "/" + name.Name,
+	}) {
+		return false
+	}
+	c.counts.Parsed++
+	if linted {
+		c.counts.Linted++
+	}
+	if checked {
+		c.counts.Checked++
+	}
+	return true
+}
+
+// countInvalid counts the given invalid object, which isLoadErr tells whether it is reported as a LoadError.
+func (c *objectCounter) countInvalid(invalidObj lintcontext.InvalidObject, isLoadErr bool) {
+	fingerprint := objectFingerprint{filePath: filepath.Clean(invalidObj.Metadata.FilePath), line: invalidObj.Metadata.Line}
+	if invalidObj.LoadErr != nil {
+		fingerprint.loadErr = invalidObj.LoadErr.Error()
+	}
+	if c.isDuplicate(fingerprint) {
+		return
+	}
+	c.counts.Invalid++
+	if !isLoadErr {
+		c.counts.Unparsed++
+	}
+}
+
+// anyCheckApplies returns whether any of the given checks applies to the kind and namespace of the given object.
+func anyCheckApplies(instantiatedChecks []*instantiatedcheck.InstantiatedCheck, obj lintcontext.Object) bool {
+	gvk := obj.K8sObject.GetObjectKind().GroupVersionKind()
+	namespace := obj.K8sObject.GetNamespace()
+	for _, check := range instantiatedChecks {
+		if check.Matcher.Matches(gvk) && check.MatchesNamespace(namespace) {
+			return true
+		}
+	}
+	return false
+}
+
+// reportFingerprint identifies a report independently of the argument that its object was loaded through.
+// Objects are told apart by the file they are in and their position in it, so that distinct objects that share a
+// name, in the same file or different ones, are never merged.
+type reportFingerprint struct {
+	filePath string
+	gvk      schema.GroupVersionKind
+	object   string
+	check    string
+	message  string
+	line     int
+	column   int
+}
+
+// isDuplicate returns whether a report with the same fingerprint as the given one was seen before, and records it
+// otherwise.
+func isDuplicate(seen map[reportFingerprint]struct{}, report *diagnostic.WithContext) bool {
+	name := report.Object.GetK8sObjectName()
+	fingerprint := reportFingerprint{
+		filePath: filepath.Clean(report.Object.Metadata.FilePath),
+		gvk:      name.GroupVersionKind,
+		object:   name.Namespace + "/" + name.Name,
+		check:    report.Check,
+		message:  report.Diagnostic.Message,
+		line:     report.Diagnostic.Line,
+		column:   report.Diagnostic.Column,
+	}
+	if _, ok := seen[fingerprint]; ok {
+		return true
+	}
+	seen[fingerprint] = struct{}{}
+	return false
+}
+
+// dedupeLoadErrors removes the load errors of files that were loaded more than once, keeping the first one.
+func dedupeLoadErrors(loadErrs []LoadError) []LoadError {
+	seen := make(map[LoadError]struct{}, len(loadErrs))
+	var out []LoadError
+	for _, loadErr := range loadErrs {
+		key := loadErr
+		key.FilePath = filepath.Clean(key.FilePath)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		out = append(out, loadErr)
+	}
+	return out
+}
+
+// runChecks runs all the given checks that apply to the object.
+func runChecks(instantiatedChecks []*instantiatedcheck.InstantiatedCheck, object objectToCheck) objectResult {
+	obj := object.obj
+	var res objectResult
+	for i, check := range instantiatedChecks {
+		if !check.Matcher.Matches(obj.K8sObject.GetObjectKind().GroupVersionKind()) || !check.MatchesNamespace(obj.K8sObject.GetNamespace()) {
+			continue
+		}
+		diagnostics := object.checkFuncs[i](object.lintCtx, obj)
+		// Ignore annotations are applied after the check has run, so that we can keep track of
+		// what was suppressed, and why.
+		reason, ignored := ignore.ReasonForCheck(obj.K8sObject.GetAnnotations(), check.Spec.Name)
+		for _, d := range diagnostics {
+			d.Line, d.Column = obj.Metadata.Position(d.FieldPath)
+			report := diagnostic.WithContext{
+				Diagnostic:  d,
+				Check:       check.Spec.Name,
+				Severity:    check.Spec.Severity,
+				Remediation: check.Spec.Remediation,
+				DocsURL:     check.Spec.DocsURL,
+				Object:      obj,
+			}
+			if ignored {
+				res.ignoredReports = append(res.ignoredReports, IgnoredReport{Report: report, Reason: reason})
+				continue
+			}
+			res.reports = append(res.reports, report)
+		}
+	}
+	return res
+}
+
+// reportLess orders reports by file path, then namespace, object kind, object name, check and message, so that the
+// order does not depend on the order in which objects were loaded or checked.
+func reportLess(a, b *diagnostic.WithContext) bool {
+	if a.Object.Metadata.FilePath != b.Object.Metadata.FilePath {
+		return a.Object.Metadata.FilePath < b.Object.Metadata.FilePath
+	}
+	aName, bName := a.Object.GetK8sObjectName(), b.Object.GetK8sObjectName()
+	if aName.Namespace != bName.Namespace {
+		return aName.Namespace < bName.Namespace
+	}
+	if aName.GroupVersionKind.Kind != bName.GroupVersionKind.Kind {
+		return aName.GroupVersionKind.Kind < bName.GroupVersionKind.Kind
+	}
+	if aGVK, bGVK := aName.GroupVersionKind.String(), bName.GroupVersionKind.String(); aGVK != bGVK {
+		return aGVK < bGVK
+	}
+	if aName.Name != bName.Name {
+		return aName.Name < bName.Name
+	}
+	if a.Check != b.Check {
+		return a.Check < b.Check
+	}
+	return a.Diagnostic.Message < b.Diagnostic.Message
+}
@@ -0,0 +1,200 @@
+package run
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.stackrox.io/kube-linter/pkg/checkregistry"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+)
+
+// The benchmarks in this file are meant to be compared across changes with benchstat, see CONTRIBUTING.md. Keep
+// their names, corpora and checks stable, so that their results stay comparable.
+
+const (
+	// corpusDir holds the fixtures of all built-in checks, which cover every object kind that the checks look at,
+	// both with and without lint errors.
+	corpusDir = "../../tests/checks"
+
+	// The size of the large Deployment of largeObjectYAML. Changing them makes results incomparable to earlier ones.
+	largeObjectContainers = 40
+	largeObjectEnvVars    = 30
+	largeObjectPorts      = 5
+	// largeObjectLabels is the number of labels, and of annotations.
+	largeObjectLabels = 20
+
+	// largeObjectContainer is a container of the large Deployment, given its index, environment variables and ports.
+	largeObjectContainer = `        - name: container-%[1]d
+          image: registry.example.com/large/container-%[1]d:1.%[1]d.0
+          imagePullPolicy: IfNotPresent
+          env:
+%[2]s          ports:
+%[3]s          volumeMounts:
+            - name: config-%[1]d
+              mountPath: /etc/config-%[1]d
+              readOnly: true
+          resources:
+            requests:
+              cpu: 100m
+              memory: 128Mi
+            limits:
+              cpu: 500m
+              memory: 512Mi
+          livenessProbe:
+            httpGet:
+              path: /healthz
+              port: port-0
+          readinessProbe:
+            httpGet:
+              path: /ready
+              port: port-0
+          securityContext:
+            readOnlyRootFilesystem: true
+            runAsNonRoot: true
+`
+)
+
+var (
+	// benchmarkCustomChecks are checks on templates that are expensive per object, but that no built-in check uses.
+	benchmarkCustomChecks = []config.Check{
+		{
+			Name:     "benchmark-cel-expression",
+			Template: "cel-expression",
+			Params: map[string]interface{}{
+				"expression": "has(object.spec) && has(object.spec.template) && object.spec.template.spec.containers.exists(c, !has(c.resources))",
+			},
+		},
+		{
+			Name:     "benchmark-jsonpath-field",
+			Template: "jsonpath-field",
+			Params: map[string]interface{}{
+				"forEach":   "{.spec.template.spec.containers[*]}",
+				"path":      "{.securityContext.runAsNonRoot}",
+				"operation": "equals",
+				"value":     "true",
+			},
+		},
+	}
+)
+
+func loadBenchmarkContexts(b *testing.B, filesOrDirs ...string) []lintcontext.LintContext {
+	lintCtxs, err := lintcontext.CreateContexts(filesOrDirs...)
+	require.NoError(b, err)
+	require.NotEmpty(b, lintCtxs)
+	return lintCtxs
+}
+
+// largeObjectYAML returns a single Deployment with many containers, each with many environment variables, ports and
+// volume mounts, for benchmarking how the checks scale with the size of objects.
+func largeObjectYAML() string {
+	var labels, annotations, volumes, containers strings.Builder
+	for i := 0; i < largeObjectLabels; i++ {
+		fmt.Fprintf(&labels, "    label-%d: value-%d\n", i, i)
+		fmt.Fprintf(&annotations, "    example.com/annotation-%d: value-%d\n", i, i)
+	}
+	for i := 0; i < largeObjectContainers; i++ {
+		fmt.Fprintf(&volumes, "        - name: config-%[1]d\n          configMap:\n            name: config-%[1]d\n", i)
+		var env, ports strings.Builder
+		for j := 0; j < largeObjectEnvVars; j++ {
+			// Every tenth environment variable comes from a secret.
+			if j%10 == 0 {
+				fmt.Fprintf(&env, "            - name: SECRET_%[2]d\n              valueFrom:\n                secretKeyRef:\n"+
+					"                  name: secret-%[1]d\n                  key: key-%[2]d\n", i, j)
+				continue
+			}
+			fmt.Fprintf(&env, "            - name: VAR_%[2]d\n              value: \"value-%[1]d-%[2]d\"\n", i, j)
+		}
+		for j := 0; j < largeObjectPorts; j++ {
+			fmt.Fprintf(&ports, "            - name: port-%d\n              containerPort: %d\n", j, 8000+10*i+j)
+		}
+		fmt.Fprintf(&containers, largeObjectContainer, i, env.String(), ports.String())
+	}
+	return `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: large
+  namespace: benchmark
+  labels:
+` + labels.String() + `  annotations:
+` + annotations.String() + `spec:
+  replicas: 3
+  selector:
+    matchLabels:
+      app: large
+  template:
+    metadata:
+      labels:
+        app: large
+    spec:
+      serviceAccountName: large
+      volumes:
+` + volumes.String() + `      containers:
+` + containers.String()
+}
+
+// writeLargeObjectFile writes largeObjectYAML to a temporary file, and returns its path.
+func writeLargeObjectFile(b *testing.B) string {
+	path := filepath.Join(b.TempDir(), "large-deployment.yaml")
+	require.NoError(b, ioutil.WriteFile(path, []byte(largeObjectYAML()), 0644))
+	return path
+}
+
+// benchmarkChecks returns a registry with the built-in checks and benchmarkCustomChecks, and the names of all of them.
+func benchmarkChecks(b *testing.B) (checkregistry.CheckRegistry, []string) {
+	registry, checks := allBuiltInChecks(b)
+	for i := range benchmarkCustomChecks {
+		check := benchmarkCustomChecks[i]
+		check.Scope = &config.ObjectKindsDesc{ObjectKinds: []string{"DeploymentLike"}}
+		require.NoError(b, registry.Register(&check))
+		checks = append(checks, check.Name)
+	}
+	return registry, checks
+}
+
+func runBenchmark(b *testing.B, options Options, lintCtxs []lintcontext.LintContext, registry checkregistry.CheckRegistry, checks []string) {
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := RunWithOptions(options, lintCtxs, registry, checks)
+		require.NoError(b, err)
+	}
+}
+
+// BenchmarkRunCorpus measures the throughput of running all checks over a representative corpus of objects.
+func BenchmarkRunCorpus(b *testing.B) {
+	registry, checks := benchmarkChecks(b)
+	lintCtxs := loadBenchmarkContexts(b, corpusDir)
+
+	b.Run("workers=1", func(b *testing.B) {
+		runBenchmark(b, Options{Workers: 1}, lintCtxs, registry, checks)
+	})
+	b.Run("workers=0", func(b *testing.B) {
+		runBenchmark(b, Options{}, lintCtxs, registry, checks)
+	})
+}
+
+// BenchmarkRunLargeObject measures how running all checks scales with the size of a single object.
+func BenchmarkRunLargeObject(b *testing.B) {
+	registry, checks := benchmarkChecks(b)
+	lintCtxs := loadBenchmarkContexts(b, writeLargeObjectFile(b))
+
+	runBenchmark(b, Options{Workers: 1}, lintCtxs, registry, checks)
+}
+
+// BenchmarkCheck measures every check on its own, over the corpus and the large object, to find out which check
+// a regression comes from. Use e.g. -bench 'BenchmarkCheck/dangling-service$' to only run some of them.
+func BenchmarkCheck(b *testing.B) {
+	registry, checks := benchmarkChecks(b)
+	lintCtxs := loadBenchmarkContexts(b, corpusDir, writeLargeObjectFile(b))
+
+	for _, check := range checks {
+		b.Run(check, func(b *testing.B) {
+			runBenchmark(b, Options{Workers: 1}, lintCtxs, registry, []string{check})
+		})
+	}
+}
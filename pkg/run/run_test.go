@@ -0,0 +1,496 @@
+package run
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.stackrox.io/kube-linter/pkg/builtinchecks"
+	"golang.stackrox.io/kube-linter/pkg/checkregistry"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	_ "golang.stackrox.io/kube-linter/pkg/templates/all"
+	appsV1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// fakeLintContext is a LintContext that returns its objects in the order they were given.
+type fakeLintContext struct {
+	objects        []lintcontext.Object
+	invalidObjects []lintcontext.InvalidObject
+}
+
+func (f *fakeLintContext) Objects() []lintcontext.Object {
+	return f.objects
+}
+
+func (f *fakeLintContext) InvalidObjects() []lintcontext.InvalidObject {
+	return f.invalidObjects
+}
+
+// syntheticLintContexts returns contexts with numObjects deployments spread over a few files, in a random order.
+func syntheticLintContexts(numObjects int, seed int64) []lintcontext.LintContext {
+	var objects []lintcontext.Object
+	for i := 0; i < numObjects; i++ {
+		objects = append(objects, lintcontext.Object{
+			Metadata: lintcontext.ObjectMetadata{FilePath: fmt.Sprintf("file-%d.yaml", i%7)},
+			K8sObject: &appsV1.Deployment{
+				TypeMeta:   metaV1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+				ObjectMeta: metaV1.ObjectMeta{Name: fmt.Sprintf("deployment-%d", i), Namespace: "default"},
+				Spec: appsV1.DeploymentSpec{
+					Template: v1.PodTemplateSpec{
+						Spec: v1.PodSpec{
+							Containers: []v1.Container{{Name: "app", Image: "app:latest"}},
+						},
+					},
+				},
+			},
+		})
+	}
+	rand.New(rand.NewSource(seed)).Shuffle(len(objects), func(i, j int) {
+		objects[i], objects[j] = objects[j], objects[i]
+	})
+	return []lintcontext.LintContext{&fakeLintContext{objects: objects}}
+}
+
+func allBuiltInChecks(t testing.TB) (checkregistry.CheckRegistry, []string) {
+	registry := checkregistry.New()
+	require.NoError(t, builtinchecks.LoadInto(registry))
+	checks, err := builtinchecks.List()
+	require.NoError(t, err)
+	checkNames := make([]string, 0, len(checks))
+	for _, check := range checks {
+		checkNames = append(checkNames, check.Name)
+	}
+	return registry, checkNames
+}
+
+func TestRunReportsAreDeterministic(t *testing.T) {
+	registry, checks := allBuiltInChecks(t)
+
+	expected, err := RunWithOptions(Options{Workers: 1}, syntheticLintContexts(100, 1), registry, checks)
+	require.NoError(t, err)
+	require.NotEmpty(t, expected.Reports)
+	for i := 1; i < len(expected.Reports); i++ {
+		assert.False(t, reportLess(&expected.Reports[i], &expected.Reports[i-1]), "reports are not sorted")
+	}
+
+	for _, workers := range []int{0, 2, 16} {
+		// Shuffle the objects differently too, the order in which they are loaded should not matter either.
+		actual, err := RunWithOptions(Options{Workers: workers}, syntheticLintContexts(100, int64(workers)), registry, checks)
+		require.NoError(t, err)
+		assert.Equal(t, expected.Reports, actual.Reports, "workers: %d", workers)
+	}
+}
+
+func BenchmarkRun(b *testing.B) {
+	registry, checks := allBuiltInChecks(b)
+	lintCtxs := syntheticLintContexts(3000, 0)
+
+	for _, workers := range []int{1, 0} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, err := RunWithOptions(Options{Workers: workers}, lintCtxs, registry, checks)
+				require.NoError(b, err)
+			}
+		})
+	}
+}
+
+func TestRunCheckNotFound(t *testing.T) {
+	registry, _ := allBuiltInChecks(t)
+	_, err := Run(syntheticLintContexts(1, 0), registry, []string{"does-not-exist"})
+	var notFoundErr *CheckNotFoundError
+	require.True(t, errors.As(err, &notFoundErr))
+	assert.Equal(t, "does-not-exist", notFoundErr.Check)
+}
+
+func TestRunCorrelatesObjectsWithinContext(t *testing.T) {
+	registry, _ := allBuiltInChecks(t)
+	service := lintcontext.Object{
+		Metadata: lintcontext.ObjectMetadata{FilePath: "service.yaml"},
+		K8sObject: &v1.Service{
+			TypeMeta:   metaV1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+			ObjectMeta: metaV1.ObjectMeta{Name: "app", Namespace: "default"},
+			Spec:       v1.ServiceSpec{Selector: map[string]string{"app": "app"}},
+		},
+	}
+	deployment := lintcontext.Object{
+		Metadata: lintcontext.ObjectMetadata{FilePath: "deployment.yaml"},
+		K8sObject: &appsV1.Deployment{
+			TypeMeta:   metaV1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+			ObjectMeta: metaV1.ObjectMeta{Name: "app", Namespace: "default"},
+			Spec: appsV1.DeploymentSpec{
+				Template: v1.PodTemplateSpec{ObjectMeta: metaV1.ObjectMeta{Labels: map[string]string{"app": "app"}}},
+			},
+		},
+	}
+
+	// The service selects the deployment when they are in the same context...
+	result, err := Run([]lintcontext.LintContext{&fakeLintContext{objects: []lintcontext.Object{service, deployment}}}, registry, []string{"dangling-service"})
+	require.NoError(t, err)
+	assert.Empty(t, result.Reports)
+
+	// ...but not when they are in different ones.
+	result, err = Run([]lintcontext.LintContext{
+		&fakeLintContext{objects: []lintcontext.Object{service}},
+		&fakeLintContext{objects: []lintcontext.Object{deployment}},
+	}, registry, []string{"dangling-service"})
+	require.NoError(t, err)
+	require.Len(t, result.Reports, 1)
+	assert.Equal(t, "service.yaml", result.Reports[0].Object.Metadata.FilePath)
+}
+
+func TestRunReportsHelmRenderErrors(t *testing.T) {
+	registry, checks := allBuiltInChecks(t)
+	lintCtx := &fakeLintContext{
+		objects: syntheticLintContexts(1, 0)[0].Objects(),
+		invalidObjects: []lintcontext.InvalidObject{
+			{
+				Metadata: lintcontext.ObjectMetadata{FilePath: "chart/templates/deployment.yaml"},
+				LoadErr:  &lintcontext.HelmRenderError{Chart: "chart", FilePath: "chart/templates/deployment.yaml", Line: 3, Err: errors.New("boom")},
+			},
+			// Other invalid objects are not load errors of whole files.
+			{Metadata: lintcontext.ObjectMetadata{FilePath: "not-k8s.yaml"}, LoadErr: errors.New("no kind")},
+		},
+	}
+
+	result, err := Run([]lintcontext.LintContext{lintCtx}, registry, checks)
+	require.NoError(t, err)
+	assert.NotEmpty(t, result.Reports, "the valid objects should still be linted")
+	assert.Equal(t, []LoadError{{FilePath: "chart/templates/deployment.yaml", Line: 3, Message: "failed to render: boom"}}, result.LoadErrors)
+}
+
+func TestRunReportsClusterListErrors(t *testing.T) {
+	registry, checks := allBuiltInChecks(t)
+	lintCtx := &fakeLintContext{
+		invalidObjects: []lintcontext.InvalidObject{{
+			Metadata: lintcontext.ObjectMetadata{FilePath: "cluster://v1/services"},
+			LoadErr:  &lintcontext.ClusterListError{Resource: "v1/services", Err: errors.New("forbidden")},
+		}},
+	}
+
+	result, err := Run([]lintcontext.LintContext{lintCtx}, registry, checks)
+	require.NoError(t, err)
+	assert.Equal(t, []LoadError{{FilePath: "cluster://v1/services", Message: "listing v1/services: forbidden"}}, result.LoadErrors)
+}
+
+func TestRunReportsArchiveErrors(t *testing.T) {
+	registry, checks := allBuiltInChecks(t)
+	lintCtx := &fakeLintContext{
+		invalidObjects: []lintcontext.InvalidObject{{
+			Metadata: lintcontext.ObjectMetadata{FilePath: "manifests.zip"},
+			LoadErr:  &lintcontext.ArchiveError{Archive: "manifests.zip", Err: errors.New("zip: not a valid zip file")},
+		}},
+	}
+
+	result, err := Run([]lintcontext.LintContext{lintCtx}, registry, checks)
+	require.NoError(t, err)
+	assert.Equal(t, []LoadError{{FilePath: "manifests.zip", Message: "reading archive manifests.zip: zip: not a valid zip file"}}, result.LoadErrors)
+}
+
+func TestRunOnlyLintsSelectedObjects(t *testing.T) {
+	registry, _ := allBuiltInChecks(t)
+	service := lintcontext.Object{
+		Metadata: lintcontext.ObjectMetadata{FilePath: "service.yaml"},
+		K8sObject: &v1.Service{
+			TypeMeta:   metaV1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+			ObjectMeta: metaV1.ObjectMeta{Name: "app", Namespace: "default"},
+			Spec:       v1.ServiceSpec{Selector: map[string]string{"app": "app"}},
+		},
+	}
+	deployment := lintcontext.Object{
+		Metadata: lintcontext.ObjectMetadata{FilePath: "deployment.yaml"},
+		K8sObject: &appsV1.Deployment{
+			TypeMeta:   metaV1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+			ObjectMeta: metaV1.ObjectMeta{Name: "app", Namespace: "default"},
+			Spec: appsV1.DeploymentSpec{
+				Template: v1.PodTemplateSpec{ObjectMeta: metaV1.ObjectMeta{Labels: map[string]string{"app": "app"}}},
+			},
+		},
+	}
+	lintCtxs := []lintcontext.LintContext{&fakeLintContext{objects: []lintcontext.Object{service, deployment}}}
+	exclude, err := ParseObjectSelectors([]string{"Deployment"})
+	require.NoError(t, err)
+
+	result, err := RunWithOptions(Options{ExcludeObjects: exclude}, lintCtxs, registry, []string{"dangling-service"})
+	require.NoError(t, err)
+	assert.Equal(t, []lintcontext.Object{service}, result.Objects)
+	// The deployment is not linted, but the service still sees it.
+	assert.Empty(t, result.Reports)
+
+	result, err = RunWithOptions(Options{IncludeObjects: exclude, ExcludeObjects: exclude}, lintCtxs, registry, []string{"dangling-service"})
+	require.NoError(t, err)
+	assert.Empty(t, result.Objects)
+}
+
+func TestRunSortsReportsByCompositeKey(t *testing.T) {
+	registry, checks := allBuiltInChecks(t)
+	containers := []v1.Container{{Name: "sidecar", Image: "sidecar:latest"}, {Name: "app", Image: "app:latest"}}
+	objects := []lintcontext.Object{
+		{
+			Metadata: lintcontext.ObjectMetadata{FilePath: "b.yaml"},
+			K8sObject: &appsV1.Deployment{
+				TypeMeta:   metaV1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+				ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: "default"},
+				Spec:       appsV1.DeploymentSpec{Template: v1.PodTemplateSpec{Spec: v1.PodSpec{Containers: containers}}},
+			},
+		},
+		{
+			Metadata: lintcontext.ObjectMetadata{FilePath: "a.yaml"},
+			K8sObject: &v1.Pod{
+				TypeMeta:   metaV1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+				ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: "default"},
+				Spec:       v1.PodSpec{Containers: containers},
+			},
+		},
+		{
+			Metadata: lintcontext.ObjectMetadata{FilePath: "a.yaml"},
+			K8sObject: &appsV1.Deployment{
+				TypeMeta:   metaV1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+				ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: "default"},
+				Spec:       appsV1.DeploymentSpec{Template: v1.PodTemplateSpec{Spec: v1.PodSpec{Containers: containers}}},
+			},
+		},
+		{
+			Metadata: lintcontext.ObjectMetadata{FilePath: "a.yaml"},
+			K8sObject: &appsV1.Deployment{
+				TypeMeta:   metaV1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+				ObjectMeta: metaV1.ObjectMeta{Name: "api", Namespace: "default"},
+				Spec:       appsV1.DeploymentSpec{Template: v1.PodTemplateSpec{Spec: v1.PodSpec{Containers: containers}}},
+			},
+		},
+		{
+			Metadata: lintcontext.ObjectMetadata{FilePath: "a.yaml"},
+			K8sObject: &v1.Service{
+				TypeMeta:   metaV1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+				ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: "apps"},
+				Spec:       v1.ServiceSpec{Selector: map[string]string{"app": "missing"}},
+			},
+		},
+	}
+
+	shuffled := func(seed int64) []lintcontext.LintContext {
+		copied := append([]lintcontext.Object(nil), objects...)
+		rand.New(rand.NewSource(seed)).Shuffle(len(copied), func(i, j int) {
+			copied[i], copied[j] = copied[j], copied[i]
+		})
+		return []lintcontext.LintContext{&fakeLintContext{objects: copied}}
+	}
+
+	expected, err := Run(shuffled(0), registry, checks)
+	require.NoError(t, err)
+	require.NotEmpty(t, expected.Reports)
+
+	keyOf := func(report diagnostic.WithContext) []string {
+		name := report.Object.GetK8sObjectName()
+		return []string{report.Object.Metadata.FilePath, name.Namespace, name.GroupVersionKind.Kind, name.Name, report.Check, report.Diagnostic.Message}
+	}
+	for i := 1; i < len(expected.Reports); i++ {
+		prev, cur := keyOf(expected.Reports[i-1]), keyOf(expected.Reports[i])
+		assert.True(t, strings.Join(prev, "\x00") <= strings.Join(cur, "\x00"), "reports %v and %v are out of order", prev, cur)
+	}
+	// The service in the apps namespace sorts before all objects in the default namespace of the same file.
+	assert.Equal(t, []string{"a.yaml", "apps", "Service", "web"}, keyOf(expected.Reports[0])[:4])
+
+	for seed := int64(1); seed < 10; seed++ {
+		actual, err := Run(shuffled(seed), registry, checks)
+		require.NoError(t, err)
+		assert.Equal(t, expected.Reports, actual.Reports, "seed: %d", seed)
+	}
+}
+
+func TestRunReportsFieldPositions(t *testing.T) {
+	registry, _ := allBuiltInChecks(t)
+	dir := t.TempDir()
+	manifest := `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        image: app:latest
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "deployment.yaml"), []byte(manifest), 0644))
+	lintCtxs, err := lintcontext.CreateContexts(dir)
+	require.NoError(t, err)
+
+	result, err := Run(lintCtxs, registry, []string{"latest-tag", "no-liveness-probe"})
+	require.NoError(t, err)
+	require.Len(t, result.Reports, 2)
+	// The image is flagged at the image field, the missing probe at the container.
+	assert.Equal(t, "latest-tag", result.Reports[0].Check)
+	assert.Equal(t, "spec.template.spec.containers[0].image", result.Reports[0].Diagnostic.FieldPath)
+	assert.Equal(t, []int{10, 9}, []int{result.Reports[0].Diagnostic.Line, result.Reports[0].Diagnostic.Column})
+	assert.Equal(t, "no-liveness-probe", result.Reports[1].Check)
+	assert.Equal(t, []int{9, 9}, []int{result.Reports[1].Diagnostic.Line, result.Reports[1].Diagnostic.Column})
+}
+
+func TestRunDeduplicatesReportsFromOverlappingPaths(t *testing.T) {
+	registry, _ := allBuiltInChecks(t)
+	dir := t.TempDir()
+	deployment := `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        image: app:latest
+`
+	// The second file holds a distinct object with the same name, which must still be reported.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "deployment.yaml"), []byte(deployment), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "copy.yaml"), []byte(deployment), 0644))
+	lintCtxs, err := lintcontext.CreateContexts(dir, filepath.Join(dir, "deployment.yaml"), dir+"/./deployment.yaml")
+	require.NoError(t, err)
+
+	result, err := Run(lintCtxs, registry, []string{"latest-tag"})
+	require.NoError(t, err)
+	require.Len(t, result.Objects, 2)
+	require.Len(t, result.Reports, 2)
+	assert.Equal(t, filepath.Join(dir, "copy.yaml"), result.Reports[0].Object.Metadata.FilePath)
+	assert.Equal(t, filepath.Join(dir, "deployment.yaml"), result.Reports[1].Object.Metadata.FilePath)
+}
+
+func TestRunCountsReports(t *testing.T) {
+	registry, _ := allBuiltInChecks(t)
+
+	result, err := Run(syntheticLintContexts(10, 0), registry, []string{"latest-tag", "no-liveness-probe"})
+	require.NoError(t, err)
+	// Every deployment has a container with a latest tag and no liveness probe.
+	assert.Equal(t, ReportCounts{Reports: 20, Objects: 10, Files: 7, Warnings: 20}, result.Summary.Counts)
+}
+
+func TestRunCountsObjects(t *testing.T) {
+	registry, _ := allBuiltInChecks(t)
+	dir := t.TempDir()
+	manifests := `apiVersion: v1
+kind: Service
+metadata:
+  name: app
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: app
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+---
+foo: bar
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "manifests.yaml"), []byte(manifests), 0644))
+	// Objects loaded through overlapping paths are only counted once.
+	lintCtxs, err := lintcontext.CreateContexts(dir, filepath.Join(dir, "manifests.yaml"))
+	require.NoError(t, err)
+	exclude, err := ParseObjectSelectors([]string{"Deployment"})
+	require.NoError(t, err)
+
+	result, err := RunWithOptions(Options{ExcludeObjects: exclude}, lintCtxs, registry, []string{"dangling-service"})
+	require.NoError(t, err)
+	// The deployment is excluded, and the check only applies to the service.
+	assert.Equal(t, ObjectCounts{Parsed: 3, Invalid: 1, Unparsed: 1, Linted: 2, Checked: 1}, result.Summary.Objects)
+}
+
+func TestRunScopesChecksByNamespace(t *testing.T) {
+	var objects []lintcontext.Object
+	for _, namespace := range []string{"kube-system", "kube-public", "app", "other", ""} {
+		objects = append(objects, lintcontext.Object{
+			Metadata: lintcontext.ObjectMetadata{FilePath: "deployments.yaml"},
+			K8sObject: &appsV1.Deployment{
+				TypeMeta:   metaV1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+				ObjectMeta: metaV1.ObjectMeta{Name: "app", Namespace: namespace},
+				Spec: appsV1.DeploymentSpec{
+					Template: v1.PodTemplateSpec{
+						Spec: v1.PodSpec{Containers: []v1.Container{{Name: "app", Image: "app:latest"}}},
+					},
+				},
+			},
+		})
+	}
+	lintCtxs := []lintcontext.LintContext{&fakeLintContext{objects: objects}}
+
+	for _, testCase := range []struct {
+		desc               string
+		scope              *config.NamespaceScope
+		expectedNamespaces []string
+	}{
+		{
+			desc:               "no scope",
+			expectedNamespaces: []string{"", "app", "kube-public", "kube-system", "other"},
+		},
+		{
+			desc:               "include",
+			scope:              &config.NamespaceScope{Include: []string{"kube-system"}, IncludeRegex: "ap+"},
+			expectedNamespaces: []string{"app", "kube-system"},
+		},
+		{
+			desc:               "include with objects without a namespace",
+			scope:              &config.NamespaceScope{Include: []string{"app"}, WithoutNamespace: config.WithoutNamespaceInclude},
+			expectedNamespaces: []string{"", "app"},
+		},
+		{
+			desc:               "exclude",
+			scope:              &config.NamespaceScope{ExcludeRegex: "kube-.*"},
+			expectedNamespaces: []string{"", "app", "other"},
+		},
+		{
+			desc:               "exclude objects without a namespace",
+			scope:              &config.NamespaceScope{Exclude: []string{"other"}, WithoutNamespace: config.WithoutNamespaceExclude},
+			expectedNamespaces: []string{"app", "kube-public", "kube-system"},
+		},
+		{
+			desc:               "exclude takes precedence",
+			scope:              &config.NamespaceScope{IncludeRegex: "kube-.*", Exclude: []string{"kube-public"}},
+			expectedNamespaces: []string{"kube-system"},
+		},
+	} {
+		t.Run(testCase.desc, func(t *testing.T) {
+			registry := checkregistry.New()
+			require.NoError(t, registry.Register(&config.Check{
+				Name:       "latest-tag",
+				Template:   "latest-tag",
+				Params:     map[string]interface{}{"blockList": []interface{}{".*:latest"}},
+				Namespaces: testCase.scope,
+			}))
+
+			result, err := Run(lintCtxs, registry, []string{"latest-tag"})
+			require.NoError(t, err)
+			namespaces := make([]string, 0, len(result.Reports))
+			for _, report := range result.Reports {
+				namespaces = append(namespaces, report.Object.K8sObject.GetNamespace())
+			}
+			assert.Equal(t, testCase.expectedNamespaces, namespaces)
+		})
+	}
+}
+
+func TestRunReportsProgress(t *testing.T) {
+	registry, checks := allBuiltInChecks(t)
+
+	var updates []int
+	_, err := RunWithOptions(Options{
+		Workers: 4,
+		Progress: func(done, total int) {
+			assert.Equal(t, 10, total)
+			updates = append(updates, done)
+		},
+	}, syntheticLintContexts(10, 0), registry, checks)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, updates)
+}
@@ -101,3 +101,11 @@ func WrapInstantiateFunc(f func(p Params) (check.Func, error)) func (interface{}
 		return f(paramsInt.(Params))
 	}
 }
+
+// WrapInstantiateContextMatcherFunc is a convenience wrapper that wraps an untyped instantiate function
+// for a context matcher into a typed one.
+func WrapInstantiateContextMatcherFunc(f func(p Params) (check.ContextMatcher, error)) func (interface{}) (check.ContextMatcher, error) {
+	return func(paramsInt interface{}) (check.ContextMatcher, error) {
+		return f(paramsInt.(Params))
+	}
+}
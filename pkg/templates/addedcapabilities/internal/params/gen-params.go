@@ -0,0 +1,94 @@
+// Code generated by kube-linter template codegen. DO NOT EDIT.
+// +build !templatecodegen
+
+package params
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
+)
+
+var (
+	// Use some imports in case they don't get used otherwise.
+	_ = util.MustParseParameterDesc
+	_ = fmt.Sprintf
+
+	deniedCapabilitiesParamDesc = util.MustParseParameterDesc(`{
+	"Name": "deniedCapabilities",
+	"Type": "array",
+	"Description": "Capabilities that containers must not add, e.g. SYS_ADMIN. Capabilities are compared ignoring case and the CAP_ prefix, and containers that add ALL are flagged if this list is not empty.",
+	"Examples": null,
+	"Enum": null,
+	"SubParameters": null,
+	"ArrayElemType": "string",
+	"Required": false,
+	"NoRegex": true,
+	"NotNegatable": true,
+	"XXXStructFieldName": "DeniedCapabilities",
+	"XXXIsPointer": false
+}
+`)
+
+	allowedCapabilitiesParamDesc = util.MustParseParameterDesc(`{
+	"Name": "allowedCapabilities",
+	"Type": "array",
+	"Description": "Capabilities that containers are allowed to add. If specified, containers must not add any other capability, and they must not add ALL unless it is part of this list.",
+	"Examples": null,
+	"Enum": null,
+	"SubParameters": null,
+	"ArrayElemType": "string",
+	"Required": false,
+	"NoRegex": true,
+	"NotNegatable": true,
+	"XXXStructFieldName": "AllowedCapabilities",
+	"XXXIsPointer": false
+}
+`)
+
+	ParamDescs = []check.ParameterDesc{
+		deniedCapabilitiesParamDesc,
+		allowedCapabilitiesParamDesc,
+	}
+)
+
+func (p *Params) Validate() error {
+	var validationErrors []string
+	if len(validationErrors) > 0 {
+		return errors.Errorf("invalid parameters: %s", strings.Join(validationErrors, ", "))
+    }
+	return nil
+}
+
+// ParseAndValidate instantiates a Params object out of the passed map[string]interface{},
+// validates it, and returns it.
+// The return type is interface{} to satisfy the type in the Template struct.
+func ParseAndValidate(m map[string]interface{}) (interface{}, error) {
+	var p Params
+	if err := util.DecodeMapStructure(m, &p); err != nil {
+		return nil, err
+	}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// WrapInstantiateFunc is a convenience wrapper that wraps an untyped instantiate function
+// into a typed one.
+func WrapInstantiateFunc(f func(p Params) (check.Func, error)) func (interface{}) (check.Func, error) {
+	return func(paramsInt interface{}) (check.Func, error) {
+		return f(paramsInt.(Params))
+	}
+}
+
+// WrapInstantiateContextMatcherFunc is a convenience wrapper that wraps an untyped instantiate function
+// for a context matcher into a typed one.
+func WrapInstantiateContextMatcherFunc(f func(p Params) (check.ContextMatcher, error)) func (interface{}) (check.ContextMatcher, error) {
+	return func(paramsInt interface{}) (check.ContextMatcher, error) {
+		return f(paramsInt.(Params))
+	}
+}
@@ -0,0 +1,17 @@
+package params
+
+// Params represents the params accepted by this template.
+type Params struct {
+
+	// Capabilities that containers must not add, e.g. SYS_ADMIN. Capabilities are compared ignoring case and the
+	// CAP_ prefix, and containers that add ALL are flagged if this list is not empty.
+	// +noregex
+	// +notnegatable
+	DeniedCapabilities []string
+
+	// Capabilities that containers are allowed to add. If specified, containers must not add any other capability,
+	// and they must not add ALL unless it is part of this list.
+	// +noregex
+	// +notnegatable
+	AllowedCapabilities []string
+}
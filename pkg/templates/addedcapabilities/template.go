@@ -0,0 +1,85 @@
+package addedcapabilities
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.stackrox.io/kube-linter/internal/set"
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/objectkinds"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/addedcapabilities/internal/params"
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	templateKey = "added-capabilities"
+
+	// capabilityAll is what containers add to get every capability.
+	capabilityAll = "ALL"
+)
+
+// normalizeCapability returns the given capability in upper case and without the CAP_ prefix, the way the container
+// runtimes accept it.
+func normalizeCapability(capability string) string {
+	return strings.TrimPrefix(strings.ToUpper(capability), "CAP_")
+}
+
+func normalizedCapabilities(capabilities []string) set.StringSet {
+	normalized := set.NewStringSet()
+	for _, capability := range capabilities {
+		normalized.Add(normalizeCapability(capability))
+	}
+	return normalized
+}
+
+func init() {
+	templates.Register(check.Template{
+		HumanName:   "Added Capabilities",
+		Key:         templateKey,
+		Description: "Flag containers that add denied capabilities, or capabilities that are not allowed",
+		SupportedObjectKinds: config.ObjectKindsDesc{
+			ObjectKinds: []string{objectkinds.DeploymentLike},
+		},
+		Parameters:             params.ParamDescs,
+		ParseAndValidateParams: params.ParseAndValidate,
+		Instantiate: params.WrapInstantiateFunc(func(p params.Params) (check.Func, error) {
+			if len(p.DeniedCapabilities) == 0 && len(p.AllowedCapabilities) == 0 {
+				return nil, errors.New("at least one of deniedCapabilities and allowedCapabilities must be specified")
+			}
+			denied := normalizedCapabilities(p.DeniedCapabilities)
+			allowed := normalizedCapabilities(p.AllowedCapabilities)
+
+			return util.PerContainerCheck(func(container *v1.Container) []diagnostic.Diagnostic {
+				sc := container.SecurityContext
+				if sc == nil || sc.Capabilities == nil {
+					return nil
+				}
+				var results []diagnostic.Diagnostic
+				for i, added := range sc.Capabilities.Add {
+					capability := normalizeCapability(string(added))
+					var msg string
+					switch {
+					case denied.Contains(capability) || denied.Contains(capabilityAll):
+						msg = fmt.Sprintf("container %q adds denied capability %q", container.Name, added)
+					case capability == capabilityAll && !denied.IsEmpty():
+						msg = fmt.Sprintf("container %q adds capability %q, which includes the denied capabilities", container.Name, added)
+					case !allowed.IsEmpty() && !allowed.Contains(capability):
+						msg = fmt.Sprintf("container %q adds capability %q, which is not in the allowed capabilities", container.Name, added)
+					default:
+						continue
+					}
+					results = append(results, diagnostic.Diagnostic{
+						Message:   msg,
+						FieldPath: fmt.Sprintf("securityContext.capabilities.add[%d]", i),
+					})
+				}
+				return results
+			}), nil
+		}),
+	})
+}
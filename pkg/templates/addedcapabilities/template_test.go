@@ -0,0 +1,87 @@
+package addedcapabilities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext/mocks"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/addedcapabilities/internal/params"
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestAddedCapabilities(t *testing.T) {
+	suite.Run(t, new(AddedCapabilitiesTestSuite))
+}
+
+type AddedCapabilitiesTestSuite struct {
+	templates.TemplateTestSuite
+
+	ctx *mocks.MockLintContext
+}
+
+func (s *AddedCapabilitiesTestSuite) SetupTest() {
+	s.Init(templateKey)
+	s.ctx = mocks.NewMockContext()
+}
+
+func (s *AddedCapabilitiesTestSuite) addDeploymentWithCapabilities(name string, capabilities ...v1.Capability) {
+	s.ctx.AddMockDeployment(s.T(), name)
+	s.ctx.AddContainerToDeployment(s.T(), name, v1.Container{
+		Name:            "app",
+		SecurityContext: &v1.SecurityContext{Capabilities: &v1.Capabilities{Add: capabilities}},
+	})
+}
+
+func (s *AddedCapabilitiesTestSuite) TestAddedCapabilities() {
+	s.ctx.AddMockDeployment(s.T(), "no-security-context")
+	s.ctx.AddContainerToDeployment(s.T(), "no-security-context", v1.Container{Name: "app"})
+	s.addDeploymentWithCapabilities("net-bind-service", "NET_BIND_SERVICE")
+	s.addDeploymentWithCapabilities("sys-admin", "CAP_SYS_ADMIN", "chown")
+	s.addDeploymentWithCapabilities("all", "ALL")
+
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{DeniedCapabilities: []string{"SYS_ADMIN", "SYS_PTRACE"}},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"sys-admin": {
+					{Message: `container "app" adds denied capability "CAP_SYS_ADMIN"`},
+				},
+				"all": {
+					{Message: `container "app" adds capability "ALL", which includes the denied capabilities`},
+				},
+			},
+		},
+		{
+			Param: params.Params{AllowedCapabilities: []string{"net_bind_service", "CHOWN"}},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"sys-admin": {
+					{Message: `container "app" adds capability "CAP_SYS_ADMIN", which is not in the allowed capabilities`},
+				},
+				"all": {
+					{Message: `container "app" adds capability "ALL", which is not in the allowed capabilities`},
+				},
+			},
+		},
+		{
+			Param: params.Params{DeniedCapabilities: []string{"ALL"}},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"net-bind-service": {
+					{Message: `container "app" adds denied capability "NET_BIND_SERVICE"`},
+				},
+				"sys-admin": {
+					{Message: `container "app" adds denied capability "CAP_SYS_ADMIN"`},
+					{Message: `container "app" adds denied capability "chown"`},
+				},
+				"all": {
+					{Message: `container "app" adds denied capability "ALL"`},
+				},
+			},
+		},
+		{
+			Param:                    params.Params{},
+			ExpectInstantiationError: true,
+		},
+	})
+}
@@ -15,7 +15,7 @@ func TestTemplatesAreValid(t *testing.T) {
 			assert.NotEmpty(t, template.Description, "description")
 			assert.NotNil(t, template.ParseAndValidateParams, "parse and validate params")
 			assert.NotNil(t, template.Parameters, "params") // We want people to use the generated code and explicitly set it to an empty list.
-			assert.NotNil(t, template.Instantiate, "instantiate")
+			assert.True(t, (template.Instantiate == nil) != (template.InstantiateContextMatcher == nil), "exactly one of instantiate and instantiate context matcher")
 		})
 	}
 }
@@ -0,0 +1,12 @@
+package params
+
+// Params represents the params accepted by this template.
+type Params struct {
+
+	// List of registry prefixes that container images are allowed to come from, like "gcr.io/my-project" or
+	// "quay.io". Images without an explicit registry host come from "docker.io", and official images like
+	// "nginx" from "docker.io/library".
+	// +noregex
+	// +notnegatable
+	AllowedRegistries []string
+}
@@ -0,0 +1,76 @@
+package allowedregistries
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/docker/distribution/reference"
+	"github.com/pkg/errors"
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/objectkinds"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/allowedregistries/internal/params"
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	templateKey = "allowed-registries"
+)
+
+func init() {
+	templates.Register(check.Template{
+		HumanName:   "Allowed Registries",
+		Key:         templateKey,
+		Description: "Flag containers, including init and ephemeral containers, whose image does not come from one of the allowed registries",
+		SupportedObjectKinds: config.ObjectKindsDesc{
+			ObjectKinds: []string{objectkinds.DeploymentLike},
+		},
+		Parameters:             params.ParamDescs,
+		ParseAndValidateParams: params.ParseAndValidate,
+		Instantiate: params.WrapInstantiateFunc(func(p params.Params) (check.Func, error) {
+			if len(p.AllowedRegistries) == 0 {
+				return nil, errors.New("no allowed registries specified")
+			}
+			allowedRegistries := make([]string, 0, len(p.AllowedRegistries))
+			for _, registry := range p.AllowedRegistries {
+				registry = strings.TrimSuffix(registry, "/")
+				if registry == "" {
+					return nil, errors.New("allowed registries must not be empty")
+				}
+				allowedRegistries = append(allowedRegistries, registry)
+			}
+
+			return util.PerContainerCheck(func(container *v1.Container) []diagnostic.Diagnostic {
+				if diag := checkImage(allowedRegistries, container.Name, container.Image); diag != nil {
+					return []diagnostic.Diagnostic{*diag}
+				}
+				return nil
+			}), nil
+		}),
+	})
+}
+
+// checkImage returns a diagnostic if the given image does not come from one of the allowed registries.
+func checkImage(allowedRegistries []string, containerName, image string) *diagnostic.Diagnostic {
+	named, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return &diagnostic.Diagnostic{
+			Message:   fmt.Sprintf("container %q has image %q that could not be parsed: %v", containerName, image, err),
+			FieldPath: "image",
+		}
+	}
+	// The normalized name includes the registry host, defaulting to docker.io, but neither the tag nor the digest.
+	name := named.Name()
+	for _, registry := range allowedRegistries {
+		if name == registry || strings.HasPrefix(name, registry+"/") {
+			return nil
+		}
+	}
+	return &diagnostic.Diagnostic{
+		Message:   fmt.Sprintf("container %q has image %q that is not from an allowed registry (allowed: %q)", containerName, image, allowedRegistries),
+		FieldPath: "image",
+	}
+}
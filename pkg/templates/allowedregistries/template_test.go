@@ -0,0 +1,129 @@
+package allowedregistries
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext/mocks"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/allowedregistries/internal/params"
+	appsV1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	digest = "sha256:4c9d6db3e1a8b4b7c2a8b9e1d66e0d1e6e3d4f1c8d1b5e7f0a9b8c7d6e5f4a3b"
+)
+
+func TestAllowedRegistries(t *testing.T) {
+	suite.Run(t, new(AllowedRegistriesTestSuite))
+}
+
+type AllowedRegistriesTestSuite struct {
+	templates.TemplateTestSuite
+
+	ctx *mocks.MockLintContext
+}
+
+func (s *AllowedRegistriesTestSuite) SetupTest() {
+	s.Init(templateKey)
+	s.ctx = mocks.NewMockContext()
+}
+
+func (s *AllowedRegistriesTestSuite) addDeploymentWithImage(name, image string) {
+	s.ctx.AddMockDeployment(s.T(), name)
+	s.ctx.AddContainerToDeployment(s.T(), name, v1.Container{Name: "app", Image: image})
+}
+
+func (s *AllowedRegistriesTestSuite) TestImages() {
+	s.addDeploymentWithImage("bare", "nginx")
+	s.addDeploymentWithImage("docker-hub-user", "myorg/app:1.0")
+	s.addDeploymentWithImage("allowed-host", "gcr.io/my-project/app:1.0")
+	s.addDeploymentWithImage("allowed-digest", "gcr.io/my-project/app@"+digest)
+	s.addDeploymentWithImage("other-project", "gcr.io/other-project/app:1.0")
+	s.addDeploymentWithImage("lookalike-host", "gcr.io.example.com/my-project/app")
+	s.addDeploymentWithImage("digest", "quay.io/app:1.0@"+digest)
+	s.addDeploymentWithImage("invalid", "not a valid image")
+
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{
+				AllowedRegistries: []string{"docker.io/library", "gcr.io/my-project/"},
+			},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"docker-hub-user": {{Message: `container "app" has image "myorg/app:1.0" that is not from an allowed registry (allowed: ["docker.io/library" "gcr.io/my-project"])`}},
+				"other-project":   {{Message: `container "app" has image "gcr.io/other-project/app:1.0" that is not from an allowed registry (allowed: ["docker.io/library" "gcr.io/my-project"])`}},
+				"lookalike-host":  {{Message: `container "app" has image "gcr.io.example.com/my-project/app" that is not from an allowed registry (allowed: ["docker.io/library" "gcr.io/my-project"])`}},
+				"digest":          {{Message: `container "app" has image "quay.io/app:1.0@` + digest + `" that is not from an allowed registry (allowed: ["docker.io/library" "gcr.io/my-project"])`}},
+				"invalid":         {{Message: `container "app" has image "not a valid image" that could not be parsed: invalid reference format`}},
+			},
+		},
+		{
+			Param: params.Params{
+				AllowedRegistries: []string{"docker.io", "gcr.io", "quay.io"},
+			},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"lookalike-host": {{Message: `container "app" has image "gcr.io.example.com/my-project/app" that is not from an allowed registry (allowed: ["docker.io" "gcr.io" "quay.io"])`}},
+				"invalid":        {{Message: `container "app" has image "not a valid image" that could not be parsed: invalid reference format`}},
+			},
+		},
+		{
+			Param:                    params.Params{},
+			ExpectInstantiationError: true,
+		},
+		{
+			Param: params.Params{
+				AllowedRegistries: []string{""},
+			},
+			ExpectInstantiationError: true,
+		},
+	})
+}
+
+func (s *AllowedRegistriesTestSuite) TestInitAndEphemeralContainers() {
+	s.ctx.AddMockDeployment(s.T(), "dep")
+	s.ctx.ModifyDeployment(s.T(), "dep", func(deployment *appsV1.Deployment) {
+		deployment.Spec.Template.Spec.InitContainers = []v1.Container{{Name: "init", Image: "busybox"}}
+		deployment.Spec.Template.Spec.Containers = []v1.Container{{Name: "app", Image: "quay.io/app:1.0"}}
+		deployment.Spec.Template.Spec.EphemeralContainers = []v1.EphemeralContainer{
+			{EphemeralContainerCommon: v1.EphemeralContainerCommon{Name: "debug", Image: "docker.io/library/busybox"}},
+		}
+	})
+
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{
+				AllowedRegistries: []string{"quay.io"},
+			},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"dep": {
+					{Message: `container "init" has image "busybox" that is not from an allowed registry (allowed: ["quay.io"])`},
+					{Message: `container "debug" has image "docker.io/library/busybox" that is not from an allowed registry (allowed: ["quay.io"])`},
+				},
+			},
+		},
+	})
+}
+
+func (s *AllowedRegistriesTestSuite) TestFieldPaths() {
+	s.ctx.AddMockDeployment(s.T(), "dep")
+	s.ctx.ModifyDeployment(s.T(), "dep", func(deployment *appsV1.Deployment) {
+		deployment.Spec.Template.Spec.Containers = []v1.Container{
+			{Name: "app", Image: "busybox"},
+			{Name: "proxy", Image: "quay.io/proxy:1.0"},
+			{Name: "agent", Image: "docker.io/library/agent:1.0"},
+		}
+	})
+
+	checkFunc, err := s.Template.Instantiate(params.Params{AllowedRegistries: []string{"quay.io"}})
+	s.Require().NoError(err)
+	var fieldPaths []string
+	for _, d := range checkFunc(s.ctx, s.ctx.Objects()[0]) {
+		fieldPaths = append(fieldPaths, d.FieldPath)
+	}
+	s.Equal([]string{
+		"spec.template.spec.containers[0].image",
+		"spec.template.spec.containers[2].image",
+	}, fieldPaths)
+}
@@ -41,17 +41,52 @@ var (
 	"Enum": null,
 	"SubParameters": null,
 	"ArrayElemType": "",
+	"Default": "kubernetes.io/hostname",
 	"Required": false,
 	"NoRegex": false,
 	"NotNegatable": false,
 	"XXXStructFieldName": "TopologyKey",
 	"XXXIsPointer": false
 }
+`)
+
+	requireHardAntiAffinityParamDesc = util.MustParseParameterDesc(`{
+	"Name": "requireHardAntiAffinity",
+	"Type": "boolean",
+	"Description": "Set to true to only accept anti-affinity that the scheduler enforces, that is required anti-affinity terms, and topology spread constraints that do not schedule pods when they cannot be satisfied. Preferred anti-affinity terms, which the scheduler may ignore, are then not enough.",
+	"Examples": null,
+	"Enum": null,
+	"SubParameters": null,
+	"ArrayElemType": "",
+	"Required": false,
+	"NoRegex": false,
+	"NotNegatable": false,
+	"XXXStructFieldName": "RequireHardAntiAffinity",
+	"XXXIsPointer": false
+}
+`)
+
+	allowTopologySpreadConstraintsParamDesc = util.MustParseParameterDesc(`{
+	"Name": "allowTopologySpreadConstraints",
+	"Type": "boolean",
+	"Description": "Set to true to also accept topology spread constraints with the topology key and a label selector matching the pods, which prevent replicas from being co-located too.",
+	"Examples": null,
+	"Enum": null,
+	"SubParameters": null,
+	"ArrayElemType": "",
+	"Required": false,
+	"NoRegex": false,
+	"NotNegatable": false,
+	"XXXStructFieldName": "AllowTopologySpreadConstraints",
+	"XXXIsPointer": false
+}
 `)
 
 	ParamDescs = []check.ParameterDesc{
 		minReplicasParamDesc,
 		topologyKeyParamDesc,
+		requireHardAntiAffinityParamDesc,
+		allowTopologySpreadConstraintsParamDesc,
 	}
 )
 
@@ -84,3 +119,11 @@ func WrapInstantiateFunc(f func(p Params) (check.Func, error)) func (interface{}
 		return f(paramsInt.(Params))
 	}
 }
+
+// WrapInstantiateContextMatcherFunc is a convenience wrapper that wraps an untyped instantiate function
+// for a context matcher into a typed one.
+func WrapInstantiateContextMatcherFunc(f func(p Params) (check.ContextMatcher, error)) func (interface{}) (check.ContextMatcher, error) {
+	return func(paramsInt interface{}) (check.ContextMatcher, error) {
+		return f(paramsInt.(Params))
+	}
+}
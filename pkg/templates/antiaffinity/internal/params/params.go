@@ -8,5 +8,15 @@ type Params struct {
 
 	// The topology key that the anti-affinity term should use.
 	// If not specified, it defaults to "kubernetes.io/hostname".
+	// +default=kubernetes.io/hostname
 	TopologyKey string
+
+	// Set to true to only accept anti-affinity that the scheduler enforces, that is required anti-affinity terms,
+	// and topology spread constraints that do not schedule pods when they cannot be satisfied.
+	// Preferred anti-affinity terms, which the scheduler may ignore, are then not enough.
+	RequireHardAntiAffinity bool `json:"requireHardAntiAffinity"`
+
+	// Set to true to also accept topology spread constraints with the topology key and a label selector matching the
+	// pods, which prevent replicas from being co-located too.
+	AllowTopologySpreadConstraints bool `json:"allowTopologySpreadConstraints"`
 }
@@ -47,6 +47,13 @@ func init() {
 					return nil, err
 				}
 			}
+			missing := "inter pod anti-affinity"
+			if p.RequireHardAntiAffinity {
+				missing = "required inter pod anti-affinity"
+			}
+			if p.AllowTopologySpreadConstraints {
+				missing += " or topology spread constraints"
+			}
 			return func(_ lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
 				replicas, found := extract.Replicas(object.K8sObject)
 				if !found {
@@ -62,9 +69,11 @@ func init() {
 				if affinity := podTemplateSpec.Spec.Affinity; affinity != nil && affinity.PodAntiAffinity != nil {
 					preferredAffinity := affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution
 					requiredAffinity := affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution
-					for _, preferred := range preferredAffinity {
-						if affinityTermMatchesLabelsAgainstNodes(preferred.PodAffinityTerm, podTemplateSpec.Namespace, podTemplateSpec.Labels, topologyKeyMatcher) {
-							return nil
+					if !p.RequireHardAntiAffinity {
+						for _, preferred := range preferredAffinity {
+							if affinityTermMatchesLabelsAgainstNodes(preferred.PodAffinityTerm, podTemplateSpec.Namespace, podTemplateSpec.Labels, topologyKeyMatcher) {
+								return nil
+							}
 						}
 					}
 					for _, required := range requiredAffinity {
@@ -73,8 +82,18 @@ func init() {
 						}
 					}
 				}
+				if p.AllowTopologySpreadConstraints {
+					for _, constraint := range podTemplateSpec.Spec.TopologySpreadConstraints {
+						if p.RequireHardAntiAffinity && constraint.WhenUnsatisfiable != coreV1.DoNotSchedule {
+							continue
+						}
+						if constraintMatchesLabelsAgainstNodes(constraint, podTemplateSpec.Labels, topologyKeyMatcher) {
+							return nil
+						}
+					}
+				}
 				return []diagnostic.Diagnostic{
-					{Message: fmt.Sprintf("object has %d %s but does not specify inter pod anti-affinity", replicas, stringutils.Ternary(replicas > 1, "replicas", "replica"))},
+					{Message: fmt.Sprintf("object has %d %s but does not specify %s", replicas, stringutils.Ternary(replicas > 1, "replicas", "replica"), missing)},
 				}
 			}, nil
 		}),
@@ -104,3 +123,15 @@ func affinityTermMatchesLabelsAgainstNodes(affinityTerm coreV1.PodAffinityTerm,
 	}
 	return false
 }
+
+func constraintMatchesLabelsAgainstNodes(constraint coreV1.TopologySpreadConstraint, podLabels map[string]string, topologyKeyMatcher func(string) bool) bool {
+	// Constraints without a label selector do not count any pods, so they do not spread the replicas.
+	if constraint.LabelSelector == nil {
+		return false
+	}
+	labelSelector, err := metaV1.LabelSelectorAsSelector(constraint.LabelSelector)
+	if err != nil {
+		return false
+	}
+	return topologyKeyMatcher(constraint.TopologyKey) && labelSelector.Matches(labels.Set(podLabels))
+}
@@ -152,3 +152,114 @@ func (s *AntiAffinityTestSuite) TestWithAntiAffinity() {
 		},
 	})
 }
+
+func (s *AntiAffinityTestSuite) addDeploymentWithRequiredAntiAffinity(name string, replicas int32) {
+	s.addDeploymentWithReplicas(name, replicas)
+	s.ctx.ModifyDeployment(s.T(), name, func(deployment *appsV1.Deployment) {
+		deployment.Spec.Template.Labels = map[string]string{"app": name}
+		deployment.Spec.Template.Spec.Affinity = &v1.Affinity{
+			PodAntiAffinity: &v1.PodAntiAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: []v1.PodAffinityTerm{
+					{
+						TopologyKey:   "kubernetes.io/hostname",
+						LabelSelector: &metaV1.LabelSelector{MatchLabels: map[string]string{"app": name}},
+					},
+				},
+			},
+		}
+	})
+}
+
+func (s *AntiAffinityTestSuite) TestRequireHardAntiAffinity() {
+	const (
+		preferredDepName = "preferred"
+		requiredDepName  = "required"
+	)
+	s.addDeploymentWithAntiAffinity(preferredDepName, 2, "kubernetes.io/hostname")
+	s.addDeploymentWithRequiredAntiAffinity(requiredDepName, 2)
+
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{
+				MinReplicas:             2,
+				RequireHardAntiAffinity: true,
+			},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				preferredDepName: {
+					{Message: "object has 2 replicas but does not specify required inter pod anti-affinity"},
+				},
+			},
+		},
+	})
+}
+
+func (s *AntiAffinityTestSuite) addDeploymentWithTopologySpreadConstraint(name string, replicas int32, topologyKey string, whenUnsatisfiable v1.UnsatisfiableConstraintAction) {
+	s.addDeploymentWithReplicas(name, replicas)
+	s.ctx.ModifyDeployment(s.T(), name, func(deployment *appsV1.Deployment) {
+		deployment.Spec.Template.Labels = map[string]string{"app": name}
+		deployment.Spec.Template.Spec.TopologySpreadConstraints = []v1.TopologySpreadConstraint{
+			{
+				MaxSkew:           1,
+				TopologyKey:       topologyKey,
+				WhenUnsatisfiable: whenUnsatisfiable,
+				LabelSelector:     &metaV1.LabelSelector{MatchLabels: map[string]string{"app": name}},
+			},
+		}
+	})
+}
+
+func (s *AntiAffinityTestSuite) TestTopologySpreadConstraints() {
+	const (
+		scheduleAnywayDepName = "schedule-anyway"
+		doNotScheduleDepName  = "do-not-schedule"
+		zoneDepName           = "zone"
+	)
+	s.addDeploymentWithTopologySpreadConstraint(scheduleAnywayDepName, 2, "kubernetes.io/hostname", v1.ScheduleAnyway)
+	s.addDeploymentWithTopologySpreadConstraint(doNotScheduleDepName, 2, "kubernetes.io/hostname", v1.DoNotSchedule)
+	s.addDeploymentWithTopologySpreadConstraint(zoneDepName, 2, "topology.kubernetes.io/zone", v1.DoNotSchedule)
+
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{
+				MinReplicas: 2,
+			},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				scheduleAnywayDepName: {
+					{Message: "object has 2 replicas but does not specify inter pod anti-affinity"},
+				},
+				doNotScheduleDepName: {
+					{Message: "object has 2 replicas but does not specify inter pod anti-affinity"},
+				},
+				zoneDepName: {
+					{Message: "object has 2 replicas but does not specify inter pod anti-affinity"},
+				},
+			},
+		},
+		{
+			Param: params.Params{
+				MinReplicas:                    2,
+				AllowTopologySpreadConstraints: true,
+			},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				zoneDepName: {
+					{Message: "object has 2 replicas but does not specify inter pod anti-affinity or topology spread constraints"},
+				},
+			},
+		},
+		{
+			Param: params.Params{
+				MinReplicas:                    2,
+				AllowTopologySpreadConstraints: true,
+				RequireHardAntiAffinity:        true,
+			},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				scheduleAnywayDepName: {
+					{Message: "object has 2 replicas but does not specify required inter pod anti-affinity or topology spread constraints"},
+				},
+				zoneDepName: {
+					{Message: "object has 2 replicas but does not specify required inter pod anti-affinity or topology spread constraints"},
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,97 @@
+// Code generated by kube-linter template codegen. DO NOT EDIT.
+// +build !templatecodegen
+
+package params
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
+)
+
+var (
+	// Use some imports in case they don't get used otherwise.
+	_ = util.MustParseParameterDesc
+	_ = fmt.Sprintf
+
+	expressionParamDesc = util.MustParseParameterDesc(`{
+	"Name": "expression",
+	"Type": "string",
+	"Description": "A CEL expression that objects are flagged for if it evaluates to true. The object is available as the object variable, with the same fields as in its YAML, e.g. !has(object.spec.replicas) || object.spec.replicas \u003c 2. Refer to https://github.com/google/cel-spec/blob/master/doc/langdef.md for the language definition.",
+	"Examples": null,
+	"Enum": null,
+	"SubParameters": null,
+	"ArrayElemType": "",
+	"Required": true,
+	"NoRegex": true,
+	"NotNegatable": true,
+	"XXXStructFieldName": "Expression",
+	"XXXIsPointer": false
+}
+`)
+
+	messageParamDesc = util.MustParseParameterDesc(`{
+	"Name": "message",
+	"Type": "string",
+	"Description": "The message of the diagnostics of flagged objects. If not specified, the message includes the expression.",
+	"Examples": null,
+	"Enum": null,
+	"SubParameters": null,
+	"ArrayElemType": "",
+	"Required": false,
+	"NoRegex": true,
+	"NotNegatable": true,
+	"XXXStructFieldName": "Message",
+	"XXXIsPointer": false
+}
+`)
+
+	ParamDescs = []check.ParameterDesc{
+		expressionParamDesc,
+		messageParamDesc,
+	}
+)
+
+func (p *Params) Validate() error {
+	var validationErrors []string
+	if p.Expression == "" {
+		validationErrors = append(validationErrors, "required param expression not found")
+	}
+	if len(validationErrors) > 0 {
+		return errors.Errorf("invalid parameters: %s", strings.Join(validationErrors, ", "))
+    }
+	return nil
+}
+
+// ParseAndValidate instantiates a Params object out of the passed map[string]interface{},
+// validates it, and returns it.
+// The return type is interface{} to satisfy the type in the Template struct.
+func ParseAndValidate(m map[string]interface{}) (interface{}, error) {
+	var p Params
+	if err := util.DecodeMapStructure(m, &p); err != nil {
+		return nil, err
+	}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// WrapInstantiateFunc is a convenience wrapper that wraps an untyped instantiate function
+// into a typed one.
+func WrapInstantiateFunc(f func(p Params) (check.Func, error)) func (interface{}) (check.Func, error) {
+	return func(paramsInt interface{}) (check.Func, error) {
+		return f(paramsInt.(Params))
+	}
+}
+
+// WrapInstantiateContextMatcherFunc is a convenience wrapper that wraps an untyped instantiate function
+// for a context matcher into a typed one.
+func WrapInstantiateContextMatcherFunc(f func(p Params) (check.ContextMatcher, error)) func (interface{}) (check.ContextMatcher, error) {
+	return func(paramsInt interface{}) (check.ContextMatcher, error) {
+		return f(paramsInt.(Params))
+	}
+}
@@ -0,0 +1,18 @@
+package params
+
+// Params represents the params accepted by this template.
+type Params struct {
+
+	// A CEL expression that objects are flagged for if it evaluates to true. The object is available as the object
+	// variable, with the same fields as in its YAML, e.g. !has(object.spec.replicas) || object.spec.replicas < 2.
+	// Refer to https://github.com/google/cel-spec/blob/master/doc/langdef.md for the language definition.
+	// +required
+	// +noregex
+	// +notnegatable
+	Expression string
+
+	// The message of the diagnostics of flagged objects. If not specified, the message includes the expression.
+	// +noregex
+	// +notnegatable
+	Message string
+}
@@ -0,0 +1,114 @@
+package celexpression
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/checker/decls"
+	"github.com/google/cel-go/common/types"
+	"github.com/pkg/errors"
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/extract"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/objectkinds"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/celexpression/internal/params"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	templateKey = "cel-expression"
+
+	// objectVariable is the name of the variable that expressions access the object through.
+	objectVariable = "object"
+
+	// interruptCheckFrequency is the number of iterations of comprehensions, like all() and exists(), after which
+	// the timeout is checked.
+	interruptCheckFrequency = 100
+)
+
+var (
+	// evaluationTimeout bounds how long an expression may take for one object, so that expressions iterating over
+	// large lists cannot hang the linter.
+	evaluationTimeout = time.Second
+)
+
+func init() {
+	templates.Register(check.Template{
+		HumanName:   "CEL Expression",
+		Key:         templateKey,
+		Description: "Flag objects for which a CEL expression evaluates to true",
+		SupportedObjectKinds: config.ObjectKindsDesc{
+			ObjectKinds: []string{objectkinds.Any},
+		},
+		Parameters:             params.ParamDescs,
+		ParseAndValidateParams: params.ParseAndValidate,
+		Instantiate: params.WrapInstantiateFunc(func(p params.Params) (check.Func, error) {
+			program, err := compile(p.Expression)
+			if err != nil {
+				return nil, err
+			}
+			message := p.Message
+			if message == "" {
+				message = fmt.Sprintf("object matches expression %q", p.Expression)
+			}
+			return func(_ lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
+				matched, err := evaluate(program, object)
+				if err != nil {
+					return []diagnostic.Diagnostic{{Message: fmt.Sprintf("evaluating expression %q: %v", p.Expression, err)}}
+				}
+				if matched {
+					return []diagnostic.Diagnostic{{Message: message}}
+				}
+				return nil
+			}, nil
+		}),
+	})
+}
+
+// compile compiles the given expression into a program that evaluates it against an object, making sure that it
+// can evaluate to a boolean.
+func compile(expression string) (cel.Program, error) {
+	env, err := cel.NewEnv(cel.Declarations(decls.NewVar(objectVariable, decls.NewMapType(decls.String, decls.Dyn))))
+	if err != nil {
+		return nil, errors.Wrap(err, "creating CEL environment")
+	}
+	ast, issues := env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, errors.Wrapf(issues.Err(), "invalid expression %q", expression)
+	}
+	if resultType := ast.ResultType(); !proto.Equal(resultType, decls.Bool) && !proto.Equal(resultType, decls.Dyn) {
+		return nil, errors.Errorf("expression %q must evaluate to a bool", expression)
+	}
+	program, err := env.Program(ast, cel.InterruptCheckFrequency(interruptCheckFrequency))
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid expression %q", expression)
+	}
+	return program, nil
+}
+
+// evaluate returns whether the given program evaluates to true for the given object. Errors, like accessing fields
+// that the object does not have or running out of time, are returned rather than treated as false, so that broken
+// expressions do not silently pass.
+func evaluate(program cel.Program, object lintcontext.Object) (bool, error) {
+	fields, err := extract.Unstructured(object.K8sObject)
+	if err != nil {
+		return false, errors.Wrap(err, "converting object")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), evaluationTimeout)
+	defer cancel()
+	out, _, err := program.ContextEval(ctx, map[string]interface{}{objectVariable: fields})
+	if err != nil {
+		return false, err
+	}
+	matched, ok := out.(types.Bool)
+	if !ok {
+		return false, errors.Errorf("expression evaluated to %v of type %s instead of a bool", out.Value(), out.Type().TypeName())
+	}
+	return bool(matched), nil
+}
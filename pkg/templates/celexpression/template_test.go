@@ -0,0 +1,120 @@
+package celexpression
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext/mocks"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/celexpression/internal/params"
+	appsV1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+)
+
+const fewReplicas = "!has(object.spec.replicas) || object.spec.replicas < 2"
+
+func TestCELExpression(t *testing.T) {
+	suite.Run(t, new(CELExpressionTestSuite))
+}
+
+type CELExpressionTestSuite struct {
+	templates.TemplateTestSuite
+
+	ctx *mocks.MockLintContext
+}
+
+func (s *CELExpressionTestSuite) SetupTest() {
+	s.Init(templateKey)
+	s.ctx = mocks.NewMockContext()
+}
+
+func (s *CELExpressionTestSuite) addDeploymentWithReplicas(name string, replicas int32) {
+	s.ctx.AddMockDeployment(s.T(), name)
+	s.ctx.ModifyDeployment(s.T(), name, func(deployment *appsV1.Deployment) {
+		deployment.Spec.Replicas = &replicas
+	})
+}
+
+func (s *CELExpressionTestSuite) TestReplicas() {
+	s.ctx.AddMockDeployment(s.T(), "default-replicas")
+	s.addDeploymentWithReplicas("one-replica", 1)
+	s.addDeploymentWithReplicas("three-replicas", 3)
+
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{Expression: fewReplicas, Message: "object has fewer than 2 replicas"},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"default-replicas": {{Message: "object has fewer than 2 replicas"}},
+				"one-replica":      {{Message: "object has fewer than 2 replicas"}},
+			},
+		},
+		{
+			Param: params.Params{Expression: "object.metadata.name.startsWith('three')"},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"three-replicas": {{Message: `object matches expression "object.metadata.name.startsWith('three')"`}},
+			},
+		},
+	})
+}
+
+func (s *CELExpressionTestSuite) TestRuntimeErrors() {
+	s.ctx.AddMockDeployment(s.T(), "default-replicas")
+	s.addDeploymentWithReplicas("three-replicas", 3)
+
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{Expression: "object.spec.replicas < 2"},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"default-replicas": {{Message: `evaluating expression "object.spec.replicas < 2": no such key: replicas`}},
+			},
+		},
+		{
+			Param: params.Params{Expression: "object.spec.replicas"},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"default-replicas": {{Message: `evaluating expression "object.spec.replicas": no such key: replicas`}},
+				"three-replicas":   {{Message: `evaluating expression "object.spec.replicas": expression evaluated to 3 of type int instead of a bool`}},
+			},
+		},
+	})
+}
+
+func (s *CELExpressionTestSuite) TestTimeout() {
+	defer func(timeout time.Duration) {
+		evaluationTimeout = timeout
+	}(evaluationTimeout)
+	evaluationTimeout = time.Nanosecond
+
+	s.ctx.AddMockDeployment(s.T(), "app")
+	containers := make([]v1.Container, 1000)
+	s.ctx.ModifyDeployment(s.T(), "app", func(deployment *appsV1.Deployment) {
+		deployment.Spec.Template.Spec.Containers = containers
+	})
+
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{Expression: "object.spec.template.spec.containers.all(c, !has(c.image))"},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"app": {{Message: `evaluating expression "object.spec.template.spec.containers.all(c, !has(c.image))": operation interrupted`}},
+			},
+		},
+	})
+}
+
+func (s *CELExpressionTestSuite) TestInvalidExpressions() {
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param:                    params.Params{Expression: "object.spec.replicas <"},
+			ExpectInstantiationError: true,
+		},
+		{
+			Param:                    params.Params{Expression: "'not a bool'"},
+			ExpectInstantiationError: true,
+		},
+		{
+			Param:                    params.Params{Expression: "unknown.field"},
+			ExpectInstantiationError: true,
+		},
+	})
+}
@@ -147,6 +147,14 @@ func WrapInstantiateFunc(f func(p Params) (check.Func, error)) func (interface{}
 		return f(paramsInt.(Params))
 	}
 }
+
+// WrapInstantiateContextMatcherFunc is a convenience wrapper that wraps an untyped instantiate function
+// for a context matcher into a typed one.
+func WrapInstantiateContextMatcherFunc(f func(p Params) (check.ContextMatcher, error)) func (interface{}) (check.ContextMatcher, error) {
+	return func(paramsInt interface{}) (check.ContextMatcher, error) {
+		return f(paramsInt.(Params))
+	}
+}
 `
 )
 
@@ -219,7 +227,16 @@ func constructParameterDescsFromStruct(typeSpec *types.Type) ([]check.ParameterD
 			desc.Type = checkType
 		case types.Slice:
 			desc.Type = check.ArrayType
-			// For now we only support array of builtin types. No array of objects or array of arrays.
+			// For now we only support arrays of builtin types and of objects. No array of arrays.
+			if member.Type.Elem.Kind == types.Struct {
+				desc.ArrayElemType = check.ObjectType
+				subParams, err := constructParameterDescsFromStruct(member.Type.Elem)
+				if err != nil {
+					return nil, errors.Wrapf(err, "handling array elem type %v", member.Type.Elem)
+				}
+				desc.SubParameters = subParams
+				break
+			}
 			elemType, err := getCheckTypeFromParsedBuiltinType(member.Type.Elem)
 			if err != nil {
 				return nil, errors.Wrapf(err, "handling array elem type %v", member.Type.Elem)
@@ -239,6 +256,12 @@ func constructParameterDescsFromStruct(typeSpec *types.Type) ([]check.ParameterD
 		extractedTags := types.ExtractCommentTags(metadataMarker, member.CommentLines)
 		desc.Examples = extractedTags["example"]
 		desc.Enum = extractedTags["enum"]
+		if defaults := extractedTags["default"]; len(defaults) > 0 {
+			if len(defaults) > 1 {
+				return nil, errors.Errorf("field %s has more than one default", member.Name)
+			}
+			desc.Default = defaults[0]
+		}
 		if err := setBoolBasedOnPresenceOfTag(&desc.Required, "required", extractedTags); err != nil {
 			return nil, err
 		}
@@ -45,6 +45,7 @@ var (
 	"Enum": null,
 	"SubParameters": null,
 	"ArrayElemType": "",
+	"Default": "0",
 	"Required": false,
 	"NoRegex": false,
 	"NotNegatable": false,
@@ -122,3 +123,11 @@ func WrapInstantiateFunc(f func(p Params) (check.Func, error)) func (interface{}
 		return f(paramsInt.(Params))
 	}
 }
+
+// WrapInstantiateContextMatcherFunc is a convenience wrapper that wraps an untyped instantiate function
+// for a context matcher into a typed one.
+func WrapInstantiateContextMatcherFunc(f func(p Params) (check.ContextMatcher, error)) func (interface{}) (check.ContextMatcher, error) {
+	return func(paramsInt interface{}) (check.ContextMatcher, error) {
+		return f(paramsInt.(Params))
+	}
+}
@@ -13,6 +13,7 @@ type Params struct {
 	// The lower bound of the requirement (inclusive), specified as
 	// a number of milli-cores.
 	// If not specified, it is treated as a lower bound of zero.
+	// +default=0
 	LowerBoundMillis int `json:"lowerBoundMillis"`
 
 	// The upper bound of the requirement (inclusive), specified as
@@ -17,7 +17,8 @@ import (
 func process(results *[]diagnostic.Diagnostic, containerName, requirementsType string, quantity *resource.Quantity, lowerBound int, upperBound *int) {
 	if util.ValueInRange(int(quantity.MilliValue()), lowerBound, upperBound) {
 		*results = append(*results, diagnostic.Diagnostic{
-			Message: fmt.Sprintf("container %q has cpu %s %s", containerName, requirementsType, quantity),
+			Message:   fmt.Sprintf("container %q has cpu %s %s", containerName, requirementsType, quantity),
+			FieldPath: fmt.Sprintf("resources.%ss.cpu", requirementsType),
 		})
 	}
 
@@ -34,7 +35,7 @@ func init() {
 		Parameters:             params.ParamDescs,
 		ParseAndValidateParams: params.ParseAndValidate,
 		Instantiate: params.WrapInstantiateFunc(func(p params.Params) (check.Func, error) {
-			return util.PerContainerCheck(func(container *v1.Container) []diagnostic.Diagnostic {
+			return util.PerNonEphemeralContainerCheck(func(container *v1.Container) []diagnostic.Diagnostic {
 				var results []diagnostic.Diagnostic
 				if p.RequirementsType == "request" || p.RequirementsType == "any" {
 					process(&results, container.Name, "request", container.Resources.Requests.Cpu(), p.LowerBoundMillis, p.UpperBoundMillis)
@@ -0,0 +1,103 @@
+package danglinghpa
+
+import (
+	"fmt"
+	"reflect"
+
+	ocsAppsV1 "github.com/openshift/api/apps/v1"
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/extract"
+	"golang.stackrox.io/kube-linter/pkg/k8sutil"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/objectkinds"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/danglinghpa/internal/params"
+	autoscalingV1 "k8s.io/api/autoscaling/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// scaleTarget is the object that a horizontal pod autoscaler scales, independent of the API version of the autoscaler.
+type scaleTarget autoscalingV1.CrossVersionObjectReference
+
+func (t scaleTarget) String() string {
+	if t.APIVersion == "" {
+		return fmt.Sprintf("%s %q", t.Kind, t.Name)
+	}
+	return fmt.Sprintf("%s %s %q", t.APIVersion, t.Kind, t.Name)
+}
+
+func scaleTargetOf(obj k8sutil.Object) (scaleTarget, bool) {
+	ref, ok := extract.ScaleTargetRef(obj)
+	return scaleTarget(ref), ok
+}
+
+// matches returns whether the target references the object with the given GVK and name.
+func (t scaleTarget) matches(gvk schema.GroupVersionKind, name string) bool {
+	return extract.ScaleTargetRefMatches(autoscalingV1.CrossVersionObjectReference(t), gvk, name)
+}
+
+// staticReplicas returns the number of replicas of the object, if they are set explicitly.
+func staticReplicas(obj k8sutil.Object) (int32, bool) {
+	// The replicas of DeploymentConfigs are not a pointer, so unset cannot be told apart from 0.
+	if depConfig, isDepConfig := obj.(*ocsAppsV1.DeploymentConfig); isDepConfig {
+		return depConfig.Spec.Replicas, depConfig.Spec.Replicas != 0
+	}
+	// extract.Replicas defaults unset replicas to 1, so look at the pointer itself.
+	spec := reflect.Indirect(reflect.ValueOf(obj)).FieldByName("Spec")
+	if !spec.IsValid() || spec.Kind() != reflect.Struct {
+		return 0, false
+	}
+	replicasField := spec.FieldByName("Replicas")
+	if !replicasField.IsValid() {
+		return 0, false
+	}
+	replicas, ok := replicasField.Interface().(*int32)
+	if !ok || replicas == nil {
+		return 0, false
+	}
+	return *replicas, true
+}
+
+func init() {
+	templates.Register(check.Template{
+		HumanName:   "Dangling Horizontal Pod Autoscalers",
+		Key:         "dangling-horizontalpodautoscaler",
+		Description: "Flag horizontal pod autoscalers whose scale target does not exist, or sets its replicas statically",
+		SupportedObjectKinds: config.ObjectKindsDesc{
+			ObjectKinds: []string{objectkinds.HorizontalPodAutoscaler},
+		},
+		Parameters:             params.ParamDescs,
+		ParseAndValidateParams: params.ParseAndValidate,
+		InstantiateContextMatcher: params.WrapInstantiateContextMatcherFunc(func(_ params.Params) (check.ContextMatcher, error) {
+			return func(lintCtx lintcontext.LintContext) check.Func {
+				// Index the objects by namespace, so that the whole context does not have to be searched for every autoscaler.
+				objectsByNamespace := make(map[string][]k8sutil.Object)
+				for _, obj := range lintCtx.Objects() {
+					namespace := obj.K8sObject.GetNamespace()
+					objectsByNamespace[namespace] = append(objectsByNamespace[namespace], obj.K8sObject)
+				}
+
+				return func(_ lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
+					target, ok := scaleTargetOf(object.K8sObject)
+					if !ok {
+						return nil
+					}
+					for _, obj := range objectsByNamespace[object.K8sObject.GetNamespace()] {
+						if !target.matches(extract.GVK(obj), obj.GetName()) {
+							continue
+						}
+						if replicas, isStatic := staticReplicas(obj); isStatic {
+							return []diagnostic.Diagnostic{{
+								Message: fmt.Sprintf("scale target %s sets replicas statically to %d, which conflicts with the horizontal pod autoscaler managing them", target, replicas),
+							}}
+						}
+						return nil
+					}
+					return []diagnostic.Diagnostic{{Message: fmt.Sprintf("scale target %s does not exist", target)}}
+				}
+			}, nil
+		}),
+	})
+}
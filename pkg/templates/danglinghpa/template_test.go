@@ -0,0 +1,69 @@
+package danglinghpa
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext/mocks"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/danglinghpa/internal/params"
+	appsV1 "k8s.io/api/apps/v1"
+	autoscalingV1 "k8s.io/api/autoscaling/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDanglingHPA(t *testing.T) {
+	suite.Run(t, new(DanglingHPATestSuite))
+}
+
+type DanglingHPATestSuite struct {
+	templates.TemplateTestSuite
+
+	ctx *mocks.MockLintContext
+}
+
+func (s *DanglingHPATestSuite) SetupTest() {
+	s.Init("dangling-horizontalpodautoscaler")
+	s.ctx = mocks.NewMockContext()
+}
+
+func (s *DanglingHPATestSuite) addDeployment(name string, replicas *int32) {
+	s.ctx.AddMockDeployment(s.T(), name)
+	s.ctx.ModifyDeployment(s.T(), name, func(deployment *appsV1.Deployment) {
+		deployment.TypeMeta = metaV1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"}
+		deployment.Spec.Replicas = replicas
+	})
+}
+
+func (s *DanglingHPATestSuite) addHPA(name string, target autoscalingV1.CrossVersionObjectReference) {
+	s.ctx.AddMockHorizontalPodAutoscaler(s.T(), name)
+	s.ctx.ModifyHorizontalPodAutoscaler(s.T(), name, func(hpa *autoscalingV1.HorizontalPodAutoscaler) {
+		hpa.Spec.ScaleTargetRef = target
+	})
+}
+
+func (s *DanglingHPATestSuite) TestScaleTargets() {
+	replicas := int32(3)
+	s.addDeployment("app", nil)
+	s.addDeployment("static", &replicas)
+
+	s.addHPA("matching", autoscalingV1.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "app"})
+	s.addHPA("other-version", autoscalingV1.CrossVersionObjectReference{APIVersion: "apps/v1beta2", Kind: "Deployment", Name: "app"})
+	s.addHPA("misspelled", autoscalingV1.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "ap"})
+	s.addHPA("wrong-kind", autoscalingV1.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: "StatefulSet", Name: "app"})
+	s.addHPA("wrong-group", autoscalingV1.CrossVersionObjectReference{APIVersion: "apps.openshift.io/v1", Kind: "Deployment", Name: "app"})
+	s.addHPA("static-replicas", autoscalingV1.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "static"})
+
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"misspelled":      {{Message: `scale target apps/v1 Deployment "ap" does not exist`}},
+				"wrong-kind":      {{Message: `scale target apps/v1 StatefulSet "app" does not exist`}},
+				"wrong-group":     {{Message: `scale target apps.openshift.io/v1 Deployment "app" does not exist`}},
+				"static-replicas": {{Message: `scale target apps/v1 Deployment "static" sets replicas statically to 3, which conflicts with the horizontal pod autoscaler managing them`}},
+			},
+		},
+	})
+}
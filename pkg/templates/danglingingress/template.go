@@ -0,0 +1,143 @@
+package danglingingress
+
+import (
+	"fmt"
+
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/objectkinds"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/danglingingress/internal/params"
+	v1 "k8s.io/api/core/v1"
+	networkingV1 "k8s.io/api/networking/v1"
+)
+
+// resourceKey identifies an object that a resource backend can reference within a namespace.
+type resourceKey struct {
+	group string
+	kind  string
+	name  string
+}
+
+// namespaceIndex holds the objects of a single namespace that ingress backends can reference.
+type namespaceIndex struct {
+	services  map[string]*v1.Service
+	resources map[resourceKey]struct{}
+}
+
+func indexObjects(lintCtx lintcontext.LintContext) map[string]*namespaceIndex {
+	indexes := make(map[string]*namespaceIndex)
+	for _, obj := range lintCtx.Objects() {
+		namespace := obj.K8sObject.GetNamespace()
+		index := indexes[namespace]
+		if index == nil {
+			index = &namespaceIndex{services: make(map[string]*v1.Service), resources: make(map[resourceKey]struct{})}
+			indexes[namespace] = index
+		}
+		gvk := obj.K8sObject.GetObjectKind().GroupVersionKind()
+		index.resources[resourceKey{group: gvk.Group, kind: gvk.Kind, name: obj.K8sObject.GetName()}] = struct{}{}
+		if service, ok := obj.K8sObject.(*v1.Service); ok {
+			index.services[service.Name] = service
+		}
+	}
+	return indexes
+}
+
+func serviceExposesPort(service *v1.Service, port networkingV1.ServiceBackendPort) bool {
+	for _, servicePort := range service.Spec.Ports {
+		if port.Name != "" {
+			if servicePort.Name == port.Name {
+				return true
+			}
+		} else if servicePort.Port == port.Number {
+			return true
+		}
+	}
+	return false
+}
+
+func describePort(port networkingV1.ServiceBackendPort) string {
+	if port.Name != "" {
+		return fmt.Sprintf("%q", port.Name)
+	}
+	return fmt.Sprintf("%d", port.Number)
+}
+
+// checkBackend returns the problem with the given backend, or an empty string if it references an existing object.
+func checkBackend(index *namespaceIndex, backend *networkingV1.IngressBackend) string {
+	if backend.Resource != nil {
+		var group string
+		if backend.Resource.APIGroup != nil {
+			group = *backend.Resource.APIGroup
+		}
+		if _, found := index.resources[resourceKey{group: group, kind: backend.Resource.Kind, name: backend.Resource.Name}]; !found {
+			return fmt.Sprintf("references resource %s %q, which does not exist", backend.Resource.Kind, backend.Resource.Name)
+		}
+		return ""
+	}
+	if backend.Service == nil {
+		return ""
+	}
+	service := index.services[backend.Service.Name]
+	if service == nil {
+		return fmt.Sprintf("references service %q, which does not exist", backend.Service.Name)
+	}
+	if !serviceExposesPort(service, backend.Service.Port) {
+		return fmt.Sprintf("references port %s of service %q, which does not expose it", describePort(backend.Service.Port), backend.Service.Name)
+	}
+	return ""
+}
+
+func init() {
+	templates.Register(check.Template{
+		HumanName:   "Dangling Ingress",
+		Key:         "dangling-ingress",
+		Description: "Flag ingress backends, including the default backend, that reference a service, service port, or resource that does not exist in the same namespace",
+		SupportedObjectKinds: config.ObjectKindsDesc{
+			ObjectKinds: []string{objectkinds.Ingress},
+		},
+		Parameters:             params.ParamDescs,
+		ParseAndValidateParams: params.ParseAndValidate,
+		InstantiateContextMatcher: params.WrapInstantiateContextMatcherFunc(func(_ params.Params) (check.ContextMatcher, error) {
+			return func(lintCtx lintcontext.LintContext) check.Func {
+				indexes := indexObjects(lintCtx)
+
+				return func(_ lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
+					ingress, ok := object.K8sObject.(*networkingV1.Ingress)
+					if !ok {
+						return nil
+					}
+					// The ingress itself is always indexed, so its namespace has an index.
+					index := indexes[ingress.Namespace]
+
+					var diagnostics []diagnostic.Diagnostic
+					if backend := ingress.Spec.DefaultBackend; backend != nil {
+						if problem := checkBackend(index, backend); problem != "" {
+							diagnostics = append(diagnostics, diagnostic.Diagnostic{Message: fmt.Sprintf("default backend %s", problem)})
+						}
+					}
+					for _, rule := range ingress.Spec.Rules {
+						if rule.HTTP == nil {
+							continue
+						}
+						host := "all hosts"
+						if rule.Host != "" {
+							host = fmt.Sprintf("host %q", rule.Host)
+						}
+						for i := range rule.HTTP.Paths {
+							path := &rule.HTTP.Paths[i]
+							if problem := checkBackend(index, &path.Backend); problem != "" {
+								diagnostics = append(diagnostics, diagnostic.Diagnostic{
+									Message: fmt.Sprintf("backend of path %q for %s %s", path.Path, host, problem),
+								})
+							}
+						}
+					}
+					return diagnostics
+				}
+			}, nil
+		}),
+	})
+}
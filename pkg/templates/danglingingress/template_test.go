@@ -0,0 +1,135 @@
+package danglingingress
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext/mocks"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/danglingingress/internal/params"
+	v1 "k8s.io/api/core/v1"
+	networkingV1 "k8s.io/api/networking/v1"
+)
+
+func TestDanglingIngress(t *testing.T) {
+	suite.Run(t, new(DanglingIngressTestSuite))
+}
+
+type DanglingIngressTestSuite struct {
+	templates.TemplateTestSuite
+
+	ctx *mocks.MockLintContext
+}
+
+func (s *DanglingIngressTestSuite) SetupTest() {
+	s.Init("dangling-ingress")
+	s.ctx = mocks.NewMockContext()
+}
+
+func (s *DanglingIngressTestSuite) addService(name, namespace string, ports ...v1.ServicePort) {
+	s.ctx.AddMockService(s.T(), name)
+	s.ctx.ModifyService(s.T(), name, func(service *v1.Service) {
+		service.Namespace = namespace
+		service.Spec.Ports = ports
+	})
+}
+
+func (s *DanglingIngressTestSuite) addIngress(name string, spec networkingV1.IngressSpec) {
+	s.ctx.AddMockIngress(s.T(), name)
+	s.ctx.ModifyIngress(s.T(), name, func(ingress *networkingV1.Ingress) {
+		ingress.Namespace = "default"
+		ingress.Spec = spec
+	})
+}
+
+func serviceBackend(name string, port networkingV1.ServiceBackendPort) networkingV1.IngressBackend {
+	return networkingV1.IngressBackend{Service: &networkingV1.IngressServiceBackend{Name: name, Port: port}}
+}
+
+func rule(host, path string, backend networkingV1.IngressBackend) networkingV1.IngressRule {
+	return networkingV1.IngressRule{
+		Host: host,
+		IngressRuleValue: networkingV1.IngressRuleValue{HTTP: &networkingV1.HTTPIngressRuleValue{
+			Paths: []networkingV1.HTTPIngressPath{{Path: path, Backend: backend}},
+		}},
+	}
+}
+
+func (s *DanglingIngressTestSuite) TestServiceBackends() {
+	s.addService("web", "default", v1.ServicePort{Name: "http", Port: 80})
+	s.addService("other-namespace", "other", v1.ServicePort{Name: "http", Port: 80})
+
+	byNumber := networkingV1.ServiceBackendPort{Number: 80}
+	byName := networkingV1.ServiceBackendPort{Name: "http"}
+	s.addIngress("matching", networkingV1.IngressSpec{
+		Rules: []networkingV1.IngressRule{
+			rule("example.com", "/", serviceBackend("web", byNumber)),
+			rule("", "/web", serviceBackend("web", byName)),
+		},
+	})
+	s.addIngress("missing-service", networkingV1.IngressSpec{
+		Rules: []networkingV1.IngressRule{
+			rule("example.com", "/", serviceBackend("web", byNumber)),
+			rule("example.com", "/api", serviceBackend("api", byNumber)),
+			rule("", "/other", serviceBackend("other-namespace", byNumber)),
+		},
+	})
+	s.addIngress("missing-port", networkingV1.IngressSpec{
+		Rules: []networkingV1.IngressRule{
+			rule("example.com", "/", serviceBackend("web", networkingV1.ServiceBackendPort{Number: 8080})),
+			rule("example.com", "/web", serviceBackend("web", networkingV1.ServiceBackendPort{Name: "https"})),
+		},
+	})
+	defaultBackend := serviceBackend("default-backend", byNumber)
+	s.addIngress("missing-default-backend", networkingV1.IngressSpec{DefaultBackend: &defaultBackend})
+
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"matching": nil,
+				"missing-service": {
+					{Message: `backend of path "/api" for host "example.com" references service "api", which does not exist`},
+					{Message: `backend of path "/other" for all hosts references service "other-namespace", which does not exist`},
+				},
+				"missing-port": {
+					{Message: `backend of path "/" for host "example.com" references port 8080 of service "web", which does not expose it`},
+					{Message: `backend of path "/web" for host "example.com" references port "https" of service "web", which does not expose it`},
+				},
+				"missing-default-backend": {
+					{Message: `default backend references service "default-backend", which does not exist`},
+				},
+			},
+		},
+	})
+}
+
+func (s *DanglingIngressTestSuite) TestResourceBackends() {
+	s.ctx.AddMockService(s.T(), "static-assets")
+	s.ctx.ModifyService(s.T(), "static-assets", func(service *v1.Service) {
+		service.Namespace = "default"
+	})
+
+	coreResource := func(kind, name string) networkingV1.IngressBackend {
+		return networkingV1.IngressBackend{Resource: &v1.TypedLocalObjectReference{Kind: kind, Name: name}}
+	}
+	s.addIngress("resource-backends", networkingV1.IngressSpec{
+		Rules: []networkingV1.IngressRule{
+			rule("", "/assets", coreResource("Service", "static-assets")),
+			rule("", "/bucket", coreResource("StorageBucket", "static-assets")),
+		},
+	})
+
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"static-assets": nil,
+				"resource-backends": {
+					{Message: `backend of path "/bucket" for all hosts references resource StorageBucket "static-assets", which does not exist`},
+				},
+			},
+		},
+	})
+}
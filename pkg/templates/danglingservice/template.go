@@ -20,48 +20,59 @@ func init() {
 	templates.Register(check.Template{
 		HumanName:   "Dangling Services",
 		Key:         "dangling-service",
-		Description: "Flag services which do not match any application",
+		Description: "Flag services which do not match any application, ignoring services of type ExternalName and headless services without a selector",
 		SupportedObjectKinds: config.ObjectKindsDesc{
 			ObjectKinds: []string{objectkinds.DeploymentLike},
 		},
 		Parameters:             params.ParamDescs,
 		ParseAndValidateParams: params.ParseAndValidate,
-		Instantiate: params.WrapInstantiateFunc(func(_ params.Params) (check.Func, error) {
-			return func(lintCtx lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
-				service, ok := object.K8sObject.(*v1.Service)
-				if !ok {
-					return nil
-				}
-				// Selector doesn't apply to external names.
-				if service.Spec.Type == v1.ServiceTypeExternalName {
-					return nil
-				}
-				selector := service.Spec.Selector
-				if len(selector) == 0 {
-					return []diagnostic.Diagnostic{{
-						Message: "service has no selector specified",
-					}}
-				}
-				labelSelector, err := metaV1.LabelSelectorAsSelector(&metaV1.LabelSelector{MatchLabels: selector})
-				if err != nil {
-					return []diagnostic.Diagnostic{{
-						Message: fmt.Sprintf("service has invalid label selector: %v", err),
-					}}
-				}
+		InstantiateContextMatcher: params.WrapInstantiateContextMatcherFunc(func(_ params.Params) (check.ContextMatcher, error) {
+			return func(lintCtx lintcontext.LintContext) check.Func {
+				// Index the labels of all pods by namespace, so that they do not have to be extracted for every service.
+				podLabelsByNamespace := make(map[string][]labels.Set)
 				for _, obj := range lintCtx.Objects() {
 					podTemplateSpec, hasPods := extract.PodTemplateSpec(obj.K8sObject)
 					if !hasPods {
 						continue
 					}
-					if service.Namespace != obj.K8sObject.GetNamespace() {
-						continue
+					namespace := obj.K8sObject.GetNamespace()
+					podLabelsByNamespace[namespace] = append(podLabelsByNamespace[namespace], labels.Set(podTemplateSpec.Labels))
+				}
+
+				return func(_ lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
+					service, ok := object.K8sObject.(*v1.Service)
+					if !ok {
+						return nil
 					}
-					if labelSelector.Matches(labels.Set(podTemplateSpec.Labels)) {
-						// Found!
+					// Selector doesn't apply to external names.
+					if service.Spec.Type == v1.ServiceTypeExternalName {
 						return nil
 					}
+					selector := service.Spec.Selector
+					if len(selector) == 0 {
+						// Headless services without selectors are meant to have their endpoints managed manually.
+						// An empty selector is treated the same as no selector by Kubernetes.
+						if service.Spec.ClusterIP == v1.ClusterIPNone {
+							return nil
+						}
+						return []diagnostic.Diagnostic{{
+							Message: "service has no selector specified",
+						}}
+					}
+					labelSelector, err := metaV1.LabelSelectorAsSelector(&metaV1.LabelSelector{MatchLabels: selector})
+					if err != nil {
+						return []diagnostic.Diagnostic{{
+							Message: fmt.Sprintf("service has invalid label selector: %v", err),
+						}}
+					}
+					for _, podLabels := range podLabelsByNamespace[service.Namespace] {
+						if labelSelector.Matches(podLabels) {
+							// Found!
+							return nil
+						}
+					}
+					return []diagnostic.Diagnostic{{Message: fmt.Sprintf("no pods found matching the labels of service %q (%v)", service.Name, selector)}}
 				}
-				return []diagnostic.Diagnostic{{Message: fmt.Sprintf("no pods found matching service labels (%v)", selector)}}
 			}, nil
 		}),
 	})
@@ -0,0 +1,73 @@
+package danglingservice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext/mocks"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/danglingservice/internal/params"
+	appsV1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestDanglingService(t *testing.T) {
+	suite.Run(t, new(DanglingServiceTestSuite))
+}
+
+type DanglingServiceTestSuite struct {
+	templates.TemplateTestSuite
+
+	ctx *mocks.MockLintContext
+}
+
+func (s *DanglingServiceTestSuite) SetupTest() {
+	s.Init("dangling-service")
+	s.ctx = mocks.NewMockContext()
+}
+
+func (s *DanglingServiceTestSuite) addDeploymentWithPodLabels(name, namespace string, podLabels map[string]string) {
+	s.ctx.AddMockDeployment(s.T(), name)
+	s.ctx.ModifyDeployment(s.T(), name, func(deployment *appsV1.Deployment) {
+		deployment.Namespace = namespace
+		deployment.Spec.Template.Labels = podLabels
+	})
+}
+
+func (s *DanglingServiceTestSuite) addServiceWithSelector(name, namespace string, selector map[string]string) {
+	s.ctx.AddMockService(s.T(), name)
+	s.ctx.ModifyService(s.T(), name, func(service *v1.Service) {
+		service.Namespace = namespace
+		service.Spec.Selector = selector
+	})
+}
+
+func (s *DanglingServiceTestSuite) TestServiceSelectsDeployment() {
+	s.addDeploymentWithPodLabels("app", "default", map[string]string{"app": "app", "tier": "backend"})
+	s.addServiceWithSelector("matching", "default", map[string]string{"app": "app"})
+	s.addServiceWithSelector("other-namespace", "other", map[string]string{"app": "app"})
+	s.addServiceWithSelector("not-matching", "default", map[string]string{"app": "app", "tier": "frontend"})
+	s.addServiceWithSelector("no-selector", "default", nil)
+	s.addServiceWithSelector("empty-selector", "default", map[string]string{})
+	s.addServiceWithSelector("headless", "default", nil)
+	s.ctx.ModifyService(s.T(), "headless", func(service *v1.Service) {
+		service.Spec.ClusterIP = v1.ClusterIPNone
+	})
+	s.addServiceWithSelector("external-name", "default", map[string]string{"app": "other"})
+	s.ctx.ModifyService(s.T(), "external-name", func(service *v1.Service) {
+		service.Spec.Type = v1.ServiceTypeExternalName
+	})
+
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"other-namespace": {{Message: `no pods found matching the labels of service "other-namespace" (map[app:app])`}},
+				"not-matching":    {{Message: `no pods found matching the labels of service "not-matching" (map[app:app tier:frontend])`}},
+				"no-selector":     {{Message: "service has no selector specified"}},
+				"empty-selector":  {{Message: "service has no selector specified"}},
+			},
+		},
+	})
+}
@@ -0,0 +1,11 @@
+package params
+
+// Params represents the params accepted by this template.
+type Params struct {
+
+	// List of service account names that pods are allowed to use. If specified, pods that use any other service
+	// account are flagged too. The default service account is only accepted if it is listed explicitly.
+	// +noregex
+	// +notnegatable
+	AllowedServiceAccounts []string
+}
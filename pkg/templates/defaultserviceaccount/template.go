@@ -0,0 +1,60 @@
+package defaultserviceaccount
+
+import (
+	"fmt"
+
+	"golang.stackrox.io/kube-linter/internal/set"
+	"golang.stackrox.io/kube-linter/internal/stringutils"
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/extract"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/objectkinds"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/defaultserviceaccount/internal/params"
+)
+
+const (
+	templateKey = "default-service-account"
+
+	// defaultServiceAccount is the service account that pods run under if they do not set one.
+	defaultServiceAccount = "default"
+)
+
+func init() {
+	templates.Register(check.Template{
+		HumanName:   "Default Service Account",
+		Key:         templateKey,
+		Description: "Flag pods that run under the default service account, either explicitly or by not setting a service account, or under a service account that is not allowed. Pods that do not mount the token of their service account are not flagged",
+		SupportedObjectKinds: config.ObjectKindsDesc{
+			ObjectKinds: []string{objectkinds.DeploymentLike},
+		},
+		Parameters:             params.ParamDescs,
+		ParseAndValidateParams: params.ParseAndValidate,
+		Instantiate: params.WrapInstantiateFunc(func(p params.Params) (check.Func, error) {
+			allowedServiceAccounts := set.NewFrozenStringSet(p.AllowedServiceAccounts...)
+			return func(_ lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
+				podSpec, found := extract.PodSpec(object.K8sObject)
+				if !found {
+					return nil
+				}
+				// Pods without the token of their service account cannot use its permissions.
+				if podSpec.AutomountServiceAccountToken != nil && !*podSpec.AutomountServiceAccountToken {
+					return nil
+				}
+				sa := stringutils.OrDefault(stringutils.OrDefault(podSpec.ServiceAccountName, podSpec.DeprecatedServiceAccount), defaultServiceAccount)
+				if allowedServiceAccounts.Contains(sa) {
+					return nil
+				}
+				if sa == defaultServiceAccount {
+					return []diagnostic.Diagnostic{{Message: "object uses the default service account"}}
+				}
+				if !allowedServiceAccounts.IsEmpty() {
+					return []diagnostic.Diagnostic{{Message: fmt.Sprintf("object uses service account %q, which is not one of the allowed service accounts %q", sa, p.AllowedServiceAccounts)}}
+				}
+				return nil
+			}, nil
+		}),
+	})
+}
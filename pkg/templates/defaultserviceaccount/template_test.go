@@ -0,0 +1,69 @@
+package defaultserviceaccount
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext/mocks"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/defaultserviceaccount/internal/params"
+	appsV1 "k8s.io/api/apps/v1"
+)
+
+func TestDefaultServiceAccount(t *testing.T) {
+	suite.Run(t, new(DefaultServiceAccountTestSuite))
+}
+
+type DefaultServiceAccountTestSuite struct {
+	templates.TemplateTestSuite
+
+	ctx *mocks.MockLintContext
+}
+
+func (s *DefaultServiceAccountTestSuite) SetupTest() {
+	s.Init(templateKey)
+	s.ctx = mocks.NewMockContext()
+}
+
+func (s *DefaultServiceAccountTestSuite) addDeploymentWithServiceAccount(name, serviceAccountName, deprecatedServiceAccount string) {
+	s.ctx.AddMockDeployment(s.T(), name)
+	s.ctx.ModifyDeployment(s.T(), name, func(deployment *appsV1.Deployment) {
+		deployment.Spec.Template.Spec.ServiceAccountName = serviceAccountName
+		deployment.Spec.Template.Spec.DeprecatedServiceAccount = deprecatedServiceAccount
+	})
+}
+
+func (s *DefaultServiceAccountTestSuite) TestServiceAccounts() {
+	s.addDeploymentWithServiceAccount("unset", "", "")
+	s.addDeploymentWithServiceAccount("explicit-default", "default", "")
+	s.addDeploymentWithServiceAccount("deprecated-field", "", "app")
+	s.addDeploymentWithServiceAccount("dedicated", "app", "")
+	s.addDeploymentWithServiceAccount("other", "other", "")
+	s.addDeploymentWithServiceAccount("no-token", "", "")
+	s.ctx.ModifyDeployment(s.T(), "no-token", func(deployment *appsV1.Deployment) {
+		automount := false
+		deployment.Spec.Template.Spec.AutomountServiceAccountToken = &automount
+	})
+
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"unset":            {{Message: "object uses the default service account"}},
+				"explicit-default": {{Message: "object uses the default service account"}},
+			},
+		},
+		{
+			Param: params.Params{AllowedServiceAccounts: []string{"app"}},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"unset":            {{Message: "object uses the default service account"}},
+				"explicit-default": {{Message: "object uses the default service account"}},
+				"other":            {{Message: `object uses service account "other", which is not one of the allowed service accounts ["app"]`}},
+			},
+		},
+		{
+			Param: params.Params{AllowedServiceAccounts: []string{"default", "app", "other"}},
+		},
+	})
+}
@@ -0,0 +1,134 @@
+// Code generated by kube-linter template codegen. DO NOT EDIT.
+// +build !templatecodegen
+
+package params
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
+)
+
+var (
+	// Use some imports in case they don't get used otherwise.
+	_ = util.MustParseParameterDesc
+	_ = fmt.Sprintf
+
+	deprecatedAPIsParamDesc = util.MustParseParameterDesc(`{
+	"Name": "deprecatedAPIs",
+	"Type": "array",
+	"Description": "The deprecated API versions to flag.",
+	"Examples": null,
+	"Enum": null,
+	"SubParameters": [
+		{
+			"Name": "apiVersion",
+			"Type": "string",
+			"Description": "The deprecated API version, in the group/version form used in the apiVersion field, like \"extensions/v1beta1\".",
+			"Examples": null,
+			"Enum": null,
+			"SubParameters": null,
+			"ArrayElemType": "",
+			"Required": false,
+			"NoRegex": true,
+			"NotNegatable": true,
+			"XXXStructFieldName": "APIVersion",
+			"XXXIsPointer": false
+		},
+		{
+			"Name": "kind",
+			"Type": "string",
+			"Description": "The kind of object that the deprecation applies to, like \"Ingress\". If not specified, the deprecation applies to all kinds of objects in the API version.",
+			"Examples": null,
+			"Enum": null,
+			"SubParameters": null,
+			"ArrayElemType": "",
+			"Required": false,
+			"NoRegex": true,
+			"NotNegatable": true,
+			"XXXStructFieldName": "Kind",
+			"XXXIsPointer": false
+		},
+		{
+			"Name": "removedIn",
+			"Type": "string",
+			"Description": "The Kubernetes version in which the API version is removed, like \"1.22\".",
+			"Examples": null,
+			"Enum": null,
+			"SubParameters": null,
+			"ArrayElemType": "",
+			"Required": false,
+			"NoRegex": true,
+			"NotNegatable": true,
+			"XXXStructFieldName": "RemovedIn",
+			"XXXIsPointer": false
+		},
+		{
+			"Name": "replacement",
+			"Type": "string",
+			"Description": "The API version to migrate to instead, like \"networking.k8s.io/v1\".",
+			"Examples": null,
+			"Enum": null,
+			"SubParameters": null,
+			"ArrayElemType": "",
+			"Required": false,
+			"NoRegex": true,
+			"NotNegatable": true,
+			"XXXStructFieldName": "Replacement",
+			"XXXIsPointer": false
+		}
+	],
+	"ArrayElemType": "object",
+	"Required": false,
+	"NoRegex": false,
+	"NotNegatable": false,
+	"XXXStructFieldName": "DeprecatedAPIs",
+	"XXXIsPointer": false
+}
+`)
+
+	ParamDescs = []check.ParameterDesc{
+		deprecatedAPIsParamDesc,
+	}
+)
+
+func (p *Params) Validate() error {
+	var validationErrors []string
+	if len(validationErrors) > 0 {
+		return errors.Errorf("invalid parameters: %s", strings.Join(validationErrors, ", "))
+    }
+	return nil
+}
+
+// ParseAndValidate instantiates a Params object out of the passed map[string]interface{},
+// validates it, and returns it.
+// The return type is interface{} to satisfy the type in the Template struct.
+func ParseAndValidate(m map[string]interface{}) (interface{}, error) {
+	var p Params
+	if err := util.DecodeMapStructure(m, &p); err != nil {
+		return nil, err
+	}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// WrapInstantiateFunc is a convenience wrapper that wraps an untyped instantiate function
+// into a typed one.
+func WrapInstantiateFunc(f func(p Params) (check.Func, error)) func (interface{}) (check.Func, error) {
+	return func(paramsInt interface{}) (check.Func, error) {
+		return f(paramsInt.(Params))
+	}
+}
+
+// WrapInstantiateContextMatcherFunc is a convenience wrapper that wraps an untyped instantiate function
+// for a context matcher into a typed one.
+func WrapInstantiateContextMatcherFunc(f func(p Params) (check.ContextMatcher, error)) func (interface{}) (check.ContextMatcher, error) {
+	return func(paramsInt interface{}) (check.ContextMatcher, error) {
+		return f(paramsInt.(Params))
+	}
+}
@@ -0,0 +1,33 @@
+package params
+
+// DeprecatedAPI describes an API version that is deprecated, or removed in a Kubernetes release.
+type DeprecatedAPI struct {
+
+	// The deprecated API version, in the group/version form used in the apiVersion field, like "extensions/v1beta1".
+	// +noregex
+	// +notnegatable
+	APIVersion string `json:"apiVersion"`
+
+	// The kind of object that the deprecation applies to, like "Ingress".
+	// If not specified, the deprecation applies to all kinds of objects in the API version.
+	// +noregex
+	// +notnegatable
+	Kind string
+
+	// The Kubernetes version in which the API version is removed, like "1.22".
+	// +noregex
+	// +notnegatable
+	RemovedIn string
+
+	// The API version to migrate to instead, like "networking.k8s.io/v1".
+	// +noregex
+	// +notnegatable
+	Replacement string
+}
+
+// Params represents the params accepted by this template.
+type Params struct {
+
+	// The deprecated API versions to flag.
+	DeprecatedAPIs []DeprecatedAPI `json:"deprecatedAPIs"`
+}
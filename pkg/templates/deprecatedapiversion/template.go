@@ -0,0 +1,67 @@
+package deprecatedapiversion
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/extract"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/objectkinds"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/deprecatedapiversion/internal/params"
+)
+
+const (
+	templateKey = "deprecated-api-version"
+)
+
+func init() {
+	templates.Register(check.Template{
+		HumanName:   "Deprecated API Versions",
+		Key:         templateKey,
+		Description: "Flag objects that use API versions which are deprecated, or removed in a given Kubernetes release",
+		SupportedObjectKinds: config.ObjectKindsDesc{
+			ObjectKinds: []string{objectkinds.Any},
+		},
+		Parameters:             params.ParamDescs,
+		ParseAndValidateParams: params.ParseAndValidate,
+		Instantiate: params.WrapInstantiateFunc(func(p params.Params) (check.Func, error) {
+			if len(p.DeprecatedAPIs) == 0 {
+				return nil, errors.New("no deprecated APIs specified")
+			}
+			for i, api := range p.DeprecatedAPIs {
+				if api.APIVersion == "" {
+					return nil, errors.Errorf("deprecated API %d has no apiVersion", i)
+				}
+			}
+			return func(_ lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
+				gvk := extract.GVK(object.K8sObject)
+				apiVersion, kind := gvk.GroupVersion().String(), gvk.Kind
+				for _, api := range p.DeprecatedAPIs {
+					if api.APIVersion == apiVersion && (api.Kind == "" || api.Kind == kind) {
+						return []diagnostic.Diagnostic{{Message: deprecationMessage(apiVersion, kind, api)}}
+					}
+				}
+				return nil
+			}, nil
+		}),
+	})
+}
+
+func deprecationMessage(apiVersion, kind string, api params.DeprecatedAPI) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s %s is ", apiVersion, kind)
+	if api.RemovedIn != "" {
+		fmt.Fprintf(&sb, "removed in Kubernetes %s", api.RemovedIn)
+	} else {
+		sb.WriteString("deprecated")
+	}
+	if api.Replacement != "" {
+		fmt.Fprintf(&sb, ", use %s instead", api.Replacement)
+	}
+	return sb.String()
+}
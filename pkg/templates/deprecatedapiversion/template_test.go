@@ -0,0 +1,67 @@
+package deprecatedapiversion
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext/mocks"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/deprecatedapiversion/internal/params"
+	appsV1 "k8s.io/api/apps/v1"
+)
+
+func TestDeprecatedAPIVersion(t *testing.T) {
+	suite.Run(t, new(DeprecatedAPIVersionTestSuite))
+}
+
+type DeprecatedAPIVersionTestSuite struct {
+	templates.TemplateTestSuite
+
+	ctx *mocks.MockLintContext
+}
+
+func (s *DeprecatedAPIVersionTestSuite) SetupTest() {
+	s.Init(templateKey)
+	s.ctx = mocks.NewMockContext()
+}
+
+func (s *DeprecatedAPIVersionTestSuite) addDeploymentWithAPIVersion(name, apiVersion, kind string) {
+	s.ctx.AddMockDeployment(s.T(), name)
+	s.ctx.ModifyDeployment(s.T(), name, func(deployment *appsV1.Deployment) {
+		deployment.APIVersion = apiVersion
+		deployment.Kind = kind
+	})
+}
+
+func (s *DeprecatedAPIVersionTestSuite) TestDeprecatedAPIs() {
+	s.addDeploymentWithAPIVersion("current", "apps/v1", "Deployment")
+	s.addDeploymentWithAPIVersion("extensions", "extensions/v1beta1", "Deployment")
+	s.addDeploymentWithAPIVersion("apps-beta", "apps/v1beta2", "Deployment")
+	s.addDeploymentWithAPIVersion("other-kind", "extensions/v1beta1", "DaemonSet")
+
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{
+				DeprecatedAPIs: []params.DeprecatedAPI{
+					{APIVersion: "extensions/v1beta1", Kind: "Deployment", RemovedIn: "1.16", Replacement: "apps/v1"},
+					{APIVersion: "apps/v1beta2"},
+				},
+			},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"extensions": {{Message: "extensions/v1beta1 Deployment is removed in Kubernetes 1.16, use apps/v1 instead"}},
+				"apps-beta":  {{Message: "apps/v1beta2 Deployment is deprecated"}},
+			},
+		},
+		{
+			Param:                    params.Params{},
+			ExpectInstantiationError: true,
+		},
+		{
+			Param: params.Params{
+				DeprecatedAPIs: []params.DeprecatedAPI{{Kind: "Deployment"}},
+			},
+			ExpectInstantiationError: true,
+		},
+	})
+}
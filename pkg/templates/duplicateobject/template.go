@@ -0,0 +1,124 @@
+package duplicateobject
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/objectkinds"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/duplicateobject/internal/params"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// identity is what identifies an object in a cluster. The version is not part of it, since the same object can be
+// served in several versions.
+type identity struct {
+	groupKind       schema.GroupKind
+	namespace, name string
+}
+
+func identityOf(obj lintcontext.Object) identity {
+	return identity{
+		groupKind: obj.K8sObject.GetObjectKind().GroupVersionKind().GroupKind(),
+		namespace: obj.K8sObject.GetNamespace(),
+		name:      obj.K8sObject.GetName(),
+	}
+}
+
+func (i identity) String() string {
+	if i.namespace == "" {
+		return fmt.Sprintf("%s %q", i.groupKind.Kind, i.name)
+	}
+	return fmt.Sprintf("%s %q", i.groupKind.Kind, i.namespace+"/"+i.name)
+}
+
+// location is where an object is defined.
+type location struct {
+	filePath string
+	line     int
+}
+
+func locationOf(obj lintcontext.Object) location {
+	return location{filePath: obj.Metadata.FilePath, line: obj.Metadata.Line}
+}
+
+// String describes the location like deployment.yaml:12.
+func (l location) String() string {
+	if l.line > 0 {
+		return fmt.Sprintf("%s:%d", l.filePath, l.line)
+	}
+	return l.filePath
+}
+
+func contains(locations []location, loc location) bool {
+	for _, l := range locations {
+		if l == loc {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	templates.Register(check.Template{
+		HumanName:   "Duplicate Objects",
+		Key:         "duplicate-object",
+		Description: "Flag objects that are defined more than once, with the same kind, namespace and name, within the same directory, Helm chart or Kustomize directory, since objects are only compared with the ones that are linted together with them. Duplicates in different directories are not flagged",
+		SupportedObjectKinds: config.ObjectKindsDesc{
+			ObjectKinds: []string{objectkinds.Any},
+		},
+		Parameters:             params.ParamDescs,
+		ParseAndValidateParams: params.ParseAndValidate,
+		InstantiateContextMatcher: params.WrapInstantiateContextMatcherFunc(func(_ params.Params) (check.ContextMatcher, error) {
+			return func(lintCtx lintcontext.LintContext) check.Func {
+				// Index the locations of all objects by identity, so that every object is only compared once.
+				locationsByIdentity := make(map[identity][]location)
+				for _, obj := range lintCtx.Objects() {
+					// Objects with a generated name are different objects every time they are created.
+					if obj.K8sObject.GetName() == "" {
+						continue
+					}
+					id := identityOf(obj)
+					// The same document can be loaded more than once, like through overlapping paths.
+					if loc := locationOf(obj); !contains(locationsByIdentity[id], loc) {
+						locationsByIdentity[id] = append(locationsByIdentity[id], loc)
+					}
+				}
+				// Locations are sorted like kubectl applies the files of a directory, so that messages do not depend
+				// on the order in which objects were loaded.
+				for _, locations := range locationsByIdentity {
+					sort.Slice(locations, func(i, j int) bool {
+						if locations[i].filePath != locations[j].filePath {
+							return locations[i].filePath < locations[j].filePath
+						}
+						return locations[i].line < locations[j].line
+					})
+				}
+
+				return func(_ lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
+					if object.K8sObject.GetName() == "" {
+						return nil
+					}
+					id := identityOf(object)
+					locations := locationsByIdentity[id]
+					if len(locations) < 2 {
+						return nil
+					}
+					descriptions := make([]string, 0, len(locations))
+					for _, loc := range locations {
+						descriptions = append(descriptions, loc.String())
+					}
+					return []diagnostic.Diagnostic{{
+						Message: fmt.Sprintf("%s is defined %d times, at %s, so only the last one applied takes effect",
+							id, len(locations), strings.Join(descriptions, ", ")),
+					}}
+				}
+			}, nil
+		}),
+	})
+}
@@ -0,0 +1,111 @@
+package duplicateobject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/k8sutil"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/duplicateobject/internal/params"
+	appsV1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDuplicateObject(t *testing.T) {
+	suite.Run(t, new(DuplicateObjectTestSuite))
+}
+
+// lintContext is a LintContext whose objects can have the same name, unlike the ones of mocks.MockLintContext.
+type lintContext struct {
+	objects []lintcontext.Object
+}
+
+func (l *lintContext) Objects() []lintcontext.Object {
+	return l.objects
+}
+
+func (l *lintContext) InvalidObjects() []lintcontext.InvalidObject {
+	return nil
+}
+
+type DuplicateObjectTestSuite struct {
+	templates.TemplateTestSuite
+
+	ctx *lintContext
+}
+
+func (s *DuplicateObjectTestSuite) SetupTest() {
+	s.Init("duplicate-object")
+	s.ctx = &lintContext{}
+}
+
+func (s *DuplicateObjectTestSuite) add(filePath string, line int, obj k8sutil.Object) {
+	s.ctx.objects = append(s.ctx.objects, lintcontext.Object{
+		Metadata:  lintcontext.ObjectMetadata{FilePath: filePath, Line: line},
+		K8sObject: obj,
+	})
+}
+
+func deployment(apiVersion, namespace, name string) *appsV1.Deployment {
+	return &appsV1.Deployment{
+		TypeMeta:   metaV1.TypeMeta{APIVersion: apiVersion, Kind: "Deployment"},
+		ObjectMeta: metaV1.ObjectMeta{Namespace: namespace, Name: name},
+	}
+}
+
+func (s *DuplicateObjectTestSuite) TestDuplicates() {
+	// The version is not part of the identity of an object, and locations are sorted regardless of the load order.
+	s.add("overlay/app.yaml", 0, deployment("apps/v1beta1", "prod", "app"))
+	s.add("base/app.yaml", 1, deployment("apps/v1", "prod", "app"))
+	s.add("base/app.yaml", 20, deployment("apps/v1", "staging", "app"))
+	s.add("base/app.yaml", 40, &v1.Service{
+		TypeMeta:   metaV1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+		ObjectMeta: metaV1.ObjectMeta{Namespace: "prod", Name: "app"},
+	})
+	s.add("base/role.yaml", 1, &v1.ServiceAccount{
+		TypeMeta:   metaV1.TypeMeta{APIVersion: "v1", Kind: "ServiceAccount"},
+		ObjectMeta: metaV1.ObjectMeta{Name: "ci"},
+	})
+	s.add("other/role.yaml", 1, &v1.ServiceAccount{
+		TypeMeta:   metaV1.TypeMeta{APIVersion: "v1", Kind: "ServiceAccount"},
+		ObjectMeta: metaV1.ObjectMeta{Name: "ci"},
+	})
+	// The same document loaded twice is not a duplicate.
+	s.add("jobs.yaml", 1, &v1.Pod{
+		TypeMeta:   metaV1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metaV1.ObjectMeta{Name: "job"},
+	})
+	s.add("jobs.yaml", 1, &v1.Pod{
+		TypeMeta:   metaV1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metaV1.ObjectMeta{Name: "job"},
+	})
+
+	contextMatcher, err := s.Template.InstantiateContextMatcher(params.Params{})
+	s.Require().NoError(err)
+	checkFunc := contextMatcher(s.ctx)
+	s.Equal([]diagnostic.Diagnostic{{
+		Message: `Deployment "prod/app" is defined 2 times, at base/app.yaml:1, overlay/app.yaml, so only the last one applied takes effect`,
+	}}, checkFunc(s.ctx, s.ctx.objects[0]))
+	s.Equal([]diagnostic.Diagnostic{{
+		Message: `Deployment "prod/app" is defined 2 times, at base/app.yaml:1, overlay/app.yaml, so only the last one applied takes effect`,
+	}}, checkFunc(s.ctx, s.ctx.objects[1]))
+	s.Empty(checkFunc(s.ctx, s.ctx.objects[2]), "objects in other namespaces are not duplicates")
+	s.Empty(checkFunc(s.ctx, s.ctx.objects[3]), "objects of other kinds are not duplicates")
+	s.Equal([]diagnostic.Diagnostic{{
+		Message: `ServiceAccount "ci" is defined 2 times, at base/role.yaml:1, other/role.yaml:1, so only the last one applied takes effect`,
+	}}, checkFunc(s.ctx, s.ctx.objects[4]))
+	s.Empty(checkFunc(s.ctx, s.ctx.objects[6]), "the same document loaded twice is not a duplicate")
+}
+
+func (s *DuplicateObjectTestSuite) TestGeneratedNames() {
+	for i := 0; i < 2; i++ {
+		s.add("jobs.yaml", i+1, &v1.Pod{
+			TypeMeta:   metaV1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+			ObjectMeta: metaV1.ObjectMeta{GenerateName: "job-"},
+		})
+	}
+	s.Validate(s.ctx, []templates.TestCase{{Param: params.Params{}}})
+}
@@ -8,11 +8,11 @@ import (
 	"golang.stackrox.io/kube-linter/pkg/check"
 	"golang.stackrox.io/kube-linter/pkg/config"
 	"golang.stackrox.io/kube-linter/pkg/diagnostic"
-	"golang.stackrox.io/kube-linter/pkg/extract"
-	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/extract/customtypes"
 	"golang.stackrox.io/kube-linter/pkg/objectkinds"
 	"golang.stackrox.io/kube-linter/pkg/templates"
 	"golang.stackrox.io/kube-linter/pkg/templates/hostmounts/internal/params"
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
 )
 
 const (
@@ -38,11 +38,7 @@ func init() {
 				}
 				compiledRegexes = append(compiledRegexes, r)
 			}
-			return func(_ lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
-				podSpec, found := extract.PodSpec(object.K8sObject)
-				if !found {
-					return nil
-				}
+			return util.PerPodSpecCheck(func(podSpec *customtypes.PodSpec) []diagnostic.Diagnostic {
 				var results []diagnostic.Diagnostic
 				containers := podSpec.AllContainers()
 				for _, v := range podSpec.Volumes {
@@ -53,18 +49,20 @@ func init() {
 						if !regex.MatchString(v.HostPath.Path) {
 							continue
 						}
-						for _, container := range containers {
-							for _, mount := range container.VolumeMounts {
+						for i, container := range containers {
+							for j, mount := range container.VolumeMounts {
 								if mount.Name == v.Name {
 									results = append(results, diagnostic.Diagnostic{
-										Message: fmt.Sprintf("host system directory %q is mounted on container %q", v.HostPath.Path, container.Name)})
+										Message:   fmt.Sprintf("host system directory %q is mounted on container %q", v.HostPath.Path, container.Name),
+										FieldPath: fmt.Sprintf("%s.volumeMounts[%d]", podSpec.ContainerFieldPath(i), j),
+									})
 								}
 							}
 						}
 					}
 				}
 				return results
-			}, nil
+			}), nil
 		}),
 	})
 }
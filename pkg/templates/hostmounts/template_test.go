@@ -140,3 +140,32 @@ func (s *HostMountsTestSuite) TestWithVolumes() {
 		},
 	})
 }
+
+func (s *HostMountsTestSuite) TestMultipleContainers() {
+	s.ctx.AddMockDeployment(s.T(), "multi")
+	s.ctx.ModifyDeployment(s.T(), "multi", func(deployment *appsV1.Deployment) {
+		deployment.Spec.Template.Spec.Volumes = []v1.Volume{
+			{Name: "etc", VolumeSource: v1.VolumeSource{HostPath: &v1.HostPathVolumeSource{Path: dirEtc}}},
+		}
+		mounts := []v1.VolumeMount{{Name: "data", MountPath: "/data"}, {Name: "etc", MountPath: "/host/etc"}}
+		deployment.Spec.Template.Spec.InitContainers = []v1.Container{{Name: "init", VolumeMounts: mounts}}
+		deployment.Spec.Template.Spec.Containers = []v1.Container{
+			{Name: "app", VolumeMounts: mounts},
+			{Name: "sidecar"},
+			{Name: "agent", VolumeMounts: mounts},
+		}
+	})
+
+	checkFunc, err := s.Template.Instantiate(params.Params{Dirs: dirParams})
+	s.Require().NoError(err)
+	var fieldPaths []string
+	for _, d := range checkFunc(s.ctx, s.ctx.Objects()[0]) {
+		fieldPaths = append(fieldPaths, d.FieldPath)
+	}
+	// Each container that mounts the directory gets its own finding.
+	s.Equal([]string{
+		"spec.template.spec.initContainers[0].volumeMounts[1]",
+		"spec.template.spec.containers[0].volumeMounts[1]",
+		"spec.template.spec.containers[2].volumeMounts[1]",
+	}, fieldPaths)
+}
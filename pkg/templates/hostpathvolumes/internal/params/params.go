@@ -0,0 +1,10 @@
+package params
+
+// Params represents the params accepted by this template.
+type Params struct {
+
+	// An array of regular expressions specifying the host paths that volumes are allowed to mount, e.g. ^/proc$ and
+	// ^/sys$ for node-exporter DaemonSets. If not specified, no host path is allowed.
+	// +notnegatable
+	AllowedPaths []string
+}
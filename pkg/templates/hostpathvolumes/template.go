@@ -0,0 +1,65 @@
+package hostpathvolumes
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/pkg/errors"
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/extract/customtypes"
+	"golang.stackrox.io/kube-linter/pkg/objectkinds"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/hostpathvolumes/internal/params"
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
+)
+
+const (
+	templateKey = "host-path-volumes"
+)
+
+func init() {
+	templates.Register(check.Template{
+		HumanName:   "Host Path Volumes",
+		Key:         templateKey,
+		Description: "Flag volumes that mount paths of the host, apart from the allowed ones",
+		SupportedObjectKinds: config.ObjectKindsDesc{
+			ObjectKinds: []string{objectkinds.DeploymentLike},
+		},
+		Parameters:             params.ParamDescs,
+		ParseAndValidateParams: params.ParseAndValidate,
+		Instantiate: params.WrapInstantiateFunc(func(p params.Params) (check.Func, error) {
+			allowedPaths := make([]*regexp.Regexp, 0, len(p.AllowedPaths))
+			for _, path := range p.AllowedPaths {
+				r, err := regexp.Compile(path)
+				if err != nil {
+					return nil, errors.Wrapf(err, "invalid regex %s", path)
+				}
+				allowedPaths = append(allowedPaths, r)
+			}
+			return util.PerPodSpecCheck(func(podSpec *customtypes.PodSpec) []diagnostic.Diagnostic {
+				var results []diagnostic.Diagnostic
+				for i, volume := range podSpec.Volumes {
+					if volume.HostPath == nil || isAllowed(allowedPaths, volume.HostPath.Path) {
+						continue
+					}
+					results = append(results, diagnostic.Diagnostic{
+						Message:   fmt.Sprintf("volume %q mounts host path %q", volume.Name, volume.HostPath.Path),
+						FieldPath: fmt.Sprintf("volumes[%d].hostPath.path", i),
+					})
+				}
+				return results
+			}), nil
+		}),
+	})
+}
+
+func isAllowed(allowedPaths []*regexp.Regexp, path string) bool {
+	for _, allowed := range allowedPaths {
+		if allowed.MatchString(path) {
+			return true
+		}
+	}
+	return false
+}
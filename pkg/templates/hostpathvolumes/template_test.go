@@ -0,0 +1,69 @@
+package hostpathvolumes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext/mocks"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/hostpathvolumes/internal/params"
+	appsV1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestHostPathVolumes(t *testing.T) {
+	suite.Run(t, new(HostPathVolumesTestSuite))
+}
+
+type HostPathVolumesTestSuite struct {
+	templates.TemplateTestSuite
+
+	ctx *mocks.MockLintContext
+}
+
+func (s *HostPathVolumesTestSuite) SetupTest() {
+	s.Init(templateKey)
+	s.ctx = mocks.NewMockContext()
+}
+
+func (s *HostPathVolumesTestSuite) addDeploymentWithVolumes(name string, volumes ...v1.Volume) {
+	s.ctx.AddMockDeployment(s.T(), name)
+	s.ctx.ModifyDeployment(s.T(), name, func(deployment *appsV1.Deployment) {
+		deployment.Spec.Template.Spec.Volumes = volumes
+	})
+}
+
+func hostPathVolume(name, path string) v1.Volume {
+	return v1.Volume{Name: name, VolumeSource: v1.VolumeSource{HostPath: &v1.HostPathVolumeSource{Path: path}}}
+}
+
+func (s *HostPathVolumesTestSuite) TestHostPathVolumes() {
+	s.addDeploymentWithVolumes("no-host-paths", v1.Volume{Name: "cache", VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}})
+	s.addDeploymentWithVolumes("node-exporter", hostPathVolume("proc", "/proc"), hostPathVolume("sys", "/sys"), hostPathVolume("root", "/"))
+
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"node-exporter": {
+					{Message: `volume "proc" mounts host path "/proc"`},
+					{Message: `volume "sys" mounts host path "/sys"`},
+					{Message: `volume "root" mounts host path "/"`},
+				},
+			},
+		},
+		{
+			Param: params.Params{AllowedPaths: []string{"^/proc$", "^/sys$"}},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"node-exporter": {
+					{Message: `volume "root" mounts host path "/"`},
+				},
+			},
+		},
+		{
+			Param:                    params.Params{AllowedPaths: []string{"^/proc("}},
+			ExpectInstantiationError: true,
+		},
+	})
+}
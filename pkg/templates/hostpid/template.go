@@ -4,11 +4,11 @@ import (
 	"golang.stackrox.io/kube-linter/pkg/check"
 	"golang.stackrox.io/kube-linter/pkg/config"
 	"golang.stackrox.io/kube-linter/pkg/diagnostic"
-	"golang.stackrox.io/kube-linter/pkg/extract"
-	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/extract/customtypes"
 	"golang.stackrox.io/kube-linter/pkg/objectkinds"
 	"golang.stackrox.io/kube-linter/pkg/templates"
 	"golang.stackrox.io/kube-linter/pkg/templates/hostpid/internal/params"
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
 )
 
 func init() {
@@ -22,16 +22,12 @@ func init() {
 		Parameters:             params.ParamDescs,
 		ParseAndValidateParams: params.ParseAndValidate,
 		Instantiate: params.WrapInstantiateFunc(func(_ params.Params) (check.Func, error) {
-			return func(_ lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
-				podSpec, found := extract.PodSpec(object.K8sObject)
-				if !found {
-					return nil
-				}
+			return util.PerPodSpecCheck(func(podSpec *customtypes.PodSpec) []diagnostic.Diagnostic {
 				if podSpec.HostPID {
-					return []diagnostic.Diagnostic{{Message: "object shares the host's process namespace (via hostPID=true)."}}
+					return []diagnostic.Diagnostic{{Message: "object shares the host's process namespace (via hostPID=true).", FieldPath: "hostPID"}}
 				}
 				return nil
-			}, nil
+			}), nil
 		}),
 	})
 }
@@ -0,0 +1,12 @@
+package params
+
+// Params represents the params accepted by this template.
+type Params struct {
+
+	// List of repositories whose images are allowed to use tags instead of digests, like "gcr.io/my-project/dev" for
+	// internal development images. Images of repositories below the listed ones are allowed too. Repositories without
+	// an explicit registry host are in "docker.io", and official images like "nginx" in "docker.io/library".
+	// +noregex
+	// +notnegatable
+	AllowedRepositories []string
+}
@@ -0,0 +1,80 @@
+package imagedigest
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/docker/distribution/reference"
+	"github.com/pkg/errors"
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/objectkinds"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/imagedigest/internal/params"
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	templateKey = "image-digest"
+)
+
+func init() {
+	templates.Register(check.Template{
+		HumanName:   "Image Digest",
+		Key:         templateKey,
+		Description: "Flag containers whose image is referenced by a tag, or by neither a tag nor a digest, instead of being pinned by digest",
+		SupportedObjectKinds: config.ObjectKindsDesc{
+			ObjectKinds: []string{objectkinds.DeploymentLike},
+		},
+		Parameters:             params.ParamDescs,
+		ParseAndValidateParams: params.ParseAndValidate,
+		Instantiate: params.WrapInstantiateFunc(func(p params.Params) (check.Func, error) {
+			allowedRepositories := make([]string, 0, len(p.AllowedRepositories))
+			for _, repository := range p.AllowedRepositories {
+				named, err := reference.ParseNormalizedNamed(strings.TrimSuffix(repository, "/"))
+				if err != nil {
+					return nil, errors.Wrapf(err, "invalid repository %q", repository)
+				}
+				if !reference.IsNameOnly(named) {
+					return nil, errors.Errorf("repository %q must not include a tag or digest", repository)
+				}
+				allowedRepositories = append(allowedRepositories, named.Name())
+			}
+
+			return util.PerContainerCheck(func(container *v1.Container) []diagnostic.Diagnostic {
+				if diag := checkImage(allowedRepositories, container.Name, container.Image); diag != nil {
+					return []diagnostic.Diagnostic{*diag}
+				}
+				return nil
+			}), nil
+		}),
+	})
+}
+
+// checkImage returns a diagnostic if the given image is not pinned by digest and does not come from one of the
+// allowed repositories.
+func checkImage(allowedRepositories []string, containerName, image string) *diagnostic.Diagnostic {
+	named, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return &diagnostic.Diagnostic{
+			Message:   fmt.Sprintf("container %q has image %q that could not be parsed: %v", containerName, image, err),
+			FieldPath: "image",
+		}
+	}
+	if _, pinned := named.(reference.Digested); pinned {
+		return nil
+	}
+	// The normalized name includes the registry host, defaulting to docker.io, but neither the tag nor the digest.
+	name := named.Name()
+	for _, repository := range allowedRepositories {
+		if name == repository || strings.HasPrefix(name, repository+"/") {
+			return nil
+		}
+	}
+	return &diagnostic.Diagnostic{
+		Message:   fmt.Sprintf("container %q has image %q that is not pinned by digest", containerName, image),
+		FieldPath: "image",
+	}
+}
@@ -0,0 +1,82 @@
+package imagedigest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext/mocks"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/imagedigest/internal/params"
+	v1 "k8s.io/api/core/v1"
+)
+
+const digest = "sha256:3b4d1b4a2e4c8f0f9b5a1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d1e2f"
+
+func TestImageDigest(t *testing.T) {
+	suite.Run(t, new(ImageDigestTestSuite))
+}
+
+type ImageDigestTestSuite struct {
+	templates.TemplateTestSuite
+
+	ctx *mocks.MockLintContext
+}
+
+func (s *ImageDigestTestSuite) SetupTest() {
+	s.Init(templateKey)
+	s.ctx = mocks.NewMockContext()
+}
+
+func (s *ImageDigestTestSuite) addDeploymentWithImage(name, image string) {
+	s.ctx.AddMockDeployment(s.T(), name)
+	s.ctx.AddContainerToDeployment(s.T(), name, v1.Container{Name: "app", Image: image})
+}
+
+func (s *ImageDigestTestSuite) TestImageDigest() {
+	s.addDeploymentWithImage("digest", "gcr.io/my-project/app@"+digest)
+	s.addDeploymentWithImage("tag-and-digest", "nginx:1.21@"+digest)
+	s.addDeploymentWithImage("tag", "gcr.io/my-project/app:1.0")
+	s.addDeploymentWithImage("no-tag", "nginx")
+	s.addDeploymentWithImage("dev", "gcr.io/my-project/dev/app:latest")
+	s.addDeploymentWithImage("invalid", "Invalid Image")
+
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"tag": {
+					{Message: `container "app" has image "gcr.io/my-project/app:1.0" that is not pinned by digest`},
+				},
+				"no-tag": {
+					{Message: `container "app" has image "nginx" that is not pinned by digest`},
+				},
+				"dev": {
+					{Message: `container "app" has image "gcr.io/my-project/dev/app:latest" that is not pinned by digest`},
+				},
+				"invalid": {
+					{Message: `container "app" has image "Invalid Image" that could not be parsed: invalid reference format: repository name must be lowercase`},
+				},
+			},
+		},
+		{
+			Param: params.Params{AllowedRepositories: []string{"gcr.io/my-project/dev/", "nginx"}},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"tag": {
+					{Message: `container "app" has image "gcr.io/my-project/app:1.0" that is not pinned by digest`},
+				},
+				"invalid": {
+					{Message: `container "app" has image "Invalid Image" that could not be parsed: invalid reference format: repository name must be lowercase`},
+				},
+			},
+		},
+		{
+			Param:                    params.Params{AllowedRepositories: []string{"nginx:1.21"}},
+			ExpectInstantiationError: true,
+		},
+		{
+			Param:                    params.Params{AllowedRepositories: []string{"Not A Repository"}},
+			ExpectInstantiationError: true,
+		},
+	})
+}
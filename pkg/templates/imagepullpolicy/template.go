@@ -32,7 +32,7 @@ func init() {
 			forbiddenPolicies := set.NewStringSet(p.ForbiddenPolicies...)
 			return util.PerContainerCheck(func(container *v1.Container) []diagnostic.Diagnostic {
 				if forbiddenPolicies.Contains(string(container.ImagePullPolicy)) {
-					return []diagnostic.Diagnostic{{Message: fmt.Sprintf("container %q has imagePullPolicy set to %s", container.Name, container.ImagePullPolicy)}}
+					return []diagnostic.Diagnostic{{Message: fmt.Sprintf("container %q has imagePullPolicy set to %s", container.Name, container.ImagePullPolicy), FieldPath: "imagePullPolicy"}}
 				}
 				return nil
 			}), nil
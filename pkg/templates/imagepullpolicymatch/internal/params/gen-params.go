@@ -0,0 +1,154 @@
+// Code generated by kube-linter template codegen. DO NOT EDIT.
+// +build !templatecodegen
+
+package params
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
+)
+
+var (
+	// Use some imports in case they don't get used otherwise.
+	_ = util.MustParseParameterDesc
+	_ = fmt.Sprintf
+
+	mutableTagsParamDesc = util.MustParseParameterDesc(`{
+	"Name": "mutableTags",
+	"Type": "string",
+	"Description": "A regular expression specifying the tags that can point to different images over time, like latest. Images without a tag or digest use latest, so they always have a mutable tag. Images pinned by digest never have one. If not specified, only latest is mutable.",
+	"Examples": null,
+	"Enum": null,
+	"SubParameters": null,
+	"ArrayElemType": "",
+	"Default": "^latest$",
+	"Required": false,
+	"NoRegex": false,
+	"NotNegatable": false,
+	"XXXStructFieldName": "MutableTags",
+	"XXXIsPointer": false
+}
+`)
+
+	mutableTagPoliciesParamDesc = util.MustParseParameterDesc(`{
+	"Name": "mutableTagPolicies",
+	"Type": "array",
+	"Description": "The image pull policies that containers with mutable tags are allowed to use. If not specified, only Always is allowed, so that containers run the image that the tag currently points to.",
+	"Examples": null,
+	"Enum": [
+		"Always",
+		"IfNotPresent",
+		"Never"
+	],
+	"SubParameters": null,
+	"ArrayElemType": "string",
+	"Default": "[\"Always\"]",
+	"Required": false,
+	"NoRegex": true,
+	"NotNegatable": true,
+	"XXXStructFieldName": "MutableTagPolicies",
+	"XXXIsPointer": false
+}
+`)
+
+	pinnedImagePoliciesParamDesc = util.MustParseParameterDesc(`{
+	"Name": "pinnedImagePolicies",
+	"Type": "array",
+	"Description": "The image pull policies that containers with images pinned by digest are allowed to use. If not specified, IfNotPresent and Never are allowed, since pulling an image that cannot change again is wasteful.",
+	"Examples": null,
+	"Enum": [
+		"Always",
+		"IfNotPresent",
+		"Never"
+	],
+	"SubParameters": null,
+	"ArrayElemType": "string",
+	"Default": "[\"IfNotPresent\", \"Never\"]",
+	"Required": false,
+	"NoRegex": true,
+	"NotNegatable": true,
+	"XXXStructFieldName": "PinnedImagePolicies",
+	"XXXIsPointer": false
+}
+`)
+
+	ParamDescs = []check.ParameterDesc{
+		mutableTagsParamDesc,
+		mutableTagPoliciesParamDesc,
+		pinnedImagePoliciesParamDesc,
+	}
+)
+
+func (p *Params) Validate() error {
+	var validationErrors []string
+	for _, value := range p.MutableTagPolicies {
+		var found bool
+		for _, allowedValue := range []string{
+			"Always",
+			"IfNotPresent",
+			"Never",
+		}{
+			if value == allowedValue {
+				found = true
+				break
+			}
+		}
+		if !found {
+			validationErrors = append(validationErrors, fmt.Sprintf("param mutableTagPolicies has invalid value %q, must be one of [Always IfNotPresent Never]", p.MutableTagPolicies))
+		}
+	}
+	for _, value := range p.PinnedImagePolicies {
+		var found bool
+		for _, allowedValue := range []string{
+			"Always",
+			"IfNotPresent",
+			"Never",
+		}{
+			if value == allowedValue {
+				found = true
+				break
+			}
+		}
+		if !found {
+			validationErrors = append(validationErrors, fmt.Sprintf("param pinnedImagePolicies has invalid value %q, must be one of [Always IfNotPresent Never]", p.PinnedImagePolicies))
+		}
+	}
+	if len(validationErrors) > 0 {
+		return errors.Errorf("invalid parameters: %s", strings.Join(validationErrors, ", "))
+    }
+	return nil
+}
+
+// ParseAndValidate instantiates a Params object out of the passed map[string]interface{},
+// validates it, and returns it.
+// The return type is interface{} to satisfy the type in the Template struct.
+func ParseAndValidate(m map[string]interface{}) (interface{}, error) {
+	var p Params
+	if err := util.DecodeMapStructure(m, &p); err != nil {
+		return nil, err
+	}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// WrapInstantiateFunc is a convenience wrapper that wraps an untyped instantiate function
+// into a typed one.
+func WrapInstantiateFunc(f func(p Params) (check.Func, error)) func (interface{}) (check.Func, error) {
+	return func(paramsInt interface{}) (check.Func, error) {
+		return f(paramsInt.(Params))
+	}
+}
+
+// WrapInstantiateContextMatcherFunc is a convenience wrapper that wraps an untyped instantiate function
+// for a context matcher into a typed one.
+func WrapInstantiateContextMatcherFunc(f func(p Params) (check.ContextMatcher, error)) func (interface{}) (check.ContextMatcher, error) {
+	return func(paramsInt interface{}) (check.ContextMatcher, error) {
+		return f(paramsInt.(Params))
+	}
+}
@@ -0,0 +1,31 @@
+package params
+
+// Params represents the params accepted by this template.
+type Params struct {
+
+	// A regular expression specifying the tags that can point to different images over time, like latest. Images
+	// without a tag or digest use latest, so they always have a mutable tag. Images pinned by digest never have one.
+	// If not specified, only latest is mutable.
+	// +default=^latest$
+	MutableTags string
+
+	// The image pull policies that containers with mutable tags are allowed to use. If not specified, only Always is
+	// allowed, so that containers run the image that the tag currently points to.
+	// +default=["Always"]
+	// +noregex
+	// +notnegatable
+	// +enum=Always
+	// +enum=IfNotPresent
+	// +enum=Never
+	MutableTagPolicies []string
+
+	// The image pull policies that containers with images pinned by digest are allowed to use. If not specified,
+	// IfNotPresent and Never are allowed, since pulling an image that cannot change again is wasteful.
+	// +default=["IfNotPresent", "Never"]
+	// +noregex
+	// +notnegatable
+	// +enum=Always
+	// +enum=IfNotPresent
+	// +enum=Never
+	PinnedImagePolicies []string
+}
@@ -0,0 +1,125 @@
+package imagepullpolicymatch
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/docker/distribution/reference"
+	"github.com/pkg/errors"
+	"golang.stackrox.io/kube-linter/internal/set"
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/objectkinds"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/imagepullpolicymatch/internal/params"
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	templateKey = "image-pull-policy-match"
+
+	defaultMutableTags = "^latest$"
+)
+
+var (
+	defaultMutableTagPolicies  = []string{string(v1.PullAlways)}
+	defaultPinnedImagePolicies = []string{string(v1.PullIfNotPresent), string(v1.PullNever)}
+)
+
+func init() {
+	templates.Register(check.Template{
+		HumanName:   "Image Pull Policy Match",
+		Key:         templateKey,
+		Description: "Flag containers whose image pull policy does not match their image, like images with mutable tags that are not always pulled, or images pinned by digest that are",
+		SupportedObjectKinds: config.ObjectKindsDesc{
+			ObjectKinds: []string{objectkinds.DeploymentLike},
+		},
+		Parameters:             params.ParamDescs,
+		ParseAndValidateParams: params.ParseAndValidate,
+		Instantiate: params.WrapInstantiateFunc(func(p params.Params) (check.Func, error) {
+			mutableTagsStr := p.MutableTags
+			if mutableTagsStr == "" {
+				mutableTagsStr = defaultMutableTags
+			}
+			mutableTags, err := regexp.Compile(mutableTagsStr)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid regex %s", mutableTagsStr)
+			}
+			mutableTagPolicies := p.MutableTagPolicies
+			if len(mutableTagPolicies) == 0 {
+				mutableTagPolicies = defaultMutableTagPolicies
+			}
+			pinnedImagePolicies := p.PinnedImagePolicies
+			if len(pinnedImagePolicies) == 0 {
+				pinnedImagePolicies = defaultPinnedImagePolicies
+			}
+			allowedForMutableTags := set.NewStringSet(mutableTagPolicies...)
+			allowedForPinnedImages := set.NewStringSet(pinnedImagePolicies...)
+
+			return util.PerContainerCheck(func(container *v1.Container) []diagnostic.Diagnostic {
+				named, err := reference.ParseNormalizedNamed(container.Image)
+				if err != nil {
+					// Images that cannot be parsed have no tag to compare the policy with.
+					return nil
+				}
+				_, pinned := named.(reference.Digested)
+				tag := ""
+				if tagged, ok := named.(reference.Tagged); ok {
+					tag = tagged.Tag()
+				} else if !pinned {
+					tag = "latest"
+				}
+
+				var kind string
+				var allowedPolicies []string
+				switch {
+				case pinned:
+					if allowedForPinnedImages.Contains(string(effectivePolicy(container.ImagePullPolicy, tag))) {
+						return nil
+					}
+					kind, allowedPolicies = "is pinned by digest", pinnedImagePolicies
+				case mutableTags.MatchString(tag):
+					if allowedForMutableTags.Contains(string(effectivePolicy(container.ImagePullPolicy, tag))) {
+						return nil
+					}
+					kind, allowedPolicies = "has the mutable tag "+tag, mutableTagPolicies
+				default:
+					return nil
+				}
+				// Containers without a policy are attributed to their image, which their default policy depends on.
+				fieldPath := "imagePullPolicy"
+				if container.ImagePullPolicy == "" {
+					fieldPath = "image"
+				}
+				return []diagnostic.Diagnostic{{
+					Message: fmt.Sprintf("container %q has image %q, which %s, but uses %s (allowed: %s)",
+						container.Name, container.Image, kind, describePolicy(container.ImagePullPolicy, tag),
+						strings.Join(allowedPolicies, ", ")),
+					FieldPath: fieldPath,
+				}}
+			}), nil
+		}),
+	})
+}
+
+// effectivePolicy returns the image pull policy that Kubernetes uses for a container with the given policy and image
+// tag. Containers without a policy always pull images tagged latest, and pull others if they are not present.
+func effectivePolicy(policy v1.PullPolicy, tag string) v1.PullPolicy {
+	if policy != "" {
+		return policy
+	}
+	if tag == "latest" {
+		return v1.PullAlways
+	}
+	return v1.PullIfNotPresent
+}
+
+func describePolicy(policy v1.PullPolicy, tag string) string {
+	if policy == "" {
+		return fmt.Sprintf("the default imagePullPolicy %s", effectivePolicy(policy, tag))
+	}
+	return fmt.Sprintf("imagePullPolicy %s", policy)
+}
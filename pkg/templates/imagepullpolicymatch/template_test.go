@@ -0,0 +1,76 @@
+package imagepullpolicymatch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext/mocks"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/imagepullpolicymatch/internal/params"
+	v1 "k8s.io/api/core/v1"
+)
+
+const digest = "sha256:3b4d1b4a2e4c8f0f9b5a1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d1e2f"
+
+func TestImagePullPolicyMatch(t *testing.T) {
+	suite.Run(t, new(ImagePullPolicyMatchTestSuite))
+}
+
+type ImagePullPolicyMatchTestSuite struct {
+	templates.TemplateTestSuite
+
+	ctx *mocks.MockLintContext
+}
+
+func (s *ImagePullPolicyMatchTestSuite) SetupTest() {
+	s.Init(templateKey)
+	s.ctx = mocks.NewMockContext()
+}
+
+func (s *ImagePullPolicyMatchTestSuite) addDeployment(name, image string, policy v1.PullPolicy) {
+	s.ctx.AddMockDeployment(s.T(), name)
+	s.ctx.AddContainerToDeployment(s.T(), name, v1.Container{Name: "app", Image: image, ImagePullPolicy: policy})
+}
+
+func (s *ImagePullPolicyMatchTestSuite) TestImagePullPolicyMatch() {
+	s.addDeployment("latest-always", "nginx:latest", v1.PullAlways)
+	s.addDeployment("latest-if-not-present", "nginx:latest", v1.PullIfNotPresent)
+	s.addDeployment("no-tag-default", "nginx", "")
+	s.addDeployment("main-default", "nginx:main", "")
+	s.addDeployment("version-always", "nginx:1.21", v1.PullAlways)
+	s.addDeployment("pinned-always", "nginx@"+digest, v1.PullAlways)
+	s.addDeployment("pinned-default", "nginx@"+digest, "")
+
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"latest-if-not-present": {
+					{Message: `container "app" has image "nginx:latest", which has the mutable tag latest, but uses imagePullPolicy IfNotPresent (allowed: Always)`},
+				},
+				"pinned-always": {
+					{Message: `container "app" has image "nginx@` + digest + `", which is pinned by digest, but uses imagePullPolicy Always (allowed: IfNotPresent, Never)`},
+				},
+			},
+		},
+		{
+			Param: params.Params{
+				MutableTags:         "^(latest|main)$",
+				PinnedImagePolicies: []string{"IfNotPresent", "Never", "Always"},
+			},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"latest-if-not-present": {
+					{Message: `container "app" has image "nginx:latest", which has the mutable tag latest, but uses imagePullPolicy IfNotPresent (allowed: Always)`},
+				},
+				"main-default": {
+					{Message: `container "app" has image "nginx:main", which has the mutable tag main, but uses the default imagePullPolicy IfNotPresent (allowed: Always)`},
+				},
+			},
+		},
+		{
+			Param:                    params.Params{MutableTags: "^(latest"},
+			ExpectInstantiationError: true,
+		},
+	})
+}
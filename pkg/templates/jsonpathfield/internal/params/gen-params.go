@@ -0,0 +1,154 @@
+// Code generated by kube-linter template codegen. DO NOT EDIT.
+// +build !templatecodegen
+
+package params
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
+)
+
+var (
+	// Use some imports in case they don't get used otherwise.
+	_ = util.MustParseParameterDesc
+	_ = fmt.Sprintf
+
+	pathParamDesc = util.MustParseParameterDesc(`{
+	"Name": "path",
+	"Type": "string",
+	"Description": "A JSONPath expression of the field to check, like .spec.replicas, in the syntax of kubectl's -o jsonpath. It can match several values, e.g. with [*] or filters, which are checked one by one.",
+	"Examples": null,
+	"Enum": null,
+	"SubParameters": null,
+	"ArrayElemType": "",
+	"Required": true,
+	"NoRegex": true,
+	"NotNegatable": true,
+	"XXXStructFieldName": "Path",
+	"XXXIsPointer": false
+}
+`)
+
+	operationParamDesc = util.MustParseParameterDesc(`{
+	"Name": "operation",
+	"Type": "string",
+	"Description": "What to check about the values at the path: exists flags objects without any, not-exists flags every value, equals flags values that are not equal to the expected value, and matches flags values that do not match it as a regular expression. Objects without any value at the path are flagged for equals and matches too.",
+	"Examples": null,
+	"Enum": [
+		"exists",
+		"not-exists",
+		"equals",
+		"matches"
+	],
+	"SubParameters": null,
+	"ArrayElemType": "",
+	"Required": true,
+	"NoRegex": true,
+	"NotNegatable": true,
+	"XXXStructFieldName": "Operation",
+	"XXXIsPointer": false
+}
+`)
+
+	valueParamDesc = util.MustParseParameterDesc(`{
+	"Name": "value",
+	"Type": "string",
+	"Description": "The expected value for the equals and matches operations. Values that are not strings are compared in their JSON form, e.g. true or 3.",
+	"Examples": null,
+	"Enum": null,
+	"SubParameters": null,
+	"ArrayElemType": "",
+	"Required": false,
+	"NoRegex": true,
+	"NotNegatable": true,
+	"XXXStructFieldName": "Value",
+	"XXXIsPointer": false
+}
+`)
+
+	forEachParamDesc = util.MustParseParameterDesc(`{
+	"Name": "forEach",
+	"Type": "string",
+	"Description": "An optional JSONPath expression of elements to check separately, like .spec.template.spec.containers[*]. The path is then relative to each element, so that elements without a value at the path are flagged on their own.",
+	"Examples": null,
+	"Enum": null,
+	"SubParameters": null,
+	"ArrayElemType": "",
+	"Required": false,
+	"NoRegex": true,
+	"NotNegatable": true,
+	"XXXStructFieldName": "ForEach",
+	"XXXIsPointer": false
+}
+`)
+
+	ParamDescs = []check.ParameterDesc{
+		pathParamDesc,
+		operationParamDesc,
+		valueParamDesc,
+		forEachParamDesc,
+	}
+)
+
+func (p *Params) Validate() error {
+	var validationErrors []string
+	if p.Path == "" {
+		validationErrors = append(validationErrors, "required param path not found")
+	}
+	if p.Operation == "" {
+		validationErrors = append(validationErrors, "required param operation not found")
+	}
+	var found bool
+	for _, allowedValue := range []string{
+		"exists",
+		"not-exists",
+		"equals",
+		"matches",
+	}{
+		if p.Operation == allowedValue {
+			found = true
+			break
+		}
+	}
+	if !found {
+		validationErrors = append(validationErrors, fmt.Sprintf("param operation has invalid value %q, must be one of [exists not-exists equals matches]", p.Operation))
+	}
+	if len(validationErrors) > 0 {
+		return errors.Errorf("invalid parameters: %s", strings.Join(validationErrors, ", "))
+    }
+	return nil
+}
+
+// ParseAndValidate instantiates a Params object out of the passed map[string]interface{},
+// validates it, and returns it.
+// The return type is interface{} to satisfy the type in the Template struct.
+func ParseAndValidate(m map[string]interface{}) (interface{}, error) {
+	var p Params
+	if err := util.DecodeMapStructure(m, &p); err != nil {
+		return nil, err
+	}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// WrapInstantiateFunc is a convenience wrapper that wraps an untyped instantiate function
+// into a typed one.
+func WrapInstantiateFunc(f func(p Params) (check.Func, error)) func (interface{}) (check.Func, error) {
+	return func(paramsInt interface{}) (check.Func, error) {
+		return f(paramsInt.(Params))
+	}
+}
+
+// WrapInstantiateContextMatcherFunc is a convenience wrapper that wraps an untyped instantiate function
+// for a context matcher into a typed one.
+func WrapInstantiateContextMatcherFunc(f func(p Params) (check.ContextMatcher, error)) func (interface{}) (check.ContextMatcher, error) {
+	return func(paramsInt interface{}) (check.ContextMatcher, error) {
+		return f(paramsInt.(Params))
+	}
+}
@@ -0,0 +1,36 @@
+package params
+
+// Params represents the params accepted by this template.
+type Params struct {
+
+	// A JSONPath expression of the field to check, like .spec.replicas, in the syntax of kubectl's -o jsonpath. It can
+	// match several values, e.g. with [*] or filters, which are checked one by one.
+	// +required
+	// +noregex
+	// +notnegatable
+	Path string
+
+	// What to check about the values at the path: exists flags objects without any, not-exists flags every value,
+	// equals flags values that are not equal to the expected value, and matches flags values that do not match it as
+	// a regular expression. Objects without any value at the path are flagged for equals and matches too.
+	// +required
+	// +noregex
+	// +notnegatable
+	// +enum=exists
+	// +enum=not-exists
+	// +enum=equals
+	// +enum=matches
+	Operation string
+
+	// The expected value for the equals and matches operations. Values that are not strings are compared in their
+	// JSON form, e.g. true or 3.
+	// +noregex
+	// +notnegatable
+	Value string
+
+	// An optional JSONPath expression of elements to check separately, like .spec.template.spec.containers[*]. The
+	// path is then relative to each element, so that elements without a value at the path are flagged on their own.
+	// +noregex
+	// +notnegatable
+	ForEach string
+}
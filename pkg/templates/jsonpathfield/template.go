@@ -0,0 +1,220 @@
+package jsonpathfield
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.stackrox.io/kube-linter/internal/jsonpathutil"
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/extract"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/objectkinds"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/jsonpathfield/internal/params"
+)
+
+const (
+	templateKey = "jsonpath-field"
+
+	operationExists    = "exists"
+	operationNotExists = "not-exists"
+	operationEquals    = "equals"
+	operationMatches   = "matches"
+)
+
+var (
+	// simplePathRegex matches paths that only consist of field names, which translate to a diagnostic field path.
+	simplePathRegex = regexp.MustCompile(`^(\.[A-Za-z0-9_-]+)+$`)
+)
+
+// path is a parsed JSONPath expression.
+type path struct {
+	parsed *jsonpathutil.Path
+	// display is the expression without braces, as users usually write it.
+	display string
+}
+
+func parsePath(name, expr string) (path, error) {
+	p := path{display: strings.TrimSuffix(strings.TrimPrefix(expr, "{"), "}")}
+	if !strings.HasPrefix(expr, "{") {
+		expr = "{" + expr + "}"
+	}
+	parsed, err := jsonpathutil.Parse(p.display, expr, true)
+	if err != nil {
+		return path{}, errors.Wrapf(err, "invalid %s %s", name, p.display)
+	}
+	p.parsed = parsed
+	return p, nil
+}
+
+// find returns the values at the path in the given data. Missing fields are not an error, but result in fewer values.
+func (p path) find(data interface{}) ([]interface{}, error) {
+	results, err := p.parsed.FindResults(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "evaluating %s", p.display)
+	}
+	var values []interface{}
+	for _, result := range results {
+		for _, value := range result {
+			// Fields set to null are treated like missing ones, like Kubernetes does.
+			if v := value.Interface(); v != nil {
+				values = append(values, v)
+			}
+		}
+	}
+	return values, nil
+}
+
+// stringValue returns the given value as it is compared with the expected value: strings as they are, and everything
+// else in its JSON form.
+func stringValue(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(b)
+}
+
+type fieldCheck struct {
+	path      path
+	forEach   *path
+	operation string
+	value     string
+	regex     *regexp.Regexp
+}
+
+func newFieldCheck(p params.Params) (*fieldCheck, error) {
+	fieldPath, err := parsePath("path", p.Path)
+	if err != nil {
+		return nil, err
+	}
+	c := &fieldCheck{path: fieldPath, operation: p.Operation, value: p.Value}
+	if p.ForEach != "" {
+		forEach, err := parsePath("forEach", p.ForEach)
+		if err != nil {
+			return nil, err
+		}
+		c.forEach = &forEach
+	}
+	switch p.Operation {
+	case operationExists, operationNotExists:
+		if p.Value != "" {
+			return nil, errors.Errorf("value is only used by the %s and %s operations", operationEquals, operationMatches)
+		}
+	case operationEquals:
+	case operationMatches:
+		c.regex, err = regexp.Compile(p.Value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid regex %s", p.Value)
+		}
+	default:
+		return nil, errors.Errorf("unknown operation %q", p.Operation)
+	}
+	return c, nil
+}
+
+func (c *fieldCheck) check(object lintcontext.Object) []diagnostic.Diagnostic {
+	fields, err := extract.Unstructured(object.K8sObject)
+	if err != nil {
+		return []diagnostic.Diagnostic{{Message: fmt.Sprintf("converting object: %v", err)}}
+	}
+	if c.forEach == nil {
+		return c.checkValues(fields, fmt.Sprintf("field %s", c.path.display))
+	}
+	elements, err := c.forEach.find(fields)
+	if err != nil {
+		return []diagnostic.Diagnostic{{Message: err.Error()}}
+	}
+	var results []diagnostic.Diagnostic
+	for i, element := range elements {
+		results = append(results, c.checkValues(element, fmt.Sprintf("field %s of %s", c.path.display, c.describeElement(i, element)))...)
+	}
+	return results
+}
+
+// describeElement describes the element with the given index at the forEach path, by name if it has one, like
+// containers and volumes.
+func (c *fieldCheck) describeElement(i int, element interface{}) string {
+	if fields, ok := element.(map[string]interface{}); ok {
+		if name, ok := fields["name"].(string); ok && name != "" {
+			return fmt.Sprintf("element %q at %s", name, c.forEach.display)
+		}
+	}
+	return fmt.Sprintf("element %d at %s", i, c.forEach.display)
+}
+
+// checkValues checks the values at the path in the given data, and returns diagnostics about the field described by
+// subject.
+func (c *fieldCheck) checkValues(data interface{}, subject string) []diagnostic.Diagnostic {
+	values, err := c.path.find(data)
+	if err != nil {
+		return []diagnostic.Diagnostic{{Message: err.Error()}}
+	}
+	// Diagnostics about values at simple paths of an object can point at the field.
+	var fieldPath string
+	if c.forEach == nil && simplePathRegex.MatchString(c.path.display) {
+		fieldPath = strings.TrimPrefix(c.path.display, ".")
+	}
+
+	if len(values) == 0 {
+		switch c.operation {
+		case operationExists:
+			return []diagnostic.Diagnostic{{Message: fmt.Sprintf("%s is not set", subject)}}
+		case operationEquals:
+			return []diagnostic.Diagnostic{{Message: fmt.Sprintf("%s is not set, but should be %q", subject, c.value)}}
+		case operationMatches:
+			return []diagnostic.Diagnostic{{Message: fmt.Sprintf("%s is not set, but should match %q", subject, c.value)}}
+		}
+		return nil
+	}
+	var results []diagnostic.Diagnostic
+	for _, value := range values {
+		var msg string
+		switch c.operation {
+		case operationNotExists:
+			msg = fmt.Sprintf("%s is set", subject)
+		case operationEquals:
+			if actual := stringValue(value); actual != c.value {
+				msg = fmt.Sprintf("%s is %q, but should be %q", subject, actual, c.value)
+			}
+		case operationMatches:
+			if actual := stringValue(value); !c.regex.MatchString(actual) {
+				msg = fmt.Sprintf("%s is %q, which does not match %q", subject, actual, c.value)
+			}
+		}
+		if msg != "" {
+			results = append(results, diagnostic.Diagnostic{Message: msg, FieldPath: fieldPath})
+		}
+	}
+	return results
+}
+
+func init() {
+	templates.Register(check.Template{
+		HumanName:   "JSONPath Field",
+		Key:         templateKey,
+		Description: "Flag objects whose fields at a JSONPath expression are missing, present, not equal to a value or not matching a regular expression",
+		SupportedObjectKinds: config.ObjectKindsDesc{
+			ObjectKinds: []string{objectkinds.Any},
+		},
+		Parameters:             params.ParamDescs,
+		ParseAndValidateParams: params.ParseAndValidate,
+		Instantiate: params.WrapInstantiateFunc(func(p params.Params) (check.Func, error) {
+			c, err := newFieldCheck(p)
+			if err != nil {
+				return nil, err
+			}
+			return func(_ lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
+				return c.check(object)
+			}, nil
+		}),
+	})
+}
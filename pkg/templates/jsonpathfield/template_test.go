@@ -0,0 +1,127 @@
+package jsonpathfield
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext/mocks"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/jsonpathfield/internal/params"
+	appsV1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestJSONPathField(t *testing.T) {
+	suite.Run(t, new(JSONPathFieldTestSuite))
+}
+
+type JSONPathFieldTestSuite struct {
+	templates.TemplateTestSuite
+
+	ctx *mocks.MockLintContext
+}
+
+func (s *JSONPathFieldTestSuite) SetupTest() {
+	s.Init(templateKey)
+	s.ctx = mocks.NewMockContext()
+
+	replicas := int32(3)
+	runAsNonRoot := true
+	s.ctx.AddMockDeployment(s.T(), "app")
+	s.ctx.ModifyDeployment(s.T(), "app", func(deployment *appsV1.Deployment) {
+		deployment.Spec.Replicas = &replicas
+		deployment.Spec.Template.Spec.Containers = []v1.Container{
+			{Name: "app", ImagePullPolicy: v1.PullAlways, SecurityContext: &v1.SecurityContext{RunAsNonRoot: &runAsNonRoot}},
+			{Name: "sidecar", ImagePullPolicy: v1.PullIfNotPresent},
+		}
+	})
+	s.ctx.AddMockDeployment(s.T(), "bare")
+}
+
+func (s *JSONPathFieldTestSuite) TestExists() {
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{Path: ".spec.replicas", Operation: operationExists},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"bare": {{Message: "field .spec.replicas is not set"}},
+			},
+		},
+		{
+			Param: params.Params{Path: "{.spec.template.spec.hostNetwork}", Operation: operationNotExists},
+		},
+		{
+			Param: params.Params{Path: ".spec.template.spec.containers[*].securityContext", Operation: operationNotExists},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"app": {{Message: "field .spec.template.spec.containers[*].securityContext is set"}},
+			},
+		},
+	})
+}
+
+func (s *JSONPathFieldTestSuite) TestValues() {
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{Path: ".spec.replicas", Operation: operationEquals, Value: "3"},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"bare": {{Message: `field .spec.replicas is not set, but should be "3"`}},
+			},
+		},
+		{
+			Param: params.Params{Path: ".spec.template.spec.containers[*].imagePullPolicy", Operation: operationEquals, Value: "Always"},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"app":  {{Message: `field .spec.template.spec.containers[*].imagePullPolicy is "IfNotPresent", but should be "Always"`}},
+				"bare": {{Message: `field .spec.template.spec.containers[*].imagePullPolicy is not set, but should be "Always"`}},
+			},
+		},
+		{
+			Param: params.Params{Path: ".spec.replicas", Operation: operationMatches, Value: "^[2-9]$"},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"bare": {{Message: `field .spec.replicas is not set, but should match "^[2-9]$"`}},
+			},
+		},
+		{
+			Param: params.Params{Path: ".metadata.name", Operation: operationMatches, Value: "^a"},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"bare": {{Message: `field .metadata.name is "bare", which does not match "^a"`}},
+			},
+		},
+	})
+}
+
+func (s *JSONPathFieldTestSuite) TestForEach() {
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{
+				ForEach:   ".spec.template.spec.containers[*]",
+				Path:      ".securityContext.runAsNonRoot",
+				Operation: operationEquals,
+				Value:     "true",
+			},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"app": {{Message: `field .securityContext.runAsNonRoot of element "sidecar" at .spec.template.spec.containers[*] is not set, but should be "true"`}},
+			},
+		},
+	})
+}
+
+func (s *JSONPathFieldTestSuite) TestInvalidParams() {
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param:                    params.Params{Path: ".spec.replicas[", Operation: operationExists},
+			ExpectInstantiationError: true,
+		},
+		{
+			Param:                    params.Params{Path: ".spec.replicas", ForEach: ".spec[", Operation: operationExists},
+			ExpectInstantiationError: true,
+		},
+		{
+			Param:                    params.Params{Path: ".spec.replicas", Operation: operationExists, Value: "3"},
+			ExpectInstantiationError: true,
+		},
+		{
+			Param:                    params.Params{Path: ".spec.replicas", Operation: operationMatches, Value: "("},
+			ExpectInstantiationError: true,
+		},
+	})
+}
@@ -56,9 +56,9 @@ func init() {
 
 			return util.PerContainerCheck(func(container *v1.Container) (results []diagnostic.Diagnostic) {
 				if len(blockedRegexes) > 0 && isInList(blockedRegexes, container.Image) {
-					results = append(results, diagnostic.Diagnostic{Message: fmt.Sprintf("The container %q is using an invalid container image, %q. Please use images that are not blocked by the `BlockList` criteria : %q", container.Name, container.Image, blockedRegexes)})
+					results = append(results, diagnostic.Diagnostic{Message: fmt.Sprintf("The container %q is using an invalid container image, %q. Please use images that are not blocked by the `BlockList` criteria : %q", container.Name, container.Image, blockedRegexes), FieldPath: "image"})
 				} else if len(allowedRegexes) > 0 && !isInList(allowedRegexes, container.Image) {
-					results = append(results, diagnostic.Diagnostic{Message: fmt.Sprintf("The container %q is using an invalid container image, %q. Please use images that satisfies the `AllowList` criteria : %q", container.Name, container.Image, allowedRegexes)})
+					results = append(results, diagnostic.Diagnostic{Message: fmt.Sprintf("The container %q is using an invalid container image, %q. Please use images that satisfies the `AllowList` criteria : %q", container.Name, container.Image, allowedRegexes), FieldPath: "image"})
 				}
 				return results
 			}), nil
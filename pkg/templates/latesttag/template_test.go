@@ -103,3 +103,24 @@ func (s *ContainerImageTestSuite) TestAcceptableContainerImage() {
 		},
 	})
 }
+
+func (s *ContainerImageTestSuite) TestEphemeralContainerImage() {
+	const depWithLatestEphemeralContainer = "dep-with-latest-ephemeral-container"
+
+	s.addDeploymentWithContainerImage(depWithLatestEphemeralContainer, "example.com/test:v1.0.0")
+	s.ctx.AddEphemeralContainerToDeployment(s.T(), depWithLatestEphemeralContainer, v1.Container{Name: "debugger", Image: "busybox:latest"})
+
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{
+				BlockList: []string{".*:(latest)$"},
+			},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				depWithLatestEphemeralContainer: {
+					{Message: "The container \"debugger\" is using an invalid container image, \"busybox:latest\". Please use images that are not blocked by the `BlockList` criteria : [\".*:(latest)$\"]"},
+				},
+			},
+			ExpectInstantiationError: false,
+		},
+	})
+}
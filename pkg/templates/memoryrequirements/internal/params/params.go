@@ -12,6 +12,7 @@ type Params struct {
 
 	// The lower bound of the requirement (inclusive), specified as
 	// a number of MB.
+	// +default=0
 	LowerBoundMB int `json:"lowerBoundMB"`
 
 	// The upper bound of the requirement (inclusive), specified as
@@ -22,7 +22,8 @@ const (
 func process(results *[]diagnostic.Diagnostic, containerName, requirementsType string, quantity *resource.Quantity, lowerBoundBytes int, upperBoundBytes *int) {
 	if util.ValueInRange(int(quantity.Value()), lowerBoundBytes, upperBoundBytes) {
 		*results = append(*results, diagnostic.Diagnostic{
-			Message: fmt.Sprintf("container %q has memory %s %s", containerName, requirementsType, quantity),
+			Message:   fmt.Sprintf("container %q has memory %s %s", containerName, requirementsType, quantity),
+			FieldPath: fmt.Sprintf("resources.%ss.memory", requirementsType),
 		})
 	}
 }
@@ -43,7 +44,7 @@ func init() {
 			if p.UpperBoundMB != nil {
 				upperBoundBytes = pointers.Int((*p.UpperBoundMB) * bytesInMB)
 			}
-			return util.PerContainerCheck(func(container *v1.Container) []diagnostic.Diagnostic {
+			return util.PerNonEphemeralContainerCheck(func(container *v1.Container) []diagnostic.Diagnostic {
 				var results []diagnostic.Diagnostic
 				if p.RequirementsType == "request" || p.RequirementsType == "any" {
 					process(&results, container.Name, "request", container.Resources.Requests.Memory(), lowerBoundBytes, upperBoundBytes)
@@ -17,7 +17,24 @@ var (
 	_ = util.MustParseParameterDesc
 	_ = fmt.Sprintf
 
+	allowedNamespacesParamDesc = util.MustParseParameterDesc(`{
+	"Name": "allowedNamespaces",
+	"Type": "array",
+	"Description": "List of namespaces that objects are allowed to be in even though they would be flagged otherwise, like \"default\" in clusters where it is used on purpose. Objects with no namespace are taken to be in \"default\".",
+	"Examples": null,
+	"Enum": null,
+	"SubParameters": null,
+	"ArrayElemType": "string",
+	"Required": false,
+	"NoRegex": true,
+	"NotNegatable": true,
+	"XXXStructFieldName": "AllowedNamespaces",
+	"XXXIsPointer": false
+}
+`)
+
 	ParamDescs = []check.ParameterDesc{
+		allowedNamespacesParamDesc,
 	}
 )
 
@@ -50,3 +67,11 @@ func WrapInstantiateFunc(f func(p Params) (check.Func, error)) func (interface{}
 		return f(paramsInt.(Params))
 	}
 }
+
+// WrapInstantiateContextMatcherFunc is a convenience wrapper that wraps an untyped instantiate function
+// for a context matcher into a typed one.
+func WrapInstantiateContextMatcherFunc(f func(p Params) (check.ContextMatcher, error)) func (interface{}) (check.ContextMatcher, error) {
+	return func(paramsInt interface{}) (check.ContextMatcher, error) {
+		return f(paramsInt.(Params))
+	}
+}
@@ -2,4 +2,10 @@ package params
 
 // Params represents the params accepted by this template.
 type Params struct {
+
+	// List of namespaces that objects are allowed to be in even though they would be flagged otherwise, like "default"
+	// in clusters where it is used on purpose. Objects with no namespace are taken to be in "default".
+	// +noregex
+	// +notnegatable
+	AllowedNamespaces []string
 }
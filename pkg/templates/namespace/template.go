@@ -3,6 +3,7 @@ package namespace
 import (
 	"strings"
 
+	"golang.stackrox.io/kube-linter/internal/set"
 	"golang.stackrox.io/kube-linter/internal/stringutils"
 	"golang.stackrox.io/kube-linter/pkg/check"
 	"golang.stackrox.io/kube-linter/pkg/config"
@@ -13,24 +14,34 @@ import (
 	"golang.stackrox.io/kube-linter/pkg/templates/namespace/internal/params"
 )
 
+const (
+	templateKey = "use-namespace"
+)
+
 func init() {
 	templates.Register(check.Template{
 		HumanName:   "Use Namespaces for Administrative Boundaries between Resources",
-		Key:         "use-namespace",
-		Description: "Flag resources with no namespace specified or using default namespace",
+		Key:         templateKey,
+		Description: "Flag namespaced resources with no namespace specified or using default namespace. Cluster-scoped resources are never flagged",
 		SupportedObjectKinds: config.ObjectKindsDesc{
-			ObjectKinds: []string{objectkinds.DeploymentLike, objectkinds.Service},
+			ObjectKinds: []string{objectkinds.Namespaced},
 		},
 		Parameters:             params.ParamDescs,
 		ParseAndValidateParams: params.ParseAndValidate,
-		Instantiate: params.WrapInstantiateFunc(func(_ params.Params) (check.Func, error) {
+		Instantiate: params.WrapInstantiateFunc(func(p params.Params) (check.Func, error) {
+			allowedNamespaces := set.NewStringSet(p.AllowedNamespaces...)
 			return func(_ lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
+				// The scope of checks may include cluster-scoped objects, like with the Any object kind.
+				if objectkinds.IsClusterScoped(object.K8sObject.GetObjectKind().GroupVersionKind()) {
+					return nil
+				}
 				namespace := object.K8sObject.GetNamespace()
 				ns := stringutils.OrDefault(namespace, "default")
-				if strings.EqualFold(ns, "default") {
-					return []diagnostic.Diagnostic{{Message: "object in default namespace"}}
+				if !strings.EqualFold(ns, "default") || allowedNamespaces.Contains(ns) {
+					return nil
 				}
-				return nil
+				// Objects without a namespace have no field to point at.
+				return []diagnostic.Diagnostic{{Message: "object in default namespace", FieldPath: stringutils.Ternary(namespace == "", "", "metadata.namespace")}}
 			}, nil
 		}),
 	})
@@ -0,0 +1,89 @@
+package namespace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext/mocks"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/namespace/internal/params"
+	appsV1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestNamespace(t *testing.T) {
+	suite.Run(t, new(NamespaceTestSuite))
+}
+
+type NamespaceTestSuite struct {
+	templates.TemplateTestSuite
+
+	ctx *mocks.MockLintContext
+}
+
+func (s *NamespaceTestSuite) SetupTest() {
+	s.Init(templateKey)
+	s.ctx = mocks.NewMockContext()
+}
+
+func (s *NamespaceTestSuite) addDeploymentInNamespace(name, namespace string) {
+	s.ctx.AddMockDeployment(s.T(), name)
+	s.ctx.ModifyDeployment(s.T(), name, func(deployment *appsV1.Deployment) {
+		deployment.Namespace = namespace
+	})
+}
+
+func (s *NamespaceTestSuite) TestNamespace() {
+	s.addDeploymentInNamespace("no-namespace", "")
+	s.addDeploymentInNamespace("default", "default")
+	s.addDeploymentInNamespace("dev", "dev")
+	s.ctx.AddMockConfigMap(s.T(), "configmap")
+	s.ctx.AddMockRole(s.T(), "role", "dev")
+	s.ctx.AddMockClusterRole(s.T(), "clusterrole")
+	s.ctx.AddMockConfigMap(s.T(), "configmap-in-default")
+	s.ctx.ModifyConfigMap(s.T(), "configmap-in-default", func(cm *v1.ConfigMap) {
+		cm.Namespace = "default"
+	})
+
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"no-namespace": {
+					{Message: "object in default namespace"},
+				},
+				"default": {
+					{Message: "object in default namespace"},
+				},
+				"configmap": {
+					{Message: "object in default namespace"},
+				},
+				"configmap-in-default": {
+					{Message: "object in default namespace"},
+				},
+			},
+		},
+		{
+			Param:       params.Params{AllowedNamespaces: []string{"default"}},
+			Diagnostics: map[string][]diagnostic.Diagnostic{},
+		},
+		{
+			Param: params.Params{AllowedNamespaces: []string{"dev"}},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"no-namespace": {
+					{Message: "object in default namespace"},
+				},
+				"default": {
+					{Message: "object in default namespace"},
+				},
+				"configmap": {
+					{Message: "object in default namespace"},
+				},
+				"configmap-in-default": {
+					{Message: "object in default namespace"},
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,160 @@
+package nonexistentconfigreference
+
+import (
+	"fmt"
+
+	"golang.stackrox.io/kube-linter/internal/set"
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/extract"
+	"golang.stackrox.io/kube-linter/pkg/extract/customtypes"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/objectkinds"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/nonexistentconfigreference/internal/params"
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	configMapKind = "ConfigMap"
+	secretKind    = "Secret"
+)
+
+// reference is a reference of a pod spec to a ConfigMap or a Secret.
+type reference struct {
+	kind string
+	name string
+	// optional is whether the pod starts even if the referenced object does not exist.
+	optional bool
+	// referrer describes what holds the reference, like `container "app"`.
+	referrer  string
+	fieldPath string
+}
+
+func isOptional(optional *bool) bool {
+	return optional != nil && *optional
+}
+
+// volumeReferences returns the references of the volumes of the given pod spec, including projected ones.
+func volumeReferences(podSpec *customtypes.PodSpec) []reference {
+	var refs []reference
+	for i, volume := range podSpec.Volumes {
+		referrer := fmt.Sprintf("volume %q", volume.Name)
+		if cm := volume.ConfigMap; cm != nil {
+			refs = append(refs, reference{kind: configMapKind, name: cm.Name, optional: isOptional(cm.Optional),
+				referrer: referrer, fieldPath: fmt.Sprintf("volumes[%d].configMap.name", i)})
+		}
+		if secret := volume.Secret; secret != nil {
+			refs = append(refs, reference{kind: secretKind, name: secret.SecretName, optional: isOptional(secret.Optional),
+				referrer: referrer, fieldPath: fmt.Sprintf("volumes[%d].secret.secretName", i)})
+		}
+		if volume.Projected == nil {
+			continue
+		}
+		for j, source := range volume.Projected.Sources {
+			if cm := source.ConfigMap; cm != nil {
+				refs = append(refs, reference{kind: configMapKind, name: cm.Name, optional: isOptional(cm.Optional),
+					referrer: referrer, fieldPath: fmt.Sprintf("volumes[%d].projected.sources[%d].configMap.name", i, j)})
+			}
+			if secret := source.Secret; secret != nil {
+				refs = append(refs, reference{kind: secretKind, name: secret.Name, optional: isOptional(secret.Optional),
+					referrer: referrer, fieldPath: fmt.Sprintf("volumes[%d].projected.sources[%d].secret.name", i, j)})
+			}
+		}
+	}
+	return refs
+}
+
+// containerReferences returns the references of the envFrom and env of the given container, whose field path is
+// containerPath.
+func containerReferences(container *v1.Container, containerPath string) []reference {
+	var refs []reference
+	referrer := fmt.Sprintf("container %q", container.Name)
+	for i, envFrom := range container.EnvFrom {
+		if cm := envFrom.ConfigMapRef; cm != nil {
+			refs = append(refs, reference{kind: configMapKind, name: cm.Name, optional: isOptional(cm.Optional),
+				referrer: referrer, fieldPath: fmt.Sprintf("%s.envFrom[%d].configMapRef.name", containerPath, i)})
+		}
+		if secret := envFrom.SecretRef; secret != nil {
+			refs = append(refs, reference{kind: secretKind, name: secret.Name, optional: isOptional(secret.Optional),
+				referrer: referrer, fieldPath: fmt.Sprintf("%s.envFrom[%d].secretRef.name", containerPath, i)})
+		}
+	}
+	for i, env := range container.Env {
+		if env.ValueFrom == nil {
+			continue
+		}
+		envReferrer := fmt.Sprintf("%s, in environment variable %q,", referrer, env.Name)
+		if cm := env.ValueFrom.ConfigMapKeyRef; cm != nil {
+			refs = append(refs, reference{kind: configMapKind, name: cm.Name, optional: isOptional(cm.Optional),
+				referrer: envReferrer, fieldPath: fmt.Sprintf("%s.env[%d].valueFrom.configMapKeyRef.name", containerPath, i)})
+		}
+		if secret := env.ValueFrom.SecretKeyRef; secret != nil {
+			refs = append(refs, reference{kind: secretKind, name: secret.Name, optional: isOptional(secret.Optional),
+				referrer: envReferrer, fieldPath: fmt.Sprintf("%s.env[%d].valueFrom.secretKeyRef.name", containerPath, i)})
+		}
+	}
+	return refs
+}
+
+// references returns the references of the given pod spec to ConfigMaps and Secrets, with field paths relative to it.
+func references(podSpec *customtypes.PodSpec) []reference {
+	refs := volumeReferences(podSpec)
+	containers := podSpec.AllContainers()
+	for i := range containers {
+		refs = append(refs, containerReferences(&containers[i], podSpec.ContainerFieldPath(i))...)
+	}
+	return refs
+}
+
+// objectKey identifies the object of the given kind and name in the given namespace. Names cannot contain slashes, so
+// keys of different objects never collide.
+func objectKey(namespace, kind, name string) string {
+	return namespace + "/" + kind + "/" + name
+}
+
+func init() {
+	templates.Register(check.Template{
+		HumanName:   "Non-Existent ConfigMap or Secret Reference",
+		Key:         "non-existent-config-reference",
+		Description: "Flag pods that reference ConfigMaps or Secrets that do not exist in their namespace, unless the reference is optional",
+		SupportedObjectKinds: config.ObjectKindsDesc{
+			ObjectKinds: []string{objectkinds.DeploymentLike},
+		},
+		Parameters:             params.ParamDescs,
+		ParseAndValidateParams: params.ParseAndValidate,
+		InstantiateContextMatcher: params.WrapInstantiateContextMatcherFunc(func(_ params.Params) (check.ContextMatcher, error) {
+			return func(lintCtx lintcontext.LintContext) check.Func {
+				// Index the ConfigMaps and Secrets, so that the whole context does not have to be searched for every
+				// reference.
+				existing := set.NewStringSet()
+				for _, obj := range lintCtx.Objects() {
+					gvk := extract.GVK(obj.K8sObject)
+					if gvk.Group == v1.GroupName && (gvk.Kind == configMapKind || gvk.Kind == secretKind) {
+						existing.Add(objectKey(obj.K8sObject.GetNamespace(), gvk.Kind, obj.K8sObject.GetName()))
+					}
+				}
+
+				return func(lintCtx lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
+					namespace := object.K8sObject.GetNamespace()
+					return util.PerPodSpecCheck(func(podSpec *customtypes.PodSpec) []diagnostic.Diagnostic {
+						var results []diagnostic.Diagnostic
+						for _, ref := range references(podSpec) {
+							// References without a name are invalid, which the API server rejects anyway.
+							if ref.optional || ref.name == "" || existing.Contains(objectKey(namespace, ref.kind, ref.name)) {
+								continue
+							}
+							results = append(results, diagnostic.Diagnostic{
+								Message:   fmt.Sprintf("%s references %s %q, which does not exist in the namespace", ref.referrer, ref.kind, ref.name),
+								FieldPath: ref.fieldPath,
+							})
+						}
+						return results
+					})(lintCtx, object)
+				}
+			}, nil
+		}),
+	})
+}
@@ -0,0 +1,148 @@
+package nonexistentconfigreference
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext/mocks"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/nonexistentconfigreference/internal/params"
+	appsV1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNonExistentConfigReference(t *testing.T) {
+	suite.Run(t, new(NonExistentConfigReferenceTestSuite))
+}
+
+type NonExistentConfigReferenceTestSuite struct {
+	templates.TemplateTestSuite
+
+	ctx *mocks.MockLintContext
+}
+
+func (s *NonExistentConfigReferenceTestSuite) SetupTest() {
+	s.Init("non-existent-config-reference")
+	s.ctx = mocks.NewMockContext()
+	s.ctx.AddMockConfigMap(s.T(), "app-config")
+	s.ctx.AddMockSecret(s.T(), "app-credentials")
+	s.ctx.AddMockConfigMap(s.T(), "other-namespace-config")
+	s.ctx.ModifyConfigMap(s.T(), "other-namespace-config", func(cm *v1.ConfigMap) {
+		cm.Namespace = "other"
+	})
+}
+
+func (s *NonExistentConfigReferenceTestSuite) addDeployment(name string, podSpec v1.PodSpec) {
+	s.ctx.AddMockDeployment(s.T(), name)
+	s.ctx.ModifyDeployment(s.T(), name, func(deployment *appsV1.Deployment) {
+		deployment.Spec.Template.Spec = podSpec
+	})
+}
+
+func localRef(name string) v1.LocalObjectReference {
+	return v1.LocalObjectReference{Name: name}
+}
+
+func (s *NonExistentConfigReferenceTestSuite) TestVolumes() {
+	optional := true
+	s.addDeployment("existing", v1.PodSpec{Volumes: []v1.Volume{
+		{Name: "config", VolumeSource: v1.VolumeSource{ConfigMap: &v1.ConfigMapVolumeSource{LocalObjectReference: localRef("app-config")}}},
+		{Name: "credentials", VolumeSource: v1.VolumeSource{Secret: &v1.SecretVolumeSource{SecretName: "app-credentials"}}},
+	}})
+	s.addDeployment("missing", v1.PodSpec{Volumes: []v1.Volume{
+		{Name: "config", VolumeSource: v1.VolumeSource{ConfigMap: &v1.ConfigMapVolumeSource{LocalObjectReference: localRef("other-namespace-config")}}},
+		{Name: "credentials", VolumeSource: v1.VolumeSource{Secret: &v1.SecretVolumeSource{SecretName: "app-config"}}},
+		{Name: "optional", VolumeSource: v1.VolumeSource{Secret: &v1.SecretVolumeSource{SecretName: "missing", Optional: &optional}}},
+		{Name: "projected", VolumeSource: v1.VolumeSource{Projected: &v1.ProjectedVolumeSource{Sources: []v1.VolumeProjection{
+			{ConfigMap: &v1.ConfigMapProjection{LocalObjectReference: localRef("app-config")}},
+			{Secret: &v1.SecretProjection{LocalObjectReference: localRef("missing-credentials")}},
+		}}}},
+	}})
+
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"missing": {
+					{Message: `volume "config" references ConfigMap "other-namespace-config", which does not exist in the namespace`},
+					{Message: `volume "credentials" references Secret "app-config", which does not exist in the namespace`},
+					{Message: `volume "projected" references Secret "missing-credentials", which does not exist in the namespace`},
+				},
+			},
+		},
+	})
+}
+
+func (s *NonExistentConfigReferenceTestSuite) TestContainers() {
+	optional := true
+	s.addDeployment("existing", v1.PodSpec{Containers: []v1.Container{{
+		Name: "app",
+		EnvFrom: []v1.EnvFromSource{
+			{ConfigMapRef: &v1.ConfigMapEnvSource{LocalObjectReference: localRef("app-config")}},
+			{SecretRef: &v1.SecretEnvSource{LocalObjectReference: localRef("missing"), Optional: &optional}},
+		},
+		Env: []v1.EnvVar{{Name: "PASSWORD", ValueFrom: &v1.EnvVarSource{
+			SecretKeyRef: &v1.SecretKeySelector{LocalObjectReference: localRef("app-credentials"), Key: "password"},
+		}}},
+	}}})
+	s.addDeployment("missing", v1.PodSpec{
+		InitContainers: []v1.Container{{
+			Name:    "init",
+			EnvFrom: []v1.EnvFromSource{{SecretRef: &v1.SecretEnvSource{LocalObjectReference: localRef("init-credentials")}}},
+		}},
+		Containers: []v1.Container{{
+			Name: "app",
+			Env: []v1.EnvVar{
+				{Name: "LOG_LEVEL", ValueFrom: &v1.EnvVarSource{
+					ConfigMapKeyRef: &v1.ConfigMapKeySelector{LocalObjectReference: localRef("logging"), Key: "level"},
+				}},
+				{Name: "DEBUG", ValueFrom: &v1.EnvVarSource{
+					ConfigMapKeyRef: &v1.ConfigMapKeySelector{LocalObjectReference: localRef("logging"), Key: "debug", Optional: &optional},
+				}},
+				{Name: "POD_NAME", ValueFrom: &v1.EnvVarSource{FieldRef: &v1.ObjectFieldSelector{FieldPath: "metadata.name"}}},
+			},
+		}},
+	})
+
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"missing": {
+					{Message: `container "init" references Secret "init-credentials", which does not exist in the namespace`},
+					{Message: `container "app", in environment variable "LOG_LEVEL", references ConfigMap "logging", which does not exist in the namespace`},
+				},
+			},
+		},
+	})
+}
+
+func (s *NonExistentConfigReferenceTestSuite) TestFieldPaths() {
+	s.addDeployment("missing", v1.PodSpec{
+		Volumes: []v1.Volume{
+			{Name: "config", VolumeSource: v1.VolumeSource{ConfigMap: &v1.ConfigMapVolumeSource{LocalObjectReference: localRef("missing")}}},
+		},
+		Containers: []v1.Container{{
+			Name:    "app",
+			EnvFrom: []v1.EnvFromSource{{ConfigMapRef: &v1.ConfigMapEnvSource{LocalObjectReference: localRef("missing")}}},
+		}},
+	})
+	s.ctx.ModifyDeployment(s.T(), "missing", func(deployment *appsV1.Deployment) {
+		deployment.ObjectMeta = metaV1.ObjectMeta{Name: "missing", Namespace: "app"}
+	})
+
+	check, err := s.Template.InstantiateContextMatcher(params.Params{})
+	s.Require().NoError(err)
+	var fieldPaths []string
+	for _, obj := range s.ctx.Objects() {
+		for _, d := range check(s.ctx)(s.ctx, obj) {
+			fieldPaths = append(fieldPaths, d.FieldPath)
+		}
+	}
+	s.Equal([]string{
+		"spec.template.spec.volumes[0].configMap.name",
+		"spec.template.spec.containers[0].envFrom[0].configMapRef.name",
+	}, fieldPaths)
+}
@@ -0,0 +1,155 @@
+package templates
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+
+	"golang.stackrox.io/kube-linter/internal/errorhelpers"
+	"golang.stackrox.io/kube-linter/pkg/check"
+)
+
+// ValidateParams validates the given params against the parameters that the template declares.
+// It reports unknown parameter names, missing required parameters and values of the wrong type,
+// each together with the parameters that are valid in that position.
+// Parameter names are matched case-insensitively, like they are when the params are decoded.
+func ValidateParams(t check.Template, params map[string]interface{}) error {
+	errorList := errorhelpers.NewErrorList(fmt.Sprintf("validating params for template %q", t.Key))
+	validateParamsAgainstDescs("", t.Parameters, params, errorList)
+	return errorList.ToError()
+}
+
+func validateParamsAgainstDescs(prefix string, descs []check.ParameterDesc, params map[string]interface{}, errorList *errorhelpers.ErrorList) {
+	descsByName := make(map[string]*check.ParameterDesc, len(descs))
+	for i := range descs {
+		descsByName[strings.ToLower(descs[i].Name)] = &descs[i]
+	}
+
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	seen := make(map[string]bool, len(params))
+	for _, key := range keys {
+		desc := descsByName[strings.ToLower(key)]
+		if desc == nil {
+			if len(descs) == 0 {
+				errorList.AddStringf("unknown parameter %q, no parameters are supported", prefix+key)
+			} else {
+				errorList.AddStringf("unknown parameter %q, valid parameters are %v", prefix+key, paramNames(descs))
+			}
+			continue
+		}
+		seen[strings.ToLower(desc.Name)] = true
+		validateParamValue(prefix+desc.Name, desc, params[key], errorList)
+	}
+	for _, desc := range descs {
+		if desc.Required && !seen[strings.ToLower(desc.Name)] {
+			errorList.AddStringf("required parameter %q not found", prefix+desc.Name)
+		}
+	}
+}
+
+func validateParamValue(name string, desc *check.ParameterDesc, value interface{}, errorList *errorhelpers.ErrorList) {
+	if value == nil {
+		return
+	}
+	if !valueHasType(value, desc.Type) {
+		errorList.AddStringf("parameter %q must be of type %s, got %T", name, desc.Type, value)
+		return
+	}
+	switch desc.Type {
+	case check.ArrayType:
+		if desc.ArrayElemType == "" {
+			return
+		}
+		elems := reflect.ValueOf(value)
+		for i := 0; i < elems.Len(); i++ {
+			elem := elems.Index(i).Interface()
+			if elem == nil {
+				continue
+			}
+			if !valueHasType(elem, desc.ArrayElemType) {
+				errorList.AddStringf("element %d of parameter %q must be of type %s, got %T", i, name, desc.ArrayElemType, elem)
+				continue
+			}
+			if desc.ArrayElemType == check.ObjectType {
+				validateSubParams(fmt.Sprintf("%s[%d]", name, i), desc.SubParameters, elem, errorList)
+			}
+		}
+	case check.ObjectType:
+		validateSubParams(name, desc.SubParameters, value, errorList)
+	}
+}
+
+func validateSubParams(name string, descs []check.ParameterDesc, value interface{}, errorList *errorhelpers.ErrorList) {
+	if len(descs) == 0 {
+		return
+	}
+	subParams, ok := toStringKeyedMap(value)
+	if !ok {
+		errorList.AddStringf("parameter %q must only have string keys", name)
+		return
+	}
+	validateParamsAgainstDescs(name+".", descs, subParams, errorList)
+}
+
+// valueHasType returns whether the given value, as decoded from YAML or JSON, can be decoded into a parameter of
+// the given type.
+func valueHasType(value interface{}, typ check.ParameterType) bool {
+	kind := reflect.TypeOf(value).Kind()
+	switch typ {
+	case check.StringType:
+		return kind == reflect.String
+	case check.BooleanType:
+		return kind == reflect.Bool
+	case check.IntegerType:
+		switch kind {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return true
+		case reflect.Float32, reflect.Float64:
+			// JSON decodes all numbers as floats, so accept floats without a fractional part.
+			f := reflect.ValueOf(value).Float()
+			return f == math.Trunc(f)
+		}
+		return false
+	case check.NumberType:
+		return valueHasType(value, check.IntegerType) || kind == reflect.Float32 || kind == reflect.Float64
+	case check.ArrayType:
+		return kind == reflect.Slice || kind == reflect.Array
+	case check.ObjectType:
+		return kind == reflect.Map
+	}
+	// Be lenient about types that are not known here, decoding the params will still catch any mismatch.
+	return true
+}
+
+func toStringKeyedMap(value interface{}) (map[string]interface{}, bool) {
+	if m, ok := value.(map[string]interface{}); ok {
+		return m, true
+	}
+	mapVal := reflect.ValueOf(value)
+	out := make(map[string]interface{}, mapVal.Len())
+	iter := mapVal.MapRange()
+	for iter.Next() {
+		key, ok := iter.Key().Interface().(string)
+		if !ok {
+			return nil, false
+		}
+		out[key] = iter.Value().Interface()
+	}
+	return out, true
+}
+
+func paramNames(descs []check.ParameterDesc) []string {
+	names := make([]string, 0, len(descs))
+	for _, desc := range descs {
+		names = append(names, desc.Name)
+	}
+	return names
+}
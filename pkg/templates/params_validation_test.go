@@ -0,0 +1,106 @@
+package templates
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.stackrox.io/kube-linter/pkg/check"
+)
+
+var (
+	testTemplate = check.Template{
+		Key: "test-template",
+		Parameters: []check.ParameterDesc{
+			{Name: "name", Type: check.StringType, Required: true},
+			{Name: "count", Type: check.IntegerType},
+			{Name: "ratio", Type: check.NumberType},
+			{Name: "enabled", Type: check.BooleanType},
+			{Name: "values", Type: check.ArrayType, ArrayElemType: check.StringType},
+			{Name: "target", Type: check.ObjectType, SubParameters: []check.ParameterDesc{
+				{Name: "kind", Type: check.StringType, Required: true},
+			}},
+			{Name: "targets", Type: check.ArrayType, ArrayElemType: check.ObjectType, SubParameters: []check.ParameterDesc{
+				{Name: "kind", Type: check.StringType},
+			}},
+		},
+	}
+)
+
+func TestValidateParams(t *testing.T) {
+	for _, testCase := range []struct {
+		desc        string
+		template    check.Template
+		params      map[string]interface{}
+		expectedErr string
+	}{
+		{
+			desc:     "valid params",
+			template: testTemplate,
+			params: map[string]interface{}{
+				"name":    "foo",
+				"count":   3,
+				"ratio":   0.5,
+				"enabled": true,
+				"values":  []interface{}{"a", "b"},
+				"target":  map[interface{}]interface{}{"kind": "Deployment"},
+			},
+		},
+		{
+			desc:     "names are case insensitive and integral floats are integers",
+			template: testTemplate,
+			params:   map[string]interface{}{"Name": "foo", "count": float64(3)},
+		},
+		{
+			desc:        "unknown parameter",
+			template:    testTemplate,
+			params:      map[string]interface{}{"name": "foo", "nmae": "bar"},
+			expectedErr: `validating params for template "test-template" error: unknown parameter "nmae", valid parameters are [name count ratio enabled values target targets]`,
+		},
+		{
+			desc:        "unknown parameter for template without parameters",
+			template:    check.Template{Key: "no-params"},
+			params:      map[string]interface{}{"name": "foo"},
+			expectedErr: `validating params for template "no-params" error: unknown parameter "name", no parameters are supported`,
+		},
+		{
+			desc:        "missing required parameter",
+			template:    testTemplate,
+			params:      map[string]interface{}{"count": 1},
+			expectedErr: `validating params for template "test-template" error: required parameter "name" not found`,
+		},
+		{
+			desc:        "type mismatch",
+			template:    testTemplate,
+			params:      map[string]interface{}{"name": "foo", "count": 1.5},
+			expectedErr: `validating params for template "test-template" error: parameter "count" must be of type integer, got float64`,
+		},
+		{
+			desc:        "array element type mismatch",
+			template:    testTemplate,
+			params:      map[string]interface{}{"name": "foo", "values": []interface{}{"a", 2}},
+			expectedErr: `validating params for template "test-template" error: element 1 of parameter "values" must be of type string, got int`,
+		},
+		{
+			desc:        "sub-parameters are validated",
+			template:    testTemplate,
+			params:      map[string]interface{}{"name": "foo", "target": map[string]interface{}{"knd": "Deployment"}},
+			expectedErr: `validating params for template "test-template" errors: [unknown parameter "target.knd", valid parameters are [kind], required parameter "target.kind" not found]`,
+		},
+		{
+			desc:        "sub-parameters of array elements are validated",
+			template:    testTemplate,
+			params:      map[string]interface{}{"name": "foo", "targets": []interface{}{map[string]interface{}{"kind": "Deployment"}, map[string]interface{}{"kind": 1}}},
+			expectedErr: `validating params for template "test-template" error: parameter "targets[1].kind" must be of type string, got int`,
+		},
+	} {
+		c := testCase
+		t.Run(c.desc, func(t *testing.T) {
+			err := ValidateParams(c.template, c.params)
+			if c.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, c.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package templates
+
+import (
+	"plugin"
+
+	"github.com/pkg/errors"
+)
+
+// LoadPlugins loads the Go plugins at the given paths. Plugins register their templates with RegisterTemplate from
+// their init functions, which run when they are loaded.
+//
+// Go plugins are only supported on some platforms, and must be built with the same version of Go and of every
+// package that they share with the program loading them, including kube-linter itself.
+func LoadPlugins(paths ...string) error {
+	for _, path := range paths {
+		if _, err := plugin.Open(path); err != nil {
+			return errors.Wrapf(err, "loading plugin %s", path)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,9 @@
+package params
+
+// Params represents the params accepted by this template.
+type Params struct {
+
+	// The minimum number of replicas an object must have before a PodDisruptionBudget is required for it.
+	// If not specified, it is required for all objects with replicas.
+	MinReplicas int
+}
@@ -0,0 +1,85 @@
+package poddisruptionbudget
+
+import (
+	"fmt"
+
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/extract"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/objectkinds"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/poddisruptionbudget/internal/params"
+	policyV1 "k8s.io/api/policy/v1"
+	policyV1beta1 "k8s.io/api/policy/v1beta1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	templateKey = "pod-disruption-budget"
+)
+
+// pdbSelector returns the selector of the given PodDisruptionBudget, and whether the object is one.
+func pdbSelector(object lintcontext.Object) (labels.Selector, bool) {
+	switch pdb := object.K8sObject.(type) {
+	case *policyV1.PodDisruptionBudget:
+		selector, err := metaV1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		return selector, err == nil
+	case *policyV1beta1.PodDisruptionBudget:
+		// Unlike in policy/v1, an empty selector selects no pods in policy/v1beta1.
+		if pdb.Spec.Selector == nil || (len(pdb.Spec.Selector.MatchLabels) == 0 && len(pdb.Spec.Selector.MatchExpressions) == 0) {
+			return labels.Nothing(), true
+		}
+		selector, err := metaV1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		return selector, err == nil
+	}
+	return nil, false
+}
+
+func init() {
+	templates.Register(check.Template{
+		HumanName:   "PodDisruptionBudget Coverage",
+		Key:         templateKey,
+		Description: "Flag objects with replicas whose pods are not selected by any PodDisruptionBudget in the same namespace",
+		SupportedObjectKinds: config.ObjectKindsDesc{
+			ObjectKinds: []string{objectkinds.DeploymentLike},
+		},
+		Parameters:             params.ParamDescs,
+		ParseAndValidateParams: params.ParseAndValidate,
+		InstantiateContextMatcher: params.WrapInstantiateContextMatcherFunc(func(p params.Params) (check.ContextMatcher, error) {
+			return func(lintCtx lintcontext.LintContext) check.Func {
+				// Index the selectors of all PodDisruptionBudgets by namespace, so that they are only parsed once.
+				selectorsByNamespace := make(map[string][]labels.Selector)
+				for _, obj := range lintCtx.Objects() {
+					if selector, ok := pdbSelector(obj); ok {
+						namespace := obj.K8sObject.GetNamespace()
+						selectorsByNamespace[namespace] = append(selectorsByNamespace[namespace], selector)
+					}
+				}
+
+				return func(_ lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
+					replicas, found := extract.Replicas(object.K8sObject)
+					if !found || int(replicas) < p.MinReplicas {
+						return nil
+					}
+					podTemplateSpec, hasPods := extract.PodTemplateSpec(object.K8sObject)
+					if !hasPods {
+						return nil
+					}
+					podLabels := labels.Set(podTemplateSpec.Labels)
+					for _, selector := range selectorsByNamespace[object.K8sObject.GetNamespace()] {
+						if selector.Matches(podLabels) {
+							return nil
+						}
+					}
+					return []diagnostic.Diagnostic{{
+						Message: fmt.Sprintf("no PodDisruptionBudget selects the pods of %s %q, add one to keep it available during voluntary disruptions like node drains",
+							extract.GVK(object.K8sObject).Kind, object.K8sObject.GetName()),
+					}}
+				}
+			}, nil
+		}),
+	})
+}
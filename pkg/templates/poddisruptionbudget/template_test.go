@@ -0,0 +1,74 @@
+package poddisruptionbudget
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext/mocks"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/poddisruptionbudget/internal/params"
+	appsV1 "k8s.io/api/apps/v1"
+	policyV1 "k8s.io/api/policy/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPodDisruptionBudget(t *testing.T) {
+	suite.Run(t, new(PodDisruptionBudgetTestSuite))
+}
+
+type PodDisruptionBudgetTestSuite struct {
+	templates.TemplateTestSuite
+
+	ctx *mocks.MockLintContext
+}
+
+func (s *PodDisruptionBudgetTestSuite) SetupTest() {
+	s.Init(templateKey)
+	s.ctx = mocks.NewMockContext()
+}
+
+func (s *PodDisruptionBudgetTestSuite) addDeployment(name, namespace string, replicas int32) {
+	s.ctx.AddMockDeployment(s.T(), name)
+	s.ctx.ModifyDeployment(s.T(), name, func(deployment *appsV1.Deployment) {
+		deployment.Kind = "Deployment"
+		deployment.Namespace = namespace
+		deployment.Spec.Replicas = &replicas
+		deployment.Spec.Template.Labels = map[string]string{"app": name}
+	})
+}
+
+func (s *PodDisruptionBudgetTestSuite) addPDB(name, namespace string, matchLabels map[string]string) {
+	s.ctx.AddMockPodDisruptionBudget(s.T(), name)
+	s.ctx.ModifyPodDisruptionBudget(s.T(), name, func(pdb *policyV1.PodDisruptionBudget) {
+		pdb.Namespace = namespace
+		pdb.Spec.Selector = &metaV1.LabelSelector{MatchLabels: matchLabels}
+	})
+}
+
+func (s *PodDisruptionBudgetTestSuite) TestPodDisruptionBudgetCoverage() {
+	s.addDeployment("covered", "default", 3)
+	s.addPDB("covered-pdb", "default", map[string]string{"app": "covered"})
+	s.addDeployment("other-namespace", "default", 3)
+	s.addPDB("other-namespace-pdb", "other", map[string]string{"app": "other-namespace"})
+	s.addDeployment("uncovered", "default", 3)
+	s.addDeployment("single-replica", "default", 1)
+
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"other-namespace": {{Message: `no PodDisruptionBudget selects the pods of Deployment "other-namespace", add one to keep it available during voluntary disruptions like node drains`}},
+				"uncovered":       {{Message: `no PodDisruptionBudget selects the pods of Deployment "uncovered", add one to keep it available during voluntary disruptions like node drains`}},
+				"single-replica":  {{Message: `no PodDisruptionBudget selects the pods of Deployment "single-replica", add one to keep it available during voluntary disruptions like node drains`}},
+			},
+		},
+		{
+			Param: params.Params{MinReplicas: 2},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"other-namespace": {{Message: `no PodDisruptionBudget selects the pods of Deployment "other-namespace", add one to keep it available during voluntary disruptions like node drains`}},
+				"uncovered":       {{Message: `no PodDisruptionBudget selects the pods of Deployment "uncovered", add one to keep it available during voluntary disruptions like node drains`}},
+			},
+		},
+	})
+}
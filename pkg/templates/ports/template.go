@@ -31,7 +31,7 @@ func init() {
 			if err != nil {
 				return nil, errors.Wrap(err, "invalid protocol")
 			}
-			return util.PerContainerCheck(func(container *v1.Container) []diagnostic.Diagnostic {
+			return util.PerNonEphemeralContainerCheck(func(container *v1.Container) []diagnostic.Diagnostic {
 				var results []diagnostic.Diagnostic
 				for _, port := range container.Ports {
 					if int(port.ContainerPort) == p.Port && protocolMatcher(string(port.Protocol)) {
@@ -27,7 +27,7 @@ func init() {
 			return util.PerContainerCheck(func(container *v1.Container) []diagnostic.Diagnostic {
 				if securityContext := container.SecurityContext; securityContext != nil {
 					if securityContext.Privileged != nil && *securityContext.Privileged {
-						return []diagnostic.Diagnostic{{Message: fmt.Sprintf("container %q is privileged", container.Name)}}
+						return []diagnostic.Diagnostic{{Message: fmt.Sprintf("container %q is privileged", container.Name), FieldPath: "securityContext.privileged"}}
 					}
 				}
 				return nil
@@ -34,15 +34,15 @@ func init() {
 					return nil
 				}
 				if securityContext.AllowPrivilegeEscalation != nil && *securityContext.AllowPrivilegeEscalation {
-					return []diagnostic.Diagnostic{{Message: fmt.Sprintf("container %q has AllowPrivilegeEscalation set to true.", container.Name)}}
+					return []diagnostic.Diagnostic{{Message: fmt.Sprintf("container %q has AllowPrivilegeEscalation set to true.", container.Name), FieldPath: "securityContext.allowPrivilegeEscalation"}}
 				}
 				if securityContext.Privileged != nil && *securityContext.Privileged {
-					return []diagnostic.Diagnostic{{Message: fmt.Sprintf("container %q is Privileged and allows privilege escalation.", container.Name)}}
+					return []diagnostic.Diagnostic{{Message: fmt.Sprintf("container %q is Privileged and allows privilege escalation.", container.Name), FieldPath: "securityContext.privileged"}}
 				}
 				if securityContext.Capabilities != nil {
 					for _, cap := range securityContext.Capabilities.Add {
 						if cap == v1.Capability(sysAdminCapability) {
-							return []diagnostic.Diagnostic{{Message: fmt.Sprintf("container %q has SYS_ADMIN capability and allows privilege escalation.", container.Name)}}
+							return []diagnostic.Diagnostic{{Message: fmt.Sprintf("container %q has SYS_ADMIN capability and allows privilege escalation.", container.Name), FieldPath: "securityContext.capabilities.add"}}
 						}
 					}
 				}
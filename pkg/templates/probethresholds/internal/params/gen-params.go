@@ -0,0 +1,130 @@
+// Code generated by kube-linter template codegen. DO NOT EDIT.
+// +build !templatecodegen
+
+package params
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
+)
+
+var (
+	// Use some imports in case they don't get used otherwise.
+	_ = util.MustParseParameterDesc
+	_ = fmt.Sprintf
+
+	minInitialDelaySecondsParamDesc = util.MustParseParameterDesc(`{
+	"Name": "minInitialDelaySeconds",
+	"Type": "integer",
+	"Description": "The lower bound of the initialDelaySeconds of probes (inclusive). Probes that do not set it are treated as having an initialDelaySeconds of 0, like Kubernetes does.",
+	"Examples": null,
+	"Enum": null,
+	"SubParameters": null,
+	"ArrayElemType": "",
+	"Default": "0",
+	"Required": false,
+	"NoRegex": false,
+	"NotNegatable": false,
+	"XXXStructFieldName": "MinInitialDelaySeconds",
+	"XXXIsPointer": false
+}
+`)
+
+	maxInitialDelaySecondsParamDesc = util.MustParseParameterDesc(`{
+	"Name": "maxInitialDelaySeconds",
+	"Type": "integer",
+	"Description": "The upper bound of the initialDelaySeconds of probes (inclusive). If not specified, it is treated as \"no upper bound\".",
+	"Examples": null,
+	"Enum": null,
+	"SubParameters": null,
+	"ArrayElemType": "",
+	"Required": false,
+	"NoRegex": false,
+	"NotNegatable": false,
+	"XXXStructFieldName": "MaxInitialDelaySeconds",
+	"XXXIsPointer": true
+}
+`)
+
+	minTimeoutSecondsParamDesc = util.MustParseParameterDesc(`{
+	"Name": "minTimeoutSeconds",
+	"Type": "integer",
+	"Description": "The lower bound of the timeoutSeconds of probes (inclusive). Probes that do not set it are treated as having a timeoutSeconds of 1, like Kubernetes does.",
+	"Examples": null,
+	"Enum": null,
+	"SubParameters": null,
+	"ArrayElemType": "",
+	"Default": "0",
+	"Required": false,
+	"NoRegex": false,
+	"NotNegatable": false,
+	"XXXStructFieldName": "MinTimeoutSeconds",
+	"XXXIsPointer": false
+}
+`)
+
+	maxTimeoutSecondsParamDesc = util.MustParseParameterDesc(`{
+	"Name": "maxTimeoutSeconds",
+	"Type": "integer",
+	"Description": "The upper bound of the timeoutSeconds of probes (inclusive). If not specified, it is treated as \"no upper bound\".",
+	"Examples": null,
+	"Enum": null,
+	"SubParameters": null,
+	"ArrayElemType": "",
+	"Required": false,
+	"NoRegex": false,
+	"NotNegatable": false,
+	"XXXStructFieldName": "MaxTimeoutSeconds",
+	"XXXIsPointer": true
+}
+`)
+
+	ParamDescs = []check.ParameterDesc{
+		minInitialDelaySecondsParamDesc,
+		maxInitialDelaySecondsParamDesc,
+		minTimeoutSecondsParamDesc,
+		maxTimeoutSecondsParamDesc,
+	}
+)
+
+func (p *Params) Validate() error {
+	var validationErrors []string
+	if len(validationErrors) > 0 {
+		return errors.Errorf("invalid parameters: %s", strings.Join(validationErrors, ", "))
+    }
+	return nil
+}
+
+// ParseAndValidate instantiates a Params object out of the passed map[string]interface{},
+// validates it, and returns it.
+// The return type is interface{} to satisfy the type in the Template struct.
+func ParseAndValidate(m map[string]interface{}) (interface{}, error) {
+	var p Params
+	if err := util.DecodeMapStructure(m, &p); err != nil {
+		return nil, err
+	}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// WrapInstantiateFunc is a convenience wrapper that wraps an untyped instantiate function
+// into a typed one.
+func WrapInstantiateFunc(f func(p Params) (check.Func, error)) func (interface{}) (check.Func, error) {
+	return func(paramsInt interface{}) (check.Func, error) {
+		return f(paramsInt.(Params))
+	}
+}
+
+// WrapInstantiateContextMatcherFunc is a convenience wrapper that wraps an untyped instantiate function
+// for a context matcher into a typed one.
+func WrapInstantiateContextMatcherFunc(f func(p Params) (check.ContextMatcher, error)) func (interface{}) (check.ContextMatcher, error) {
+	return func(paramsInt interface{}) (check.ContextMatcher, error) {
+		return f(paramsInt.(Params))
+	}
+}
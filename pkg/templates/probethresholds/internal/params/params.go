@@ -0,0 +1,23 @@
+package params
+
+// Params represents the params accepted by this template.
+type Params struct {
+
+	// The lower bound of the initialDelaySeconds of probes (inclusive).
+	// Probes that do not set it are treated as having an initialDelaySeconds of 0, like Kubernetes does.
+	// +default=0
+	MinInitialDelaySeconds int `json:"minInitialDelaySeconds"`
+
+	// The upper bound of the initialDelaySeconds of probes (inclusive).
+	// If not specified, it is treated as "no upper bound".
+	MaxInitialDelaySeconds *int `json:"maxInitialDelaySeconds"`
+
+	// The lower bound of the timeoutSeconds of probes (inclusive).
+	// Probes that do not set it are treated as having a timeoutSeconds of 1, like Kubernetes does.
+	// +default=0
+	MinTimeoutSeconds int `json:"minTimeoutSeconds"`
+
+	// The upper bound of the timeoutSeconds of probes (inclusive).
+	// If not specified, it is treated as "no upper bound".
+	MaxTimeoutSeconds *int `json:"maxTimeoutSeconds"`
+}
@@ -0,0 +1,120 @@
+package probethresholds
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/pkg/errors"
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/objectkinds"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/probethresholds/internal/params"
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	templateKey = "probe-thresholds"
+
+	// defaultTimeoutSeconds is the timeoutSeconds that Kubernetes uses for probes that do not set it.
+	defaultTimeoutSeconds = 1
+)
+
+// bounds is an inclusive range of values that a field of probes must be in.
+type bounds struct {
+	field string
+	lower int
+	upper *int
+}
+
+func (b bounds) validate() error {
+	if b.upper != nil && *b.upper < b.lower {
+		return errors.Errorf("the upper bound of %s (%d) is lower than its lower bound (%d)", b.field, *b.upper, b.lower)
+	}
+	return nil
+}
+
+// String describes the range, like "between 1 and 10".
+func (b bounds) String() string {
+	if b.upper == nil {
+		return fmt.Sprintf("at least %d", b.lower)
+	}
+	if b.lower == 0 {
+		return fmt.Sprintf("at most %d", *b.upper)
+	}
+	return fmt.Sprintf("between %d and %d", b.lower, *b.upper)
+}
+
+// namedProbe is a probe of a container, with the name of the field that it is set at.
+type namedProbe struct {
+	field string
+	probe *v1.Probe
+}
+
+func probesOf(container *v1.Container) []namedProbe {
+	return []namedProbe{
+		{field: "livenessProbe", probe: container.LivenessProbe},
+		{field: "readinessProbe", probe: container.ReadinessProbe},
+		{field: "startupProbe", probe: container.StartupProbe},
+	}
+}
+
+func init() {
+	templates.Register(check.Template{
+		HumanName:   "Probe Thresholds",
+		Key:         templateKey,
+		Description: "Flag probes whose initialDelaySeconds or timeoutSeconds are outside the given ranges, and liveness probes that are identical to the readiness probe",
+		SupportedObjectKinds: config.ObjectKindsDesc{
+			ObjectKinds: []string{objectkinds.DeploymentLike},
+		},
+		Parameters:             params.ParamDescs,
+		ParseAndValidateParams: params.ParseAndValidate,
+		Instantiate: params.WrapInstantiateFunc(func(p params.Params) (check.Func, error) {
+			initialDelayBounds := bounds{field: "initialDelaySeconds", lower: p.MinInitialDelaySeconds, upper: p.MaxInitialDelaySeconds}
+			timeoutBounds := bounds{field: "timeoutSeconds", lower: p.MinTimeoutSeconds, upper: p.MaxTimeoutSeconds}
+			for _, b := range []bounds{initialDelayBounds, timeoutBounds} {
+				if err := b.validate(); err != nil {
+					return nil, err
+				}
+			}
+			return util.PerNonEphemeralContainerCheck(func(container *v1.Container) []diagnostic.Diagnostic {
+				var results []diagnostic.Diagnostic
+				for _, named := range probesOf(container) {
+					if named.probe == nil {
+						continue
+					}
+					timeoutSeconds := int(named.probe.TimeoutSeconds)
+					if timeoutSeconds == 0 {
+						timeoutSeconds = defaultTimeoutSeconds
+					}
+					for _, field := range []struct {
+						bounds
+						value int
+					}{
+						{bounds: initialDelayBounds, value: int(named.probe.InitialDelaySeconds)},
+						{bounds: timeoutBounds, value: timeoutSeconds},
+					} {
+						if util.ValueInRange(field.value, field.lower, field.upper) {
+							continue
+						}
+						results = append(results, diagnostic.Diagnostic{
+							Message: fmt.Sprintf("container %q has a %s with %s %d, but it should be %s",
+								container.Name, named.field, field.field, field.value, field.bounds),
+							FieldPath: named.field + "." + field.field,
+						})
+					}
+				}
+				if container.LivenessProbe != nil && reflect.DeepEqual(container.LivenessProbe, container.ReadinessProbe) {
+					results = append(results, diagnostic.Diagnostic{
+						Message: fmt.Sprintf("container %q has a livenessProbe identical to its readinessProbe, "+
+							"so it is restarted whenever it is not ready", container.Name),
+						FieldPath: "livenessProbe",
+					})
+				}
+				return results
+			}), nil
+		}),
+	})
+}
@@ -0,0 +1,114 @@
+package probethresholds
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext/mocks"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/probethresholds/internal/params"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestProbeThresholds(t *testing.T) {
+	suite.Run(t, new(ProbeThresholdsTestSuite))
+}
+
+type ProbeThresholdsTestSuite struct {
+	templates.TemplateTestSuite
+
+	ctx *mocks.MockLintContext
+}
+
+func (s *ProbeThresholdsTestSuite) SetupTest() {
+	s.Init(templateKey)
+	s.ctx = mocks.NewMockContext()
+}
+
+func (s *ProbeThresholdsTestSuite) addDeploymentWithContainer(name string, container v1.Container) {
+	s.ctx.AddMockDeployment(s.T(), name)
+	s.ctx.AddContainerToDeployment(s.T(), name, container)
+}
+
+func httpProbe(path string, initialDelaySeconds, timeoutSeconds int32) *v1.Probe {
+	probe := &v1.Probe{InitialDelaySeconds: initialDelaySeconds, TimeoutSeconds: timeoutSeconds}
+	probe.HTTPGet = &v1.HTTPGetAction{Path: path, Port: intstr.FromInt(8080)}
+	return probe
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func (s *ProbeThresholdsTestSuite) TestBounds() {
+	s.addDeploymentWithContainer("in-range", v1.Container{
+		Name:           "app",
+		LivenessProbe:  httpProbe("/healthz", 10, 5),
+		ReadinessProbe: httpProbe("/ready", 5, 2),
+	})
+	s.addDeploymentWithContainer("out-of-range", v1.Container{
+		Name:           "app",
+		LivenessProbe:  httpProbe("/healthz", 600, 30),
+		ReadinessProbe: httpProbe("/ready", 5, 0),
+		StartupProbe:   httpProbe("/healthz", 0, 2),
+	})
+	s.addDeploymentWithContainer("no-probes", v1.Container{Name: "app"})
+
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{
+				MaxInitialDelaySeconds: intPtr(300),
+				MinTimeoutSeconds:      2,
+				MaxTimeoutSeconds:      intPtr(10),
+			},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"out-of-range": {
+					{Message: `container "app" has a livenessProbe with initialDelaySeconds 600, but it should be at most 300`},
+					{Message: `container "app" has a livenessProbe with timeoutSeconds 30, but it should be between 2 and 10`},
+					// Probes that do not set timeoutSeconds time out after 1 second.
+					{Message: `container "app" has a readinessProbe with timeoutSeconds 1, but it should be between 2 and 10`},
+				},
+			},
+		},
+		{
+			Param: params.Params{},
+		},
+		{
+			Param: params.Params{
+				MinTimeoutSeconds: 10,
+				MaxTimeoutSeconds: intPtr(5),
+			},
+			ExpectInstantiationError: true,
+		},
+	})
+}
+
+func (s *ProbeThresholdsTestSuite) TestIdenticalLivenessAndReadinessProbes() {
+	s.addDeploymentWithContainer("identical", v1.Container{
+		Name:           "app",
+		LivenessProbe:  httpProbe("/healthz", 10, 5),
+		ReadinessProbe: httpProbe("/healthz", 10, 5),
+	})
+	s.addDeploymentWithContainer("different-delay", v1.Container{
+		Name:           "app",
+		LivenessProbe:  httpProbe("/healthz", 30, 5),
+		ReadinessProbe: httpProbe("/healthz", 10, 5),
+	})
+	s.addDeploymentWithContainer("liveness-only", v1.Container{
+		Name:          "app",
+		LivenessProbe: httpProbe("/healthz", 10, 5),
+	})
+
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"identical": {
+					{Message: `container "app" has a livenessProbe identical to its readinessProbe, so it is restarted whenever it is not ready`},
+				},
+			},
+		},
+	})
+}
@@ -1,9 +1,10 @@
 package templates
 
 import (
-	"fmt"
 	"sort"
 
+	"github.com/pkg/errors"
+	"golang.stackrox.io/kube-linter/internal/stringutils"
 	"golang.stackrox.io/kube-linter/pkg/check"
 )
 
@@ -11,13 +12,37 @@ var (
 	allTemplates = make(map[string]check.Template)
 )
 
-// Register registers a template with the given name.
-// Intended to be called at program init time.
+// Register registers a template with the given name, and panics if it is invalid.
+// Intended to be called at program init time by the templates of kube-linter itself; other packages should use
+// RegisterTemplate.
 func Register(t check.Template) {
+	if err := RegisterTemplate(t); err != nil {
+		panic(err)
+	}
+}
+
+// RegisterTemplate registers a template, so that checks can use it like any of the built-in templates. It is the
+// entry point for templates that are not part of kube-linter, and is intended to be called from the init functions
+// of the packages that define them, which custom builds of kube-linter import, or of Go plugins loaded with
+// LoadPlugins.
+//
+// The template must have a key that no other template has, a ParseAndValidateParams function, and exactly one of
+// Instantiate and InstantiateContextMatcher.
+func RegisterTemplate(t check.Template) error {
+	if t.Key == "" {
+		return errors.New("template key must not be empty")
+	}
 	if _, ok := allTemplates[t.Key]; ok {
-		panic(fmt.Sprintf("duplicate template: %v", t.Key))
+		return errors.Errorf("duplicate template: %v", t.Key)
+	}
+	if t.ParseAndValidateParams == nil {
+		return errors.Errorf("template %q: ParseAndValidateParams must be set", t.Key)
+	}
+	if (t.Instantiate == nil) == (t.InstantiateContextMatcher == nil) {
+		return errors.Errorf("template %q: exactly one of Instantiate and InstantiateContextMatcher must be set", t.Key)
 	}
 	allTemplates[t.Key] = t
+	return nil
 }
 
 // Get gets a template by name, returning a boolean indicating whether it was found.
@@ -37,3 +62,13 @@ func List() []check.Template {
 	})
 	return out
 }
+
+// DidYouMean suggests the keys of the known templates that the given unknown template key is most likely a typo of,
+// to be appended to the error about it. It returns an empty string if there are none.
+func DidYouMean(key string) string {
+	keys := make([]string, 0, len(allTemplates))
+	for k := range allTemplates {
+		keys = append(keys, k)
+	}
+	return stringutils.DidYouMean(key, keys)
+}
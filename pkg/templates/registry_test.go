@@ -0,0 +1,77 @@
+package templates
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+)
+
+func parseNoParams(map[string]interface{}) (interface{}, error) {
+	return nil, nil
+}
+
+func instantiateNoop(interface{}) (check.Func, error) {
+	return func(lintcontext.LintContext, lintcontext.Object) []diagnostic.Diagnostic {
+		return nil
+	}, nil
+}
+
+func TestRegisterTemplate(t *testing.T) {
+	template := check.Template{
+		Key:                    "test-register-template",
+		ParseAndValidateParams: parseNoParams,
+		Instantiate:            instantiateNoop,
+	}
+	require.NoError(t, RegisterTemplate(template))
+	defer delete(allTemplates, template.Key)
+
+	_, found := Get(template.Key)
+	assert.True(t, found)
+	assert.EqualError(t, RegisterTemplate(template), "duplicate template: test-register-template")
+}
+
+func TestRegisterTemplateInvalid(t *testing.T) {
+	for _, testCase := range []struct {
+		desc        string
+		template    check.Template
+		expectedErr string
+	}{
+		{
+			desc:        "no key",
+			template:    check.Template{ParseAndValidateParams: parseNoParams, Instantiate: instantiateNoop},
+			expectedErr: "template key must not be empty",
+		},
+		{
+			desc:        "no params parser",
+			template:    check.Template{Key: "test-invalid", Instantiate: instantiateNoop},
+			expectedErr: `template "test-invalid": ParseAndValidateParams must be set`,
+		},
+		{
+			desc:        "no instantiate function",
+			template:    check.Template{Key: "test-invalid", ParseAndValidateParams: parseNoParams},
+			expectedErr: `template "test-invalid": exactly one of Instantiate and InstantiateContextMatcher must be set`,
+		},
+		{
+			desc: "both instantiate functions",
+			template: check.Template{
+				Key:                    "test-invalid",
+				ParseAndValidateParams: parseNoParams,
+				Instantiate:            instantiateNoop,
+				InstantiateContextMatcher: func(interface{}) (check.ContextMatcher, error) {
+					return nil, nil
+				},
+			},
+			expectedErr: `template "test-invalid": exactly one of Instantiate and InstantiateContextMatcher must be set`,
+		},
+	} {
+		t.Run(testCase.desc, func(t *testing.T) {
+			assert.EqualError(t, RegisterTemplate(testCase.template), testCase.expectedErr)
+			_, found := Get(testCase.template.Key)
+			assert.False(t, found)
+		})
+	}
+}
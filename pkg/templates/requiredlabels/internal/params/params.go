@@ -0,0 +1,21 @@
+package params
+
+// RequiredLabel describes a label that objects must have.
+type RequiredLabel struct {
+
+	// Key of the required label.
+	// +noregex
+	// +notnegatable
+	Key string
+
+	// A regular expression that the value of the label must match.
+	// If not specified, any value is accepted.
+	Value string
+}
+
+// Params represents the params accepted by this template.
+type Params struct {
+
+	// The labels that objects must carry.
+	Labels []RequiredLabel
+}
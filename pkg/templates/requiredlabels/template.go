@@ -0,0 +1,78 @@
+package requiredlabels
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/extract"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/matcher"
+	"golang.stackrox.io/kube-linter/pkg/objectkinds"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/requiredlabels/internal/params"
+)
+
+const (
+	templateKey = "required-labels"
+)
+
+type requiredLabel struct {
+	params.RequiredLabel
+	valueMatcher func(string) bool
+}
+
+func init() {
+	templates.Register(check.Template{
+		HumanName:   "Required Labels",
+		Key:         templateKey,
+		Description: "Flag objects that do not carry all of the given labels, optionally with values matching the given patterns",
+		SupportedObjectKinds: config.ObjectKindsDesc{
+			ObjectKinds: []string{objectkinds.Any},
+		},
+		Parameters:             params.ParamDescs,
+		ParseAndValidateParams: params.ParseAndValidate,
+		Instantiate: params.WrapInstantiateFunc(func(p params.Params) (check.Func, error) {
+			if len(p.Labels) == 0 {
+				return nil, errors.New("no labels specified")
+			}
+			requiredLabels := make([]requiredLabel, 0, len(p.Labels))
+			for _, label := range p.Labels {
+				if label.Key == "" {
+					return nil, errors.New("labels must have a key")
+				}
+				valueMatcher, err := matcher.ForString(label.Value)
+				if err != nil {
+					return nil, errors.Wrapf(err, "invalid value for label %q", label.Key)
+				}
+				requiredLabels = append(requiredLabels, requiredLabel{RequiredLabel: label, valueMatcher: valueMatcher})
+			}
+			return func(_ lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
+				labels := extract.Labels(object.K8sObject)
+				var missing []string
+				var results []diagnostic.Diagnostic
+				for _, label := range requiredLabels {
+					value, found := labels[label.Key]
+					if !found {
+						missing = append(missing, label.Key)
+						continue
+					}
+					if !label.valueMatcher(value) {
+						results = append(results, diagnostic.Diagnostic{
+							Message: fmt.Sprintf("label %q has value %q that does not match %q", label.Key, value, label.Value),
+						})
+					}
+				}
+				if len(missing) > 0 {
+					results = append([]diagnostic.Diagnostic{{
+						Message: fmt.Sprintf("object is missing required labels: %s", strings.Join(missing, ", ")),
+					}}, results...)
+				}
+				return results
+			}, nil
+		}),
+	})
+}
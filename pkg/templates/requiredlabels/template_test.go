@@ -0,0 +1,70 @@
+package requiredlabels
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext/mocks"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/requiredlabels/internal/params"
+	appsV1 "k8s.io/api/apps/v1"
+)
+
+func TestRequiredLabels(t *testing.T) {
+	suite.Run(t, new(RequiredLabelsTestSuite))
+}
+
+type RequiredLabelsTestSuite struct {
+	templates.TemplateTestSuite
+
+	ctx *mocks.MockLintContext
+}
+
+func (s *RequiredLabelsTestSuite) SetupTest() {
+	s.Init(templateKey)
+	s.ctx = mocks.NewMockContext()
+}
+
+func (s *RequiredLabelsTestSuite) addDeploymentWithLabels(name string, labels map[string]string) {
+	s.ctx.AddMockDeployment(s.T(), name)
+	s.ctx.ModifyDeployment(s.T(), name, func(deployment *appsV1.Deployment) {
+		deployment.Labels = labels
+	})
+}
+
+func (s *RequiredLabelsTestSuite) TestRequiredLabels() {
+	s.addDeploymentWithLabels("all-labels", map[string]string{"team": "payments", "env": "prod", "cost-center": "1234"})
+	s.addDeploymentWithLabels("no-labels", nil)
+	s.addDeploymentWithLabels("some-labels", map[string]string{"team": "payments"})
+	s.addDeploymentWithLabels("invalid-value", map[string]string{"team": "payments", "env": "qa", "cost-center": "1234"})
+
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{
+				Labels: []params.RequiredLabel{{Key: "team"}, {Key: "env", Value: "^(dev|prod)$"}, {Key: "cost-center"}},
+			},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"no-labels":     {{Message: "object is missing required labels: team, env, cost-center"}},
+				"some-labels":   {{Message: "object is missing required labels: env, cost-center"}},
+				"invalid-value": {{Message: `label "env" has value "qa" that does not match "^(dev|prod)$"`}},
+			},
+		},
+		{
+			Param:                    params.Params{},
+			ExpectInstantiationError: true,
+		},
+		{
+			Param: params.Params{
+				Labels: []params.RequiredLabel{{Value: "prod"}},
+			},
+			ExpectInstantiationError: true,
+		},
+		{
+			Param: params.Params{
+				Labels: []params.RequiredLabel{{Key: "env", Value: "["}},
+			},
+			ExpectInstantiationError: true,
+		},
+	})
+}
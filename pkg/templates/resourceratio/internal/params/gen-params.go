@@ -0,0 +1,115 @@
+// Code generated by kube-linter template codegen. DO NOT EDIT.
+// +build !templatecodegen
+
+package params
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
+)
+
+var (
+	// Use some imports in case they don't get used otherwise.
+	_ = util.MustParseParameterDesc
+	_ = fmt.Sprintf
+
+	resourceTypeParamDesc = util.MustParseParameterDesc(`{
+	"Name": "resourceType",
+	"Type": "string",
+	"Description": "The type of resource. Use any to apply to both cpu and memory.",
+	"Examples": null,
+	"Enum": [
+		"cpu",
+		"memory",
+		"any"
+	],
+	"SubParameters": null,
+	"ArrayElemType": "",
+	"Required": true,
+	"NoRegex": false,
+	"NotNegatable": false,
+	"XXXStructFieldName": "ResourceType",
+	"XXXIsPointer": false
+}
+`)
+
+	maxRatioParamDesc = util.MustParseParameterDesc(`{
+	"Name": "maxRatio",
+	"Type": "number",
+	"Description": "The maximum ratio of the limit to the request, for example 4 to flag containers whose limit is more than four times their request.",
+	"Examples": null,
+	"Enum": null,
+	"SubParameters": null,
+	"ArrayElemType": "",
+	"Required": false,
+	"NoRegex": false,
+	"NotNegatable": false,
+	"XXXStructFieldName": "MaxRatio",
+	"XXXIsPointer": false
+}
+`)
+
+	ParamDescs = []check.ParameterDesc{
+		resourceTypeParamDesc,
+		maxRatioParamDesc,
+	}
+)
+
+func (p *Params) Validate() error {
+	var validationErrors []string
+	if p.ResourceType == "" {
+		validationErrors = append(validationErrors, "required param resourceType not found")
+	}
+	var found bool
+	for _, allowedValue := range []string{
+		"cpu",
+		"memory",
+		"any",
+	}{
+		if p.ResourceType == allowedValue {
+			found = true
+			break
+		}
+	}
+	if !found {
+		validationErrors = append(validationErrors, fmt.Sprintf("param resourceType has invalid value %q, must be one of [cpu memory any]", p.ResourceType))
+	}
+	if len(validationErrors) > 0 {
+		return errors.Errorf("invalid parameters: %s", strings.Join(validationErrors, ", "))
+    }
+	return nil
+}
+
+// ParseAndValidate instantiates a Params object out of the passed map[string]interface{},
+// validates it, and returns it.
+// The return type is interface{} to satisfy the type in the Template struct.
+func ParseAndValidate(m map[string]interface{}) (interface{}, error) {
+	var p Params
+	if err := util.DecodeMapStructure(m, &p); err != nil {
+		return nil, err
+	}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// WrapInstantiateFunc is a convenience wrapper that wraps an untyped instantiate function
+// into a typed one.
+func WrapInstantiateFunc(f func(p Params) (check.Func, error)) func (interface{}) (check.Func, error) {
+	return func(paramsInt interface{}) (check.Func, error) {
+		return f(paramsInt.(Params))
+	}
+}
+
+// WrapInstantiateContextMatcherFunc is a convenience wrapper that wraps an untyped instantiate function
+// for a context matcher into a typed one.
+func WrapInstantiateContextMatcherFunc(f func(p Params) (check.ContextMatcher, error)) func (interface{}) (check.ContextMatcher, error) {
+	return func(paramsInt interface{}) (check.ContextMatcher, error) {
+		return f(paramsInt.(Params))
+	}
+}
@@ -0,0 +1,16 @@
+package params
+
+// Params represents the params accepted by this template.
+type Params struct {
+
+	// The type of resource. Use any to apply to both cpu and memory.
+	// +enum=cpu
+	// +enum=memory
+	// +enum=any
+	// +required
+	ResourceType string
+
+	// The maximum ratio of the limit to the request, for example 4 to flag containers whose limit is more than four
+	// times their request.
+	MaxRatio float64
+}
@@ -0,0 +1,63 @@
+package resourceratio
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/objectkinds"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/resourceratio/internal/params"
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	templateKey = "resource-ratio"
+)
+
+func process(results *[]diagnostic.Diagnostic, container *v1.Container, resourceName v1.ResourceName, maxRatio float64) {
+	request, hasRequest := container.Resources.Requests[resourceName]
+	limit, hasLimit := container.Resources.Limits[resourceName]
+	// Missing requests and limits are flagged by other checks.
+	if !hasRequest || !hasLimit || request.IsZero() {
+		return
+	}
+	ratio := limit.AsApproximateFloat64() / request.AsApproximateFloat64()
+	if ratio > maxRatio {
+		*results = append(*results, diagnostic.Diagnostic{
+			Message: fmt.Sprintf("container %q has %s limit %s that is %.2f times its request %s, more than the maximum ratio of %v",
+				container.Name, resourceName, &limit, ratio, &request, maxRatio),
+		})
+	}
+}
+
+func init() {
+	templates.Register(check.Template{
+		HumanName:   "Resource Limit To Request Ratio",
+		Key:         templateKey,
+		Description: "Flag containers whose CPU or memory limit exceeds their request by more than the given ratio",
+		SupportedObjectKinds: config.ObjectKindsDesc{
+			ObjectKinds: []string{objectkinds.DeploymentLike},
+		},
+		Parameters:             params.ParamDescs,
+		ParseAndValidateParams: params.ParseAndValidate,
+		Instantiate: params.WrapInstantiateFunc(func(p params.Params) (check.Func, error) {
+			if p.MaxRatio < 1 {
+				return nil, errors.Errorf("maxRatio must be at least 1, got %v", p.MaxRatio)
+			}
+			return util.PerNonEphemeralContainerCheck(func(container *v1.Container) []diagnostic.Diagnostic {
+				var results []diagnostic.Diagnostic
+				if p.ResourceType == "cpu" || p.ResourceType == "any" {
+					process(&results, container, v1.ResourceCPU, p.MaxRatio)
+				}
+				if p.ResourceType == "memory" || p.ResourceType == "any" {
+					process(&results, container, v1.ResourceMemory, p.MaxRatio)
+				}
+				return results
+			}), nil
+		}),
+	})
+}
@@ -0,0 +1,77 @@
+package resourceratio
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext/mocks"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/resourceratio/internal/params"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestResourceRatio(t *testing.T) {
+	suite.Run(t, new(ResourceRatioTestSuite))
+}
+
+type ResourceRatioTestSuite struct {
+	templates.TemplateTestSuite
+
+	ctx *mocks.MockLintContext
+}
+
+func (s *ResourceRatioTestSuite) SetupTest() {
+	s.Init(templateKey)
+	s.ctx = mocks.NewMockContext()
+}
+
+func resourceList(cpu, memory string) v1.ResourceList {
+	list := v1.ResourceList{}
+	if cpu != "" {
+		list[v1.ResourceCPU] = resource.MustParse(cpu)
+	}
+	if memory != "" {
+		list[v1.ResourceMemory] = resource.MustParse(memory)
+	}
+	return list
+}
+
+func (s *ResourceRatioTestSuite) addDeploymentWithResources(name string, requests, limits v1.ResourceList) {
+	s.ctx.AddMockDeployment(s.T(), name)
+	s.ctx.AddContainerToDeployment(s.T(), name, v1.Container{
+		Name:      "app",
+		Resources: v1.ResourceRequirements{Requests: requests, Limits: limits},
+	})
+}
+
+func (s *ResourceRatioTestSuite) TestRatios() {
+	s.addDeploymentWithResources("within-ratio", resourceList("500m", "1Gi"), resourceList("2", "4Gi"))
+	s.addDeploymentWithResources("over-provisioned", resourceList("100m", "256Mi"), resourceList("1", "2Gi"))
+	s.addDeploymentWithResources("missing-values", resourceList("", "256Mi"), resourceList("1", ""))
+	s.addDeploymentWithResources("zero-request", resourceList("0", ""), resourceList("1", ""))
+
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{ResourceType: "any", MaxRatio: 4},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"over-provisioned": {
+					{Message: `container "app" has cpu limit 1 that is 10.00 times its request 100m, more than the maximum ratio of 4`},
+					{Message: `container "app" has memory limit 2Gi that is 8.00 times its request 256Mi, more than the maximum ratio of 4`},
+				},
+			},
+		},
+		{
+			Param: params.Params{ResourceType: "memory", MaxRatio: 2.5},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"within-ratio":     {{Message: `container "app" has memory limit 4Gi that is 4.00 times its request 1Gi, more than the maximum ratio of 2.5`}},
+				"over-provisioned": {{Message: `container "app" has memory limit 2Gi that is 8.00 times its request 256Mi, more than the maximum ratio of 2.5`}},
+			},
+		},
+		{
+			Param:                    params.Params{ResourceType: "cpu", MaxRatio: 0.5},
+			ExpectInstantiationError: true,
+		},
+	})
+}
@@ -0,0 +1,13 @@
+package params
+
+// Params represents the params accepted by this template.
+type Params struct {
+
+	// The maximum CPU that all replicas of an object may request together, as a Kubernetes quantity like "8" or
+	// "8000m". If empty, the CPU requests are not checked.
+	MaxCPU string
+
+	// The maximum memory that all replicas of an object may request together, as a Kubernetes quantity like "32Gi".
+	// If empty, the memory requests are not checked.
+	MaxMemory string
+}
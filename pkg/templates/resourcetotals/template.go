@@ -0,0 +1,153 @@
+package resourcetotals
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"golang.stackrox.io/kube-linter/internal/stringutils"
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/extract"
+	"golang.stackrox.io/kube-linter/pkg/extract/customtypes"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/objectkinds"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/resourcetotals/internal/params"
+	autoscalingV1 "k8s.io/api/autoscaling/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	templateKey = "total-resource-requests"
+)
+
+// maxTotal is the maximum total request of a resource.
+type maxTotal struct {
+	resourceName v1.ResourceName
+	max          resource.Quantity
+}
+
+// parseMaxTotals parses the maximum totals of the params, leaving out the resources whose maximum is empty.
+func parseMaxTotals(p params.Params) ([]maxTotal, error) {
+	var maxTotals []maxTotal
+	for _, param := range []struct {
+		name         string
+		value        string
+		resourceName v1.ResourceName
+	}{
+		{name: "maxCPU", value: p.MaxCPU, resourceName: v1.ResourceCPU},
+		{name: "maxMemory", value: p.MaxMemory, resourceName: v1.ResourceMemory},
+	} {
+		if param.value == "" {
+			continue
+		}
+		max, err := resource.ParseQuantity(param.value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid %s %q", param.name, param.value)
+		}
+		maxTotals = append(maxTotals, maxTotal{resourceName: param.resourceName, max: max})
+	}
+	if len(maxTotals) == 0 {
+		return nil, errors.New("at least one of maxCPU and maxMemory must be set")
+	}
+	return maxTotals, nil
+}
+
+// podRequest returns the request of the given resource of a pod with the given spec, which is what the scheduler
+// reserves for it: the sum of the requests of its containers, or the largest request of its init containers if that
+// is more, since they run one after the other before the containers, plus the overhead of the pod.
+func podRequest(podSpec *customtypes.PodSpec, resourceName v1.ResourceName) resource.Quantity {
+	var request resource.Quantity
+	for _, container := range podSpec.NonInitContainers() {
+		if containerRequest, ok := container.Resources.Requests[resourceName]; ok {
+			request.Add(containerRequest)
+		}
+	}
+	for _, container := range podSpec.InitContainers() {
+		if initRequest, ok := container.Resources.Requests[resourceName]; ok && initRequest.Cmp(request) > 0 {
+			request = initRequest.DeepCopy()
+		}
+	}
+	if overhead, ok := podSpec.Overhead[resourceName]; ok {
+		request.Add(overhead)
+	}
+	return request
+}
+
+// totalRequest returns the request of the given resource of all replicas of an object, given the one of a single pod.
+func totalRequest(podRequest resource.Quantity, replicas int32, resourceName v1.ResourceName) *resource.Quantity {
+	format := podRequest.Format
+	if format == "" {
+		format = resource.DecimalSI
+	}
+	// CPU is counted in millicores, so that fractions of cores add up exactly.
+	if resourceName == v1.ResourceCPU {
+		return resource.NewMilliQuantity(podRequest.MilliValue()*int64(replicas), format)
+	}
+	return resource.NewQuantity(podRequest.Value()*int64(replicas), format)
+}
+
+func init() {
+	templates.Register(check.Template{
+		HumanName:   "Total Resource Requests",
+		Key:         templateKey,
+		Description: "Flag objects whose replicas together request more CPU or memory than the given maximum, computed as the number of replicas times the requests of a pod",
+		SupportedObjectKinds: config.ObjectKindsDesc{
+			ObjectKinds: []string{objectkinds.DeploymentLike},
+		},
+		Parameters:             params.ParamDescs,
+		ParseAndValidateParams: params.ParseAndValidate,
+		InstantiateContextMatcher: params.WrapInstantiateContextMatcherFunc(func(p params.Params) (check.ContextMatcher, error) {
+			maxTotals, err := parseMaxTotals(p)
+			if err != nil {
+				return nil, err
+			}
+			return func(lintCtx lintcontext.LintContext) check.Func {
+				// Index the scale targets of horizontal pod autoscalers by namespace, since the number of replicas of
+				// the objects that they scale changes over time.
+				scaleTargetsByNamespace := make(map[string][]autoscalingV1.CrossVersionObjectReference)
+				for _, obj := range lintCtx.Objects() {
+					if ref, ok := extract.ScaleTargetRef(obj.K8sObject); ok {
+						namespace := obj.K8sObject.GetNamespace()
+						scaleTargetsByNamespace[namespace] = append(scaleTargetsByNamespace[namespace], ref)
+					}
+				}
+
+				return func(_ lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
+					podSpec, hasPods := extract.PodSpec(object.K8sObject)
+					if !hasPods {
+						return nil
+					}
+					gvk := extract.GVK(object.K8sObject)
+					for _, ref := range scaleTargetsByNamespace[object.K8sObject.GetNamespace()] {
+						if extract.ScaleTargetRefMatches(ref, gvk, object.K8sObject.GetName()) {
+							return nil
+						}
+					}
+					// Objects without replicas, like pods, and objects whose replicas are unset, run a single pod.
+					replicas, found := extract.Replicas(object.K8sObject)
+					if !found {
+						replicas = 1
+					}
+
+					var results []diagnostic.Diagnostic
+					for _, maxTotal := range maxTotals {
+						request := podRequest(&podSpec, maxTotal.resourceName)
+						total := totalRequest(request, replicas, maxTotal.resourceName)
+						if total.Cmp(maxTotal.max) <= 0 {
+							continue
+						}
+						results = append(results, diagnostic.Diagnostic{
+							Message: fmt.Sprintf("object requests %s %s in total (%d %s of %s), more than the maximum of %s",
+								total, maxTotal.resourceName, replicas, stringutils.Ternary(replicas > 1, "replicas", "replica"),
+								&request, &maxTotal.max),
+						})
+					}
+					return results
+				}
+			}, nil
+		}),
+	})
+}
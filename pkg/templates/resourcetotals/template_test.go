@@ -0,0 +1,121 @@
+package resourcetotals
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext/mocks"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/resourcetotals/internal/params"
+	appsV1 "k8s.io/api/apps/v1"
+	autoscalingV1 "k8s.io/api/autoscaling/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestResourceTotals(t *testing.T) {
+	suite.Run(t, new(ResourceTotalsTestSuite))
+}
+
+type ResourceTotalsTestSuite struct {
+	templates.TemplateTestSuite
+
+	ctx *mocks.MockLintContext
+}
+
+func (s *ResourceTotalsTestSuite) SetupTest() {
+	s.Init(templateKey)
+	s.ctx = mocks.NewMockContext()
+}
+
+func requests(cpu, memory string) v1.ResourceRequirements {
+	return v1.ResourceRequirements{Requests: v1.ResourceList{
+		v1.ResourceCPU:    resource.MustParse(cpu),
+		v1.ResourceMemory: resource.MustParse(memory),
+	}}
+}
+
+func (s *ResourceTotalsTestSuite) addDeployment(name string, replicas *int32, containers ...v1.Container) {
+	s.ctx.AddMockDeployment(s.T(), name)
+	s.ctx.ModifyDeployment(s.T(), name, func(deployment *appsV1.Deployment) {
+		deployment.TypeMeta = metaV1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"}
+		deployment.Spec.Replicas = replicas
+	})
+	for _, container := range containers {
+		s.ctx.AddContainerToDeployment(s.T(), name, container)
+	}
+}
+
+func (s *ResourceTotalsTestSuite) TestTotals() {
+	ten, two := int32(10), int32(2)
+	s.addDeployment("large", &ten,
+		v1.Container{Name: "app", Resources: requests("500m", "1Gi")},
+		v1.Container{Name: "sidecar", Resources: requests("250m", "512Mi")})
+	s.addDeployment("small", &two, v1.Container{Name: "app", Resources: requests("500m", "1Gi")})
+	s.addDeployment("unset-replicas", nil, v1.Container{Name: "app", Resources: requests("6", "20Gi")})
+	s.addDeployment("no-requests", &ten, v1.Container{Name: "app"})
+	s.addDeployment("autoscaled", &ten, v1.Container{Name: "app", Resources: requests("4", "16Gi")})
+	s.ctx.AddMockHorizontalPodAutoscaler(s.T(), "hpa")
+	s.ctx.ModifyHorizontalPodAutoscaler(s.T(), "hpa", func(hpa *autoscalingV1.HorizontalPodAutoscaler) {
+		hpa.Spec.ScaleTargetRef = autoscalingV1.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "autoscaled"}
+	})
+
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{MaxCPU: "4", MaxMemory: "16Gi"},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"large": {
+					{Message: "object requests 7500m cpu in total (10 replicas of 750m), more than the maximum of 4"},
+				},
+				"unset-replicas": {
+					{Message: "object requests 6 cpu in total (1 replica of 6), more than the maximum of 4"},
+					{Message: "object requests 20Gi memory in total (1 replica of 20Gi), more than the maximum of 16Gi"},
+				},
+			},
+		},
+		{
+			Param: params.Params{MaxMemory: "8Gi"},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"large": {
+					{Message: "object requests 15Gi memory in total (10 replicas of 1536Mi), more than the maximum of 8Gi"},
+				},
+				"unset-replicas": {
+					{Message: "object requests 20Gi memory in total (1 replica of 20Gi), more than the maximum of 8Gi"},
+				},
+			},
+		},
+		{
+			Param:                    params.Params{},
+			ExpectInstantiationError: true,
+		},
+		{
+			Param:                    params.Params{MaxCPU: "four"},
+			ExpectInstantiationError: true,
+		},
+	})
+}
+
+func (s *ResourceTotalsTestSuite) TestInitContainers() {
+	three := int32(3)
+	s.addDeployment("init", &three, v1.Container{Name: "app", Resources: requests("1", "1Gi")})
+	s.ctx.ModifyDeployment(s.T(), "init", func(deployment *appsV1.Deployment) {
+		deployment.Spec.Template.Spec.InitContainers = []v1.Container{
+			{Name: "migrate", Resources: requests("2", "512Mi")},
+			{Name: "warm-up", Resources: requests("500m", "256Mi")},
+		}
+	})
+
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{MaxCPU: "4", MaxMemory: "2Gi"},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"init": {
+					{Message: "object requests 6 cpu in total (3 replicas of 2), more than the maximum of 4"},
+					{Message: "object requests 3Gi memory in total (3 replicas of 1Gi), more than the maximum of 2Gi"},
+				},
+			},
+		},
+	})
+}
@@ -6,11 +6,11 @@ import (
 	"golang.stackrox.io/kube-linter/pkg/check"
 	"golang.stackrox.io/kube-linter/pkg/config"
 	"golang.stackrox.io/kube-linter/pkg/diagnostic"
-	"golang.stackrox.io/kube-linter/pkg/extract"
-	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/extract/customtypes"
 	"golang.stackrox.io/kube-linter/pkg/objectkinds"
 	"golang.stackrox.io/kube-linter/pkg/templates"
 	"golang.stackrox.io/kube-linter/pkg/templates/runasnonroot/internal/params"
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
 	v1 "k8s.io/api/core/v1"
 )
 
@@ -45,13 +45,9 @@ func init() {
 		Parameters:             params.ParamDescs,
 		ParseAndValidateParams: params.ParseAndValidate,
 		Instantiate: params.WrapInstantiateFunc(func(_ params.Params) (check.Func, error) {
-			return func(_ lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
-				podSpec, found := extract.PodSpec(object.K8sObject)
-				if !found {
-					return nil
-				}
+			return util.PerPodSpecCheck(func(podSpec *customtypes.PodSpec) []diagnostic.Diagnostic {
 				var results []diagnostic.Diagnostic
-				for _, container := range podSpec.AllContainers() {
+				for i, container := range podSpec.AllContainers() {
 					runAsUser := effectiveRunAsUser(podSpec.SecurityContext, container.SecurityContext)
 					// runAsUser explicitly set to non-root. All good.
 					if runAsUser != nil && *runAsUser > 0 {
@@ -62,15 +58,19 @@ func init() {
 						// runAsNonRoot set, but runAsUser set to 0. This will result in a runtime failure.
 						if runAsUser != nil && *runAsUser == 0 {
 							results = append(results, diagnostic.Diagnostic{
-								Message: fmt.Sprintf("container %q is set to runAsNonRoot, but runAsUser set to %d", container.Name, *runAsUser),
+								Message:   fmt.Sprintf("container %q is set to runAsNonRoot, but runAsUser set to %d", container.Name, *runAsUser),
+								FieldPath: podSpec.ContainerFieldPath(i),
 							})
 						}
 						continue
 					}
-					results = append(results, diagnostic.Diagnostic{Message: fmt.Sprintf("container %q is not set to runAsNonRoot", container.Name)})
+					results = append(results, diagnostic.Diagnostic{
+						Message:   fmt.Sprintf("container %q is not set to runAsNonRoot", container.Name),
+						FieldPath: podSpec.ContainerFieldPath(i),
+					})
 				}
 				return results
-			}, nil
+			}), nil
 		}),
 	})
 }
@@ -0,0 +1,12 @@
+package params
+
+// Params represents the params accepted by this template.
+type Params struct {
+
+	// The seccomp profile types that containers are allowed to use, out of RuntimeDefault, Localhost and Unconfined.
+	// If not specified, RuntimeDefault and Localhost are allowed.
+	// +default=["RuntimeDefault", "Localhost"]
+	// +noregex
+	// +notnegatable
+	AllowedProfileTypes []string
+}
@@ -0,0 +1,101 @@
+package seccompprofile
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"golang.stackrox.io/kube-linter/internal/set"
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/extract"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/objectkinds"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/seccompprofile/internal/params"
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	templateKey = "seccomp-profile"
+)
+
+var (
+	knownProfileTypes   = []v1.SeccompProfileType{v1.SeccompProfileTypeRuntimeDefault, v1.SeccompProfileTypeLocalhost, v1.SeccompProfileTypeUnconfined}
+	defaultProfileTypes = []string{string(v1.SeccompProfileTypeRuntimeDefault), string(v1.SeccompProfileTypeLocalhost)}
+)
+
+// effectiveSeccompProfile returns the seccomp profile that applies to a container, and the path of the field it is
+// set in relative to the pod spec, or nil if none is set. The profile of the container overrides the one of the pod.
+func effectiveSeccompProfile(podSC *v1.PodSecurityContext, containerSC *v1.SecurityContext, containerPath string) (*v1.SeccompProfile, string) {
+	if containerSC != nil && containerSC.SeccompProfile != nil {
+		return containerSC.SeccompProfile, containerPath + ".securityContext.seccompProfile"
+	}
+	if podSC != nil && podSC.SeccompProfile != nil {
+		return podSC.SeccompProfile, "securityContext.seccompProfile"
+	}
+	return nil, ""
+}
+
+func init() {
+	templates.Register(check.Template{
+		HumanName:   "Seccomp Profile",
+		Key:         templateKey,
+		Description: "Flag containers that do not have a seccomp profile of one of the allowed types, either directly or through their pod",
+		SupportedObjectKinds: config.ObjectKindsDesc{
+			ObjectKinds: []string{objectkinds.DeploymentLike},
+		},
+		Parameters:             params.ParamDescs,
+		ParseAndValidateParams: params.ParseAndValidate,
+		Instantiate: params.WrapInstantiateFunc(func(p params.Params) (check.Func, error) {
+			allowedTypes := p.AllowedProfileTypes
+			if len(allowedTypes) == 0 {
+				allowedTypes = defaultProfileTypes
+			}
+			allowed := set.NewStringSet()
+			for _, profileType := range allowedTypes {
+				if !isKnownProfileType(profileType) {
+					return nil, errors.Errorf("unknown seccomp profile type %q, valid types are %v", profileType, knownProfileTypes)
+				}
+				allowed.Add(profileType)
+			}
+			return func(_ lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
+				podSpec, found := extract.PodSpec(object.K8sObject)
+				if !found {
+					return nil
+				}
+				podSpecPath, _ := extract.PodSpecFieldPath(object.K8sObject)
+				var results []diagnostic.Diagnostic
+				containers := podSpec.AllContainers()
+				for i := range containers {
+					container := &containers[i]
+					containerPath := podSpec.ContainerFieldPath(i)
+					profile, profilePath := effectiveSeccompProfile(podSpec.SecurityContext, container.SecurityContext, containerPath)
+					if profile == nil {
+						results = append(results, diagnostic.Diagnostic{
+							Message:   fmt.Sprintf("container %q does not have a seccomp profile", container.Name),
+							FieldPath: podSpecPath + "." + containerPath,
+						})
+						continue
+					}
+					if !allowed.Contains(string(profile.Type)) {
+						results = append(results, diagnostic.Diagnostic{
+							Message:   fmt.Sprintf("container %q has seccomp profile type %q, which is not one of the allowed types %v", container.Name, profile.Type, allowedTypes),
+							FieldPath: podSpecPath + "." + profilePath + ".type",
+						})
+					}
+				}
+				return results
+			}, nil
+		}),
+	})
+}
+
+func isKnownProfileType(profileType string) bool {
+	for _, known := range knownProfileTypes {
+		if string(known) == profileType {
+			return true
+		}
+	}
+	return false
+}
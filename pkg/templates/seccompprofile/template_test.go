@@ -0,0 +1,119 @@
+package seccompprofile
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext/mocks"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/seccompprofile/internal/params"
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestSeccompProfile(t *testing.T) {
+	suite.Run(t, new(SeccompProfileTestSuite))
+}
+
+type SeccompProfileTestSuite struct {
+	templates.TemplateTestSuite
+
+	ctx *mocks.MockLintContext
+}
+
+func (s *SeccompProfileTestSuite) SetupTest() {
+	s.Init(templateKey)
+	s.ctx = mocks.NewMockContext()
+}
+
+func seccompProfile(profileType v1.SeccompProfileType) *v1.SeccompProfile {
+	return &v1.SeccompProfile{Type: profileType}
+}
+
+// addDeployment adds a deployment with the given pod-level seccomp profile and a container for each of the given
+// container-level ones, named after their type.
+func (s *SeccompProfileTestSuite) addDeployment(name string, podProfile *v1.SeccompProfile, containerProfiles ...*v1.SeccompProfile) {
+	s.ctx.AddMockDeployment(s.T(), name)
+	s.ctx.AddSecurityContextToDeployment(s.T(), name, &v1.PodSecurityContext{SeccompProfile: podProfile})
+	for _, profile := range containerProfiles {
+		containerName := "none"
+		if profile != nil {
+			containerName = string(profile.Type)
+		}
+		s.ctx.AddContainerToDeployment(s.T(), name, v1.Container{
+			Name:            containerName,
+			SecurityContext: &v1.SecurityContext{SeccompProfile: profile},
+		})
+	}
+}
+
+func (s *SeccompProfileTestSuite) TestContainerProfiles() {
+	s.addDeployment("containers", nil,
+		nil, seccompProfile(v1.SeccompProfileTypeRuntimeDefault), seccompProfile(v1.SeccompProfileTypeLocalhost), seccompProfile(v1.SeccompProfileTypeUnconfined))
+
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"containers": {
+					{Message: `container "none" does not have a seccomp profile`},
+					{Message: `container "Unconfined" has seccomp profile type "Unconfined", which is not one of the allowed types [RuntimeDefault Localhost]`},
+				},
+			},
+		},
+		{
+			Param: params.Params{AllowedProfileTypes: []string{"RuntimeDefault"}},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"containers": {
+					{Message: `container "none" does not have a seccomp profile`},
+					{Message: `container "Localhost" has seccomp profile type "Localhost", which is not one of the allowed types [RuntimeDefault]`},
+					{Message: `container "Unconfined" has seccomp profile type "Unconfined", which is not one of the allowed types [RuntimeDefault]`},
+				},
+			},
+		},
+		{
+			Param:                    params.Params{AllowedProfileTypes: []string{"runtime/default"}},
+			ExpectInstantiationError: true,
+		},
+	})
+}
+
+func (s *SeccompProfileTestSuite) TestPodProfileIsInherited() {
+	s.addDeployment("pod-runtime-default", seccompProfile(v1.SeccompProfileTypeRuntimeDefault),
+		nil, seccompProfile(v1.SeccompProfileTypeUnconfined))
+	s.addDeployment("pod-unconfined", seccompProfile(v1.SeccompProfileTypeUnconfined),
+		nil, seccompProfile(v1.SeccompProfileTypeLocalhost))
+
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"pod-runtime-default": {
+					{Message: `container "Unconfined" has seccomp profile type "Unconfined", which is not one of the allowed types [RuntimeDefault Localhost]`},
+				},
+				"pod-unconfined": {
+					{Message: `container "none" has seccomp profile type "Unconfined", which is not one of the allowed types [RuntimeDefault Localhost]`},
+				},
+			},
+		},
+	})
+}
+
+func (s *SeccompProfileTestSuite) TestEphemeralContainers() {
+	s.addDeployment("ephemeral", nil, seccompProfile(v1.SeccompProfileTypeRuntimeDefault))
+	s.ctx.AddEphemeralContainerToDeployment(s.T(), "ephemeral", v1.Container{
+		Name:            "debugger",
+		SecurityContext: &v1.SecurityContext{SeccompProfile: seccompProfile(v1.SeccompProfileTypeUnconfined)},
+	})
+
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"ephemeral": {
+					{Message: `container "debugger" has seccomp profile type "Unconfined", which is not one of the allowed types [RuntimeDefault Localhost]`},
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,10 @@
+package params
+
+// Params represents the params accepted by this template.
+type Params struct {
+
+	// An array of regular expressions matching the values that are placeholders rather than actual secrets, like
+	// ^\$\{.+\}$ for values that are substituted when deploying. Values of data are matched once base64-decoded.
+	// +notnegatable
+	AllowedValues []string
+}
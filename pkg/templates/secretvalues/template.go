@@ -0,0 +1,127 @@
+package secretvalues
+
+import (
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/objectkinds"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/secretvalues/internal/params"
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	// minBase64Length is the minimum length of the stringData values that are checked for looking base64-encoded, so
+	// that short words which happen to be valid base64 are not flagged.
+	minBase64Length = 8
+)
+
+// looksBase64Encoded returns whether the given value is valid base64 that decodes to printable text, which suggests
+// that it was encoded by hand although stringData values are stored as they are.
+func looksBase64Encoded(value string) bool {
+	if len(value) < minBase64Length {
+		return false
+	}
+	decoded, err := base64.StdEncoding.DecodeString(value)
+	if err != nil || !utf8.Valid(decoded) {
+		return false
+	}
+	for _, r := range string(decoded) {
+		if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// fieldPath returns the path of the given key of the given field of the secret. Keys with dots, like tls.crt, cannot be
+// expressed in field paths, so the field itself is returned for them.
+func fieldPath(field, key string) string {
+	if strings.ContainsAny(key, ".[]") {
+		return field
+	}
+	return field + "." + key
+}
+
+func isAllowed(allowedValues []*regexp.Regexp, value string) bool {
+	for _, allowed := range allowedValues {
+		if allowed.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func init() {
+	templates.Register(check.Template{
+		HumanName:   "Secret Values",
+		Key:         "secret-values",
+		Description: "Flag Secrets that hold values in data or stringData, apart from the allowed placeholders",
+		SupportedObjectKinds: config.ObjectKindsDesc{
+			ObjectKinds: []string{objectkinds.Secret},
+		},
+		Parameters:             params.ParamDescs,
+		ParseAndValidateParams: params.ParseAndValidate,
+		Instantiate: params.WrapInstantiateFunc(func(p params.Params) (check.Func, error) {
+			allowedValues := make([]*regexp.Regexp, 0, len(p.AllowedValues))
+			for _, value := range p.AllowedValues {
+				r, err := regexp.Compile(value)
+				if err != nil {
+					return nil, errors.Wrapf(err, "invalid regex %s", value)
+				}
+				allowedValues = append(allowedValues, r)
+			}
+			return func(_ lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
+				secret, ok := object.K8sObject.(*v1.Secret)
+				if !ok {
+					return nil
+				}
+				// The values of data are already base64-decoded.
+				data := make(map[string]string, len(secret.Data))
+				for key, value := range secret.Data {
+					data[key] = string(value)
+				}
+				var results []diagnostic.Diagnostic
+				for _, field := range []struct {
+					name   string
+					values map[string]string
+				}{
+					{name: "data", values: data},
+					{name: "stringData", values: secret.StringData},
+				} {
+					for _, key := range sortedKeys(field.values) {
+						value := field.values[key]
+						if value == "" || isAllowed(allowedValues, value) {
+							continue
+						}
+						message := fmt.Sprintf("secret has a value for key %q in %s", key, field.name)
+						if field.name == "stringData" && looksBase64Encoded(value) {
+							message += ", which looks base64-encoded, although stringData values are stored without being decoded"
+						}
+						results = append(results, diagnostic.Diagnostic{Message: message, FieldPath: fieldPath(field.name, key)})
+					}
+				}
+				return results
+			}, nil
+		}),
+	})
+}
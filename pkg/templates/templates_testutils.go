@@ -38,7 +38,7 @@ func (s *TemplateTestSuite) Validate(
 ) {
 	for _, c := range cases {
 		s.Run(fmt.Sprintf("%+v", c.Param), func() {
-			checkFunc, err := s.Template.Instantiate(c.Param)
+			checkFunc, err := s.instantiate(ctx, c.Param)
 			if c.ExpectInstantiationError {
 				s.Error(err, "param should have caused error but did not raise one")
 				return
@@ -52,6 +52,19 @@ func (s *TemplateTestSuite) Validate(
 	}
 }
 
+// instantiate instantiates the template with the given params, and returns the function that checks the objects in
+// the given LintContext.
+func (s *TemplateTestSuite) instantiate(ctx lintcontext.LintContext, param interface{}) (check.Func, error) {
+	if s.Template.InstantiateContextMatcher != nil {
+		contextMatcher, err := s.Template.InstantiateContextMatcher(param)
+		if err != nil {
+			return nil, err
+		}
+		return contextMatcher(ctx), nil
+	}
+	return s.Template.Instantiate(param)
+}
+
 func (s *TemplateTestSuite) compareDiagnostics(expected, actual []diagnostic.Diagnostic) {
 	expectedMessages, actualMessages := make([]string, 0, len(expected)), make([]string, 0, len(actual))
 	for _, diag := range expected {
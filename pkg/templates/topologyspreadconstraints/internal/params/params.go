@@ -0,0 +1,9 @@
+package params
+
+// Params represents the params accepted by this template.
+type Params struct {
+
+	// The topology key that one of the topology spread constraints must use, like "topology.kubernetes.io/zone".
+	// If not specified, any topology spread constraint is accepted.
+	TopologyKey string
+}
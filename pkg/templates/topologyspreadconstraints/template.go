@@ -0,0 +1,63 @@
+package topologyspreadconstraints
+
+import (
+	"fmt"
+
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/config"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/extract"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/matcher"
+	"golang.stackrox.io/kube-linter/pkg/objectkinds"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/topologyspreadconstraints/internal/params"
+)
+
+const (
+	templateKey = "topology-spread-constraints"
+)
+
+func init() {
+	templates.Register(check.Template{
+		HumanName:   "Topology Spread Constraints",
+		Key:         templateKey,
+		Description: "Flag objects whose pod template spec does not specify topology spread constraints, optionally with a given topology key",
+		SupportedObjectKinds: config.ObjectKindsDesc{
+			ObjectKinds: []string{objectkinds.DeploymentLike},
+		},
+		Parameters:             params.ParamDescs,
+		ParseAndValidateParams: params.ParseAndValidate,
+		Instantiate: params.WrapInstantiateFunc(func(p params.Params) (check.Func, error) {
+			var topologyKeyMatcher func(string) bool
+			if p.TopologyKey != "" {
+				var err error
+				topologyKeyMatcher, err = matcher.ForString(p.TopologyKey)
+				if err != nil {
+					return nil, err
+				}
+			}
+			return func(_ lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
+				podSpec, found := extract.PodSpec(object.K8sObject)
+				if !found {
+					return nil
+				}
+				constraints := podSpec.TopologySpreadConstraints
+				if len(constraints) == 0 {
+					return []diagnostic.Diagnostic{{Message: "object does not specify any topology spread constraints"}}
+				}
+				if topologyKeyMatcher == nil {
+					return nil
+				}
+				for _, constraint := range constraints {
+					if topologyKeyMatcher(constraint.TopologyKey) {
+						return nil
+					}
+				}
+				return []diagnostic.Diagnostic{
+					{Message: fmt.Sprintf("object does not specify a topology spread constraint with topology key %q", p.TopologyKey)},
+				}
+			}, nil
+		}),
+	})
+}
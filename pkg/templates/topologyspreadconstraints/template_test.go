@@ -0,0 +1,69 @@
+package topologyspreadconstraints
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext/mocks"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/topologyspreadconstraints/internal/params"
+	appsV1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	zoneKey     = "topology.kubernetes.io/zone"
+	hostnameKey = "kubernetes.io/hostname"
+)
+
+func TestTopologySpreadConstraints(t *testing.T) {
+	suite.Run(t, new(TopologySpreadConstraintsTestSuite))
+}
+
+type TopologySpreadConstraintsTestSuite struct {
+	templates.TemplateTestSuite
+
+	ctx *mocks.MockLintContext
+}
+
+func (s *TopologySpreadConstraintsTestSuite) SetupTest() {
+	s.Init(templateKey)
+	s.ctx = mocks.NewMockContext()
+}
+
+func (s *TopologySpreadConstraintsTestSuite) addDeploymentWithTopologyKeys(name string, topologyKeys ...string) {
+	s.ctx.AddMockDeployment(s.T(), name)
+	s.ctx.ModifyDeployment(s.T(), name, func(deployment *appsV1.Deployment) {
+		for _, topologyKey := range topologyKeys {
+			deployment.Spec.Template.Spec.TopologySpreadConstraints = append(deployment.Spec.Template.Spec.TopologySpreadConstraints,
+				v1.TopologySpreadConstraint{MaxSkew: 1, TopologyKey: topologyKey, WhenUnsatisfiable: v1.DoNotSchedule})
+		}
+	})
+}
+
+func (s *TopologySpreadConstraintsTestSuite) TestTopologySpreadConstraints() {
+	s.addDeploymentWithTopologyKeys("none")
+	s.addDeploymentWithTopologyKeys("hostname", hostnameKey)
+	s.addDeploymentWithTopologyKeys("zone-and-hostname", hostnameKey, zoneKey)
+
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"none": {{Message: "object does not specify any topology spread constraints"}},
+			},
+		},
+		{
+			Param: params.Params{TopologyKey: zoneKey},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"none":     {{Message: "object does not specify any topology spread constraints"}},
+				"hostname": {{Message: `object does not specify a topology spread constraint with topology key "topology.kubernetes.io/zone"`}},
+			},
+		},
+		{
+			Param:                    params.Params{TopologyKey: "["},
+			ExpectInstantiationError: true,
+		},
+	})
+}
@@ -9,18 +9,41 @@ import (
 )
 
 // PerContainerCheck returns a check that abstracts away some of the boilerplate of writing a check
-// that applies to containers. The given function is passed each container, and is allowed to return
-// diagnostics if an error is found.
+// that applies to containers. The given function is passed each container, including init and ephemeral containers,
+// and is allowed to return diagnostics if an error is found. The field paths of the diagnostics are relative to the
+// container, and diagnostics without one are attributed to the container as a whole.
 func PerContainerCheck(matchFunc func(container *v1.Container) []diagnostic.Diagnostic) check.Func {
+	return perContainerCheck(matchFunc, true)
+}
+
+// PerNonEphemeralContainerCheck is like PerContainerCheck, but skips ephemeral containers. It is meant for checks of
+// fields that ephemeral containers cannot set, like probes, resources and ports, or that they should not be held to.
+func PerNonEphemeralContainerCheck(matchFunc func(container *v1.Container) []diagnostic.Diagnostic) check.Func {
+	return perContainerCheck(matchFunc, false)
+}
+
+func perContainerCheck(matchFunc func(container *v1.Container) []diagnostic.Diagnostic, includeEphemeral bool) check.Func {
 	return func(_ lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
 		podSpec, found := extract.PodSpec(object.K8sObject)
 		if !found {
 			return nil
 		}
+		podSpecPath, _ := extract.PodSpecFieldPath(object.K8sObject)
 		var results []diagnostic.Diagnostic
-		containers := podSpec.AllContainers()
+		containers := podSpec.NonEphemeralContainers()
+		if includeEphemeral {
+			containers = podSpec.AllContainers()
+		}
 		for i := range containers {
-			results = append(results, matchFunc(&containers[i])...)
+			containerPath := podSpecPath + "." + podSpec.ContainerFieldPath(i)
+			for _, d := range matchFunc(&containers[i]) {
+				if d.FieldPath == "" {
+					d.FieldPath = containerPath
+				} else {
+					d.FieldPath = containerPath + "." + d.FieldPath
+				}
+				results = append(results, d)
+			}
 		}
 		return results
 	}
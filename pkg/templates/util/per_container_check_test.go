@@ -0,0 +1,29 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext/mocks"
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestPerContainerCheck(t *testing.T) {
+	ctx := mocks.NewMockContext()
+	ctx.AddMockDeployment(t, "app")
+	ctx.AddContainerToDeployment(t, "app", v1.Container{Name: "app"})
+	ctx.AddEphemeralContainerToDeployment(t, "app", v1.Container{Name: "debugger"})
+	object := ctx.Objects()[0]
+
+	matchFunc := func(container *v1.Container) []diagnostic.Diagnostic {
+		return []diagnostic.Diagnostic{{Message: container.Name, FieldPath: "image"}}
+	}
+	assert.Equal(t, []diagnostic.Diagnostic{
+		{Message: "app", FieldPath: "spec.template.spec.containers[0].image"},
+		{Message: "debugger", FieldPath: "spec.template.spec.ephemeralContainers[0].image"},
+	}, PerContainerCheck(matchFunc)(ctx, object))
+	assert.Equal(t, []diagnostic.Diagnostic{
+		{Message: "app", FieldPath: "spec.template.spec.containers[0].image"},
+	}, PerNonEphemeralContainerCheck(matchFunc)(ctx, object))
+}
@@ -0,0 +1,32 @@
+package util
+
+import (
+	"golang.stackrox.io/kube-linter/pkg/check"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/extract"
+	"golang.stackrox.io/kube-linter/pkg/extract/customtypes"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+)
+
+// PerPodSpecCheck returns a check that abstracts away the boilerplate of writing a check that applies to pod specs.
+// The given function is passed the pod spec of every object that has one, and is allowed to return diagnostics if an
+// error is found. The field paths of the diagnostics are relative to the pod spec, and diagnostics without one are
+// attributed to the pod spec as a whole.
+func PerPodSpecCheck(matchFunc func(podSpec *customtypes.PodSpec) []diagnostic.Diagnostic) check.Func {
+	return func(_ lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
+		podSpec, found := extract.PodSpec(object.K8sObject)
+		if !found {
+			return nil
+		}
+		podSpecPath, _ := extract.PodSpecFieldPath(object.K8sObject)
+		results := matchFunc(&podSpec)
+		for i := range results {
+			if results[i].FieldPath == "" {
+				results[i].FieldPath = podSpecPath
+			} else {
+				results[i].FieldPath = podSpecPath + "." + results[i].FieldPath
+			}
+		}
+		return results
+	}
+}
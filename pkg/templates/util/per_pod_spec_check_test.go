@@ -0,0 +1,24 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/extract/customtypes"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext/mocks"
+)
+
+func TestPerPodSpecCheck(t *testing.T) {
+	ctx := mocks.NewMockContext()
+	ctx.AddMockDeployment(t, "app")
+	object := ctx.Objects()[0]
+
+	matchFunc := func(_ *customtypes.PodSpec) []diagnostic.Diagnostic {
+		return []diagnostic.Diagnostic{{Message: "field", FieldPath: "hostNetwork"}, {Message: "whole"}}
+	}
+	assert.Equal(t, []diagnostic.Diagnostic{
+		{Message: "field", FieldPath: "spec.template.spec.hostNetwork"},
+		{Message: "whole", FieldPath: "spec.template.spec"},
+	}, PerPodSpecCheck(matchFunc)(ctx, object))
+}
@@ -6,10 +6,10 @@ import (
 	"golang.stackrox.io/kube-linter/pkg/check"
 	"golang.stackrox.io/kube-linter/pkg/config"
 	"golang.stackrox.io/kube-linter/pkg/diagnostic"
-	"golang.stackrox.io/kube-linter/pkg/extract"
-	"golang.stackrox.io/kube-linter/pkg/lintcontext"
+	"golang.stackrox.io/kube-linter/pkg/extract/customtypes"
 	"golang.stackrox.io/kube-linter/pkg/objectkinds"
 	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/util"
 	"golang.stackrox.io/kube-linter/pkg/templates/writablehostmount/internal/params"
 )
 
@@ -24,11 +24,7 @@ func init() {
 		Parameters:             params.ParamDescs,
 		ParseAndValidateParams: params.ParseAndValidate,
 		Instantiate: params.WrapInstantiateFunc(func(_ params.Params) (check.Func, error) {
-			return func(_ lintcontext.LintContext, object lintcontext.Object) []diagnostic.Diagnostic {
-				podSpec, found := extract.PodSpec(object.K8sObject)
-				if !found {
-					return nil
-				}
+			return util.PerPodSpecCheck(func(podSpec *customtypes.PodSpec) []diagnostic.Diagnostic {
 				hostPaths := make(map[string]string)
 				for _, volume := range podSpec.Volumes {
 					if volume.HostPath != nil {
@@ -39,18 +35,21 @@ func init() {
 					return nil
 				}
 				var results []diagnostic.Diagnostic
-				for _, container := range podSpec.AllContainers() {
-					for _, mount := range container.VolumeMounts {
+				for i, container := range podSpec.AllContainers() {
+					for j, mount := range container.VolumeMounts {
 						if mount.ReadOnly {
 							continue
 						}
 						if hostPath, exists := hostPaths[mount.Name]; exists {
-							results = append(results, diagnostic.Diagnostic{Message: fmt.Sprintf("container %s mounts path %s on the host as writable", container.Name, hostPath)})
+							results = append(results, diagnostic.Diagnostic{
+								Message:   fmt.Sprintf("container %s mounts path %s on the host as writable", container.Name, hostPath),
+								FieldPath: fmt.Sprintf("%s.volumeMounts[%d]", podSpec.ContainerFieldPath(i), j),
+							})
 						}
 					}
 				}
 				return results
-			}, nil
+			}), nil
 		}),
 	})
 }
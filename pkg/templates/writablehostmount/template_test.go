@@ -0,0 +1,66 @@
+package writablehostmount
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"golang.stackrox.io/kube-linter/pkg/diagnostic"
+	"golang.stackrox.io/kube-linter/pkg/lintcontext/mocks"
+	"golang.stackrox.io/kube-linter/pkg/templates"
+	"golang.stackrox.io/kube-linter/pkg/templates/writablehostmount/internal/params"
+	appsV1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestWritableHostMount(t *testing.T) {
+	suite.Run(t, new(WritableHostMountTestSuite))
+}
+
+type WritableHostMountTestSuite struct {
+	templates.TemplateTestSuite
+
+	ctx *mocks.MockLintContext
+}
+
+func (s *WritableHostMountTestSuite) SetupTest() {
+	s.Init("writable-host-mount")
+	s.ctx = mocks.NewMockContext()
+}
+
+func (s *WritableHostMountTestSuite) TestMultipleContainers() {
+	s.ctx.AddMockDeployment(s.T(), "dep")
+	s.ctx.ModifyDeployment(s.T(), "dep", func(deployment *appsV1.Deployment) {
+		deployment.Spec.Template.Spec.Volumes = []v1.Volume{
+			{Name: "logs", VolumeSource: v1.VolumeSource{HostPath: &v1.HostPathVolumeSource{Path: "/var/log"}}},
+			{Name: "cache", VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}},
+		}
+		deployment.Spec.Template.Spec.Containers = []v1.Container{
+			{Name: "app", VolumeMounts: []v1.VolumeMount{{Name: "cache", MountPath: "/cache"}, {Name: "logs", MountPath: "/logs"}}},
+			{Name: "reader", VolumeMounts: []v1.VolumeMount{{Name: "logs", MountPath: "/logs", ReadOnly: true}}},
+			{Name: "shipper", VolumeMounts: []v1.VolumeMount{{Name: "logs", MountPath: "/logs"}}},
+		}
+	})
+
+	s.Validate(s.ctx, []templates.TestCase{
+		{
+			Param: params.Params{},
+			Diagnostics: map[string][]diagnostic.Diagnostic{
+				"dep": {
+					{Message: "container app mounts path /var/log on the host as writable"},
+					{Message: "container shipper mounts path /var/log on the host as writable"},
+				},
+			},
+		},
+	})
+
+	checkFunc, err := s.Template.Instantiate(params.Params{})
+	s.Require().NoError(err)
+	var fieldPaths []string
+	for _, d := range checkFunc(s.ctx, s.ctx.Objects()[0]) {
+		fieldPaths = append(fieldPaths, d.FieldPath)
+	}
+	s.Equal([]string{
+		"spec.template.spec.containers[0].volumeMounts[1]",
+		"spec.template.spec.containers[2].volumeMounts[0]",
+	}, fieldPaths)
+}
@@ -10,3 +10,5 @@ gitroot="$(git rev-parse --show-toplevel)"
 [[ -n "${gitroot}" ]] || die "Could not determine git root"
 
 echo "VERSION $("${gitroot}/get-tag")"
+echo "GIT_COMMIT $(git -C "${gitroot}" rev-parse HEAD)"
+echo "BUILD_DATE $(date -u +%Y-%m-%dT%H:%M:%SZ)"